@@ -2,8 +2,10 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"github.com/go-co-op/gocron"
 	"linkTraccer/internal/application/botservice"
+	"linkTraccer/internal/domain/tgbot"
 	"linkTraccer/internal/infrastructure/botconf"
 	"linkTraccer/internal/infrastructure/bothandler"
 	"linkTraccer/internal/infrastructure/cache/redisstore"
@@ -14,9 +16,13 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
 	"sync"
+	"syscall"
 	"time"
+	_ "time/tzdata" // база часовых поясов для команды /timezone
 
+	"github.com/go-redis/redis"
 	"github.com/gorilla/mux"
 )
 
@@ -31,14 +37,17 @@ func main() {
 
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: logLevel}))
 
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	appConf, err := botconf.New()
 	if err != nil {
 		logger.Error("ошибка при получении конфига бота", "err", err.Error())
 		return
 	}
 
-	tgClient := telegram.NewClient(&http.Client{Timeout: time.Minute}, appConf.BotToken, telegramBotAPI)
-	ctxStore := contextstorage.New()
+	tgClient := telegram.NewClient(&http.Client{Timeout: time.Minute}, appConf.BotToken, telegramBotAPI,
+		telegram.Limits{GlobalRPS: appConf.TgGlobalRPS, ChatRPS: appConf.TgChatRPS, MaxRetries: appConf.TgMaxRetries})
 	scrapClient := scrapclient.New(&http.Client{Timeout: time.Minute}, appConf.ScrapperHost, appConf.ScrapperPort)
 
 	redisConf, err := redisstore.NewConfig()
@@ -47,60 +56,205 @@ func main() {
 		return
 	}
 
-	redisStore, err := redisstore.NewStore(redisConf)
+	redisClient, err := redisstore.NewClient(redisConf)
 	if err != nil {
 		logger.Error("ошибка при создании redis хранилища", "err", err.Error())
 		return
 	}
 
-	tgBot := botservice.New(tgClient, scrapClient, ctxStore, redisStore, logger, appConf.BotBatch)
+	ctxStore, stateStore, langStore, err := initDialogStorage(appConf, redisConf, redisClient)
+	if err != nil {
+		logger.Error("ошибка конфигурации", "err", err.Error())
+		return
+	}
+
+	tgBot := botservice.New(tgClient, scrapClient, ctxStore, stateStore, langStore, redisstore.NewStore(redisClient),
+		logger, appConf.BotBatch)
 
-	if err = tgBot.Init(); err != nil {
+	if err = tgBot.Init(ctx); err != nil {
 		logger.Error("ошибка при инициализации бота", "err", err.Error())
 		return
 	}
 
 	logger.Info("инициализация телеграмм бота прошла успешно")
 
+	wg := &sync.WaitGroup{}
+
+	wg.Add(1)
+
+	go startReceiveTgUpdates(ctx, tgBot, tgClient, appConf, logger, wg)
+
+	deliveryStore := redisstore.NewDeliveryStore(redisClient, redisConf.DeliveredTTL)
+
+	wg.Add(1)
+
+	go startReceiveUpdates(ctx, tgClient, tgBot, deliveryStore, appConf, logger, wg)
+
+	wg.Wait()
+
+	if err = redisClient.Close(); err != nil {
+		logger.Error("ошибка при закрытии соединения с redis", "err", err.Error())
+	}
+
+	logger.Info("бот остановлен")
+}
+
+func startReceiveTgUpdates(ctx context.Context, tgBot *botservice.TgBot, tg *telegram.TgClient, config *botconf.Config,
+	logger *slog.Logger, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	switch config.TgUpdatesMode {
+	case "POLLING":
+		if err := tg.DeleteWebhook(ctx); err != nil {
+			logger.Error("ошибка при удалении вебхука", "err", err.Error())
+			return
+		}
+
+		initAndRunPolling(ctx, tgBot, config, logger)
+	case "WEBHOOK":
+		if config.WebhookSecret == "" {
+			logger.Error("ошибка конфигурации", "err", "для работы через вебхук нужно задать WEBHOOK_SECRET")
+			return
+		}
+
+		if err := tg.SetWebhook(ctx, config.WebhookURL, config.WebhookSecret); err != nil {
+			logger.Error("ошибка при установке вебхука", "err", err.Error())
+			return
+		}
+
+		logger.Info("запущен сервер принимающий обновления от телеграмм")
+		initAndRunWebhook(ctx, tgBot, config, logger)
+	default:
+		logger.Error("ошибка конфигурации", "err", "получение сообщений бота должно быть POLLING или WEBHOOK")
+	}
+}
+
+// initAndRunPolling при остановке ждет начатую обработку сообщений не дольше ShutdownTimeout.
+func initAndRunPolling(ctx context.Context, tgBot *botservice.TgBot, config *botconf.Config, logger *slog.Logger) {
 	s := gocron.NewScheduler(time.UTC)
 
-	_, err = s.Every(time.Second * 3).Do(tgBot.ProcessMsg)
+	jobsCtx, cancelJobs := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelJobs()
+
+	_, err := s.Every(time.Second*3).Do(tgBot.ProcessMsg, jobsCtx)
 	if err != nil {
 		logger.Error("ошибка в работе планировщика", "err", err.Error())
 		return
 	}
 
-	s.StartAsync()
 	logger.Info("планировщик с проверкой новых сообщений в боте, успешно запущен")
+	s.StartAsync()
 
-	wg := &sync.WaitGroup{}
+	<-ctx.Done()
 
-	wg.Add(1)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), config.ShutdownTimeout)
+	defer cancel()
+
+	if err = within(shutdownCtx, func() error { s.Stop(); return nil }); err != nil {
+		logger.Warn("обработка сообщений не закончилась вовремя и будет прервана", "err", err.Error())
+	}
+}
 
-	go startReceiveUpdates(context.Background(), tgClient, appConf, logger, wg)
+func initAndRunWebhook(ctx context.Context, tgBot *botservice.TgBot, config *botconf.Config, logger *slog.Logger) {
+	r := mux.NewRouter()
+	webhook := bothandler.NewWebhookHandler(tgBot, config.WebhookSecret, config.WebhookQueueSize, logger)
 
-	wg.Wait()
+	r.HandleFunc(config.WebhookPath, webhook.HandleTgUpdate).Methods(http.MethodPost)
+
+	jobsCtx, cancelJobs := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelJobs()
+
+	processed := make(chan struct{})
+
+	go func() {
+		defer close(processed)
+
+		webhook.Run(jobsCtx)
+	}()
+
+	srv := &http.Server{
+		Addr:         config.WebhookPort,
+		Handler:      r,
+		ReadTimeout:  30 * time.Second,
+		WriteTimeout: 30 * time.Second,
+		IdleTimeout:  30 * time.Second,
+	}
+
+	if err := runServer(ctx, srv, config.ShutdownTimeout); err != nil {
+		logger.Error("сервер принимающий обновления от телеграмм, закончил работу", "err", err.Error())
+	}
+
+	webhook.Stop()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), config.ShutdownTimeout)
+	defer cancel()
+
+	if err := within(shutdownCtx, func() error { <-processed; return nil }); err != nil {
+		logger.Warn("обработка принятых обновлений не закончилась вовремя и будет прервана", "err", err.Error())
+		cancelJobs()
+	}
 }
 
-func startReceiveUpdates(ctx context.Context, tg botservice.TgClient, config *botconf.Config, logger *slog.Logger, wg *sync.WaitGroup) {
-	defer wg.Done()
+func initDialogStorage(config *botconf.Config, redisConf *redisstore.Config,
+	redisClient *redis.Client) (botservice.CtxStorage, tgbot.StateStore, tgbot.LangStore, error) {
+	switch config.DialogStorage {
+	case "REDIS":
+		dialogStore := redisstore.NewDialogStore(redisClient, redisConf.DialogTTL)
 
-	switch config.UpdatesTransport {
-	case "KAFKA":
-		logger.Info("запущен консьюмер принимающий обновления по ссылкам")
-		initAndRunConsumer(ctx, tg, config, logger)
-	case "HTTP":
-		logger.Info("запущен сервер принимающий обновления по ссылкам")
-		initAndRunServer(tg, config, logger)
+		return dialogStore, dialogStore, dialogStore, nil
+	case "MEMORY":
+		return contextstorage.New(), tgbot.NewMemoryStateStore(), tgbot.NewMemoryLangStore(), nil
 	default:
-		logger.Error("ошибка конфигурации", "err", "получение обновлений должно быть KAFKA или HTTP")
+		return nil, nil, nil, fmt.Errorf("хранилище диалогов должно быть REDIS или MEMORY, получено %s", config.DialogStorage)
 	}
 }
 
-func initAndRunServer(tg botservice.TgClient, config *botconf.Config, logger *slog.Logger) {
+func startReceiveUpdates(ctx context.Context, tg botservice.TgClient, tgBot *botservice.TgBot,
+	deliveries *redisstore.DeliveryStore, config *botconf.Config, logger *slog.Logger, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	transports := &sync.WaitGroup{}
+
+	for _, transport := range updatesTransports(config) {
+		transports.Add(1)
+
+		go func() {
+			defer transports.Done()
+
+			switch transport {
+			case "KAFKA":
+				logger.Info("запущен консьюмер принимающий обновления по ссылкам")
+				initAndRunConsumer(ctx, tg, tgBot, deliveries, config, logger)
+			case "HTTP":
+				logger.Info("запущен сервер принимающий обновления по ссылкам")
+				initAndRunServer(ctx, tg, tgBot, deliveries, config, logger)
+			default:
+				logger.Error("ошибка конфигурации", "err", "получение обновлений должно быть KAFKA или HTTP", "transport", transport)
+			}
+		}()
+	}
+
+	transports.Wait()
+}
+
+// updatesTransports при включенном fallback слушает оба транспорта, scrapper может переключиться на любой.
+func updatesTransports(config *botconf.Config) []string {
+	if !config.UpdatesFallback {
+		return []string{config.UpdatesTransport}
+	}
+
+	if config.UpdatesTransport == "HTTP" {
+		return []string{"HTTP", "KAFKA"}
+	}
+
+	return []string{"KAFKA", "HTTP"}
+}
+
+func initAndRunServer(ctx context.Context, tg botservice.TgClient, remover bothandler.ChatRemover, deliveries bothandler.DeliveryLog,
+	config *botconf.Config, logger *slog.Logger) {
 	r := mux.NewRouter()
 
-	r.HandleFunc("/updates", bothandler.New(tg, logger).HandleLinkUpdates).Methods(http.MethodPost)
+	r.HandleFunc("/updates", bothandler.New(tg, remover, deliveries, logger).HandleLinkUpdates).Methods(http.MethodPost)
 
 	srv := &http.Server{
 		Addr:         config.BotPort,
@@ -110,26 +264,66 @@ func initAndRunServer(tg botservice.TgClient, config *botconf.Config, logger *sl
 		IdleTimeout:  30 * time.Second,
 	}
 
-	if err := srv.ListenAndServe(); err != nil {
+	if err := runServer(ctx, srv, config.ShutdownTimeout); err != nil {
 		logger.Error("сервер принимающий обновления ссылок, закончил работу", "err", err.Error())
 	}
 }
 
-func initAndRunConsumer(ctx context.Context, tg botservice.TgClient, config *botconf.Config, logger *slog.Logger) {
+func initAndRunConsumer(ctx context.Context, tg botservice.TgClient, remover consumer.ChatRemover,
+	deliveries consumer.DeliveryLog, config *botconf.Config, logger *slog.Logger) {
 	conf, err := consumer.NewConfig()
 	if err != nil {
 		logger.Error("ошибка при создании конфига kafka консьюмера", "err", err.Error())
+		return
 	}
 
-	consumer := consumer.New(tg, conf, logger)
+	consumer := consumer.New(tg, remover, deliveries, conf, logger)
 
 	err = consumer.ReadUserUpdates(ctx)
 	if err != nil {
 		logger.Error("консьюмер закончил работу с ошибкой", "err", err.Error())
 	}
 
-	err = consumer.Close()
-	if err != nil {
+	closeCtx, cancel := context.WithTimeout(context.Background(), config.ShutdownTimeout)
+	defer cancel()
+
+	if err = within(closeCtx, consumer.Close); err != nil {
 		logger.Error("ошибка при закрытии консьюмера", "err", err.Error())
 	}
 }
+
+// runServer после отмены ctx ждет начатые запросы не дольше timeout.
+func runServer(ctx context.Context, srv *http.Server, timeout time.Duration) error {
+	errs := make(chan error, 1)
+
+	go func() {
+		errs <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return srv.Shutdown(shutdownCtx)
+}
+
+// within ждет fn не дольше ctx. Если время вышло, fn продолжает работу в фоне.
+func within(ctx context.Context, fn func() error) error {
+	done := make(chan error, 1)
+
+	go func() {
+		done <- fn()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
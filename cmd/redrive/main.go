@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"github.com/segmentio/kafka-go"
+	"linkTraccer/internal/infrastructure/kafka/consumer"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// redrive возвращает обновления из DLQ в основной топик, своя группа не дает переложить их дважды.
+func main() {
+	idle := flag.Duration("idle", 10*time.Second, "завершить работу, если столько времени в DLQ нет новых сообщений")
+
+	flag.Parse()
+
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	conf, err := consumer.NewConfig()
+	if err != nil {
+		logger.Error("ошибка при создании конфига kafka консьюмера", "err", err.Error())
+		return
+	}
+
+	brokers := strings.Split(conf.Brokers, ",")
+
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:  brokers,
+		Topic:    conf.DLQTopic,
+		GroupID:  conf.GroupID + "-redrive",
+		MaxBytes: 10e6,
+	})
+	writer := &kafka.Writer{
+		Addr:     kafka.TCP(brokers...),
+		Balancer: &kafka.Hash{},
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	redriven, err := consumer.Redrive(ctx, reader, writer, conf.Topic, *idle, logger)
+	if err != nil {
+		logger.Error("ошибка при возврате сообщений из DLQ", "err", err.Error())
+	}
+
+	logger.Info("сообщения из DLQ возвращены в основной топик", "count", redriven, "topic", conf.Topic)
+
+	if err = reader.Close(); err != nil {
+		logger.Error("ошибка при закрытии консьюмера", "err", err.Error())
+	}
+
+	if err = writer.Close(); err != nil {
+		logger.Error("ошибка при закрытии продюсера", "err", err.Error())
+	}
+}
@@ -3,32 +3,45 @@ package main
 import (
 	"context"
 	"errors"
+	"expvar"
 	"fmt"
 	_ "github.com/doug-martin/goqu/v9/dialect/postgres"
 	"github.com/go-co-op/gocron"
 	"github.com/gorilla/mux"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"io"
+	"linkTraccer/internal/application/scrapper/filters"
+	"linkTraccer/internal/application/scrapper/notifiers/digest"
+	"linkTraccer/internal/application/scrapper/notifiers/outbox"
 	"linkTraccer/internal/application/scrapper/notifiers/tgnotifier"
 	"linkTraccer/internal/application/scrapper/scrapservice"
+	"linkTraccer/internal/application/scrapper/singleton"
+	"linkTraccer/internal/domain/scrapper"
 	"linkTraccer/internal/infrastructure/botclient"
 	"linkTraccer/internal/infrastructure/database/sql"
+	"linkTraccer/internal/infrastructure/database/sql/advisorylock"
 	"linkTraccer/internal/infrastructure/database/sql/buildersql"
 	"linkTraccer/internal/infrastructure/database/sql/cleansql"
 	"linkTraccer/internal/infrastructure/database/sql/transactor"
+	"linkTraccer/internal/infrastructure/fallbackclient"
 	"linkTraccer/internal/infrastructure/kafka/producer"
 	"linkTraccer/internal/infrastructure/scrapconfig"
 	"linkTraccer/internal/infrastructure/scraphandlers"
+	"linkTraccer/internal/infrastructure/siteclients"
 	"linkTraccer/internal/infrastructure/siteclients/github"
 	"linkTraccer/internal/infrastructure/siteclients/stackoverflow"
 	"log/slog"
 	"net"
 	"net/http"
 	"os"
-	"sync"
+	"os/signal"
+	"syscall"
 	"time"
+	_ "time/tzdata" // база часовых поясов для образов без tzdata
 )
 
 const (
+	linksJob         = "links_updates"
 	stackOverflowAPI = "api.stackexchange.com"
 	gitHubAPI        = "api.github.com"
 	maxPreviewLen    = 200
@@ -37,8 +50,19 @@ const (
 )
 
 type UserRepo = scrapservice.UserRepo
+
+type Store interface {
+	UserRepo
+	digest.DigestRepo
+	tgnotifier.SettingsRepo
+	tgnotifier.Outbox
+	outbox.Repo
+	scraphandlers.DeliveryRepo
+	scraphandlers.SettingsRepo
+}
+
 type Transactor = scrapservice.Transactor
-type SiteClient = scrapservice.SiteClient
+type SiteClient = scraphandlers.SiteClient
 type Config = scrapconfig.Config
 
 func main() {
@@ -48,13 +72,16 @@ func main() {
 
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: logLevel}))
 
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	dbConfig, err := sql.NewConfig()
 	if err != nil {
 		logger.Error("ошибка при получении конфига БД", "err", err.Error())
 		return
 	}
 
-	pgxPool, err := initPgxPool(dbConfig)
+	pgxPool, err := initPgxPool(ctx, dbConfig)
 	if err != nil {
 		logger.Error("ошибка инициализации пула соединений", "err", err.Error())
 		return
@@ -75,45 +102,165 @@ func main() {
 	}
 
 	dbTransactor := transactor.New(pgxPool)
-	stackClient := stackoverflow.NewClient(stackOverflowAPI, &http.Client{Timeout: time.Second * 10},
-		stackoverflow.HTMLStrCleaner(maxPreviewLen))
-	gitClient := github.NewClient(gitHubAPI, config.GitHubAPIKey, &http.Client{Timeout: time.Second * 10})
-
-	tgBotClient, err := initUpdatesTransport(config)
+	siteTransport := siteclients.NewTransport(&http.Client{Timeout: time.Second * 10}, &siteclients.TransportConfig{
+		MaxRetries:      config.SiteMaxRetries,
+		RetryDelay:      config.SiteRetryDelay,
+		MaxRetryDelay:   config.SiteMaxDelay,
+		BreakerFailures: config.BreakerFailures,
+		BreakerCooldown: config.BreakerCooldown,
+	})
+	stackClient := stackoverflow.NewClient(stackOverflowAPI, siteTransport, stackoverflow.HTMLStrCleaner(maxPreviewLen))
+	gitClient := github.NewClient(gitHubAPI, config.GitHubAPIKey, siteTransport)
+
+	tgBotClient, err := initUpdatesTransport(config, logger)
 	if err != nil {
 		logger.Error("ошибка при инициализации клиента тг бота", "err", err.Error())
 		return
 	}
 
-	notifierService := tgnotifier.New(userStore, tgBotClient)
-	scrapper := scrapservice.New(userStore, notifierService, logger, stackClient, gitClient)
+	notifierService := tgnotifier.New(userStore, userStore, dbTransactor, logger)
+	outboxRelay := outbox.New(userStore, tgBotClient, &outbox.Config{
+		BatchSize:   config.OutboxBatch,
+		Retention:   config.OutboxRetention,
+		Lease:       config.OutboxLease,
+		MaxAttempts: config.OutboxAttempts,
+	}, logger)
+	digestDispatcher := digest.New(userStore, notifierService, dbTransactor, logger)
+	updatesFilter := filters.New(userStore)
+	checkPolicy := &scrapper.CheckPolicy{MinInterval: config.CheckMinInterval, MaxInterval: config.CheckMaxInterval}
+	pipeline := &scrapservice.PipelineConfig{
+		QueueSize: config.CheckQueueSize,
+		Hosts: map[string]scrapservice.HostBudget{
+			gitHubAPI:        {Concurrency: config.GitHubWorkers, RPS: config.GitHubRPS},
+			stackOverflowAPI: {Concurrency: config.StackWorkers, RPS: config.StackRPS},
+		},
+	}
+	linkScrapper := scrapservice.New(userStore, digestDispatcher, updatesFilter, dbTransactor, checkPolicy, pipeline,
+		logger, stackClient, gitClient)
+	linksUpdates := singleton.New(linksJob, linkScrapper.LinksUpdates, advisorylock.New(pgxPool), logger)
 	scheduler := gocron.NewScheduler(time.UTC)
 
-	_, err = scheduler.Every(time.Minute).Do(scrapper.LinksUpdates)
+	// задачи получают свой контекст, чтобы при остановке начатые циклы могли закончиться
+	jobsCtx, cancelJobs := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelJobs()
+
+	expvar.Publish(linksJob, linksUpdates.Stats())
+
+	_, err = scheduler.Cron(config.LinksCron).Do(linksUpdates.Run, jobsCtx)
 	if err != nil {
 		logger.Error("ошибка при запуске планировщика с проверкой ссылок", "err", err.Error())
 		return
 	}
 
+	_, err = scheduler.Every(time.Minute).Do(digestDispatcher.SendDigests, jobsCtx)
+	if err != nil {
+		logger.Error("ошибка при запуске планировщика с отправкой дайджестов", "err", err.Error())
+		return
+	}
+
+	_, err = scheduler.Every(time.Minute).Do(notifierService.ReleaseHeldUpdates, jobsCtx)
+	if err != nil {
+		logger.Error("ошибка при запуске планировщика с отправкой задержанных уведомлений", "err", err.Error())
+		return
+	}
+
+	_, err = scheduler.Every(config.OutboxInterval).Do(outboxRelay.Publish, jobsCtx)
+	if err != nil {
+		logger.Error("ошибка при запуске планировщика с отправкой уведомлений из outbox", "err", err.Error())
+		return
+	}
+
 	scheduler.StartAsync()
 
 	logger.Info("планировщик с проверкой ссылок успешно запущен")
+
+	srv := newServer(userStore, dbTransactor, logger, config, gitClient, stackClient)
+
+	go func() {
+		if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("сервер закончил работу", "err", err.Error())
+			stop()
+		}
+	}()
+
 	logger.Info("сервер успешно запущен")
 
-	wg := &sync.WaitGroup{}
+	<-ctx.Done()
+
+	logger.Info("scrapper получил сигнал остановки")
+
+	shutdown(srv, scheduler, cancelJobs, tgBotClient, pgxPool, config.ShutdownTimeout, logger)
+}
+
+// shutdown останавливает сервер, планировщик, транспорт и пул соединений в пределах timeout.
+func shutdown(srv *http.Server, scheduler *gocron.Scheduler, cancelJobs context.CancelFunc, transport outbox.BotClient,
+	pgxPool *pgxpool.Pool, timeout time.Duration, log *slog.Logger) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Error("ошибка при остановке сервера", "err", err.Error())
+	}
+
+	if err := within(ctx, func() error { scheduler.Stop(); return nil }); err != nil {
+		log.Warn("задачи планировщика не закончились вовремя и будут прерваны", "err", err.Error())
+		cancelJobs()
+	}
 
-	wg.Add(1)
+	if closer, ok := transport.(io.Closer); ok {
+		if err := within(ctx, closer.Close); err != nil {
+			log.Error("ошибка при закрытии транспорта обновлений", "err", err.Error())
+		}
+	}
+
+	if err := within(ctx, func() error { pgxPool.Close(); return nil }); err != nil {
+		log.Error("пул соединений с БД не закрылся вовремя", "err", err.Error())
+	}
+
+	log.Info("scrapper остановлен")
+}
+
+// within ждет fn не дольше ctx. Если время вышло, fn продолжает работу в фоне.
+func within(ctx context.Context, fn func() error) error {
+	done := make(chan error, 1)
 
 	go func() {
-		defer wg.Done()
-		initAndRunServer(userStore, dbTransactor, logger, config, gitClient, stackClient)
+		done <- fn()
 	}()
 
-	wg.Wait()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
-func initUpdatesTransport(config *scrapconfig.Config) (tgnotifier.BotClient, error) {
-	switch config.UpdatesTransport {
+func initUpdatesTransport(config *scrapconfig.Config, log *slog.Logger) (outbox.BotClient, error) {
+	primary, err := newUpdatesTransport(config.UpdatesTransport)
+	if err != nil {
+		return nil, err
+	}
+
+	if !config.UpdatesFallback {
+		return primary, nil
+	}
+
+	fallback := "HTTP"
+	if config.UpdatesTransport == "HTTP" {
+		fallback = "KAFKA"
+	}
+
+	secondary, err := newUpdatesTransport(fallback)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при инициализации запасного транспорта: %w", err)
+	}
+
+	return fallbackclient.New(primary, secondary, config.FallbackFailures, config.FallbackProbe, log), nil
+}
+
+func newUpdatesTransport(transport string) (outbox.BotClient, error) {
+	switch transport {
 	case "KAFKA":
 		kafkaConfig, err := producer.NewConfig()
 		if err != nil {
@@ -134,7 +281,7 @@ func initUpdatesTransport(config *scrapconfig.Config) (tgnotifier.BotClient, err
 	}
 }
 
-func initPgxPool(dbConfig *sql.DBConfig) (*pgxpool.Pool, error) {
+func initPgxPool(ctx context.Context, dbConfig *sql.DBConfig) (*pgxpool.Pool, error) {
 	pgxConfig, err := pgxpool.ParseConfig(dbConfig.ToDSN())
 
 	if err != nil {
@@ -143,20 +290,20 @@ func initPgxPool(dbConfig *sql.DBConfig) (*pgxpool.Pool, error) {
 
 	pgxConfig.MinConns, pgxConfig.MaxConns = minCons, maxCons // разобраться с тем как настроить время ожидания конекта
 
-	pgxPool, err := pgxpool.NewWithConfig(context.Background(), pgxConfig)
+	pgxPool, err := pgxpool.NewWithConfig(ctx, pgxConfig)
 
 	if err != nil {
 		return nil, fmt.Errorf("ошибка при создании pgxpool.Pool: %w", err)
 	}
 
-	if err = pgxPool.Ping(context.Background()); err != nil {
+	if err = pgxPool.Ping(ctx); err != nil {
 		return nil, fmt.Errorf("ошибка при проверке соединения с БД: %w", err)
 	}
 
 	return pgxPool, nil
 }
 
-func initStore(config *sql.DBConfig, pool *pgxpool.Pool) (UserRepo, error) {
+func initStore(config *sql.DBConfig, pool *pgxpool.Pool) (Store, error) {
 	switch config.AccessType {
 	case "SQL":
 		return cleansql.NewStore(config, pool), nil
@@ -167,26 +314,37 @@ func initStore(config *sql.DBConfig, pool *pgxpool.Pool) (UserRepo, error) {
 	}
 }
 
-func initAndRunServer(userStore UserRepo, dbTransactor Transactor, log *slog.Logger, cfg *Config, siteClients ...SiteClient) {
+func newServer(userStore Store, dbTransactor Transactor, log *slog.Logger, cfg *Config, siteClients ...SiteClient) *http.Server {
 	r := mux.NewRouter()
 	linksHandler := scraphandlers.NewLinkHandler(userStore, dbTransactor, log, siteClients...)
 	chatHandler := scraphandlers.NewChatHandler(userStore, dbTransactor, log)
+	deliveryHandler := scraphandlers.NewDeliveryHandler(userStore, log)
+	settingsHandler := scraphandlers.NewSettingsHandler(userStore, log)
 
 	r.HandleFunc("/tg-chat/{id}", chatHandler.HandleChatChanges).
 		Methods(http.MethodPost, http.MethodDelete)
+	r.HandleFunc("/tg-chat/{id}/delivery", deliveryHandler.HandleDeliveryChanges).
+		Methods(http.MethodPut)
+	r.HandleFunc("/tg-chat/{id}/quiet", settingsHandler.HandleQuietHoursChanges).
+		Methods(http.MethodPut, http.MethodDelete)
+	r.HandleFunc("/tg-chat/{id}/timezone", settingsHandler.HandleTimezoneChanges).
+		Methods(http.MethodPut)
+	r.HandleFunc("/tg-chat/{id}/language", settingsHandler.HandleLanguageChanges).
+		Methods(http.MethodPut)
 	r.HandleFunc("/links", linksHandler.HandleLinksChanges).
 		Methods(http.MethodGet, http.MethodPost, http.MethodDelete)
-
-	srv := &http.Server{
+	r.HandleFunc("/tagedlinks", linksHandler.HandleTagedLinks).
+		Methods(http.MethodGet)
+	r.HandleFunc("/links/interval", linksHandler.HandleIntervalChanges).
+		Methods(http.MethodPut, http.MethodDelete)
+	r.Handle("/debug/vars", expvar.Handler()).
+		Methods(http.MethodGet)
+
+	return &http.Server{
 		Addr:         cfg.ScrapperPort,
 		Handler:      r,
 		ReadTimeout:  30 * time.Second,
 		WriteTimeout: 30 * time.Second,
 		IdleTimeout:  30 * time.Second,
 	}
-
-	err := srv.ListenAndServe()
-	if err != nil {
-		log.Error("сервер закончил работу", "err", err.Error())
-	}
 }
@@ -1,39 +1,57 @@
 package botservice
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"linkTraccer/internal/domain/i18n"
 	"linkTraccer/internal/domain/tgbot"
 	"log/slog"
+	"time"
 )
 
-type Handler func(tgbot.ID, tgbot.Event) error
+type Handler func(context.Context, tgbot.ID, tgbot.Event) error
 
 type TgClient interface {
-	HandleUsersUpdates(offset, limit int) (tgbot.Updates, error)
-	SendMessage(userID int64, text string) error
-	SetBotCommands(data *tgbot.SetCommands) error
+	HandleUsersUpdates(ctx context.Context, offset, limit int) (tgbot.Updates, error)
+	SendMessage(ctx context.Context, userID int64, text string) error
+	SendKeyboard(ctx context.Context, userID int64, text string, keyboard *tgbot.InlineKeyboardMarkup) error
+	EditMessageText(ctx context.Context, userID int64, messageID int, text string) error
+	AnswerCallbackQuery(ctx context.Context, callbackID, text string) error
+	SetBotCommands(ctx context.Context, data *tgbot.SetCommands) error
 }
 
 type CtxStorage interface {
-	RegUser(id tgbot.ID) error
-	AddURL(id tgbot.ID, url string) error
-	AddFilters(id tgbot.ID, filters []string) error
-	AddTags(id tgbot.ID, tags []string) error
-	ResetCtx(id tgbot.ID) error
-	UserContext(id tgbot.ID) (*tgbot.ContextData, error)
+	RegUser(ctx context.Context, id tgbot.ID) error
+	AddURL(ctx context.Context, id tgbot.ID, url string) error
+	AddFilters(ctx context.Context, id tgbot.ID, filters []string) error
+	AddTags(ctx context.Context, id tgbot.ID, tags []string) error
+	ResetCtx(ctx context.Context, id tgbot.ID) error
+	UserContext(ctx context.Context, id tgbot.ID) (*tgbot.ContextData, error)
+	DeleteUser(ctx context.Context, id tgbot.ID) error
 }
 
 type ScrapClient interface {
-	RegUser(id tgbot.ID) error
-	AddLink(tgbot.ID, *tgbot.ContextData) error
-	RemoveLink(tgbot.ID, tgbot.Link) error
-	UserLinks(tgbot.ID) ([]tgbot.Link, error)
+	RegUser(ctx context.Context, id tgbot.ID) error
+	DeleteUser(ctx context.Context, id tgbot.ID) error
+	AddLink(ctx context.Context, id tgbot.ID, data *tgbot.ContextData) error
+	RemoveLink(ctx context.Context, id tgbot.ID, link tgbot.Link) error
+	UserLinks(ctx context.Context, id tgbot.ID) ([]tgbot.SavedLink, error)
+	TagedLinks(ctx context.Context, id tgbot.ID, tag tgbot.Tag) ([]tgbot.TagedLinks, error)
+	SetDigestTime(ctx context.Context, id tgbot.ID, sendTime string) error
+	SetInstantDelivery(ctx context.Context, id tgbot.ID) error
+	SetQuietHours(ctx context.Context, id tgbot.ID, start, end string) error
+	DisableQuietHours(ctx context.Context, id tgbot.ID) error
+	SetTimezone(ctx context.Context, id tgbot.ID, timezone string) error
+	SetLanguage(ctx context.Context, id tgbot.ID, lang i18n.Lang) error
+	SetCheckInterval(ctx context.Context, id tgbot.ID, link tgbot.Link, interval time.Duration) error
+	ResetCheckInterval(ctx context.Context, id tgbot.ID, link tgbot.Link) error
 }
 
 type CacheStorage interface {
-	SetUserLinks(id tgbot.ID, links string) error
-	GetUserLinks(id tgbot.ID) (string, error)
-	InvalidateUserCache(id tgbot.ID) error
+	SetUserLinks(ctx context.Context, id tgbot.ID, tag tgbot.Tag, links string) error
+	GetUserLinks(ctx context.Context, id tgbot.ID, tag tgbot.Tag) (string, error)
+	InvalidateUserCache(ctx context.Context, id tgbot.ID) error
 }
 
 type TgBot struct {
@@ -41,6 +59,8 @@ type TgBot struct {
 	limit         int
 	log           *slog.Logger
 	states        *tgbot.StateMachine
+	stateStore    tgbot.StateStore
+	langStore     tgbot.LangStore
 	tg            TgClient
 	ctxStore      CtxStorage
 	cache         CacheStorage
@@ -48,21 +68,24 @@ type TgBot struct {
 	stateHandlers map[tgbot.State]Handler
 }
 
-func New(tg TgClient, scrap ScrapClient, ctxStore CtxStorage, cache CacheStorage, log *slog.Logger, limit int) *TgBot {
+func New(tg TgClient, scrap ScrapClient, ctxStore CtxStorage, stateStore tgbot.StateStore,
+	langStore tgbot.LangStore, cache CacheStorage, log *slog.Logger, limit int) *TgBot {
 	return &TgBot{
-		ctxStore: ctxStore,
-		cache:    cache,
-		tg:       tg,
-		limit:    limit,
-		scrap:    scrap,
-		log:      log,
+		ctxStore:   ctxStore,
+		stateStore: stateStore,
+		langStore:  langStore,
+		cache:      cache,
+		tg:         tg,
+		limit:      limit,
+		scrap:      scrap,
+		log:        log,
 	}
 }
 
-func (bot *TgBot) Init() error {
+func (bot *TgBot) Init(ctx context.Context) error {
 	var err error
 
-	bot.states, err = tgbot.NewStateMachine(InitialState, botStates())
+	bot.states, err = tgbot.NewStateMachine(InitialState, botStates(), bot.stateStore)
 	if err != nil {
 		return err
 	}
@@ -76,15 +99,15 @@ func (bot *TgBot) Init() error {
 		AddLinkFilter:        bot.SaveLinkHandler,
 	}
 
-	if err := bot.setCommands(); err != nil {
+	if err := bot.setCommands(ctx); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (bot *TgBot) ProcessMsg() {
-	updates, err := bot.tg.HandleUsersUpdates(bot.offset, bot.limit)
+func (bot *TgBot) ProcessMsg(ctx context.Context) {
+	updates, err := bot.tg.HandleUsersUpdates(ctx, bot.offset, bot.limit)
 	if err != nil {
 		bot.log.Error("ошибка при пулинге новых сообщений", "err", err.Error())
 		return
@@ -97,42 +120,139 @@ func (bot *TgBot) ProcessMsg() {
 		bot.log.Info(fmt.Sprintf("Получено %d новых апдейтов", len(updates)))
 
 		for _, update := range updates {
-			id := update.Msg.From.ID
-			state := bot.states.Current(id)
+			bot.ProcessUpdate(ctx, update)
+		}
+	}
+}
+
+// ProcessUpdate обрабатывает одно обновление от telegram, используется и при пулинге, и в вебхуке.
+func (bot *TgBot) ProcessUpdate(ctx context.Context, update tgbot.Update) {
+	if update.CallbackQuery != nil {
+		bot.rememberLang(ctx, update.CallbackQuery.From)
+		bot.processCallback(ctx, update.CallbackQuery)
+
+		return
+	}
+
+	bot.rememberLang(ctx, update.Msg.From)
+	bot.processEvent(ctx, update.Msg.From.ID, update.Msg.Text)
+}
+
+// rememberLang берет язык из Telegram, только если пользователь еще не выбрал его командой /lang.
+func (bot *TgBot) rememberLang(ctx context.Context, user tgbot.User) {
+	lang, err := bot.langStore.UserLang(ctx, user.ID)
+	if err != nil {
+		bot.log.Error("ошибка при получении языка пользователя", "err", err.Error())
+
+		return
+	}
+
+	if lang != "" {
+		return
+	}
 
-			if _, ok := bot.stateHandlers[state]; !ok {
-				bot.log.Debug(fmt.Sprintf("у состояния %s, нет обработчика", state))
+	if err := bot.langStore.SetUserLang(ctx, user.ID, i18n.FromLanguageCode(user.LanguageCode)); err != nil {
+		bot.log.Error("ошибка при сохранении языка пользователя", "err", err.Error())
+	}
+}
 
-				continue
-			}
+// lang возвращает язык пользователя, а если его не удалось получить - язык по умолчанию.
+func (bot *TgBot) lang(ctx context.Context, id tgbot.ID) i18n.Lang {
+	lang, err := bot.langStore.UserLang(ctx, id)
+	if err != nil {
+		bot.log.Error("ошибка при получении языка пользователя", "err", err.Error())
 
-			bot.log.Debug(fmt.Sprintf("у состояния %s, нет обработчика", state))
+		return i18n.Default
+	}
 
-			err := bot.stateHandlers[state](id, update.Msg.Text)
+	if lang == "" {
+		return i18n.Default
+	}
 
-			if err != nil {
-				bot.log.Debug("ошибка при обработке состояния пользователя", "err", err.Error())
-			}
+	return lang
+}
+
+func (bot *TgBot) text(ctx context.Context, id tgbot.ID, key string, args ...any) string {
+	msg := Text(bot.lang(ctx, id), key)
+
+	if len(args) == 0 {
+		return msg
+	}
+
+	return fmt.Sprintf(msg, args...)
+}
+
+func (bot *TgBot) processEvent(ctx context.Context, id tgbot.ID, event tgbot.Event) {
+	state, err := bot.states.Current(ctx, id)
+	if err != nil {
+		bot.log.Error("ошибка при получении состояния пользователя", "err", err.Error())
 
-			if _, err := bot.states.Transition(id, update.Msg.Text); err != nil {
-				bot.log.Debug(fmt.Sprintf("ошибка при переходе из состояния %s", state))
-			}
+		return
+	}
+
+	if _, ok := bot.stateHandlers[state]; !ok {
+		bot.log.Debug(fmt.Sprintf("у состояния %s, нет обработчика", state))
+
+		return
+	}
+
+	err = bot.stateHandlers[state](ctx, id, event)
+
+	if err != nil {
+		bot.log.Debug("ошибка при обработке состояния пользователя", "err", err.Error())
+	}
+
+	if _, err := bot.states.Transition(ctx, id, commandEvent(event)); err != nil {
+		bot.log.Debug(fmt.Sprintf("ошибка при переходе из состояния %s", state))
+	}
+}
+
+// setCommands регистрирует меню команд для каждого языка и меню по умолчанию.
+func (bot *TgBot) setCommands(ctx context.Context) error {
+	if err := bot.tg.SetBotCommands(ctx, commandsMenu(i18n.Default, "")); err != nil {
+		return fmt.Errorf("ошибка при отправке запроса SetBotCommands: %w", err)
+	}
+
+	for _, lang := range i18n.Languages {
+		if err := bot.tg.SetBotCommands(ctx, commandsMenu(lang, lang)); err != nil {
+			return fmt.Errorf("ошибка при отправке запроса SetBotCommands для языка %s: %w", lang, err)
 		}
 	}
+
+	return nil
 }
 
-func (bot *TgBot) setCommands() error {
-	commandsMsg := &tgbot.SetCommands{}
+func commandsMenu(lang i18n.Lang, languageCode string) *tgbot.SetCommands {
+	commandsMsg := &tgbot.SetCommands{LanguageCode: languageCode}
 
 	for _, command := range commandsDescription {
 		commandsMsg.Commands = append(commandsMsg.Commands,
-			tgbot.BotCommand{Command: command[0], Description: command[1]})
+			tgbot.BotCommand{Command: command[0], Description: Text(lang, command[1])})
 	}
 
-	if err := bot.tg.SetBotCommands(commandsMsg); err != nil {
-		return fmt.Errorf("ошибка при отправке запроса SetBotCommands: %w", err)
+	return commandsMsg
+}
+
+// RemoveChat удаляет пользователя в скраппере и все его данные в боте.
+func (bot *TgBot) RemoveChat(ctx context.Context, id tgbot.ID) error {
+	var err error
+
+	if scrapErr := bot.scrap.DeleteUser(ctx, id); scrapErr != nil {
+		err = fmt.Errorf("ошибка при удалении пользователя в скраппере: %w", scrapErr)
 	}
 
+	err = errors.Join(err,
+		bot.stateStore.DeleteUserState(ctx, id),
+		bot.ctxStore.DeleteUser(ctx, id),
+		bot.langStore.DeleteUserLang(ctx, id),
+		bot.cache.InvalidateUserCache(ctx, id))
+
+	if err != nil {
+		return fmt.Errorf("ошибка при удалении чата %d: %w", id, err)
+	}
+
+	bot.log.Info(fmt.Sprintf("чат %d недоступен, пользователь отписан от обновлений", id))
+
 	return nil
 }
 
@@ -0,0 +1,191 @@
+package botservice_test
+
+import (
+	"context"
+	"linkTraccer/internal/application/botservice"
+	"linkTraccer/internal/application/botservice/mocks"
+	"linkTraccer/internal/domain/i18n"
+	"linkTraccer/internal/domain/tgbot"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTgBot_ProcessUpdate(t *testing.T) {
+	tg := mocks.NewTgClient(t)
+	scrap := mocks.NewScrapClient(t)
+	ctxStore := mocks.NewCtxStorage(t)
+	cache := mocks.NewCacheStorage(t)
+	stateStore := tgbot.NewMemoryStateStore()
+	langStore := tgbot.NewMemoryLangStore()
+
+	userCtx := &tgbot.ContextData{URL: link, Tags: []string{}, Filters: []string{}}
+
+	tg.On("SetBotCommands", mock.Anything, mock.Anything).Return(nil).Times(len(i18n.Languages) + 1)
+	tg.On("SendMessage", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	tg.On("SendKeyboard", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	tg.On("EditMessageText", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	tg.On("AnswerCallbackQuery", mock.Anything, mock.Anything, "").Return(nil)
+	tg.On("AnswerCallbackQuery", mock.Anything, mock.Anything, "This button is no longer relevant").Return(nil).Once()
+	scrap.On("RegUser", mock.Anything, mock.Anything).Return(nil)
+	scrap.On("SetLanguage", mock.Anything, mock.Anything, i18n.English).Return(nil)
+	scrap.On("UserLinks", mock.Anything, mock.Anything).Return([]tgbot.SavedLink{{ID: 7, URL: link}}, nil)
+	scrap.On("AddLink", mock.Anything, mock.Anything, userCtx).Return(nil)
+	scrap.On("RemoveLink", mock.Anything, mock.Anything, link).Return(nil)
+	ctxStore.On("RegUser", mock.Anything, mock.Anything).Return(nil)
+	ctxStore.On("AddURL", mock.Anything, mock.Anything, link).Return(nil)
+	ctxStore.On("AddTags", mock.Anything, mock.Anything, []string{}).Return(nil)
+	ctxStore.On("AddFilters", mock.Anything, mock.Anything, []string{}).Return(nil)
+	ctxStore.On("UserContext", mock.Anything, mock.Anything).Return(userCtx, nil)
+	cache.On("InvalidateUserCache", mock.Anything, mock.Anything).Return(nil)
+
+	tgBot := botservice.New(tg, scrap, ctxStore, stateStore, langStore, cache, logger, botLimit)
+
+	assert.NoError(t, tgBot.Init(context.Background()))
+
+	message := func(text string) tgbot.Update {
+		return tgbot.Update{Msg: tgbot.Message{From: tgbot.User{ID: testID, LanguageCode: "en-US"}, Text: text}}
+	}
+
+	button := func(data string) tgbot.Update {
+		return tgbot.Update{CallbackQuery: &tgbot.CallbackQuery{
+			ID:      "callback",
+			From:    tgbot.User{ID: testID},
+			Message: &tgbot.Message{MessageID: 1},
+			Data:    data,
+		}}
+	}
+
+	type testCase struct {
+		name   string
+		update tgbot.Update
+		state  tgbot.State
+	}
+
+	tests := []testCase{
+		{
+			name:   "незарегистрированный пользователь не может выполнять команды",
+			update: message(botservice.Help),
+			state:  "",
+		},
+		{
+			name:   "регистрация пользователя",
+			update: message(botservice.Start),
+			state:  botservice.AnyRegisteredCommand,
+		},
+		{
+			name:   "начало добавления ссылки",
+			update: message(botservice.Track),
+			state:  botservice.AddNewLink,
+		},
+		{
+			name:   "ввод ссылки",
+			update: message(link),
+			state:  botservice.AddLinkTag,
+		},
+		{
+			name:   "пропуск ввода тегов кнопкой",
+			update: button("skip"),
+			state:  botservice.AddLinkFilter,
+		},
+		{
+			name:   "кнопка удаления не относится к текущему шагу",
+			update: button("untrack:7"),
+			state:  botservice.AddLinkFilter,
+		},
+		{
+			name:   "пропуск ввода фильтров кнопкой сохраняет ссылку",
+			update: button("skip"),
+			state:  botservice.AnyRegisteredCommand,
+		},
+		{
+			name:   "начало удаления ссылки",
+			update: message(botservice.Untrack),
+			state:  botservice.RemoveLink,
+		},
+		{
+			name:   "удаление ссылки кнопкой",
+			update: button("untrack:7"),
+			state:  botservice.AnyRegisteredCommand,
+		},
+	}
+
+	for _, test := range tests {
+		tgBot.ProcessUpdate(context.Background(), test.update)
+
+		state, err := stateStore.UserState(context.Background(), testID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, test.state, state, test.name)
+	}
+
+	lang, err := langStore.UserLang(context.Background(), testID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, i18n.English, lang, "язык берется из настроек Telegram")
+
+	tg.AssertCalled(t, "SendKeyboard", mock.Anything, mock.Anything, botservice.Text(i18n.English, botservice.UntrackLink),
+		&tgbot.InlineKeyboardMarkup{
+			InlineKeyboard: [][]tgbot.InlineKeyboardButton{{{Text: link, CallbackData: "untrack:7"}}},
+		})
+}
+
+func TestTgBot_RemoveChat(t *testing.T) {
+	type testCase struct {
+		name    string
+		scrap   func() *mocks.ScrapClient
+		correct bool
+	}
+
+	tests := []testCase{
+		{
+			name: "пользователь удален в скраппере и в боте",
+			scrap: func() *mocks.ScrapClient {
+				scrap := mocks.NewScrapClient(t)
+				scrap.On("DeleteUser", mock.Anything, int64(testID)).Return(nil).Once()
+
+				return scrap
+			},
+			correct: true,
+		},
+		{
+			name: "скраппер недоступен, данные бота все равно удаляются",
+			scrap: func() *mocks.ScrapClient {
+				scrap := mocks.NewScrapClient(t)
+				scrap.On("DeleteUser", mock.Anything, int64(testID)).Return(errTest).Once()
+
+				return scrap
+			},
+			correct: false,
+		},
+	}
+
+	for _, test := range tests {
+		ctxStore := mocks.NewCtxStorage(t)
+		cache := mocks.NewCacheStorage(t)
+		stateStore := tgbot.NewMemoryStateStore()
+		langStore := tgbot.NewMemoryLangStore()
+
+		ctxStore.On("DeleteUser", mock.Anything, int64(testID)).Return(nil).Once()
+		cache.On("InvalidateUserCache", mock.Anything, int64(testID)).Return(nil).Once()
+
+		assert.NoError(t, stateStore.SetUserState(context.Background(), testID, botservice.AnyRegisteredCommand))
+		assert.NoError(t, langStore.SetUserLang(context.Background(), testID, i18n.English))
+
+		tgBot := botservice.New(mocks.NewTgClient(t), test.scrap(), ctxStore, stateStore, langStore, cache, logger, botLimit)
+		err := tgBot.RemoveChat(context.Background(), testID)
+
+		if test.correct {
+			assert.NoError(t, err, test.name)
+		} else {
+			assert.Error(t, err, test.name)
+		}
+
+		state, _ := stateStore.UserState(context.Background(), testID)
+		lang, _ := langStore.UserLang(context.Background(), testID)
+
+		assert.Empty(t, state, test.name)
+		assert.Empty(t, lang, test.name)
+	}
+}
@@ -0,0 +1,96 @@
+package botservice
+
+import (
+	"context"
+	"linkTraccer/internal/domain/i18n"
+	"linkTraccer/internal/domain/tgbot"
+	"strconv"
+	"strings"
+)
+
+// В callback_data помещается не больше 64 байт, поэтому кнопка удаления хранит id ссылки, а не ее url.
+
+const (
+	untrackCallback = "untrack:"
+	skipCallback    = "skip"
+)
+
+// processCallback превращает нажатие кнопки в событие диалога, кнопки прошлых шагов отклоняются.
+func (bot *TgBot) processCallback(ctx context.Context, query *tgbot.CallbackQuery) {
+	id := query.From.ID
+
+	event, choice, err := bot.callbackEvent(ctx, id, query.Data)
+	if err != nil {
+		bot.log.Debug("нажатие кнопки не обработано", "err", err.Error())
+		bot.answerCallback(ctx, query.ID, bot.text(ctx, id, ButtonOutdated))
+
+		return
+	}
+
+	bot.answerCallback(ctx, query.ID, "")
+
+	if query.Message != nil {
+		if err := bot.tg.EditMessageText(ctx, id, query.Message.MessageID, query.Message.Text+"\n\n"+choice); err != nil {
+			bot.log.Error("ошибка при удалении клавиатуры из сообщения", "err", err.Error())
+		}
+	}
+
+	bot.processEvent(ctx, id, event)
+}
+
+func (bot *TgBot) callbackEvent(ctx context.Context, id tgbot.ID, data string) (tgbot.Event, string, error) {
+	state, err := bot.states.Current(ctx, id)
+	if err != nil {
+		return "", "", err
+	}
+
+	switch {
+	case strings.HasPrefix(data, untrackCallback) && state == RemoveLink:
+		linkID, err := strconv.ParseInt(strings.TrimPrefix(data, untrackCallback), 10, 64)
+		if err != nil {
+			return "", "", ErrButtonOutdated
+		}
+
+		links, err := bot.scrap.UserLinks(ctx, id)
+		if err != nil {
+			return "", "", err
+		}
+
+		for _, link := range links {
+			if link.ID == linkID {
+				return link.URL, "➡️ " + link.URL, nil
+			}
+		}
+
+		return "", "", ErrButtonOutdated
+	case data == skipCallback && (state == AddLinkTag || state == AddLinkFilter):
+		return "", "➡️ " + bot.text(ctx, id, SkipButton), nil
+	default:
+		return "", "", ErrButtonOutdated
+	}
+}
+
+func (bot *TgBot) answerCallback(ctx context.Context, callbackID, text string) {
+	if err := bot.tg.AnswerCallbackQuery(ctx, callbackID, text); err != nil {
+		bot.log.Error("ошибка при ответе на нажатие кнопки", "err", err.Error())
+	}
+}
+
+func untrackKeyboard(links []tgbot.SavedLink) *tgbot.InlineKeyboardMarkup {
+	keyboard := &tgbot.InlineKeyboardMarkup{InlineKeyboard: make([][]tgbot.InlineKeyboardButton, 0, len(links))}
+
+	for _, link := range links {
+		keyboard.InlineKeyboard = append(keyboard.InlineKeyboard, []tgbot.InlineKeyboardButton{{
+			Text:         link.URL,
+			CallbackData: untrackCallback + strconv.FormatInt(link.ID, 10),
+		}})
+	}
+
+	return keyboard
+}
+
+func skipKeyboard(lang i18n.Lang) *tgbot.InlineKeyboardMarkup {
+	return &tgbot.InlineKeyboardMarkup{InlineKeyboard: [][]tgbot.InlineKeyboardButton{{
+		{Text: Text(lang, SkipButton), CallbackData: skipCallback},
+	}}}
+}
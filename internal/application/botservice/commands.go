@@ -1,17 +1,57 @@
 package botservice
 
+import (
+	"linkTraccer/internal/domain/tgbot"
+	"strings"
+)
+
+const (
+	Start    = "/start"    // Регистрация пользователя
+	Help     = "/help"     // Вывод списка доступных команд.
+	Track    = "/track"    // Начать отслеживание ссылки
+	Untrack  = "/untrack"  //  Прекратить отслеживание ссылки.
+	List     = "/list"     // Показать список отслеживаемых ссылок, /list <тег> - только ссылки с этим тегом
+	Digest   = "/digest"   // /digest HH:MM - получать обновления раз в день, /digest off - получать сразу
+	Quiet    = "/quiet"    // /quiet HH:MM-HH:MM - не присылать уведомления в этот интервал, /quiet off - выключить
+	Timezone = "/timezone" // /timezone <пояс> - часовой пояс для тихих часов, дайджеста и времени в уведомлениях
+	Lang     = "/lang"     // /lang <язык> - язык бота вместо языка из настроек Telegram
+	Interval = "/interval" // /interval <ссылка> <интервал> - как часто проверять ссылку, off - адаптивно
+)
+
 const (
-	Start   = "/start"   // Регистрация пользователя
-	Help    = "/help"    // Вывод списка доступных команд.
-	Track   = "/track"   // Начать отслеживание ссылки
-	Untrack = "/untrack" //  Прекратить отслеживание ссылки.
-	List    = "/list"    // Показать список отслеживаемых ссылок (cписок ссылок, полученных при /track)
+	settingOff     = "off"
+	timeLayout     = "15:04"
+	quietSeparator = "-"
 )
 
+// commandsDescription - команды для меню бота и ключи их описаний в каталоге сообщений.
 var commandsDescription = [][2]string{
-	{Start, "начало общения с ботом"},
-	{Help, "вывод всех команд"},
-	{Track, "начать отслеживать ссылку"},
-	{Untrack, "перестать отслеживать ссылку"},
-	{List, "список сохраненных ссылок"},
+	{Start, startDescription},
+	{Help, helpDescription},
+	{Track, trackDescription},
+	{Untrack, untrackDescription},
+	{List, listDescription},
+	{Digest, digestDescription},
+	{Quiet, quietDescription},
+	{Timezone, timezoneDescription},
+	{Lang, langDescription},
+	{Interval, intervalDescription},
+}
+
+// parseCommand отделяет команду от ее аргумента: "/list work" -> "/list", "work".
+func parseCommand(event tgbot.Event) (command, arg string) {
+	command, arg, _ = strings.Cut(strings.TrimSpace(event), " ")
+
+	return command, strings.TrimSpace(arg)
+}
+
+// commandEvent возвращает для команды с аргументом саму команду, а для текста - текст.
+func commandEvent(event tgbot.Event) tgbot.Event {
+	if !strings.HasPrefix(event, "/") {
+		return event
+	}
+
+	command, _ := parseCommand(event)
+
+	return command
 }
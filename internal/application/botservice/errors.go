@@ -3,3 +3,4 @@ package botservice
 import "errors"
 
 var ErrCommandNotFound = errors.New("команда бота не найдена")
+var ErrButtonOutdated = errors.New("кнопка не относится к текущему шагу диалога")
@@ -1,27 +1,242 @@
 package botservice
 
-// Сообщения пользователю
+import (
+	"linkTraccer/internal/domain/i18n"
+	"linkTraccer/internal/domain/scrapper"
+)
+
+// Ключи сообщений пользователю, тексты на каждом языке лежат в messages.
+
+const (
+	HelpMessage      = "help"
+	FirstMessage     = "first"
+	AddLinkFilterMsg = "addLinkFilter"
+	NoSavedLinks     = "noSavedLinks"
+	NotSaveThisLink  = "notSaveThisLink"
+	UnknownCommand   = "unknownCommand"
+	UntrackLink      = "untrackLink"
+	TrackLink        = "trackLink"
+	LinkDeleted      = "linkDeleted"
+	AddLinkTagMsg    = "addLinkTag"
+	WrongLink        = "wrongLink"
+	GoodLink         = "goodLink"
+	LinksHeader      = "linksHeader"
+	NoLinksWithTag   = "noLinksWithTag"
+	WithoutTag       = "withoutTag"
+	SkipButton       = "skipButton"
+	ButtonOutdated   = "buttonOutdated"
+	DigestEnabled    = "digestEnabled"
+	DigestDisabled   = "digestDisabled"
+	DigestUsage      = "digestUsage"
+	QuietEnabled     = "quietEnabled"
+	QuietDisabled    = "quietDisabled"
+	QuietUsage       = "quietUsage"
+	TimezoneSaved    = "timezoneSaved"
+	TimezoneUsage    = "timezoneUsage"
+	LangSaved        = "langSaved"
+	LangUsage        = "langUsage"
+	IntervalSaved    = "intervalSaved"
+	IntervalReset    = "intervalReset"
+	IntervalUsage    = "intervalUsage"
+)
+
+// Описания команд для меню бота.
+
+const (
+	startDescription    = "startDescription"
+	helpDescription     = "helpDescription"
+	trackDescription    = "trackDescription"
+	untrackDescription  = "untrackDescription"
+	listDescription     = "listDescription"
+	digestDescription   = "digestDescription"
+	quietDescription    = "quietDescription"
+	timezoneDescription = "timezoneDescription"
+	langDescription     = "langDescription"
+	intervalDescription = "intervalDescription"
+)
+
+// Тексты уведомлений об обновлениях ссылок.
+
+const (
+	updateHeader     = "updateHeader"
+	updateEvent      = "updateEvent"
+	updateTitle      = "updateTitle"
+	updateAuthor     = "updateAuthor"
+	updateCreatedAt  = "updateCreatedAt"
+	updateExcerpt    = "updateExcerpt"
+	digestHeader     = "digestHeader"
+	digestLinkFormat = "digestLink"
+	digestItemFormat = "digestItem"
+)
 
 const (
-	HelpMessage = `Команды:
+	ruHelp = `Команды:
 
  /start - поможет перезапустить бота
-	/help - справка по всем командам 
+	/help - справка по всем командам
 	/track - добавить новую ссылку, на отслеживание
 	/untrack - удалить ссылку, за которой следите
-	/list - вернуть список всех отслеживаемых ссылок`
-
-	FirstMessage = `Привет! Я бот, который может уведомлять тебя, об изменения в публичных репозиториях GitHub и о новых
-ответах, на интересующий тебя вопрос StackOverflow` + "\n\n" + HelpMessage
-
-	NoSavedLinks     = "У вас нет сохраненных ссылок😟"
-	NotSaveThisLink  = "Вы не сохраняли такой ссылки❌"
-	UnknownCommand   = "Я пока не знаю такой команды 😔. Введите /help"
-	UntrackLink      = "Введите ссылку, которую хотите перестать отслеживать⬇️"
-	TrackLink        = "Введите ссылку, которую хотите начать отслеживать⬇️"
-	LinkDeleted      = "Ссылка больше не отслеживаается✔️"
-	AddLinkTagMsg    = "Добавьте тег для ссылки💬"
-	AddLinkFilterMsg = "Введите фильтр для ссылки👁️‍🗨️"
-	WrongLink        = "Ваша ссылка не поддерживается❌"
-	GoodLink         = "Ссылка успешно сохранена✔️"
+	/list - вернуть список всех отслеживаемых ссылок
+	/list <тег> - вернуть список ссылок с тегом
+	/digest HH:MM - присылать обновления одним сообщением раз в день (время в вашем часовом поясе)
+	/digest off - присылать обновления сразу
+	/quiet 23:00-08:00 - не присылать уведомления ночью, они придут после окончания тихих часов
+	/quiet off - выключить тихие часы
+	/timezone Europe/Moscow - часовой пояс для тихих часов, дайджеста и времени в уведомлениях
+	/lang ru|en - язык бота
+	/interval <ссылка> 30m - проверять ссылку с этим интервалом (от 1m до 24h)
+	/interval <ссылка> off - проверять ссылку чаще, когда на ней много обновлений, и реже, когда их нет`
+
+	enHelp = `Commands:
+
+ /start - restart the bot
+	/help - help on all commands
+	/track - start tracking a new link
+	/untrack - stop tracking a link
+	/list - show all tracked links
+	/list <tag> - show links with the tag
+	/digest HH:MM - send updates as one message once a day (in your time zone)
+	/digest off - send updates right away
+	/quiet 23:00-08:00 - hold notifications at night, they will arrive when quiet hours end
+	/quiet off - turn quiet hours off
+	/timezone Europe/Moscow - time zone for quiet hours, digest and times in notifications
+	/lang ru|en - bot language
+	/interval <link> 30m - check the link at this interval (from 1m to 24h)
+	/interval <link> off - check the link more often when it is busy and less often when it is quiet`
 )
+
+var messages = i18n.Catalog{
+	i18n.Russian: {
+		HelpMessage: ruHelp,
+		FirstMessage: `Привет! Я бот, который может уведомлять тебя, об изменения в публичных репозиториях GitHub и о новых
+ответах, на интересующий тебя вопрос StackOverflow` + "\n\n" + ruHelp,
+		AddLinkFilterMsg: `Введите фильтры для ссылки через пробел👁️‍🗨️
+
+	user:<логин> - не присылать события этого пользователя
+	type:<типы> - присылать только события этих типов: issue, pr, answer, comment, например type:issue|pr
+	<слово> - присылать только события, содержащие слово
+	-<слово> - не присылать события, содержащие слово`,
+		NoSavedLinks:    "У вас нет сохраненных ссылок😟",
+		NotSaveThisLink: "Вы не сохраняли такой ссылки❌",
+		UnknownCommand:  "Я пока не знаю такой команды 😔. Введите /help",
+		UntrackLink:     "Выберите или введите ссылку, которую хотите перестать отслеживать⬇️",
+		TrackLink:       "Введите ссылку, которую хотите начать отслеживать⬇️",
+		LinkDeleted:     "Ссылка больше не отслеживаается✔️",
+		AddLinkTagMsg:   "Добавьте теги для ссылки через пробел💬",
+		WrongLink:       "Ваша ссылка не поддерживается❌",
+		GoodLink:        "Ссылка успешно сохранена✔️",
+		LinksHeader:     "Список ваших ссылок:\n",
+		NoLinksWithTag:  "У вас нет ссылок с тегом %s😟",
+		WithoutTag:      "Без тега",
+		SkipButton:      "Пропустить ⏭",
+		ButtonOutdated:  "Эта кнопка уже неактуальна",
+		DigestEnabled:   "Теперь обновления будут приходить одним сообщением в %s по вашему часовому поясу📰",
+		DigestDisabled:  "Теперь обновления будут приходить сразу🔔",
+		DigestUsage:     "Укажите время дайджеста в формате HH:MM, например /digest 09:00, или /digest off❗",
+		QuietEnabled:    "Тихие часы включены с %s до %s🌙 Уведомления за это время придут после их окончания",
+		QuietDisabled:   "Тихие часы выключены🔔",
+		QuietUsage:      "Укажите тихие часы в формате HH:MM-HH:MM, например /quiet 23:00-08:00, или /quiet off❗",
+		TimezoneSaved:   "Часовой пояс %s сохранен🌍",
+		TimezoneUsage:   "Укажите часовой пояс из базы IANA, например /timezone Europe/Moscow❗",
+		LangSaved:       "Теперь я буду общаться с вами на русском🇷🇺",
+		LangUsage:       "Укажите язык: /lang ru или /lang en❗",
+		IntervalSaved:   "Ссылка %s будет проверяться раз в %s⏱",
+		IntervalReset:   "Ссылка %s снова проверяется по адаптивному расписанию⏱",
+		IntervalUsage:   "Укажите ссылку и интервал от 1m до 24h, например /interval <ссылка> 30m, или /interval <ссылка> off❗",
+
+		startDescription:    "начало общения с ботом",
+		helpDescription:     "вывод всех команд",
+		trackDescription:    "начать отслеживать ссылку",
+		untrackDescription:  "перестать отслеживать ссылку",
+		listDescription:     "список сохраненных ссылок, /list <тег> - ссылки с тегом",
+		digestDescription:   "ежедневный дайджест, /digest HH:MM или /digest off",
+		quietDescription:    "тихие часы, /quiet 23:00-08:00 или /quiet off",
+		timezoneDescription: "часовой пояс, например /timezone Europe/Moscow",
+		langDescription:     "язык бота, /lang ru или /lang en",
+		intervalDescription: "интервал проверки ссылки, /interval <ссылка> 30m или off",
+
+		updateHeader:     "Пришло новое уведомление 🔥\n\n",
+		updateEvent:      "Событие: %s (%s)\n",
+		updateTitle:      "Заголовок: %s\n",
+		updateAuthor:     "Пользователь: %s\n",
+		updateCreatedAt:  "Время создания: %s\n",
+		updateExcerpt:    "Превью: %s\n",
+		digestHeader:     "Дайджест обновлений 📰\n",
+		digestLinkFormat: "\n🔗 %s\n",
+		digestItemFormat: "• %s, %s (%s)\n%s\n",
+
+		scrapper.IssueUpdate:   "Issue",
+		scrapper.PRUpdate:      "Pull Request",
+		scrapper.AnswerUpdate:  "Ответ",
+		scrapper.CommentUpdate: "Комментарий",
+	},
+	i18n.English: {
+		HelpMessage: enHelp,
+		FirstMessage: `Hi! I am a bot that can notify you about changes in public GitHub repositories and about new
+answers to StackOverflow questions you are interested in` + "\n\n" + enHelp,
+		AddLinkFilterMsg: `Enter link filters separated by spaces👁️‍🗨️
+
+	user:<login> - do not send events by this user
+	type:<types> - send only events of these types: issue, pr, answer, comment, e.g. type:issue|pr
+	<word> - send only events containing the word
+	-<word> - do not send events containing the word`,
+		NoSavedLinks:    "You have no saved links😟",
+		NotSaveThisLink: "You have not saved this link❌",
+		UnknownCommand:  "I don't know this command yet 😔. Type /help",
+		UntrackLink:     "Choose or enter the link you want to stop tracking⬇️",
+		TrackLink:       "Enter the link you want to start tracking⬇️",
+		LinkDeleted:     "The link is no longer tracked✔️",
+		AddLinkTagMsg:   "Add link tags separated by spaces💬",
+		WrongLink:       "Your link is not supported❌",
+		GoodLink:        "The link has been saved✔️",
+		LinksHeader:     "Your links:\n",
+		NoLinksWithTag:  "You have no links tagged %s😟",
+		WithoutTag:      "No tag",
+		SkipButton:      "Skip ⏭",
+		ButtonOutdated:  "This button is no longer relevant",
+		DigestEnabled:   "Updates will now arrive as one message at %s in your time zone📰",
+		DigestDisabled:  "Updates will now arrive right away🔔",
+		DigestUsage:     "Specify the digest time as HH:MM, for example /digest 09:00, or /digest off❗",
+		QuietEnabled:    "Quiet hours are on from %s to %s🌙 Notifications will arrive when they end",
+		QuietDisabled:   "Quiet hours are off🔔",
+		QuietUsage:      "Specify quiet hours as HH:MM-HH:MM, for example /quiet 23:00-08:00, or /quiet off❗",
+		TimezoneSaved:   "Time zone %s saved🌍",
+		TimezoneUsage:   "Specify an IANA time zone, for example /timezone Europe/Moscow❗",
+		LangSaved:       "From now on I will talk to you in English🇬🇧",
+		LangUsage:       "Specify the language: /lang ru or /lang en❗",
+		IntervalSaved:   "The link %s will be checked every %s⏱",
+		IntervalReset:   "The link %s is checked on the adaptive schedule again⏱",
+		IntervalUsage:   "Specify the link and an interval from 1m to 24h, for example /interval <link> 30m, or /interval <link> off❗",
+
+		startDescription:    "start talking to the bot",
+		helpDescription:     "list all commands",
+		trackDescription:    "start tracking a link",
+		untrackDescription:  "stop tracking a link",
+		listDescription:     "saved links, /list <tag> - links with the tag",
+		digestDescription:   "daily digest, /digest HH:MM or /digest off",
+		quietDescription:    "quiet hours, /quiet 23:00-08:00 or /quiet off",
+		timezoneDescription: "time zone, for example /timezone Europe/Moscow",
+		langDescription:     "bot language, /lang ru or /lang en",
+		intervalDescription: "link check interval, /interval <link> 30m or off",
+
+		updateHeader:     "New notification 🔥\n\n",
+		updateEvent:      "Event: %s (%s)\n",
+		updateTitle:      "Title: %s\n",
+		updateAuthor:     "User: %s\n",
+		updateCreatedAt:  "Created at: %s\n",
+		updateExcerpt:    "Preview: %s\n",
+		digestHeader:     "Updates digest 📰\n",
+		digestLinkFormat: "\n🔗 %s\n",
+		digestItemFormat: "• %s, %s (%s)\n%s\n",
+
+		scrapper.IssueUpdate:   "Issue",
+		scrapper.PRUpdate:      "Pull Request",
+		scrapper.AnswerUpdate:  "Answer",
+		scrapper.CommentUpdate: "Comment",
+	},
+}
+
+// Text возвращает сообщение с ключом key на языке lang.
+func Text(lang i18n.Lang, key string) string {
+	return messages.Text(lang, key)
+}
@@ -2,7 +2,11 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // CacheStorage is an autogenerated mock type for the CacheStorage type
 type CacheStorage struct {
@@ -17,9 +21,9 @@ func (_m *CacheStorage) EXPECT() *CacheStorage_Expecter {
 	return &CacheStorage_Expecter{mock: &_m.Mock}
 }
 
-// GetUserLinks provides a mock function with given fields: id
-func (_m *CacheStorage) GetUserLinks(id int64) (string, error) {
-	ret := _m.Called(id)
+// GetUserLinks provides a mock function with given fields: ctx, id, tag
+func (_m *CacheStorage) GetUserLinks(ctx context.Context, id int64, tag string) (string, error) {
+	ret := _m.Called(ctx, id, tag)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetUserLinks")
@@ -27,17 +31,17 @@ func (_m *CacheStorage) GetUserLinks(id int64) (string, error) {
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int64) (string, error)); ok {
-		return rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) (string, error)); ok {
+		return rf(ctx, id, tag)
 	}
-	if rf, ok := ret.Get(0).(func(int64) string); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) string); ok {
+		r0 = rf(ctx, id, tag)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(int64) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, id, tag)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -51,14 +55,16 @@ type CacheStorage_GetUserLinks_Call struct {
 }
 
 // GetUserLinks is a helper method to define mock.On call
+//   - ctx context.Context
 //   - id int64
-func (_e *CacheStorage_Expecter) GetUserLinks(id interface{}) *CacheStorage_GetUserLinks_Call {
-	return &CacheStorage_GetUserLinks_Call{Call: _e.mock.On("GetUserLinks", id)}
+//   - tag string
+func (_e *CacheStorage_Expecter) GetUserLinks(ctx interface{}, id interface{}, tag interface{}) *CacheStorage_GetUserLinks_Call {
+	return &CacheStorage_GetUserLinks_Call{Call: _e.mock.On("GetUserLinks", ctx, id, tag)}
 }
 
-func (_c *CacheStorage_GetUserLinks_Call) Run(run func(id int64)) *CacheStorage_GetUserLinks_Call {
+func (_c *CacheStorage_GetUserLinks_Call) Run(run func(ctx context.Context, id int64, tag string)) *CacheStorage_GetUserLinks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int64))
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
 	})
 	return _c
 }
@@ -68,22 +74,22 @@ func (_c *CacheStorage_GetUserLinks_Call) Return(_a0 string, _a1 error) *CacheSt
 	return _c
 }
 
-func (_c *CacheStorage_GetUserLinks_Call) RunAndReturn(run func(int64) (string, error)) *CacheStorage_GetUserLinks_Call {
+func (_c *CacheStorage_GetUserLinks_Call) RunAndReturn(run func(context.Context, int64, string) (string, error)) *CacheStorage_GetUserLinks_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// InvalidateUserCache provides a mock function with given fields: id
-func (_m *CacheStorage) InvalidateUserCache(id int64) error {
-	ret := _m.Called(id)
+// InvalidateUserCache provides a mock function with given fields: ctx, id
+func (_m *CacheStorage) InvalidateUserCache(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for InvalidateUserCache")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64) error); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -97,14 +103,15 @@ type CacheStorage_InvalidateUserCache_Call struct {
 }
 
 // InvalidateUserCache is a helper method to define mock.On call
+//   - ctx context.Context
 //   - id int64
-func (_e *CacheStorage_Expecter) InvalidateUserCache(id interface{}) *CacheStorage_InvalidateUserCache_Call {
-	return &CacheStorage_InvalidateUserCache_Call{Call: _e.mock.On("InvalidateUserCache", id)}
+func (_e *CacheStorage_Expecter) InvalidateUserCache(ctx interface{}, id interface{}) *CacheStorage_InvalidateUserCache_Call {
+	return &CacheStorage_InvalidateUserCache_Call{Call: _e.mock.On("InvalidateUserCache", ctx, id)}
 }
 
-func (_c *CacheStorage_InvalidateUserCache_Call) Run(run func(id int64)) *CacheStorage_InvalidateUserCache_Call {
+func (_c *CacheStorage_InvalidateUserCache_Call) Run(run func(ctx context.Context, id int64)) *CacheStorage_InvalidateUserCache_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int64))
+		run(args[0].(context.Context), args[1].(int64))
 	})
 	return _c
 }
@@ -114,22 +121,22 @@ func (_c *CacheStorage_InvalidateUserCache_Call) Return(_a0 error) *CacheStorage
 	return _c
 }
 
-func (_c *CacheStorage_InvalidateUserCache_Call) RunAndReturn(run func(int64) error) *CacheStorage_InvalidateUserCache_Call {
+func (_c *CacheStorage_InvalidateUserCache_Call) RunAndReturn(run func(context.Context, int64) error) *CacheStorage_InvalidateUserCache_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SetUserLinks provides a mock function with given fields: id, links
-func (_m *CacheStorage) SetUserLinks(id int64, links string) error {
-	ret := _m.Called(id, links)
+// SetUserLinks provides a mock function with given fields: ctx, id, tag, links
+func (_m *CacheStorage) SetUserLinks(ctx context.Context, id int64, tag string, links string) error {
+	ret := _m.Called(ctx, id, tag, links)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SetUserLinks")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64, string) error); ok {
-		r0 = rf(id, links)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, string) error); ok {
+		r0 = rf(ctx, id, tag, links)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -143,15 +150,17 @@ type CacheStorage_SetUserLinks_Call struct {
 }
 
 // SetUserLinks is a helper method to define mock.On call
+//   - ctx context.Context
 //   - id int64
+//   - tag string
 //   - links string
-func (_e *CacheStorage_Expecter) SetUserLinks(id interface{}, links interface{}) *CacheStorage_SetUserLinks_Call {
-	return &CacheStorage_SetUserLinks_Call{Call: _e.mock.On("SetUserLinks", id, links)}
+func (_e *CacheStorage_Expecter) SetUserLinks(ctx interface{}, id interface{}, tag interface{}, links interface{}) *CacheStorage_SetUserLinks_Call {
+	return &CacheStorage_SetUserLinks_Call{Call: _e.mock.On("SetUserLinks", ctx, id, tag, links)}
 }
 
-func (_c *CacheStorage_SetUserLinks_Call) Run(run func(id int64, links string)) *CacheStorage_SetUserLinks_Call {
+func (_c *CacheStorage_SetUserLinks_Call) Run(run func(ctx context.Context, id int64, tag string, links string)) *CacheStorage_SetUserLinks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int64), args[1].(string))
+		run(args[0].(context.Context), args[1].(int64), args[2].(string), args[3].(string))
 	})
 	return _c
 }
@@ -161,7 +170,7 @@ func (_c *CacheStorage_SetUserLinks_Call) Return(_a0 error) *CacheStorage_SetUse
 	return _c
 }
 
-func (_c *CacheStorage_SetUserLinks_Call) RunAndReturn(run func(int64, string) error) *CacheStorage_SetUserLinks_Call {
+func (_c *CacheStorage_SetUserLinks_Call) RunAndReturn(run func(context.Context, int64, string, string) error) *CacheStorage_SetUserLinks_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -3,6 +3,7 @@
 package mocks
 
 import (
+	context "context"
 	tgbot "linkTraccer/internal/domain/tgbot"
 
 	mock "github.com/stretchr/testify/mock"
@@ -21,17 +22,17 @@ func (_m *CtxStorage) EXPECT() *CtxStorage_Expecter {
 	return &CtxStorage_Expecter{mock: &_m.Mock}
 }
 
-// AddFilters provides a mock function with given fields: id, filters
-func (_m *CtxStorage) AddFilters(id int64, filters []string) error {
-	ret := _m.Called(id, filters)
+// AddFilters provides a mock function with given fields: ctx, id, filters
+func (_m *CtxStorage) AddFilters(ctx context.Context, id int64, filters []string) error {
+	ret := _m.Called(ctx, id, filters)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AddFilters")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64, []string) error); ok {
-		r0 = rf(id, filters)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []string) error); ok {
+		r0 = rf(ctx, id, filters)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -45,15 +46,16 @@ type CtxStorage_AddFilters_Call struct {
 }
 
 // AddFilters is a helper method to define mock.On call
+//   - ctx context.Context
 //   - id int64
 //   - filters []string
-func (_e *CtxStorage_Expecter) AddFilters(id interface{}, filters interface{}) *CtxStorage_AddFilters_Call {
-	return &CtxStorage_AddFilters_Call{Call: _e.mock.On("AddFilters", id, filters)}
+func (_e *CtxStorage_Expecter) AddFilters(ctx interface{}, id interface{}, filters interface{}) *CtxStorage_AddFilters_Call {
+	return &CtxStorage_AddFilters_Call{Call: _e.mock.On("AddFilters", ctx, id, filters)}
 }
 
-func (_c *CtxStorage_AddFilters_Call) Run(run func(id int64, filters []string)) *CtxStorage_AddFilters_Call {
+func (_c *CtxStorage_AddFilters_Call) Run(run func(ctx context.Context, id int64, filters []string)) *CtxStorage_AddFilters_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int64), args[1].([]string))
+		run(args[0].(context.Context), args[1].(int64), args[2].([]string))
 	})
 	return _c
 }
@@ -63,22 +65,22 @@ func (_c *CtxStorage_AddFilters_Call) Return(_a0 error) *CtxStorage_AddFilters_C
 	return _c
 }
 
-func (_c *CtxStorage_AddFilters_Call) RunAndReturn(run func(int64, []string) error) *CtxStorage_AddFilters_Call {
+func (_c *CtxStorage_AddFilters_Call) RunAndReturn(run func(context.Context, int64, []string) error) *CtxStorage_AddFilters_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// AddTags provides a mock function with given fields: id, tags
-func (_m *CtxStorage) AddTags(id int64, tags []string) error {
-	ret := _m.Called(id, tags)
+// AddTags provides a mock function with given fields: ctx, id, tags
+func (_m *CtxStorage) AddTags(ctx context.Context, id int64, tags []string) error {
+	ret := _m.Called(ctx, id, tags)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AddTags")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64, []string) error); ok {
-		r0 = rf(id, tags)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []string) error); ok {
+		r0 = rf(ctx, id, tags)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -92,15 +94,16 @@ type CtxStorage_AddTags_Call struct {
 }
 
 // AddTags is a helper method to define mock.On call
+//   - ctx context.Context
 //   - id int64
 //   - tags []string
-func (_e *CtxStorage_Expecter) AddTags(id interface{}, tags interface{}) *CtxStorage_AddTags_Call {
-	return &CtxStorage_AddTags_Call{Call: _e.mock.On("AddTags", id, tags)}
+func (_e *CtxStorage_Expecter) AddTags(ctx interface{}, id interface{}, tags interface{}) *CtxStorage_AddTags_Call {
+	return &CtxStorage_AddTags_Call{Call: _e.mock.On("AddTags", ctx, id, tags)}
 }
 
-func (_c *CtxStorage_AddTags_Call) Run(run func(id int64, tags []string)) *CtxStorage_AddTags_Call {
+func (_c *CtxStorage_AddTags_Call) Run(run func(ctx context.Context, id int64, tags []string)) *CtxStorage_AddTags_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int64), args[1].([]string))
+		run(args[0].(context.Context), args[1].(int64), args[2].([]string))
 	})
 	return _c
 }
@@ -110,22 +113,22 @@ func (_c *CtxStorage_AddTags_Call) Return(_a0 error) *CtxStorage_AddTags_Call {
 	return _c
 }
 
-func (_c *CtxStorage_AddTags_Call) RunAndReturn(run func(int64, []string) error) *CtxStorage_AddTags_Call {
+func (_c *CtxStorage_AddTags_Call) RunAndReturn(run func(context.Context, int64, []string) error) *CtxStorage_AddTags_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// AddURL provides a mock function with given fields: id, url
-func (_m *CtxStorage) AddURL(id int64, url string) error {
-	ret := _m.Called(id, url)
+// AddURL provides a mock function with given fields: ctx, id, url
+func (_m *CtxStorage) AddURL(ctx context.Context, id int64, url string) error {
+	ret := _m.Called(ctx, id, url)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AddURL")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64, string) error); ok {
-		r0 = rf(id, url)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, id, url)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -139,15 +142,16 @@ type CtxStorage_AddURL_Call struct {
 }
 
 // AddURL is a helper method to define mock.On call
+//   - ctx context.Context
 //   - id int64
 //   - url string
-func (_e *CtxStorage_Expecter) AddURL(id interface{}, url interface{}) *CtxStorage_AddURL_Call {
-	return &CtxStorage_AddURL_Call{Call: _e.mock.On("AddURL", id, url)}
+func (_e *CtxStorage_Expecter) AddURL(ctx interface{}, id interface{}, url interface{}) *CtxStorage_AddURL_Call {
+	return &CtxStorage_AddURL_Call{Call: _e.mock.On("AddURL", ctx, id, url)}
 }
 
-func (_c *CtxStorage_AddURL_Call) Run(run func(id int64, url string)) *CtxStorage_AddURL_Call {
+func (_c *CtxStorage_AddURL_Call) Run(run func(ctx context.Context, id int64, url string)) *CtxStorage_AddURL_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int64), args[1].(string))
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
 	})
 	return _c
 }
@@ -157,22 +161,69 @@ func (_c *CtxStorage_AddURL_Call) Return(_a0 error) *CtxStorage_AddURL_Call {
 	return _c
 }
 
-func (_c *CtxStorage_AddURL_Call) RunAndReturn(run func(int64, string) error) *CtxStorage_AddURL_Call {
+func (_c *CtxStorage_AddURL_Call) RunAndReturn(run func(context.Context, int64, string) error) *CtxStorage_AddURL_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RegUser provides a mock function with given fields: id
-func (_m *CtxStorage) RegUser(id int64) error {
-	ret := _m.Called(id)
+// DeleteUser provides a mock function with given fields: ctx, id
+func (_m *CtxStorage) DeleteUser(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CtxStorage_DeleteUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUser'
+type CtxStorage_DeleteUser_Call struct {
+	*mock.Call
+}
+
+// DeleteUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *CtxStorage_Expecter) DeleteUser(ctx interface{}, id interface{}) *CtxStorage_DeleteUser_Call {
+	return &CtxStorage_DeleteUser_Call{Call: _e.mock.On("DeleteUser", ctx, id)}
+}
+
+func (_c *CtxStorage_DeleteUser_Call) Run(run func(ctx context.Context, id int64)) *CtxStorage_DeleteUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *CtxStorage_DeleteUser_Call) Return(_a0 error) *CtxStorage_DeleteUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *CtxStorage_DeleteUser_Call) RunAndReturn(run func(context.Context, int64) error) *CtxStorage_DeleteUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RegUser provides a mock function with given fields: ctx, id
+func (_m *CtxStorage) RegUser(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for RegUser")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64) error); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -186,14 +237,15 @@ type CtxStorage_RegUser_Call struct {
 }
 
 // RegUser is a helper method to define mock.On call
+//   - ctx context.Context
 //   - id int64
-func (_e *CtxStorage_Expecter) RegUser(id interface{}) *CtxStorage_RegUser_Call {
-	return &CtxStorage_RegUser_Call{Call: _e.mock.On("RegUser", id)}
+func (_e *CtxStorage_Expecter) RegUser(ctx interface{}, id interface{}) *CtxStorage_RegUser_Call {
+	return &CtxStorage_RegUser_Call{Call: _e.mock.On("RegUser", ctx, id)}
 }
 
-func (_c *CtxStorage_RegUser_Call) Run(run func(id int64)) *CtxStorage_RegUser_Call {
+func (_c *CtxStorage_RegUser_Call) Run(run func(ctx context.Context, id int64)) *CtxStorage_RegUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int64))
+		run(args[0].(context.Context), args[1].(int64))
 	})
 	return _c
 }
@@ -203,22 +255,22 @@ func (_c *CtxStorage_RegUser_Call) Return(_a0 error) *CtxStorage_RegUser_Call {
 	return _c
 }
 
-func (_c *CtxStorage_RegUser_Call) RunAndReturn(run func(int64) error) *CtxStorage_RegUser_Call {
+func (_c *CtxStorage_RegUser_Call) RunAndReturn(run func(context.Context, int64) error) *CtxStorage_RegUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ResetCtx provides a mock function with given fields: id
-func (_m *CtxStorage) ResetCtx(id int64) error {
-	ret := _m.Called(id)
+// ResetCtx provides a mock function with given fields: ctx, id
+func (_m *CtxStorage) ResetCtx(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ResetCtx")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64) error); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -232,14 +284,15 @@ type CtxStorage_ResetCtx_Call struct {
 }
 
 // ResetCtx is a helper method to define mock.On call
+//   - ctx context.Context
 //   - id int64
-func (_e *CtxStorage_Expecter) ResetCtx(id interface{}) *CtxStorage_ResetCtx_Call {
-	return &CtxStorage_ResetCtx_Call{Call: _e.mock.On("ResetCtx", id)}
+func (_e *CtxStorage_Expecter) ResetCtx(ctx interface{}, id interface{}) *CtxStorage_ResetCtx_Call {
+	return &CtxStorage_ResetCtx_Call{Call: _e.mock.On("ResetCtx", ctx, id)}
 }
 
-func (_c *CtxStorage_ResetCtx_Call) Run(run func(id int64)) *CtxStorage_ResetCtx_Call {
+func (_c *CtxStorage_ResetCtx_Call) Run(run func(ctx context.Context, id int64)) *CtxStorage_ResetCtx_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int64))
+		run(args[0].(context.Context), args[1].(int64))
 	})
 	return _c
 }
@@ -249,14 +302,14 @@ func (_c *CtxStorage_ResetCtx_Call) Return(_a0 error) *CtxStorage_ResetCtx_Call
 	return _c
 }
 
-func (_c *CtxStorage_ResetCtx_Call) RunAndReturn(run func(int64) error) *CtxStorage_ResetCtx_Call {
+func (_c *CtxStorage_ResetCtx_Call) RunAndReturn(run func(context.Context, int64) error) *CtxStorage_ResetCtx_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UserContext provides a mock function with given fields: id
-func (_m *CtxStorage) UserContext(id int64) (*tgbot.ContextData, error) {
-	ret := _m.Called(id)
+// UserContext provides a mock function with given fields: ctx, id
+func (_m *CtxStorage) UserContext(ctx context.Context, id int64) (*tgbot.ContextData, error) {
+	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UserContext")
@@ -264,19 +317,19 @@ func (_m *CtxStorage) UserContext(id int64) (*tgbot.ContextData, error) {
 
 	var r0 *tgbot.ContextData
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int64) (*tgbot.ContextData, error)); ok {
-		return rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (*tgbot.ContextData, error)); ok {
+		return rf(ctx, id)
 	}
-	if rf, ok := ret.Get(0).(func(int64) *tgbot.ContextData); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *tgbot.ContextData); ok {
+		r0 = rf(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*tgbot.ContextData)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int64) error); ok {
-		r1 = rf(id)
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -290,14 +343,15 @@ type CtxStorage_UserContext_Call struct {
 }
 
 // UserContext is a helper method to define mock.On call
+//   - ctx context.Context
 //   - id int64
-func (_e *CtxStorage_Expecter) UserContext(id interface{}) *CtxStorage_UserContext_Call {
-	return &CtxStorage_UserContext_Call{Call: _e.mock.On("UserContext", id)}
+func (_e *CtxStorage_Expecter) UserContext(ctx interface{}, id interface{}) *CtxStorage_UserContext_Call {
+	return &CtxStorage_UserContext_Call{Call: _e.mock.On("UserContext", ctx, id)}
 }
 
-func (_c *CtxStorage_UserContext_Call) Run(run func(id int64)) *CtxStorage_UserContext_Call {
+func (_c *CtxStorage_UserContext_Call) Run(run func(ctx context.Context, id int64)) *CtxStorage_UserContext_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int64))
+		run(args[0].(context.Context), args[1].(int64))
 	})
 	return _c
 }
@@ -307,7 +361,7 @@ func (_c *CtxStorage_UserContext_Call) Return(_a0 *tgbot.ContextData, _a1 error)
 	return _c
 }
 
-func (_c *CtxStorage_UserContext_Call) RunAndReturn(run func(int64) (*tgbot.ContextData, error)) *CtxStorage_UserContext_Call {
+func (_c *CtxStorage_UserContext_Call) RunAndReturn(run func(context.Context, int64) (*tgbot.ContextData, error)) *CtxStorage_UserContext_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -3,9 +3,12 @@
 package mocks
 
 import (
+	context "context"
 	tgbot "linkTraccer/internal/domain/tgbot"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // ScrapClient is an autogenerated mock type for the ScrapClient type
@@ -21,17 +24,17 @@ func (_m *ScrapClient) EXPECT() *ScrapClient_Expecter {
 	return &ScrapClient_Expecter{mock: &_m.Mock}
 }
 
-// AddLink provides a mock function with given fields: _a0, _a1
-func (_m *ScrapClient) AddLink(_a0 int64, _a1 *tgbot.ContextData) error {
-	ret := _m.Called(_a0, _a1)
+// AddLink provides a mock function with given fields: ctx, id, data
+func (_m *ScrapClient) AddLink(ctx context.Context, id int64, data *tgbot.ContextData) error {
+	ret := _m.Called(ctx, id, data)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AddLink")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64, *tgbot.ContextData) error); ok {
-		r0 = rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *tgbot.ContextData) error); ok {
+		r0 = rf(ctx, id, data)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -45,15 +48,16 @@ type ScrapClient_AddLink_Call struct {
 }
 
 // AddLink is a helper method to define mock.On call
-//   - _a0 int64
-//   - _a1 *tgbot.ContextData
-func (_e *ScrapClient_Expecter) AddLink(_a0 interface{}, _a1 interface{}) *ScrapClient_AddLink_Call {
-	return &ScrapClient_AddLink_Call{Call: _e.mock.On("AddLink", _a0, _a1)}
+//   - ctx context.Context
+//   - id int64
+//   - data *tgbot.ContextData
+func (_e *ScrapClient_Expecter) AddLink(ctx interface{}, id interface{}, data interface{}) *ScrapClient_AddLink_Call {
+	return &ScrapClient_AddLink_Call{Call: _e.mock.On("AddLink", ctx, id, data)}
 }
 
-func (_c *ScrapClient_AddLink_Call) Run(run func(_a0 int64, _a1 *tgbot.ContextData)) *ScrapClient_AddLink_Call {
+func (_c *ScrapClient_AddLink_Call) Run(run func(ctx context.Context, id int64, data *tgbot.ContextData)) *ScrapClient_AddLink_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int64), args[1].(*tgbot.ContextData))
+		run(args[0].(context.Context), args[1].(int64), args[2].(*tgbot.ContextData))
 	})
 	return _c
 }
@@ -63,22 +67,116 @@ func (_c *ScrapClient_AddLink_Call) Return(_a0 error) *ScrapClient_AddLink_Call
 	return _c
 }
 
-func (_c *ScrapClient_AddLink_Call) RunAndReturn(run func(int64, *tgbot.ContextData) error) *ScrapClient_AddLink_Call {
+func (_c *ScrapClient_AddLink_Call) RunAndReturn(run func(context.Context, int64, *tgbot.ContextData) error) *ScrapClient_AddLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteUser provides a mock function with given fields: ctx, id
+func (_m *ScrapClient) DeleteUser(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ScrapClient_DeleteUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUser'
+type ScrapClient_DeleteUser_Call struct {
+	*mock.Call
+}
+
+// DeleteUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *ScrapClient_Expecter) DeleteUser(ctx interface{}, id interface{}) *ScrapClient_DeleteUser_Call {
+	return &ScrapClient_DeleteUser_Call{Call: _e.mock.On("DeleteUser", ctx, id)}
+}
+
+func (_c *ScrapClient_DeleteUser_Call) Run(run func(ctx context.Context, id int64)) *ScrapClient_DeleteUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *ScrapClient_DeleteUser_Call) Return(_a0 error) *ScrapClient_DeleteUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ScrapClient_DeleteUser_Call) RunAndReturn(run func(context.Context, int64) error) *ScrapClient_DeleteUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DisableQuietHours provides a mock function with given fields: ctx, id
+func (_m *ScrapClient) DisableQuietHours(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DisableQuietHours")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ScrapClient_DisableQuietHours_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DisableQuietHours'
+type ScrapClient_DisableQuietHours_Call struct {
+	*mock.Call
+}
+
+// DisableQuietHours is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *ScrapClient_Expecter) DisableQuietHours(ctx interface{}, id interface{}) *ScrapClient_DisableQuietHours_Call {
+	return &ScrapClient_DisableQuietHours_Call{Call: _e.mock.On("DisableQuietHours", ctx, id)}
+}
+
+func (_c *ScrapClient_DisableQuietHours_Call) Run(run func(ctx context.Context, id int64)) *ScrapClient_DisableQuietHours_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *ScrapClient_DisableQuietHours_Call) Return(_a0 error) *ScrapClient_DisableQuietHours_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ScrapClient_DisableQuietHours_Call) RunAndReturn(run func(context.Context, int64) error) *ScrapClient_DisableQuietHours_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RegUser provides a mock function with given fields: id
-func (_m *ScrapClient) RegUser(id int64) error {
-	ret := _m.Called(id)
+// RegUser provides a mock function with given fields: ctx, id
+func (_m *ScrapClient) RegUser(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for RegUser")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64) error); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -92,14 +190,15 @@ type ScrapClient_RegUser_Call struct {
 }
 
 // RegUser is a helper method to define mock.On call
+//   - ctx context.Context
 //   - id int64
-func (_e *ScrapClient_Expecter) RegUser(id interface{}) *ScrapClient_RegUser_Call {
-	return &ScrapClient_RegUser_Call{Call: _e.mock.On("RegUser", id)}
+func (_e *ScrapClient_Expecter) RegUser(ctx interface{}, id interface{}) *ScrapClient_RegUser_Call {
+	return &ScrapClient_RegUser_Call{Call: _e.mock.On("RegUser", ctx, id)}
 }
 
-func (_c *ScrapClient_RegUser_Call) Run(run func(id int64)) *ScrapClient_RegUser_Call {
+func (_c *ScrapClient_RegUser_Call) Run(run func(ctx context.Context, id int64)) *ScrapClient_RegUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int64))
+		run(args[0].(context.Context), args[1].(int64))
 	})
 	return _c
 }
@@ -109,22 +208,22 @@ func (_c *ScrapClient_RegUser_Call) Return(_a0 error) *ScrapClient_RegUser_Call
 	return _c
 }
 
-func (_c *ScrapClient_RegUser_Call) RunAndReturn(run func(int64) error) *ScrapClient_RegUser_Call {
+func (_c *ScrapClient_RegUser_Call) RunAndReturn(run func(context.Context, int64) error) *ScrapClient_RegUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RemoveLink provides a mock function with given fields: _a0, _a1
-func (_m *ScrapClient) RemoveLink(_a0 int64, _a1 string) error {
-	ret := _m.Called(_a0, _a1)
+// RemoveLink provides a mock function with given fields: ctx, id, link
+func (_m *ScrapClient) RemoveLink(ctx context.Context, id int64, link string) error {
+	ret := _m.Called(ctx, id, link)
 
 	if len(ret) == 0 {
 		panic("no return value specified for RemoveLink")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64, string) error); ok {
-		r0 = rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, id, link)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -138,15 +237,16 @@ type ScrapClient_RemoveLink_Call struct {
 }
 
 // RemoveLink is a helper method to define mock.On call
-//   - _a0 int64
-//   - _a1 string
-func (_e *ScrapClient_Expecter) RemoveLink(_a0 interface{}, _a1 interface{}) *ScrapClient_RemoveLink_Call {
-	return &ScrapClient_RemoveLink_Call{Call: _e.mock.On("RemoveLink", _a0, _a1)}
+//   - ctx context.Context
+//   - id int64
+//   - link string
+func (_e *ScrapClient_Expecter) RemoveLink(ctx interface{}, id interface{}, link interface{}) *ScrapClient_RemoveLink_Call {
+	return &ScrapClient_RemoveLink_Call{Call: _e.mock.On("RemoveLink", ctx, id, link)}
 }
 
-func (_c *ScrapClient_RemoveLink_Call) Run(run func(_a0 int64, _a1 string)) *ScrapClient_RemoveLink_Call {
+func (_c *ScrapClient_RemoveLink_Call) Run(run func(ctx context.Context, id int64, link string)) *ScrapClient_RemoveLink_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int64), args[1].(string))
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
 	})
 	return _c
 }
@@ -156,34 +256,431 @@ func (_c *ScrapClient_RemoveLink_Call) Return(_a0 error) *ScrapClient_RemoveLink
 	return _c
 }
 
-func (_c *ScrapClient_RemoveLink_Call) RunAndReturn(run func(int64, string) error) *ScrapClient_RemoveLink_Call {
+func (_c *ScrapClient_RemoveLink_Call) RunAndReturn(run func(context.Context, int64, string) error) *ScrapClient_RemoveLink_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ResetCheckInterval provides a mock function with given fields: ctx, id, link
+func (_m *ScrapClient) ResetCheckInterval(ctx context.Context, id int64, link string) error {
+	ret := _m.Called(ctx, id, link)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetCheckInterval")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, id, link)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ScrapClient_ResetCheckInterval_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetCheckInterval'
+type ScrapClient_ResetCheckInterval_Call struct {
+	*mock.Call
+}
+
+// ResetCheckInterval is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - link string
+func (_e *ScrapClient_Expecter) ResetCheckInterval(ctx interface{}, id interface{}, link interface{}) *ScrapClient_ResetCheckInterval_Call {
+	return &ScrapClient_ResetCheckInterval_Call{Call: _e.mock.On("ResetCheckInterval", ctx, id, link)}
+}
+
+func (_c *ScrapClient_ResetCheckInterval_Call) Run(run func(ctx context.Context, id int64, link string)) *ScrapClient_ResetCheckInterval_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ScrapClient_ResetCheckInterval_Call) Return(_a0 error) *ScrapClient_ResetCheckInterval_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ScrapClient_ResetCheckInterval_Call) RunAndReturn(run func(context.Context, int64, string) error) *ScrapClient_ResetCheckInterval_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetCheckInterval provides a mock function with given fields: ctx, id, link, interval
+func (_m *ScrapClient) SetCheckInterval(ctx context.Context, id int64, link string, interval time.Duration) error {
+	ret := _m.Called(ctx, id, link, interval)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetCheckInterval")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, time.Duration) error); ok {
+		r0 = rf(ctx, id, link, interval)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ScrapClient_SetCheckInterval_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetCheckInterval'
+type ScrapClient_SetCheckInterval_Call struct {
+	*mock.Call
+}
+
+// SetCheckInterval is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - link string
+//   - interval time.Duration
+func (_e *ScrapClient_Expecter) SetCheckInterval(ctx interface{}, id interface{}, link interface{}, interval interface{}) *ScrapClient_SetCheckInterval_Call {
+	return &ScrapClient_SetCheckInterval_Call{Call: _e.mock.On("SetCheckInterval", ctx, id, link, interval)}
+}
+
+func (_c *ScrapClient_SetCheckInterval_Call) Run(run func(ctx context.Context, id int64, link string, interval time.Duration)) *ScrapClient_SetCheckInterval_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *ScrapClient_SetCheckInterval_Call) Return(_a0 error) *ScrapClient_SetCheckInterval_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ScrapClient_SetCheckInterval_Call) RunAndReturn(run func(context.Context, int64, string, time.Duration) error) *ScrapClient_SetCheckInterval_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetDigestTime provides a mock function with given fields: ctx, id, sendTime
+func (_m *ScrapClient) SetDigestTime(ctx context.Context, id int64, sendTime string) error {
+	ret := _m.Called(ctx, id, sendTime)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDigestTime")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, id, sendTime)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ScrapClient_SetDigestTime_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetDigestTime'
+type ScrapClient_SetDigestTime_Call struct {
+	*mock.Call
+}
+
+// SetDigestTime is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - sendTime string
+func (_e *ScrapClient_Expecter) SetDigestTime(ctx interface{}, id interface{}, sendTime interface{}) *ScrapClient_SetDigestTime_Call {
+	return &ScrapClient_SetDigestTime_Call{Call: _e.mock.On("SetDigestTime", ctx, id, sendTime)}
+}
+
+func (_c *ScrapClient_SetDigestTime_Call) Run(run func(ctx context.Context, id int64, sendTime string)) *ScrapClient_SetDigestTime_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ScrapClient_SetDigestTime_Call) Return(_a0 error) *ScrapClient_SetDigestTime_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ScrapClient_SetDigestTime_Call) RunAndReturn(run func(context.Context, int64, string) error) *ScrapClient_SetDigestTime_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetInstantDelivery provides a mock function with given fields: ctx, id
+func (_m *ScrapClient) SetInstantDelivery(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetInstantDelivery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ScrapClient_SetInstantDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetInstantDelivery'
+type ScrapClient_SetInstantDelivery_Call struct {
+	*mock.Call
+}
+
+// SetInstantDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *ScrapClient_Expecter) SetInstantDelivery(ctx interface{}, id interface{}) *ScrapClient_SetInstantDelivery_Call {
+	return &ScrapClient_SetInstantDelivery_Call{Call: _e.mock.On("SetInstantDelivery", ctx, id)}
+}
+
+func (_c *ScrapClient_SetInstantDelivery_Call) Run(run func(ctx context.Context, id int64)) *ScrapClient_SetInstantDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *ScrapClient_SetInstantDelivery_Call) Return(_a0 error) *ScrapClient_SetInstantDelivery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ScrapClient_SetInstantDelivery_Call) RunAndReturn(run func(context.Context, int64) error) *ScrapClient_SetInstantDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetLanguage provides a mock function with given fields: ctx, id, lang
+func (_m *ScrapClient) SetLanguage(ctx context.Context, id int64, lang string) error {
+	ret := _m.Called(ctx, id, lang)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetLanguage")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, id, lang)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ScrapClient_SetLanguage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetLanguage'
+type ScrapClient_SetLanguage_Call struct {
+	*mock.Call
+}
+
+// SetLanguage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - lang string
+func (_e *ScrapClient_Expecter) SetLanguage(ctx interface{}, id interface{}, lang interface{}) *ScrapClient_SetLanguage_Call {
+	return &ScrapClient_SetLanguage_Call{Call: _e.mock.On("SetLanguage", ctx, id, lang)}
+}
+
+func (_c *ScrapClient_SetLanguage_Call) Run(run func(ctx context.Context, id int64, lang string)) *ScrapClient_SetLanguage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ScrapClient_SetLanguage_Call) Return(_a0 error) *ScrapClient_SetLanguage_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ScrapClient_SetLanguage_Call) RunAndReturn(run func(context.Context, int64, string) error) *ScrapClient_SetLanguage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetQuietHours provides a mock function with given fields: ctx, id, start, end
+func (_m *ScrapClient) SetQuietHours(ctx context.Context, id int64, start string, end string) error {
+	ret := _m.Called(ctx, id, start, end)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetQuietHours")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, string) error); ok {
+		r0 = rf(ctx, id, start, end)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ScrapClient_SetQuietHours_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetQuietHours'
+type ScrapClient_SetQuietHours_Call struct {
+	*mock.Call
+}
+
+// SetQuietHours is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - start string
+//   - end string
+func (_e *ScrapClient_Expecter) SetQuietHours(ctx interface{}, id interface{}, start interface{}, end interface{}) *ScrapClient_SetQuietHours_Call {
+	return &ScrapClient_SetQuietHours_Call{Call: _e.mock.On("SetQuietHours", ctx, id, start, end)}
+}
+
+func (_c *ScrapClient_SetQuietHours_Call) Run(run func(ctx context.Context, id int64, start string, end string)) *ScrapClient_SetQuietHours_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *ScrapClient_SetQuietHours_Call) Return(_a0 error) *ScrapClient_SetQuietHours_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ScrapClient_SetQuietHours_Call) RunAndReturn(run func(context.Context, int64, string, string) error) *ScrapClient_SetQuietHours_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetTimezone provides a mock function with given fields: ctx, id, timezone
+func (_m *ScrapClient) SetTimezone(ctx context.Context, id int64, timezone string) error {
+	ret := _m.Called(ctx, id, timezone)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetTimezone")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, id, timezone)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ScrapClient_SetTimezone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTimezone'
+type ScrapClient_SetTimezone_Call struct {
+	*mock.Call
+}
+
+// SetTimezone is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - timezone string
+func (_e *ScrapClient_Expecter) SetTimezone(ctx interface{}, id interface{}, timezone interface{}) *ScrapClient_SetTimezone_Call {
+	return &ScrapClient_SetTimezone_Call{Call: _e.mock.On("SetTimezone", ctx, id, timezone)}
+}
+
+func (_c *ScrapClient_SetTimezone_Call) Run(run func(ctx context.Context, id int64, timezone string)) *ScrapClient_SetTimezone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ScrapClient_SetTimezone_Call) Return(_a0 error) *ScrapClient_SetTimezone_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ScrapClient_SetTimezone_Call) RunAndReturn(run func(context.Context, int64, string) error) *ScrapClient_SetTimezone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TagedLinks provides a mock function with given fields: ctx, id, tag
+func (_m *ScrapClient) TagedLinks(ctx context.Context, id int64, tag string) ([]tgbot.TagedLinks, error) {
+	ret := _m.Called(ctx, id, tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TagedLinks")
+	}
+
+	var r0 []tgbot.TagedLinks
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) ([]tgbot.TagedLinks, error)); ok {
+		return rf(ctx, id, tag)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) []tgbot.TagedLinks); ok {
+		r0 = rf(ctx, id, tag)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]tgbot.TagedLinks)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, id, tag)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ScrapClient_TagedLinks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TagedLinks'
+type ScrapClient_TagedLinks_Call struct {
+	*mock.Call
+}
+
+// TagedLinks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - tag string
+func (_e *ScrapClient_Expecter) TagedLinks(ctx interface{}, id interface{}, tag interface{}) *ScrapClient_TagedLinks_Call {
+	return &ScrapClient_TagedLinks_Call{Call: _e.mock.On("TagedLinks", ctx, id, tag)}
+}
+
+func (_c *ScrapClient_TagedLinks_Call) Run(run func(ctx context.Context, id int64, tag string)) *ScrapClient_TagedLinks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *ScrapClient_TagedLinks_Call) Return(_a0 []tgbot.TagedLinks, _a1 error) *ScrapClient_TagedLinks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ScrapClient_TagedLinks_Call) RunAndReturn(run func(context.Context, int64, string) ([]tgbot.TagedLinks, error)) *ScrapClient_TagedLinks_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UserLinks provides a mock function with given fields: _a0
-func (_m *ScrapClient) UserLinks(_a0 int64) ([]string, error) {
-	ret := _m.Called(_a0)
+// UserLinks provides a mock function with given fields: ctx, id
+func (_m *ScrapClient) UserLinks(ctx context.Context, id int64) ([]tgbot.SavedLink, error) {
+	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UserLinks")
 	}
 
-	var r0 []string
+	var r0 []tgbot.SavedLink
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int64) ([]string, error)); ok {
-		return rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]tgbot.SavedLink, error)); ok {
+		return rf(ctx, id)
 	}
-	if rf, ok := ret.Get(0).(func(int64) []string); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []tgbot.SavedLink); ok {
+		r0 = rf(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
+			r0 = ret.Get(0).([]tgbot.SavedLink)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int64) error); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -197,24 +694,25 @@ type ScrapClient_UserLinks_Call struct {
 }
 
 // UserLinks is a helper method to define mock.On call
-//   - _a0 int64
-func (_e *ScrapClient_Expecter) UserLinks(_a0 interface{}) *ScrapClient_UserLinks_Call {
-	return &ScrapClient_UserLinks_Call{Call: _e.mock.On("UserLinks", _a0)}
+//   - ctx context.Context
+//   - id int64
+func (_e *ScrapClient_Expecter) UserLinks(ctx interface{}, id interface{}) *ScrapClient_UserLinks_Call {
+	return &ScrapClient_UserLinks_Call{Call: _e.mock.On("UserLinks", ctx, id)}
 }
 
-func (_c *ScrapClient_UserLinks_Call) Run(run func(_a0 int64)) *ScrapClient_UserLinks_Call {
+func (_c *ScrapClient_UserLinks_Call) Run(run func(ctx context.Context, id int64)) *ScrapClient_UserLinks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int64))
+		run(args[0].(context.Context), args[1].(int64))
 	})
 	return _c
 }
 
-func (_c *ScrapClient_UserLinks_Call) Return(_a0 []string, _a1 error) *ScrapClient_UserLinks_Call {
+func (_c *ScrapClient_UserLinks_Call) Return(_a0 []tgbot.SavedLink, _a1 error) *ScrapClient_UserLinks_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *ScrapClient_UserLinks_Call) RunAndReturn(run func(int64) ([]string, error)) *ScrapClient_UserLinks_Call {
+func (_c *ScrapClient_UserLinks_Call) RunAndReturn(run func(context.Context, int64) ([]tgbot.SavedLink, error)) *ScrapClient_UserLinks_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -3,11 +3,10 @@
 package mocks
 
 import (
+	context "context"
 	scrapper "linkTraccer/internal/domain/scrapper"
 
 	mock "github.com/stretchr/testify/mock"
-
-	time "time"
 )
 
 // SiteClient is an autogenerated mock type for the SiteClient type
@@ -23,17 +22,17 @@ func (_m *SiteClient) EXPECT() *SiteClient_Expecter {
 	return &SiteClient_Expecter{mock: &_m.Mock}
 }
 
-// CanTrack provides a mock function with given fields: link
-func (_m *SiteClient) CanTrack(link string) bool {
-	ret := _m.Called(link)
+// CanTrack provides a mock function with given fields: ctx, link
+func (_m *SiteClient) CanTrack(ctx context.Context, link string) bool {
+	ret := _m.Called(ctx, link)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CanTrack")
 	}
 
 	var r0 bool
-	if rf, ok := ret.Get(0).(func(string) bool); ok {
-		r0 = rf(link)
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, link)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
@@ -47,14 +46,15 @@ type SiteClient_CanTrack_Call struct {
 }
 
 // CanTrack is a helper method to define mock.On call
+//   - ctx context.Context
 //   - link string
-func (_e *SiteClient_Expecter) CanTrack(link interface{}) *SiteClient_CanTrack_Call {
-	return &SiteClient_CanTrack_Call{Call: _e.mock.On("CanTrack", link)}
+func (_e *SiteClient_Expecter) CanTrack(ctx interface{}, link interface{}) *SiteClient_CanTrack_Call {
+	return &SiteClient_CanTrack_Call{Call: _e.mock.On("CanTrack", ctx, link)}
 }
 
-func (_c *SiteClient_CanTrack_Call) Run(run func(link string)) *SiteClient_CanTrack_Call {
+func (_c *SiteClient_CanTrack_Call) Run(run func(ctx context.Context, link string)) *SiteClient_CanTrack_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -64,14 +64,59 @@ func (_c *SiteClient_CanTrack_Call) Return(_a0 bool) *SiteClient_CanTrack_Call {
 	return _c
 }
 
-func (_c *SiteClient_CanTrack_Call) RunAndReturn(run func(string) bool) *SiteClient_CanTrack_Call {
+func (_c *SiteClient_CanTrack_Call) RunAndReturn(run func(context.Context, string) bool) *SiteClient_CanTrack_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Host provides a mock function with no fields
+func (_m *SiteClient) Host() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Host")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// SiteClient_Host_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Host'
+type SiteClient_Host_Call struct {
+	*mock.Call
+}
+
+// Host is a helper method to define mock.On call
+func (_e *SiteClient_Expecter) Host() *SiteClient_Host_Call {
+	return &SiteClient_Host_Call{Call: _e.mock.On("Host")}
+}
+
+func (_c *SiteClient_Host_Call) Run(run func()) *SiteClient_Host_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *SiteClient_Host_Call) Return(_a0 string) *SiteClient_Host_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SiteClient_Host_Call) RunAndReturn(run func() string) *SiteClient_Host_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// LinkUpdates provides a mock function with given fields: link, updatesSince
-func (_m *SiteClient) LinkUpdates(link string, updatesSince time.Time) ([]*scrapper.LinkUpdate, error) {
-	ret := _m.Called(link, updatesSince)
+// LinkUpdates provides a mock function with given fields: ctx, link, cursors
+func (_m *SiteClient) LinkUpdates(ctx context.Context, link string, cursors *scrapper.LinkCursors) ([]*scrapper.LinkUpdate, error) {
+	ret := _m.Called(ctx, link, cursors)
 
 	if len(ret) == 0 {
 		panic("no return value specified for LinkUpdates")
@@ -79,19 +124,19 @@ func (_m *SiteClient) LinkUpdates(link string, updatesSince time.Time) ([]*scrap
 
 	var r0 []*scrapper.LinkUpdate
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, time.Time) ([]*scrapper.LinkUpdate, error)); ok {
-		return rf(link, updatesSince)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *scrapper.LinkCursors) ([]*scrapper.LinkUpdate, error)); ok {
+		return rf(ctx, link, cursors)
 	}
-	if rf, ok := ret.Get(0).(func(string, time.Time) []*scrapper.LinkUpdate); ok {
-		r0 = rf(link, updatesSince)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *scrapper.LinkCursors) []*scrapper.LinkUpdate); ok {
+		r0 = rf(ctx, link, cursors)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*scrapper.LinkUpdate)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, time.Time) error); ok {
-		r1 = rf(link, updatesSince)
+	if rf, ok := ret.Get(1).(func(context.Context, string, *scrapper.LinkCursors) error); ok {
+		r1 = rf(ctx, link, cursors)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -105,15 +150,16 @@ type SiteClient_LinkUpdates_Call struct {
 }
 
 // LinkUpdates is a helper method to define mock.On call
+//   - ctx context.Context
 //   - link string
-//   - updatesSince time.Time
-func (_e *SiteClient_Expecter) LinkUpdates(link interface{}, updatesSince interface{}) *SiteClient_LinkUpdates_Call {
-	return &SiteClient_LinkUpdates_Call{Call: _e.mock.On("LinkUpdates", link, updatesSince)}
+//   - cursors *scrapper.LinkCursors
+func (_e *SiteClient_Expecter) LinkUpdates(ctx interface{}, link interface{}, cursors interface{}) *SiteClient_LinkUpdates_Call {
+	return &SiteClient_LinkUpdates_Call{Call: _e.mock.On("LinkUpdates", ctx, link, cursors)}
 }
 
-func (_c *SiteClient_LinkUpdates_Call) Run(run func(link string, updatesSince time.Time)) *SiteClient_LinkUpdates_Call {
+func (_c *SiteClient_LinkUpdates_Call) Run(run func(ctx context.Context, link string, cursors *scrapper.LinkCursors)) *SiteClient_LinkUpdates_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(time.Time))
+		run(args[0].(context.Context), args[1].(string), args[2].(*scrapper.LinkCursors))
 	})
 	return _c
 }
@@ -123,7 +169,7 @@ func (_c *SiteClient_LinkUpdates_Call) Return(_a0 []*scrapper.LinkUpdate, _a1 er
 	return _c
 }
 
-func (_c *SiteClient_LinkUpdates_Call) RunAndReturn(run func(string, time.Time) ([]*scrapper.LinkUpdate, error)) *SiteClient_LinkUpdates_Call {
+func (_c *SiteClient_LinkUpdates_Call) RunAndReturn(run func(context.Context, string, *scrapper.LinkCursors) ([]*scrapper.LinkUpdate, error)) *SiteClient_LinkUpdates_Call {
 	_c.Call.Return(run)
 	return _c
 }
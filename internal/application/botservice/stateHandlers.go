@@ -1,173 +1,359 @@
 package botservice
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"linkTraccer/internal/domain/i18n"
+	"linkTraccer/internal/domain/scrapper"
 	"linkTraccer/internal/domain/tgbot"
 	"strings"
+	"time"
 )
 
-func (bot *TgBot) RegHandler(id tgbot.ID, _ tgbot.Event) error {
-	if err := bot.ctxStore.RegUser(id); err != nil {
+func (bot *TgBot) RegHandler(ctx context.Context, id tgbot.ID, _ tgbot.Event) error {
+	if err := bot.ctxStore.RegUser(ctx, id); err != nil {
 		return fmt.Errorf("при регистрации в хранилище контекстной информации возникла ошибка: %w", err)
 	}
 
-	if err := bot.tg.SendMessage(id, FirstMessage); err != nil {
-		return fmt.Errorf("при отправке %s произошла ошибка: %w", FirstMessage, err)
+	if err := bot.sendText(ctx, id, FirstMessage); err != nil {
+		return err
 	}
 
-	if err := bot.scrap.RegUser(id); err != nil {
+	if err := bot.scrap.RegUser(ctx, id); err != nil {
 		return fmt.Errorf("при регистрации пользователя произошла ошибка: %w", err)
 	}
 
+	if err := bot.scrap.SetLanguage(ctx, id, bot.lang(ctx, id)); err != nil {
+		return fmt.Errorf("при сохранении языка пользователя в скраппере произошла ошибка: %w", err)
+	}
+
 	return nil
 }
 
-func (bot *TgBot) CommandsHandler(id tgbot.ID, event tgbot.Event) error {
-	err := bot.Commands(id, event)
+func (bot *TgBot) CommandsHandler(ctx context.Context, id tgbot.ID, event tgbot.Event) error {
+	err := bot.Commands(ctx, id, event)
 	if err != nil {
-		return bot.sendMessage(id, UnknownCommand)
+		return bot.sendText(ctx, id, UnknownCommand)
 	}
 
 	return nil
 }
 
-func (bot *TgBot) LinkRemoveHandler(id tgbot.ID, event tgbot.Event) error {
-	err := bot.Commands(id, event)
+func (bot *TgBot) LinkRemoveHandler(ctx context.Context, id tgbot.ID, event tgbot.Event) error {
+	err := bot.Commands(ctx, id, event)
 	if err == nil || !errors.Is(err, ErrCommandNotFound) {
 		return err
 	}
 
-	err = bot.scrap.RemoveLink(id, event)
+	err = bot.scrap.RemoveLink(ctx, id, event)
 	if errors.Is(err, tgbot.LinkNotExist) {
-		return bot.sendMessage(id, NotSaveThisLink)
+		return bot.sendText(ctx, id, NotSaveThisLink)
 	}
 
 	if err != nil {
 		return err
 	}
 
-	if err := bot.cache.InvalidateUserCache(id); err != nil {
+	if err := bot.cache.InvalidateUserCache(ctx, id); err != nil {
 		bot.log.Error("ошибка инвалидации кеша, при удалении ссылки", "err", err.Error())
 	}
 
-	return bot.sendMessage(id, LinkDeleted)
+	return bot.sendText(ctx, id, LinkDeleted)
 }
 
-func (bot *TgBot) AddLinkHandler(id tgbot.ID, event tgbot.Event) error {
-	err := bot.Commands(id, event)
+func (bot *TgBot) AddLinkHandler(ctx context.Context, id tgbot.ID, event tgbot.Event) error {
+	err := bot.Commands(ctx, id, event)
 	if err == nil || !errors.Is(err, ErrCommandNotFound) {
 		return err
 	}
 
-	if err := bot.ctxStore.AddURL(id, event); err != nil {
+	if err := bot.ctxStore.AddURL(ctx, id, event); err != nil {
 		return fmt.Errorf("при добавлении ссылки в контекстное хранилище, произошла ошибка :%w", err)
 	}
 
-	return bot.sendMessage(id, AddLinkTagMsg)
+	return bot.sendKeyboard(ctx, id, AddLinkTagMsg, skipKeyboard(bot.lang(ctx, id)))
 }
 
-func (bot *TgBot) AddTagHandler(id tgbot.ID, event tgbot.Event) error {
-	err := bot.Commands(id, event)
+func (bot *TgBot) AddTagHandler(ctx context.Context, id tgbot.ID, event tgbot.Event) error {
+	err := bot.Commands(ctx, id, event)
 	if err == nil || !errors.Is(err, ErrCommandNotFound) {
 		return err
 	}
 
-	if err := bot.ctxStore.AddTags(id, []string{event}); err != nil {
+	if err := bot.ctxStore.AddTags(ctx, id, strings.Fields(event)); err != nil {
 		return fmt.Errorf("при добавлении тегов в контекстное хранилище, произошла ошибка :%w", err)
 	}
 
-	return bot.sendMessage(id, AddLinkFilterMsg)
+	return bot.sendKeyboard(ctx, id, AddLinkFilterMsg, skipKeyboard(bot.lang(ctx, id)))
 }
 
-func (bot *TgBot) SaveLinkHandler(id tgbot.ID, event tgbot.Event) error {
-	err := bot.Commands(id, event)
+func (bot *TgBot) SaveLinkHandler(ctx context.Context, id tgbot.ID, event tgbot.Event) error {
+	err := bot.Commands(ctx, id, event)
 	if err == nil || !errors.Is(err, ErrCommandNotFound) {
 		return nil
 	}
 
-	if err := bot.ctxStore.AddFilters(id, []string{event}); err != nil {
+	if err := bot.ctxStore.AddFilters(ctx, id, strings.Fields(event)); err != nil {
 		return fmt.Errorf("при добавлении фильтров в контекстное хранилище произошла ошибка: %w", err)
 	}
 
-	userContext, err := bot.ctxStore.UserContext(id)
+	userContext, err := bot.ctxStore.UserContext(ctx, id)
 	if err != nil {
 		return fmt.Errorf("ошибка при сохраненни, при получении контекстной информации произошла ошибка: %w", err)
 	}
 
-	err = bot.scrap.AddLink(id, userContext)
+	err = bot.scrap.AddLink(ctx, id, userContext)
 	if errors.Is(err, tgbot.LinkNotSupport) {
-		return bot.sendMessage(id, WrongLink)
+		return bot.sendText(ctx, id, WrongLink)
 	}
 
 	if err != nil {
 		return err
 	}
 
-	if err := bot.cache.InvalidateUserCache(id); err != nil {
+	if err := bot.cache.InvalidateUserCache(ctx, id); err != nil {
 		bot.log.Error("ошибка инвалидации кеша, при добавлении ссылки", "err", err.Error())
 	}
 
-	return bot.sendMessage(id, GoodLink)
+	return bot.sendText(ctx, id, GoodLink)
 }
 
-func (bot *TgBot) Commands(id tgbot.ID, command tgbot.Event) error {
+func (bot *TgBot) Commands(ctx context.Context, id tgbot.ID, event tgbot.Event) error {
+	command, arg := parseCommand(event)
+
 	switch command {
 	case Start:
-		return bot.sendMessage(id, FirstMessage)
+		return bot.sendText(ctx, id, FirstMessage)
 	case Help:
-		return bot.sendMessage(id, HelpMessage)
+		return bot.sendText(ctx, id, HelpMessage)
 	case List:
-		links, err := bot.cache.GetUserLinks(id)
+		return bot.listLinks(ctx, id, arg)
+	case Untrack:
+		return bot.untrackLinks(ctx, id)
+	case Track:
+		return bot.sendText(ctx, id, TrackLink)
+	case Digest:
+		return bot.setDigest(ctx, id, arg)
+	case Quiet:
+		return bot.setQuietHours(ctx, id, arg)
+	case Timezone:
+		return bot.setTimezone(ctx, id, arg)
+	case Lang:
+		return bot.setLang(ctx, id, arg)
+	case Interval:
+		return bot.setInterval(ctx, id, arg)
+	default:
+		return ErrCommandNotFound
+	}
+}
+
+func (bot *TgBot) listLinks(ctx context.Context, id tgbot.ID, tag tgbot.Tag) error {
+	links, err := bot.cache.GetUserLinks(ctx, id, tag)
+	if err != nil {
+		bot.log.Error("не удалось получить список ссылок из кеша", "err", err.Error())
+
+		tagedLinks, err := bot.scrap.TagedLinks(ctx, id, tag)
 		if err != nil {
-			bot.log.Error("не удалось получить список ссылок из кеша", "err", err.Error())
+			return err
+		}
 
-			userLinks, err := bot.scrap.UserLinks(id)
-			if err != nil {
-				return err
-			}
+		links = formatLinksMsg(bot.lang(ctx, id), tagedLinks)
 
-			links = formatLinksMsg(userLinks)
+		if err := bot.cache.SetUserLinks(ctx, id, tag, links); err != nil {
+			bot.log.Error("ошибка при кешировании ссылок пользователя", "err", err.Error())
+		}
+	}
+
+	if len(links) == 0 && tag != "" {
+		return bot.sendText(ctx, id, NoLinksWithTag, tag)
+	}
+
+	if len(links) == 0 {
+		return bot.sendText(ctx, id, NoSavedLinks)
+	}
 
-			if err := bot.cache.SetUserLinks(id, links); err != nil {
-				bot.log.Error("ошибка при кешировании ссылок пользователя", "err", err.Error())
-			}
+	return bot.sendMessage(ctx, id, links)
+}
+
+// setDigest включает дайджест на указанное время или, для аргумента off, возвращает мгновенную доставку.
+func (bot *TgBot) setDigest(ctx context.Context, id tgbot.ID, arg string) error {
+	if arg == settingOff {
+		if err := bot.scrap.SetInstantDelivery(ctx, id); err != nil {
+			return err
 		}
 
-		if len(links) == 0 {
-			return bot.sendMessage(id, NoSavedLinks)
+		return bot.sendText(ctx, id, DigestDisabled)
+	}
+
+	sendTime, err := time.Parse(timeLayout, arg)
+	if err != nil {
+		return bot.sendText(ctx, id, DigestUsage)
+	}
+
+	if err = bot.scrap.SetDigestTime(ctx, id, sendTime.Format(timeLayout)); err != nil {
+		return err
+	}
+
+	return bot.sendText(ctx, id, DigestEnabled, sendTime.Format(timeLayout))
+}
+
+// setQuietHours включает тихие часы для аргумента вида 23:00-08:00 или выключает их для аргумента off.
+func (bot *TgBot) setQuietHours(ctx context.Context, id tgbot.ID, arg string) error {
+	if arg == settingOff {
+		if err := bot.scrap.DisableQuietHours(ctx, id); err != nil {
+			return err
 		}
 
-		return bot.sendMessage(id, links)
+		return bot.sendText(ctx, id, QuietDisabled)
+	}
 
-	case Untrack:
-		return bot.sendMessage(id, UntrackLink)
-	case Track:
-		return bot.sendMessage(id, TrackLink)
-	default:
-		return ErrCommandNotFound
+	startArg, endArg, _ := strings.Cut(arg, quietSeparator)
+
+	start, startErr := time.Parse(timeLayout, strings.TrimSpace(startArg))
+	end, endErr := time.Parse(timeLayout, strings.TrimSpace(endArg))
+
+	if startErr != nil || endErr != nil || start.Equal(end) {
+		return bot.sendText(ctx, id, QuietUsage)
+	}
+
+	err := bot.scrap.SetQuietHours(ctx, id, start.Format(timeLayout), end.Format(timeLayout))
+	if err != nil {
+		return err
+	}
+
+	return bot.sendText(ctx, id, QuietEnabled, start.Format(timeLayout), end.Format(timeLayout))
+}
+
+func (bot *TgBot) setTimezone(ctx context.Context, id tgbot.ID, timezone string) error {
+	if _, err := time.LoadLocation(timezone); err != nil || timezone == "" || timezone == "Local" {
+		return bot.sendText(ctx, id, TimezoneUsage)
+	}
+
+	if err := bot.scrap.SetTimezone(ctx, id, timezone); err != nil {
+		return err
+	}
+
+	return bot.sendText(ctx, id, TimezoneSaved, timezone)
+}
+
+// setLang сбрасывает кеш ссылок, потому что в нем лежит уже переведенный текст.
+func (bot *TgBot) setLang(ctx context.Context, id tgbot.ID, lang i18n.Lang) error {
+	lang = strings.ToLower(lang)
+
+	if !i18n.Supported(lang) {
+		return bot.sendText(ctx, id, LangUsage)
+	}
+
+	if err := bot.scrap.SetLanguage(ctx, id, lang); err != nil {
+		return err
+	}
+
+	if err := bot.langStore.SetUserLang(ctx, id, lang); err != nil {
+		return fmt.Errorf("при сохранении языка пользователя произошла ошибка: %w", err)
+	}
+
+	if err := bot.cache.InvalidateUserCache(ctx, id); err != nil {
+		bot.log.Error("ошибка инвалидации кеша, при смене языка", "err", err.Error())
 	}
+
+	return bot.sendText(ctx, id, LangSaved)
 }
 
-func (bot *TgBot) sendMessage(id tgbot.ID, message string) error {
-	if err := bot.tg.SendMessage(id, message); err != nil {
+// setInterval разбирает аргумент вида "<ссылка> 30m" или "<ссылка> off".
+func (bot *TgBot) setInterval(ctx context.Context, id tgbot.ID, arg string) error {
+	link, value, _ := strings.Cut(arg, " ")
+	value = strings.TrimSpace(value)
+
+	if link == "" || value == "" {
+		return bot.sendText(ctx, id, IntervalUsage)
+	}
+
+	var err error
+
+	if value == settingOff {
+		err = bot.scrap.ResetCheckInterval(ctx, id, link)
+	} else {
+		interval, parseErr := time.ParseDuration(value)
+		if parseErr != nil || interval < scrapper.MinCheckInterval || interval > scrapper.MaxCheckInterval {
+			return bot.sendText(ctx, id, IntervalUsage)
+		}
+
+		err = bot.scrap.SetCheckInterval(ctx, id, link, interval)
+	}
+
+	if errors.Is(err, tgbot.LinkNotExist) {
+		return bot.sendText(ctx, id, NotSaveThisLink)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if value == settingOff {
+		return bot.sendText(ctx, id, IntervalReset, link)
+	}
+
+	return bot.sendText(ctx, id, IntervalSaved, link, value)
+}
+
+func (bot *TgBot) untrackLinks(ctx context.Context, id tgbot.ID) error {
+	links, err := bot.scrap.UserLinks(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if len(links) == 0 {
+		return bot.sendText(ctx, id, NoSavedLinks)
+	}
+
+	return bot.sendKeyboard(ctx, id, UntrackLink, untrackKeyboard(links))
+}
+
+func (bot *TgBot) sendKeyboard(ctx context.Context, id tgbot.ID, key string, keyboard *tgbot.InlineKeyboardMarkup) error {
+	message := bot.text(ctx, id, key)
+
+	if err := bot.tg.SendKeyboard(ctx, id, message, keyboard); err != nil {
+		return fmt.Errorf("при отправке сообщения с клавиатурой %s произошла ошибка: %w", message, err)
+	}
+
+	return nil
+}
+
+// sendText отправляет сообщение с ключом key на языке пользователя, args подставляются в текст сообщения.
+func (bot *TgBot) sendText(ctx context.Context, id tgbot.ID, key string, args ...any) error {
+	return bot.sendMessage(ctx, id, bot.text(ctx, id, key, args...))
+}
+
+func (bot *TgBot) sendMessage(ctx context.Context, id tgbot.ID, message string) error {
+	if err := bot.tg.SendMessage(ctx, id, message); err != nil {
 		return fmt.Errorf("при отправке сообщения %s произошла ошибка: %w", message, err)
 	}
 
 	return nil
 }
 
-func formatLinksMsg(links []tgbot.Link) string {
-	if len(links) == 0 {
+func formatLinksMsg(lang i18n.Lang, tagedLinks []tgbot.TagedLinks) string {
+	if len(tagedLinks) == 0 {
 		return ""
 	}
 
 	builder := strings.Builder{}
 
-	builder.WriteString("Список ваших ссылок:\n\n")
+	builder.WriteString(Text(lang, LinksHeader))
+
+	for _, tagedLink := range tagedLinks {
+		tag := tagedLink.Tag
+
+		if tag == "" {
+			tag = Text(lang, WithoutTag)
+		}
+
+		builder.WriteString(fmt.Sprintf("\n🏷 %s:\n", tag))
 
-	for ind, link := range links {
-		builder.WriteString(fmt.Sprintf("%d) %s", ind+1, link))
+		for ind, link := range tagedLink.Links {
+			builder.WriteString(fmt.Sprintf("%d) %s\n", ind+1, link))
+		}
 	}
 
 	return builder.String()
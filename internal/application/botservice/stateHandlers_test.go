@@ -1,15 +1,18 @@
 package botservice_test
 
 import (
+	"context"
 	"errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"linkTraccer/internal/application/botservice"
 	"linkTraccer/internal/application/botservice/mocks"
+	"linkTraccer/internal/domain/i18n"
 	"linkTraccer/internal/domain/tgbot"
 	"log/slog"
 	"os"
 	"testing"
+	"time"
 )
 
 const (
@@ -41,14 +44,41 @@ func TestTgBot_Commands(t *testing.T) {
 	notEmtyCache := mocks.NewCacheStorage(t)
 	emptyCache := mocks.NewCacheStorage(t)
 
-	tgWithoutErr.On("SendMessage", mock.Anything, mock.Anything).Return(nil)
-	tgWithErr.On("SendMessage", mock.Anything, mock.Anything).Return(errTest)
-
-	scrapWithError.On("UserLinks", mock.Anything).Return(nil, errTest)
-	scrapWithoutLinks.On("UserLinks", mock.Anything).Return([]tgbot.Link{}, nil)
-
-	emptyCache.On("GetUserLinks", mock.Anything).Return("", errTest)
-	notEmtyCache.On("GetUserLinks", mock.Anything).Return("hello word", nil)
+	tgWithErr.On("SendMessage", mock.Anything, mock.Anything, mock.Anything).Return(errTest)
+
+	scrapWithTagedLinks := mocks.NewScrapClient(t)
+	tgWithTagedLinks := mocks.NewTgClient(t)
+
+	scrapWithError.On("TagedLinks", mock.Anything, mock.Anything, mock.Anything).Return(nil, errTest)
+	scrapWithError.On("UserLinks", mock.Anything, mock.Anything).Return(nil, errTest)
+	scrapWithError.On("SetDigestTime", mock.Anything, mock.Anything, mock.Anything).Return(errTest)
+	scrapWithoutLinks.On("SetDigestTime", mock.Anything, mock.Anything, "09:05").Return(nil)
+	scrapWithoutLinks.On("SetInstantDelivery", mock.Anything, mock.Anything).Return(nil)
+	scrapWithError.On("SetQuietHours", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errTest)
+	scrapWithoutLinks.On("SetQuietHours", mock.Anything, mock.Anything, "23:00", "08:00").Return(nil)
+	scrapWithoutLinks.On("DisableQuietHours", mock.Anything, mock.Anything).Return(nil)
+	scrapWithoutLinks.On("SetTimezone", mock.Anything, mock.Anything, "Asia/Novosibirsk").Return(nil)
+	scrapWithoutLinks.On("SetLanguage", mock.Anything, mock.Anything, i18n.English).Return(nil)
+	scrapWithError.On("SetLanguage", mock.Anything, mock.Anything, mock.Anything).Return(errTest)
+	scrapWithoutLinks.On("SetCheckInterval", mock.Anything, mock.Anything, "https://github.com/orlov4919/test", 30*time.Minute).
+		Return(nil)
+	scrapWithoutLinks.On("ResetCheckInterval", mock.Anything, mock.Anything, "https://github.com/orlov4919/test").Return(nil)
+	scrapWithError.On("SetCheckInterval", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(tgbot.LinkNotExist)
+	scrapWithoutLinks.On("TagedLinks", mock.Anything, mock.Anything, mock.Anything).Return([]tgbot.TagedLinks{}, nil)
+	scrapWithTagedLinks.On("TagedLinks", mock.Anything, mock.Anything, "").Return([]tgbot.TagedLinks{
+		{Tag: "work", Links: []tgbot.Link{"https://github.com/orlov4919/test"}},
+		{Tag: "", Links: []tgbot.Link{"https://stackoverflow.com/questions/1"}},
+	}, nil)
+
+	tgWithoutErr.On("SendMessage", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	tgWithTagedLinks.On("SendMessage", mock.Anything, mock.Anything, "Список ваших ссылок:\n"+
+		"\n🏷 work:\n1) https://github.com/orlov4919/test\n"+
+		"\n🏷 Без тега:\n1) https://stackoverflow.com/questions/1\n").Return(nil)
+
+	emptyCache.On("GetUserLinks", mock.Anything, mock.Anything, mock.Anything).Return("", errTest)
+	emptyCache.On("SetUserLinks", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	notEmtyCache.On("GetUserLinks", mock.Anything, mock.Anything, mock.Anything).Return("hello word", nil)
+	notEmtyCache.On("InvalidateUserCache", mock.Anything, mock.Anything).Return(nil)
 
 	type testCase struct {
 		name    string
@@ -99,6 +129,22 @@ func TestTgBot_Commands(t *testing.T) {
 			event:   botservice.List,
 			correct: true,
 		},
+		{
+			name:    "у пользователя нет ссылок с запрошенным тегом",
+			tg:      tgWithoutErr,
+			cache:   emptyCache,
+			scrap:   scrapWithoutLinks,
+			event:   botservice.List + " work",
+			correct: true,
+		},
+		{
+			name:    "ссылки группируются по тегам",
+			tg:      tgWithTagedLinks,
+			cache:   emptyCache,
+			scrap:   scrapWithTagedLinks,
+			event:   botservice.List,
+			correct: true,
+		},
 		{
 			name:    "ошибка при отправке сообщения для удаления ссылки",
 			tg:      tgWithErr,
@@ -113,6 +159,126 @@ func TestTgBot_Commands(t *testing.T) {
 			event:   botservice.Track,
 			correct: false,
 		},
+		{
+			name:    "ошибка в скраппере при включении дайджеста",
+			tg:      tgWithoutErr,
+			scrap:   scrapWithError,
+			event:   botservice.Digest + " 09:00",
+			correct: false,
+		},
+		{
+			name:    "включаем дайджест, время приводится к формату HH:MM",
+			tg:      tgWithoutErr,
+			scrap:   scrapWithoutLinks,
+			event:   botservice.Digest + " 9:05",
+			correct: true,
+		},
+		{
+			name:    "выключаем дайджест",
+			tg:      tgWithoutErr,
+			scrap:   scrapWithoutLinks,
+			event:   botservice.Digest + " off",
+			correct: true,
+		},
+		{
+			name:    "некорректное время дайджеста, отправляем подсказку",
+			tg:      tgWithoutErr,
+			scrap:   scrapWithError,
+			event:   botservice.Digest + " 25:00",
+			correct: true,
+		},
+		{
+			name:    "ошибка в скраппере при включении тихих часов",
+			tg:      tgWithoutErr,
+			scrap:   scrapWithError,
+			event:   botservice.Quiet + " 23:00-08:00",
+			correct: false,
+		},
+		{
+			name:    "включаем тихие часы",
+			tg:      tgWithoutErr,
+			scrap:   scrapWithoutLinks,
+			event:   botservice.Quiet + " 23:00 - 8:00",
+			correct: true,
+		},
+		{
+			name:    "выключаем тихие часы",
+			tg:      tgWithoutErr,
+			scrap:   scrapWithoutLinks,
+			event:   botservice.Quiet + " off",
+			correct: true,
+		},
+		{
+			name:    "некорректный интервал тихих часов, отправляем подсказку",
+			tg:      tgWithoutErr,
+			scrap:   scrapWithError,
+			event:   botservice.Quiet + " 23:00",
+			correct: true,
+		},
+		{
+			name:    "сохраняем часовой пояс",
+			tg:      tgWithoutErr,
+			scrap:   scrapWithoutLinks,
+			event:   botservice.Timezone + " Asia/Novosibirsk",
+			correct: true,
+		},
+		{
+			name:    "неизвестный часовой пояс, отправляем подсказку",
+			tg:      tgWithoutErr,
+			scrap:   scrapWithError,
+			event:   botservice.Timezone + " Mars/Olympus",
+			correct: true,
+		},
+		{
+			name:    "меняем язык бота",
+			tg:      tgWithoutErr,
+			cache:   notEmtyCache,
+			scrap:   scrapWithoutLinks,
+			event:   botservice.Lang + " EN",
+			correct: true,
+		},
+		{
+			name:    "неподдерживаемый язык, отправляем подсказку",
+			tg:      tgWithoutErr,
+			scrap:   scrapWithError,
+			event:   botservice.Lang + " de",
+			correct: true,
+		},
+		{
+			name:    "ошибка при сохранении языка в скраппере",
+			tg:      tgWithoutErr,
+			scrap:   scrapWithError,
+			event:   botservice.Lang + " en",
+			correct: false,
+		},
+		{
+			name:    "задаем интервал проверки ссылки",
+			tg:      tgWithoutErr,
+			scrap:   scrapWithoutLinks,
+			event:   botservice.Interval + " https://github.com/orlov4919/test 30m",
+			correct: true,
+		},
+		{
+			name:    "возвращаем ссылке адаптивное расписание",
+			tg:      tgWithoutErr,
+			scrap:   scrapWithoutLinks,
+			event:   botservice.Interval + " https://github.com/orlov4919/test off",
+			correct: true,
+		},
+		{
+			name:    "интервал меньше минимального, отправляем подсказку",
+			tg:      tgWithoutErr,
+			scrap:   scrapWithError,
+			event:   botservice.Interval + " https://github.com/orlov4919/test 10s",
+			correct: true,
+		},
+		{
+			name:    "пользователь не отслеживает ссылку",
+			tg:      tgWithoutErr,
+			scrap:   scrapWithError,
+			event:   botservice.Interval + " https://github.com/orlov4919/other 1h",
+			correct: true,
+		},
 		{
 			name:    "в боте нет обработчика для такой команды",
 			tg:      tgWithErr,
@@ -123,8 +289,9 @@ func TestTgBot_Commands(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		bot := botservice.New(test.tg, test.scrap, store, test.cache, logger, botLimit)
-		err := bot.Commands(testID, test.event)
+		bot := botservice.New(test.tg, test.scrap, store, tgbot.NewMemoryStateStore(), tgbot.NewMemoryLangStore(),
+			test.cache, logger, botLimit)
+		err := bot.Commands(context.Background(), testID, test.event)
 
 		if test.correct {
 			assert.NoError(t, err)
@@ -146,14 +313,15 @@ func TestTgBot_RegHandler(t *testing.T) {
 	scrapWithErr := mocks.NewScrapClient(t)
 	scrapWithoutErr := mocks.NewScrapClient(t)
 
-	storeWithErr.On("RegUser", mock.Anything).Return(errTest)
-	storeWithoutErr.On("RegUser", mock.Anything).Return(nil)
+	storeWithErr.On("RegUser", mock.Anything, mock.Anything).Return(errTest)
+	storeWithoutErr.On("RegUser", mock.Anything, mock.Anything).Return(nil)
 
-	scrapWithErr.On("RegUser", mock.Anything).Return(errTest)
-	scrapWithoutErr.On("RegUser", mock.Anything).Return(nil)
+	scrapWithErr.On("RegUser", mock.Anything, mock.Anything).Return(errTest)
+	scrapWithoutErr.On("RegUser", mock.Anything, mock.Anything).Return(nil)
+	scrapWithoutErr.On("SetLanguage", mock.Anything, mock.Anything, i18n.Default).Return(nil)
 
-	tgWithErr.On("SendMessage", mock.Anything, mock.Anything).Return(errTest)
-	tgWithoutErr.On("SendMessage", mock.Anything, mock.Anything).Return(nil)
+	tgWithErr.On("SendMessage", mock.Anything, mock.Anything, mock.Anything).Return(errTest)
+	tgWithoutErr.On("SendMessage", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	type testCase struct {
 		name     string
@@ -200,8 +368,9 @@ func TestTgBot_RegHandler(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		tgBot := botservice.New(test.tg, test.scrap, test.ctxStore, cache, logger, botLimit)
-		err := tgBot.RegHandler(testID, test.event)
+		tgBot := botservice.New(test.tg, test.scrap, test.ctxStore, tgbot.NewMemoryStateStore(), tgbot.NewMemoryLangStore(),
+			cache, logger, botLimit)
+		err := tgBot.RegHandler(context.Background(), testID, test.event)
 
 		if test.correct {
 			assert.NoError(t, err)
@@ -221,8 +390,8 @@ func TestTgBot_CommandsHandler(t *testing.T) {
 
 	store := mocks.NewCtxStorage(t)
 
-	tgWithErr.On("SendMessage", mock.Anything, mock.Anything).Return(errTest)
-	tgWithoutErr.On("SendMessage", mock.Anything, mock.Anything).Return(nil)
+	tgWithErr.On("SendMessage", mock.Anything, mock.Anything, mock.Anything).Return(errTest)
+	tgWithoutErr.On("SendMessage", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	type testCase struct {
 		name     string
@@ -253,8 +422,9 @@ func TestTgBot_CommandsHandler(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		tgBot := botservice.New(test.tg, test.scrap, test.ctxStore, cache, logger, botLimit)
-		err := tgBot.CommandsHandler(testID, test.event)
+		tgBot := botservice.New(test.tg, test.scrap, test.ctxStore, tgbot.NewMemoryStateStore(), tgbot.NewMemoryLangStore(),
+			cache, logger, botLimit)
+		err := tgBot.CommandsHandler(context.Background(), testID, test.event)
 
 		if test.correct {
 			assert.NoError(t, err)
@@ -276,14 +446,14 @@ func TestTgBot_LinkRemoveHandler(t *testing.T) {
 
 	cacheWithErr := mocks.NewCacheStorage(t)
 
-	cacheWithErr.On("InvalidateUserCache", mock.Anything).Return(errTest)
+	cacheWithErr.On("InvalidateUserCache", mock.Anything, mock.Anything).Return(errTest)
 
-	scrapWithoutErr.On("RemoveLink", mock.Anything, mock.Anything).Return(nil)
-	scrapWithoutLinks.On("RemoveLink", mock.Anything, mock.Anything).Return(tgbot.LinkNotExist)
-	scrapWithErr.On("RemoveLink", mock.Anything, mock.Anything).Return(errTest)
+	scrapWithoutErr.On("RemoveLink", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	scrapWithoutLinks.On("RemoveLink", mock.Anything, mock.Anything, mock.Anything).Return(tgbot.LinkNotExist)
+	scrapWithErr.On("RemoveLink", mock.Anything, mock.Anything, mock.Anything).Return(errTest)
 
-	tgWithErr.On("SendMessage", mock.Anything, mock.Anything).Return(errTest)
-	tgWithoutErr.On("SendMessage", mock.Anything, mock.Anything).Return(nil)
+	tgWithErr.On("SendMessage", mock.Anything, mock.Anything, mock.Anything).Return(errTest)
+	tgWithoutErr.On("SendMessage", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	type testCase struct {
 		name    string
@@ -330,8 +500,9 @@ func TestTgBot_LinkRemoveHandler(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		tgBot := botservice.New(test.tg, test.scrap, store, test.cache, logger, botLimit)
-		err := tgBot.LinkRemoveHandler(testID, test.event)
+		tgBot := botservice.New(test.tg, test.scrap, store, tgbot.NewMemoryStateStore(), tgbot.NewMemoryLangStore(),
+			test.cache, logger, botLimit)
+		err := tgBot.LinkRemoveHandler(context.Background(), testID, test.event)
 
 		if test.correct {
 			assert.NoError(t, err)
@@ -351,10 +522,12 @@ func TestTgBot_AddLinkHandler(t *testing.T) {
 
 	tgWithoutErr := mocks.NewTgClient(t)
 
-	storeWithErr.On("AddURL", mock.Anything, mock.Anything).Return(errTest)
-	storeWithoutErr.On("AddURL", mock.Anything, mock.Anything).Return(nil)
+	storeWithErr.On("AddURL", mock.Anything, mock.Anything, mock.Anything).Return(errTest)
+	storeWithoutErr.On("AddURL", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
-	tgWithoutErr.On("SendMessage", mock.Anything, mock.Anything).Return(nil)
+	tgWithoutErr.On("SendMessage", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	tgWithoutErr.On("SendKeyboard", mock.Anything, mock.Anything, botservice.Text(i18n.Default, botservice.AddLinkTagMsg), mock.Anything).
+		Return(nil)
 
 	type testCase struct {
 		name     string
@@ -393,8 +566,9 @@ func TestTgBot_AddLinkHandler(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		tgBot := botservice.New(test.tg, test.scrap, test.ctxStore, cache, logger, botLimit)
-		err := tgBot.AddLinkHandler(testID, test.event)
+		tgBot := botservice.New(test.tg, test.scrap, test.ctxStore, tgbot.NewMemoryStateStore(), tgbot.NewMemoryLangStore(),
+			cache, logger, botLimit)
+		err := tgBot.AddLinkHandler(context.Background(), testID, test.event)
 
 		if test.correct {
 			assert.NoError(t, err)
@@ -414,10 +588,12 @@ func TestTgBot_AddTagHandler(t *testing.T) {
 
 	tgWithoutErr := mocks.NewTgClient(t)
 
-	storeWithErr.On("AddTags", mock.Anything, mock.Anything).Return(errTest)
-	storeWithoutErr.On("AddTags", mock.Anything, mock.Anything).Return(nil)
+	storeWithErr.On("AddTags", mock.Anything, mock.Anything, mock.Anything).Return(errTest)
+	storeWithoutErr.On("AddTags", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
-	tgWithoutErr.On("SendMessage", mock.Anything, mock.Anything).Return(nil)
+	tgWithoutErr.On("SendMessage", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	tgWithoutErr.On("SendKeyboard", mock.Anything, mock.Anything, botservice.Text(i18n.Default, botservice.AddLinkFilterMsg),
+		mock.Anything).Return(nil)
 
 	type testCase struct {
 		name     string
@@ -456,8 +632,9 @@ func TestTgBot_AddTagHandler(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		tgBot := botservice.New(test.tg, test.scrap, test.ctxStore, cache, logger, botLimit)
-		err := tgBot.AddTagHandler(testID, test.event)
+		tgBot := botservice.New(test.tg, test.scrap, test.ctxStore, tgbot.NewMemoryStateStore(), tgbot.NewMemoryLangStore(),
+			cache, logger, botLimit)
+		err := tgBot.AddTagHandler(context.Background(), testID, test.event)
 
 		if test.correct {
 			assert.NoError(t, err)
@@ -480,22 +657,22 @@ func TestTgBot_SaveLinkHandler(t *testing.T) {
 	scrapWithInternalErr := mocks.NewScrapClient(t)
 	scrapWithoutErr := mocks.NewScrapClient(t)
 
-	tgWithoutErr.On("SendMessage", mock.Anything, mock.Anything).Return(nil)
+	tgWithoutErr.On("SendMessage", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
-	storeWithAddErr.On("AddFilters", mock.Anything, mock.Anything).Return(errTest)
-	storeWithContextErr.On("AddFilters", mock.Anything, mock.Anything).Return(nil)
-	storeWithContextErr.On("UserContext", mock.Anything, mock.Anything).
+	storeWithAddErr.On("AddFilters", mock.Anything, mock.Anything, mock.Anything).Return(errTest)
+	storeWithContextErr.On("AddFilters", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	storeWithContextErr.On("UserContext", mock.Anything, mock.Anything, mock.Anything).
 		Return(nil, errTest)
 
-	storeWithoutErr.On("AddFilters", mock.Anything, mock.Anything).Return(nil)
-	storeWithoutErr.On("UserContext", mock.Anything, mock.Anything).
+	storeWithoutErr.On("AddFilters", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	storeWithoutErr.On("UserContext", mock.Anything, mock.Anything, mock.Anything).
 		Return(&tgbot.ContextData{}, nil)
 
-	scrapWithLinkNotSupport.On("AddLink", mock.Anything, mock.Anything).Return(tgbot.LinkNotSupport)
-	scrapWithInternalErr.On("AddLink", mock.Anything, mock.Anything).Return(errTest)
-	scrapWithoutErr.On("AddLink", mock.Anything, mock.Anything).Return(nil)
+	scrapWithLinkNotSupport.On("AddLink", mock.Anything, mock.Anything, mock.Anything).Return(tgbot.LinkNotSupport)
+	scrapWithInternalErr.On("AddLink", mock.Anything, mock.Anything, mock.Anything).Return(errTest)
+	scrapWithoutErr.On("AddLink", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
-	cacheWithErr.On("InvalidateUserCache", mock.Anything).Return(errTest)
+	cacheWithErr.On("InvalidateUserCache", mock.Anything, mock.Anything).Return(errTest)
 
 	type testCase struct {
 		name     string
@@ -558,8 +735,9 @@ func TestTgBot_SaveLinkHandler(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		tgBot := botservice.New(test.tg, test.scrap, test.ctxStore, cacheWithErr, logger, botLimit)
-		err := tgBot.SaveLinkHandler(testID, test.event)
+		tgBot := botservice.New(test.tg, test.scrap, test.ctxStore, tgbot.NewMemoryStateStore(), tgbot.NewMemoryLangStore(),
+			cacheWithErr, logger, botLimit)
+		err := tgBot.SaveLinkHandler(context.Background(), testID, test.event)
 
 		if test.correct {
 			assert.NoError(t, err)
@@ -19,15 +19,20 @@ func NewTransition(event tgbot.Event, dst tgbot.State) tgbot.Transition {
 }
 
 var (
-	StartTransition   = NewTransition(Start, AnyRegisteredCommand)
-	HelpTransition    = NewTransition(Help, AnyRegisteredCommand)
-	UntrackTransition = NewTransition(Untrack, RemoveLink)
-	RemoveTransition  = NewTransition(tgbot.TextEvent, AnyRegisteredCommand)
-	ListTransition    = NewTransition(List, AnyRegisteredCommand)
-	TrackTransition   = NewTransition(Track, AddNewLink)
-	LinkTransition    = NewTransition(tgbot.TextEvent, AddLinkTag)
-	TagTransition     = NewTransition(tgbot.TextEvent, AddLinkFilter)
-	FilterTransition  = NewTransition(tgbot.TextEvent, AnyRegisteredCommand)
+	StartTransition    = NewTransition(Start, AnyRegisteredCommand)
+	HelpTransition     = NewTransition(Help, AnyRegisteredCommand)
+	UntrackTransition  = NewTransition(Untrack, RemoveLink)
+	RemoveTransition   = NewTransition(tgbot.TextEvent, AnyRegisteredCommand)
+	ListTransition     = NewTransition(List, AnyRegisteredCommand)
+	DigestTransition   = NewTransition(Digest, AnyRegisteredCommand)
+	QuietTransition    = NewTransition(Quiet, AnyRegisteredCommand)
+	TimezoneTransition = NewTransition(Timezone, AnyRegisteredCommand)
+	LangTransition     = NewTransition(Lang, AnyRegisteredCommand)
+	IntervalTransition = NewTransition(Interval, AnyRegisteredCommand)
+	TrackTransition    = NewTransition(Track, AddNewLink)
+	LinkTransition     = NewTransition(tgbot.TextEvent, AddLinkTag)
+	TagTransition      = NewTransition(tgbot.TextEvent, AddLinkFilter)
+	FilterTransition   = NewTransition(tgbot.TextEvent, AnyRegisteredCommand)
 )
 
 var commandTransition = tgbot.Transitions{
@@ -36,6 +41,11 @@ var commandTransition = tgbot.Transitions{
 	UntrackTransition,
 	ListTransition,
 	TrackTransition,
+	DigestTransition,
+	QuietTransition,
+	TimezoneTransition,
+	LangTransition,
+	IntervalTransition,
 }
 
 var states = tgbot.States{
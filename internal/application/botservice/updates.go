@@ -0,0 +1,98 @@
+package botservice
+
+import (
+	"fmt"
+	"linkTraccer/internal/domain/dto"
+	"linkTraccer/internal/domain/i18n"
+	"linkTraccer/internal/domain/scrapper"
+	"strings"
+	"time"
+)
+
+// itemTimeLayout - формат времени создания элемента в уведомлении.
+const itemTimeLayout = "15:04:05 02-01-2006"
+
+// siteNames - названия сайтов в уведомлениях, они не переводятся.
+var siteNames = map[string]string{
+	scrapper.GitHubSite:        "GitHub",
+	scrapper.StackOverflowSite: "Stack Overflow",
+}
+
+// RenderLinkUpdate отправляет старые уведомления без структуры готовым текстом из Description.
+func RenderLinkUpdate(update *dto.LinkUpdate) string {
+	location := updateLocation(update.Timezone)
+
+	switch {
+	case update.Update != nil:
+		return renderUpdate(update.Language, location, update.URL, update.Update)
+	case len(update.Digest) > 0:
+		return renderDigest(update.Language, location, update.Digest)
+	default:
+		return update.Description + update.URL
+	}
+}
+
+func renderUpdate(lang i18n.Lang, location *time.Location, url string, item *dto.UpdateItem) string {
+	builder := strings.Builder{}
+
+	builder.WriteString(Text(lang, updateHeader))
+	builder.WriteString(fmt.Sprintf(Text(lang, updateEvent), typeName(lang, item.Type), siteName(item.Site)))
+	builder.WriteString(fmt.Sprintf(Text(lang, updateTitle), item.Title))
+	builder.WriteString(fmt.Sprintf(Text(lang, updateAuthor), item.Author))
+	builder.WriteString(fmt.Sprintf(Text(lang, updateCreatedAt), item.CreatedAt.In(location).Format(itemTimeLayout)))
+
+	if item.Excerpt != "" {
+		builder.WriteString(fmt.Sprintf(Text(lang, updateExcerpt), item.Excerpt))
+	}
+
+	if item.URL != "" {
+		url = item.URL
+	}
+
+	builder.WriteString("\n" + url)
+
+	return builder.String()
+}
+
+func renderDigest(lang i18n.Lang, location *time.Location, digest []*dto.LinkDigest) string {
+	builder := strings.Builder{}
+
+	builder.WriteString(Text(lang, digestHeader))
+
+	for _, linkDigest := range digest {
+		builder.WriteString(fmt.Sprintf(Text(lang, digestLinkFormat), linkDigest.URL))
+
+		for _, item := range linkDigest.Updates {
+			builder.WriteString(fmt.Sprintf(Text(lang, digestItemFormat), typeName(lang, item.Type), item.Author,
+				item.CreatedAt.In(location).Format(itemTimeLayout), item.Title))
+		}
+	}
+
+	return builder.String()
+}
+
+// typeName возвращает название типа элемента, а для неизвестного типа - сам тип.
+func typeName(lang i18n.Lang, updateType string) string {
+	if name := Text(lang, updateType); name != "" {
+		return name
+	}
+
+	return updateType
+}
+
+func siteName(site string) string {
+	if name, ok := siteNames[site]; ok {
+		return name
+	}
+
+	return site
+}
+
+// updateLocation по умолчанию возвращает UTC, в котором скраппер хранит время.
+func updateLocation(timezone string) *time.Location {
+	if location, err := time.LoadLocation(timezone); err == nil {
+		return location
+	}
+
+	return time.UTC
+}
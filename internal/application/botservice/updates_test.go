@@ -0,0 +1,60 @@
+package botservice_test
+
+import (
+	"linkTraccer/internal/application/botservice"
+	"linkTraccer/internal/domain/dto"
+	"linkTraccer/internal/domain/i18n"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderLinkUpdate(t *testing.T) {
+	createdAt := time.Date(2025, 4, 1, 7, 0, 0, 0, time.UTC)
+
+	issue := &dto.UpdateItem{Site: "github", Type: "issue", URL: "https://github.com/orlov4919/test/issues/1",
+		Author: "orlov4919", CreatedAt: createdAt, Title: "New feature", Excerpt: "please add it"}
+	answer := &dto.UpdateItem{Site: "stackoverflow", Type: "answer", Author: "John", CreatedAt: createdAt,
+		Title: "How to exit vim?"}
+
+	tests := []struct {
+		name     string
+		update   *dto.LinkUpdate
+		expected string
+	}{
+		{
+			name: "обновление на русском в часовом поясе получателя",
+			update: &dto.LinkUpdate{URL: "https://github.com/orlov4919/test", Update: issue, Language: i18n.Russian,
+				Timezone: "Europe/Moscow"},
+			expected: "Пришло новое уведомление 🔥\n\nСобытие: Issue (GitHub)\nЗаголовок: New feature\n" +
+				"Пользователь: orlov4919\nВремя создания: 10:00:00 01-04-2025\nПревью: please add it\n\n" +
+				"https://github.com/orlov4919/test/issues/1",
+		},
+		{
+			name: "без ссылки на элемент и превью, время в UTC",
+			update: &dto.LinkUpdate{URL: "https://stackoverflow.com/questions/1", Update: answer,
+				Language: i18n.English},
+			expected: "New notification 🔥\n\nEvent: Answer (Stack Overflow)\nTitle: How to exit vim?\nUser: John\n" +
+				"Created at: 07:00:00 01-04-2025\n\nhttps://stackoverflow.com/questions/1",
+		},
+		{
+			name: "дайджест",
+			update: &dto.LinkUpdate{Digest: []*dto.LinkDigest{{URL: "https://github.com/orlov4919/test",
+				Updates: []*dto.UpdateItem{issue}}}, Language: i18n.English, Timezone: "Asia/Yekaterinburg"},
+			expected: "Updates digest 📰\n\n🔗 https://github.com/orlov4919/test\n" +
+				"• Issue, orlov4919 (12:00:00 01-04-2025)\nNew feature\n",
+		},
+		{
+			name:     "уведомление в старом формате",
+			update:   &dto.LinkUpdate{URL: "https://github.com/orlov4919/test", Description: "new "},
+			expected: "new https://github.com/orlov4919/test",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, botservice.RenderLinkUpdate(test.update))
+		})
+	}
+}
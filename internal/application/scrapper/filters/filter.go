@@ -1,20 +1,134 @@
 package filters
 
 import (
-	"linkTraccer/internal/application/scrapper/scrapservice"
+	"context"
+	"fmt"
 	"linkTraccer/internal/domain/scrapper"
+	"strings"
 )
 
+// Поддерживаемые фильтры:
+//
+//	user:<login>  - не уведомлять о событиях, созданных пользователем login
+//	type:<type>   - уведомлять только о событиях указанного типа (issue, pr, answer, comment),
+//	                несколько типов перечисляются через |, например type:issue|pr
+//	-<word>       - не уведомлять о событиях, содержащих слово
+//	<word>        - уведомлять только о событиях, содержащих хотя бы одно из слов
+
+const (
+	userPrefix    = "user:"
+	typePrefix    = "type:"
+	typeSeparator = "|"
+	excludePrefix = "-"
+)
+
+type User = scrapper.User
+type LinkUpdate = scrapper.LinkUpdate
+
+type FiltersRepo interface {
+	UsersFilters(ctx context.Context, linkID scrapper.LinkID) (map[User][]scrapper.Filter, error)
+}
+
 type UpdatesFilter struct {
-	userRepo scrapservice.UserRepo
+	userRepo FiltersRepo
 }
 
-func New(repo scrapservice.UserRepo) *UpdatesFilter {
+func New(repo FiltersRepo) *UpdatesFilter {
 	return &UpdatesFilter{userRepo: repo}
 }
 
-func (f *UpdatesFilter) FilterByCreator(info *scrapper.LinkInfo, update *scrapper.LinkUpdate) ([]scrapper.User, error) {
+// Users получает фильтры всех пользователей ссылки одним запросом.
+func (f *UpdatesFilter) Users(ctx context.Context, info *scrapper.LinkInfo,
+	updates scrapper.LinkUpdates) (map[*LinkUpdate][]User, error) {
+	usersFilters, err := f.userRepo.UsersFilters(ctx, info.ID)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении фильтров пользователей: %w", err)
+	}
+
+	rules := make(map[User]*userRules, len(usersFilters))
+
+	for user, filters := range usersFilters {
+		rules[user] = parseFilters(filters)
+	}
+
+	usersToNotify := make(map[*LinkUpdate][]User, len(updates))
+
+	for _, update := range updates {
+		users := make([]User, 0, len(rules))
+
+		for user, userRules := range rules {
+			if userRules.match(update) {
+				users = append(users, user)
+			}
+		}
+
+		usersToNotify[update] = users
+	}
+
+	return usersToNotify, nil
+}
+
+type userRules struct {
+	excludedAuthors map[string]struct{}
+	types           map[scrapper.UpdateType]struct{}
+	include         []string
+	exclude         []string
+}
+
+func parseFilters(filters []scrapper.Filter) *userRules {
+	rules := &userRules{
+		excludedAuthors: make(map[string]struct{}),
+		types:           make(map[scrapper.UpdateType]struct{}),
+	}
+
+	for _, filter := range filters {
+		filter = strings.ToLower(strings.TrimSpace(filter))
+
+		switch {
+		case filter == "":
+			continue
+		case strings.HasPrefix(filter, userPrefix):
+			rules.excludedAuthors[strings.TrimPrefix(filter, userPrefix)] = struct{}{}
+		case strings.HasPrefix(filter, typePrefix):
+			for _, updateType := range strings.Split(strings.TrimPrefix(filter, typePrefix), typeSeparator) {
+				rules.types[strings.TrimSpace(updateType)] = struct{}{}
+			}
+		case strings.HasPrefix(filter, excludePrefix):
+			rules.exclude = append(rules.exclude, strings.TrimPrefix(filter, excludePrefix))
+		default:
+			rules.include = append(rules.include, filter)
+		}
+	}
+
+	return rules
+}
+
+func (r *userRules) match(update *LinkUpdate) bool {
+	if _, ok := r.excludedAuthors[strings.ToLower(update.Author)]; ok {
+		return false
+	}
+
+	if _, ok := r.types[update.Type]; len(r.types) != 0 && !ok {
+		return false
+	}
+
+	text := strings.ToLower(update.Title + " " + update.Excerpt)
+
+	for _, word := range r.exclude {
+		if strings.Contains(text, word) {
+			return false
+		}
+	}
+
+	if len(r.include) == 0 {
+		return true
+	}
 
-	// одним sql запросом доставать тех кто не подходит по ссылке
+	for _, word := range r.include {
+		if strings.Contains(text, word) {
+			return true
+		}
+	}
 
+	return false
 }
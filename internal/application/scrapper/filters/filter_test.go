@@ -0,0 +1,136 @@
+package filters_test
+
+import (
+	"context"
+	"errors"
+	"linkTraccer/internal/application/scrapper/filters"
+	"linkTraccer/internal/application/scrapper/filters/mocks"
+	"linkTraccer/internal/domain/scrapper"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	firstUser  = 1
+	secondUser = 2
+	thirdUser  = 3
+)
+
+var (
+	errRepo  = errors.New("ошибка в репозитории")
+	linkInfo = &scrapper.LinkInfo{ID: 1, URL: "https://github.com/orlov4919/test"}
+)
+
+func TestUpdatesFilter_Users(t *testing.T) {
+	repoWithErr := mocks.NewFiltersRepo(t)
+	repoWithUsers := mocks.NewFiltersRepo(t)
+
+	repoWithErr.On("UsersFilters", mock.Anything, mock.Anything).Return(nil, errRepo)
+	repoWithUsers.On("UsersFilters", mock.Anything, mock.Anything).Return(map[scrapper.User][]scrapper.Filter{
+		firstUser:  {},
+		secondUser: {"user:Orlov4919", "type:issue"},
+		thirdUser:  {"kafka", "-draft"},
+	}, nil)
+
+	type TestCase struct {
+		name          string
+		repo          *mocks.FiltersRepo
+		update        *scrapper.LinkUpdate
+		expectedUsers []scrapper.User
+		correct       bool
+	}
+
+	tests := []TestCase{
+		{
+			name:    "ошибка при получении фильтров пользователей",
+			repo:    repoWithErr,
+			update:  &scrapper.LinkUpdate{},
+			correct: false,
+		},
+		{
+			name: "автор обновления исключен фильтром, не подходит по ключевым словам",
+			repo: repoWithUsers,
+			update: &scrapper.LinkUpdate{
+				Type:   scrapper.IssueUpdate,
+				Author: "orlov4919",
+				Title:  "new feature",
+			},
+			expectedUsers: []scrapper.User{firstUser},
+			correct:       true,
+		},
+		{
+			name: "тип обновления не подходит, ключевое слово найдено",
+			repo: repoWithUsers,
+			update: &scrapper.LinkUpdate{
+				Type:   scrapper.PRUpdate,
+				Author: "dummy",
+				Title:  "add Kafka consumer",
+			},
+			expectedUsers: []scrapper.User{firstUser, thirdUser},
+			correct:       true,
+		},
+		{
+			name: "обновление содержит исключенное слово",
+			repo: repoWithUsers,
+			update: &scrapper.LinkUpdate{
+				Type:    scrapper.IssueUpdate,
+				Author:  "dummy",
+				Title:   "kafka retries",
+				Excerpt: "[DRAFT] пока не готово",
+			},
+			expectedUsers: []scrapper.User{firstUser, secondUser},
+			correct:       true,
+		},
+	}
+
+	for _, test := range tests {
+		updatesFilter := filters.New(test.repo)
+		users, err := updatesFilter.Users(context.Background(), linkInfo, scrapper.LinkUpdates{test.update})
+
+		if test.correct {
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, test.expectedUsers, users[test.update], test.name)
+		} else {
+			assert.Error(t, err)
+		}
+	}
+}
+
+func TestUpdatesFilter_UsersTypeAlternatives(t *testing.T) {
+	repo := mocks.NewFiltersRepo(t)
+
+	repo.On("UsersFilters", mock.Anything, mock.Anything).Return(map[scrapper.User][]scrapper.Filter{
+		firstUser: {"type:issue|pr"},
+	}, nil)
+
+	tests := []struct {
+		name          string
+		update        *scrapper.LinkUpdate
+		expectedUsers []scrapper.User
+	}{
+		{
+			name:          "issue подходит под type:issue|pr",
+			update:        &scrapper.LinkUpdate{Type: scrapper.IssueUpdate},
+			expectedUsers: []scrapper.User{firstUser},
+		},
+		{
+			name:          "pr подходит под type:issue|pr",
+			update:        &scrapper.LinkUpdate{Type: scrapper.PRUpdate},
+			expectedUsers: []scrapper.User{firstUser},
+		},
+		{
+			name:          "ответ не подходит под type:issue|pr",
+			update:        &scrapper.LinkUpdate{Type: scrapper.AnswerUpdate},
+			expectedUsers: []scrapper.User{},
+		},
+	}
+
+	for _, test := range tests {
+		users, err := filters.New(repo).Users(context.Background(), linkInfo, scrapper.LinkUpdates{test.update})
+
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, test.expectedUsers, users[test.update], test.name)
+	}
+}
@@ -0,0 +1,95 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// FiltersRepo is an autogenerated mock type for the FiltersRepo type
+type FiltersRepo struct {
+	mock.Mock
+}
+
+type FiltersRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *FiltersRepo) EXPECT() *FiltersRepo_Expecter {
+	return &FiltersRepo_Expecter{mock: &_m.Mock}
+}
+
+// UsersFilters provides a mock function with given fields: ctx, linkID
+func (_m *FiltersRepo) UsersFilters(ctx context.Context, linkID int64) (map[int64][]string, error) {
+	ret := _m.Called(ctx, linkID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UsersFilters")
+	}
+
+	var r0 map[int64][]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (map[int64][]string, error)); ok {
+		return rf(ctx, linkID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) map[int64][]string); ok {
+		r0 = rf(ctx, linkID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int64][]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, linkID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FiltersRepo_UsersFilters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UsersFilters'
+type FiltersRepo_UsersFilters_Call struct {
+	*mock.Call
+}
+
+// UsersFilters is a helper method to define mock.On call
+//   - ctx context.Context
+//   - linkID int64
+func (_e *FiltersRepo_Expecter) UsersFilters(ctx interface{}, linkID interface{}) *FiltersRepo_UsersFilters_Call {
+	return &FiltersRepo_UsersFilters_Call{Call: _e.mock.On("UsersFilters", ctx, linkID)}
+}
+
+func (_c *FiltersRepo_UsersFilters_Call) Run(run func(ctx context.Context, linkID int64)) *FiltersRepo_UsersFilters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *FiltersRepo_UsersFilters_Call) Return(_a0 map[int64][]string, _a1 error) *FiltersRepo_UsersFilters_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *FiltersRepo_UsersFilters_Call) RunAndReturn(run func(context.Context, int64) (map[int64][]string, error)) *FiltersRepo_UsersFilters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewFiltersRepo creates a new instance of FiltersRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewFiltersRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *FiltersRepo {
+	mock := &FiltersRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,145 @@
+package digest
+
+import (
+	"context"
+	"fmt"
+	"linkTraccer/internal/application/scrapper/scrapservice"
+	"linkTraccer/internal/domain/scrapper"
+	"log/slog"
+	"time"
+)
+
+type User = scrapper.User
+
+type DigestRepo interface {
+	DigestUsers(ctx context.Context, users []User) ([]User, error)
+	SavePendingUpdate(ctx context.Context, linkID scrapper.LinkID, update *scrapper.LinkUpdate, users []User) error
+	DueDigestUsers(ctx context.Context, now time.Time) ([]User, error)
+	PendingUpdates(ctx context.Context, user User) ([]*scrapper.PendingUpdate, error)
+	DeletePendingUpdates(ctx context.Context, user User, lastUpdateID int64) error
+	MarkDigestSent(ctx context.Context, user User, sentAt time.Time) error
+}
+
+type Notifier interface {
+	SendUpdate(ctx context.Context, linkInfo *scrapper.LinkInfo, linkUpdate *scrapper.LinkUpdate, users []User) error
+	SendDigest(ctx context.Context, user User, digest []*scrapper.LinkDigest) error
+}
+
+// Dispatcher откладывает обновления для пользователей с дайджестом, остальным отправляет сразу.
+type Dispatcher struct {
+	repo       DigestRepo
+	notifier   Notifier
+	transactor scrapservice.Transactor
+	log        *slog.Logger
+}
+
+func New(repo DigestRepo, notifier Notifier, transactor scrapservice.Transactor, log *slog.Logger) *Dispatcher {
+	return &Dispatcher{
+		repo:       repo,
+		notifier:   notifier,
+		transactor: transactor,
+		log:        log,
+	}
+}
+
+func (d *Dispatcher) SendUpdate(ctx context.Context, linkInfo *scrapper.LinkInfo, linkUpdate *scrapper.LinkUpdate,
+	users []User) error {
+	digestUsers, err := d.repo.DigestUsers(ctx, users)
+	if err != nil {
+		return fmt.Errorf("ошибка при получении способа доставки обновлений: %w", err)
+	}
+
+	if len(digestUsers) > 0 {
+		err = d.repo.SavePendingUpdate(ctx, linkInfo.ID, linkUpdate, digestUsers)
+		if err != nil {
+			return fmt.Errorf("ошибка при откладывании обновления в дайджест: %w", err)
+		}
+	}
+
+	instantUsers := withoutUsers(users, digestUsers)
+
+	if len(instantUsers) == 0 {
+		return nil
+	}
+
+	return d.notifier.SendUpdate(ctx, linkInfo, linkUpdate, instantUsers)
+}
+
+// SendDigests удаляет обновления только после отправки, при ошибке дайджест уйдет при следующем запуске.
+func (d *Dispatcher) SendDigests(ctx context.Context) {
+	now := time.Now().UTC()
+
+	users, err := d.repo.DueDigestUsers(ctx, now)
+	if err != nil {
+		d.log.Error("ошибка при получении пользователей для отправки дайджеста", "err", err.Error())
+
+		return
+	}
+
+	for _, user := range users {
+		err := d.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+			return d.sendUserDigest(ctx, user, now)
+		})
+
+		if err != nil {
+			d.log.Error(fmt.Sprintf("ошибка при отправке дайджеста пользователю %d", user), "err", err.Error())
+		}
+	}
+}
+
+func (d *Dispatcher) sendUserDigest(ctx context.Context, user User, now time.Time) error {
+	pendingUpdates, err := d.repo.PendingUpdates(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	if len(pendingUpdates) > 0 {
+		if err = d.notifier.SendDigest(ctx, user, groupByLink(pendingUpdates)); err != nil {
+			return err
+		}
+
+		if err = d.repo.DeletePendingUpdates(ctx, user, pendingUpdates[len(pendingUpdates)-1].ID); err != nil {
+			return err
+		}
+	}
+
+	return d.repo.MarkDigestSent(ctx, user, now)
+}
+
+// groupByLink собирает обновления по ссылкам, сохраняя порядок, в котором ссылки впервые встретились.
+func groupByLink(pendingUpdates []*scrapper.PendingUpdate) []*scrapper.LinkDigest {
+	digest := make([]*scrapper.LinkDigest, 0)
+	linkInd := make(map[scrapper.LinkID]int)
+
+	for _, pending := range pendingUpdates {
+		ind, ok := linkInd[pending.LinkID]
+
+		if !ok {
+			ind = len(digest)
+			linkInd[pending.LinkID] = ind
+			digest = append(digest, &scrapper.LinkDigest{URL: pending.URL})
+		}
+
+		digest[ind].Updates = append(digest[ind].Updates, pending.Update)
+	}
+
+	return digest
+}
+
+func withoutUsers(users, excluded []User) []User {
+	excludedSet := make(map[User]struct{}, len(excluded))
+
+	for _, user := range excluded {
+		excludedSet[user] = struct{}{}
+	}
+
+	result := make([]User, 0, len(users))
+
+	for _, user := range users {
+		if _, ok := excludedSet[user]; !ok {
+			result = append(result, user)
+		}
+	}
+
+	return result
+}
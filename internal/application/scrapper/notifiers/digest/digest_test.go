@@ -0,0 +1,147 @@
+package digest_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"linkTraccer/internal/application/scrapper/notifiers/digest"
+	"linkTraccer/internal/application/scrapper/notifiers/mocks"
+	"linkTraccer/internal/domain/scrapper"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	firstUser  = 1
+	secondUser = 2
+	thirdUser  = 3
+)
+
+var (
+	errRepo     = errors.New("ошибка в репозитории")
+	errNotifier = errors.New("ошибка при отправке")
+	linkInfo    = &scrapper.LinkInfo{ID: 1, URL: "https://github.com/orlov4919/test"}
+	linkUpdate  = &scrapper.LinkUpdate{Title: "Issue"}
+	users       = []scrapper.User{firstUser, secondUser, thirdUser}
+	log         = slog.New(slog.NewTextHandler(io.Discard, nil))
+)
+
+func TestDispatcher_SendUpdate(t *testing.T) {
+	type TestCase struct {
+		name    string
+		prepare func(repo *mocks.DigestRepo, notifier *mocks.Notifier)
+		correct bool
+	}
+
+	tests := []TestCase{
+		{
+			name: "ошибка при получении способа доставки",
+			prepare: func(repo *mocks.DigestRepo, _ *mocks.Notifier) {
+				repo.On("DigestUsers", mock.Anything, users).Return(nil, errRepo)
+			},
+			correct: false,
+		},
+		{
+			name: "ошибка при откладывании обновления",
+			prepare: func(repo *mocks.DigestRepo, _ *mocks.Notifier) {
+				repo.On("DigestUsers", mock.Anything, users).Return([]scrapper.User{secondUser}, nil)
+				repo.On("SavePendingUpdate", mock.Anything, linkInfo.ID, linkUpdate,
+					[]scrapper.User{secondUser}).Return(errRepo)
+			},
+			correct: false,
+		},
+		{
+			name: "часть пользователей получает дайджест, остальным обновление уходит сразу",
+			prepare: func(repo *mocks.DigestRepo, notifier *mocks.Notifier) {
+				repo.On("DigestUsers", mock.Anything, users).Return([]scrapper.User{secondUser}, nil)
+				repo.On("SavePendingUpdate", mock.Anything, linkInfo.ID, linkUpdate,
+					[]scrapper.User{secondUser}).Return(nil)
+				notifier.On("SendUpdate", mock.Anything, linkInfo, linkUpdate,
+					[]scrapper.User{firstUser, thirdUser}).Return(nil)
+			},
+			correct: true,
+		},
+		{
+			name: "все пользователи получают дайджест",
+			prepare: func(repo *mocks.DigestRepo, _ *mocks.Notifier) {
+				repo.On("DigestUsers", mock.Anything, users).Return(users, nil)
+				repo.On("SavePendingUpdate", mock.Anything, linkInfo.ID, linkUpdate, users).Return(nil)
+			},
+			correct: true,
+		},
+		{
+			name: "ошибка при мгновенной отправке",
+			prepare: func(repo *mocks.DigestRepo, notifier *mocks.Notifier) {
+				repo.On("DigestUsers", mock.Anything, users).Return([]scrapper.User{}, nil)
+				notifier.On("SendUpdate", mock.Anything, linkInfo, linkUpdate, users).Return(errNotifier)
+			},
+			correct: false,
+		},
+	}
+
+	for _, test := range tests {
+		repo := mocks.NewDigestRepo(t)
+		notifier := mocks.NewNotifier(t)
+		test.prepare(repo, notifier)
+
+		dispatcher := digest.New(repo, notifier, mocks.NewTransactor(t), log)
+		err := dispatcher.SendUpdate(context.Background(), linkInfo, linkUpdate, users)
+
+		if test.correct {
+			assert.NoError(t, err, test.name)
+		} else {
+			assert.Error(t, err, test.name)
+		}
+	}
+}
+
+func TestDispatcher_SendDigests(t *testing.T) {
+	firstLink := &scrapper.LinkUpdate{Title: "first"}
+	secondLink := &scrapper.LinkUpdate{Title: "second"}
+	thirdLink := &scrapper.LinkUpdate{Title: "third"}
+
+	pending := []*scrapper.PendingUpdate{
+		{ID: 1, LinkID: 10, URL: "https://github.com/a/b", Update: firstLink},
+		{ID: 2, LinkID: 20, URL: "https://stackoverflow.com/questions/1", Update: secondLink},
+		{ID: 5, LinkID: 10, URL: "https://github.com/a/b", Update: thirdLink},
+	}
+
+	expectedDigest := []*scrapper.LinkDigest{
+		{URL: "https://github.com/a/b", Updates: scrapper.LinkUpdates{firstLink, thirdLink}},
+		{URL: "https://stackoverflow.com/questions/1", Updates: scrapper.LinkUpdates{secondLink}},
+	}
+
+	repo := mocks.NewDigestRepo(t)
+	notifier := mocks.NewNotifier(t)
+	transactor := mocks.NewTransactor(t)
+
+	transactor.On("WithTransaction", mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+
+	repo.On("DueDigestUsers", mock.Anything, mock.Anything).Return([]scrapper.User{firstUser, secondUser, thirdUser}, nil)
+
+	// у первого пользователя есть обновления, дайджест отправлен
+	repo.On("PendingUpdates", mock.Anything, scrapper.User(firstUser)).Return(pending, nil)
+	notifier.On("SendDigest", mock.Anything, scrapper.User(firstUser), expectedDigest).Return(nil)
+	repo.On("DeletePendingUpdates", mock.Anything, scrapper.User(firstUser), int64(5)).Return(nil)
+	repo.On("MarkDigestSent", mock.Anything, scrapper.User(firstUser), mock.Anything).Return(nil)
+
+	// у второго пользователя нет обновлений, отмечаем только время отправки
+	repo.On("PendingUpdates", mock.Anything, scrapper.User(secondUser)).Return(nil, nil)
+	repo.On("MarkDigestSent", mock.Anything, scrapper.User(secondUser), mock.Anything).Return(nil)
+
+	// третьему пользователю не удалось отправить дайджест, обновления остаются в БД
+	repo.On("PendingUpdates", mock.Anything, scrapper.User(thirdUser)).Return(pending[:1], nil)
+	notifier.On("SendDigest", mock.Anything, scrapper.User(thirdUser), mock.Anything).Return(errNotifier)
+
+	dispatcher := digest.New(repo, notifier, transactor, log)
+	dispatcher.SendDigests(context.Background())
+
+	repo.AssertNotCalled(t, "DeletePendingUpdates", mock.Anything, scrapper.User(thirdUser), mock.Anything)
+	repo.AssertNotCalled(t, "MarkDigestSent", mock.Anything, scrapper.User(thirdUser), mock.Anything)
+}
@@ -3,6 +3,7 @@
 package mocks
 
 import (
+	context "context"
 	dto "linkTraccer/internal/domain/dto"
 
 	mock "github.com/stretchr/testify/mock"
@@ -21,17 +22,17 @@ func (_m *BotClient) EXPECT() *BotClient_Expecter {
 	return &BotClient_Expecter{mock: &_m.Mock}
 }
 
-// SendLinkUpdates provides a mock function with given fields: update
-func (_m *BotClient) SendLinkUpdates(update *dto.LinkUpdate) error {
-	ret := _m.Called(update)
+// SendLinkUpdates provides a mock function with given fields: ctx, update
+func (_m *BotClient) SendLinkUpdates(ctx context.Context, update *dto.LinkUpdate) error {
+	ret := _m.Called(ctx, update)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SendLinkUpdates")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*dto.LinkUpdate) error); ok {
-		r0 = rf(update)
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.LinkUpdate) error); ok {
+		r0 = rf(ctx, update)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -45,14 +46,15 @@ type BotClient_SendLinkUpdates_Call struct {
 }
 
 // SendLinkUpdates is a helper method to define mock.On call
+//   - ctx context.Context
 //   - update *dto.LinkUpdate
-func (_e *BotClient_Expecter) SendLinkUpdates(update interface{}) *BotClient_SendLinkUpdates_Call {
-	return &BotClient_SendLinkUpdates_Call{Call: _e.mock.On("SendLinkUpdates", update)}
+func (_e *BotClient_Expecter) SendLinkUpdates(ctx interface{}, update interface{}) *BotClient_SendLinkUpdates_Call {
+	return &BotClient_SendLinkUpdates_Call{Call: _e.mock.On("SendLinkUpdates", ctx, update)}
 }
 
-func (_c *BotClient_SendLinkUpdates_Call) Run(run func(update *dto.LinkUpdate)) *BotClient_SendLinkUpdates_Call {
+func (_c *BotClient_SendLinkUpdates_Call) Run(run func(ctx context.Context, update *dto.LinkUpdate)) *BotClient_SendLinkUpdates_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*dto.LinkUpdate))
+		run(args[0].(context.Context), args[1].(*dto.LinkUpdate))
 	})
 	return _c
 }
@@ -62,7 +64,7 @@ func (_c *BotClient_SendLinkUpdates_Call) Return(_a0 error) *BotClient_SendLinkU
 	return _c
 }
 
-func (_c *BotClient_SendLinkUpdates_Call) RunAndReturn(run func(*dto.LinkUpdate) error) *BotClient_SendLinkUpdates_Call {
+func (_c *BotClient_SendLinkUpdates_Call) RunAndReturn(run func(context.Context, *dto.LinkUpdate) error) *BotClient_SendLinkUpdates_Call {
 	_c.Call.Return(run)
 	return _c
 }
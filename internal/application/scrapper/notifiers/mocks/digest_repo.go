@@ -0,0 +1,362 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	scrapper "linkTraccer/internal/domain/scrapper"
+
+	time "time"
+)
+
+// DigestRepo is an autogenerated mock type for the DigestRepo type
+type DigestRepo struct {
+	mock.Mock
+}
+
+type DigestRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *DigestRepo) EXPECT() *DigestRepo_Expecter {
+	return &DigestRepo_Expecter{mock: &_m.Mock}
+}
+
+// DeletePendingUpdates provides a mock function with given fields: ctx, user, lastUpdateID
+func (_m *DigestRepo) DeletePendingUpdates(ctx context.Context, user int64, lastUpdateID int64) error {
+	ret := _m.Called(ctx, user, lastUpdateID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletePendingUpdates")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, user, lastUpdateID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DigestRepo_DeletePendingUpdates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletePendingUpdates'
+type DigestRepo_DeletePendingUpdates_Call struct {
+	*mock.Call
+}
+
+// DeletePendingUpdates is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user int64
+//   - lastUpdateID int64
+func (_e *DigestRepo_Expecter) DeletePendingUpdates(ctx interface{}, user interface{}, lastUpdateID interface{}) *DigestRepo_DeletePendingUpdates_Call {
+	return &DigestRepo_DeletePendingUpdates_Call{Call: _e.mock.On("DeletePendingUpdates", ctx, user, lastUpdateID)}
+}
+
+func (_c *DigestRepo_DeletePendingUpdates_Call) Run(run func(ctx context.Context, user int64, lastUpdateID int64)) *DigestRepo_DeletePendingUpdates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *DigestRepo_DeletePendingUpdates_Call) Return(_a0 error) *DigestRepo_DeletePendingUpdates_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DigestRepo_DeletePendingUpdates_Call) RunAndReturn(run func(context.Context, int64, int64) error) *DigestRepo_DeletePendingUpdates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DigestUsers provides a mock function with given fields: ctx, users
+func (_m *DigestRepo) DigestUsers(ctx context.Context, users []int64) ([]int64, error) {
+	ret := _m.Called(ctx, users)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DigestUsers")
+	}
+
+	var r0 []int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) ([]int64, error)); ok {
+		return rf(ctx, users)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) []int64); ok {
+		r0 = rf(ctx, users)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int64) error); ok {
+		r1 = rf(ctx, users)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DigestRepo_DigestUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DigestUsers'
+type DigestRepo_DigestUsers_Call struct {
+	*mock.Call
+}
+
+// DigestUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - users []int64
+func (_e *DigestRepo_Expecter) DigestUsers(ctx interface{}, users interface{}) *DigestRepo_DigestUsers_Call {
+	return &DigestRepo_DigestUsers_Call{Call: _e.mock.On("DigestUsers", ctx, users)}
+}
+
+func (_c *DigestRepo_DigestUsers_Call) Run(run func(ctx context.Context, users []int64)) *DigestRepo_DigestUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int64))
+	})
+	return _c
+}
+
+func (_c *DigestRepo_DigestUsers_Call) Return(_a0 []int64, _a1 error) *DigestRepo_DigestUsers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DigestRepo_DigestUsers_Call) RunAndReturn(run func(context.Context, []int64) ([]int64, error)) *DigestRepo_DigestUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DueDigestUsers provides a mock function with given fields: ctx, now
+func (_m *DigestRepo) DueDigestUsers(ctx context.Context, now time.Time) ([]int64, error) {
+	ret := _m.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DueDigestUsers")
+	}
+
+	var r0 []int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]int64, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []int64); ok {
+		r0 = rf(ctx, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DigestRepo_DueDigestUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DueDigestUsers'
+type DigestRepo_DueDigestUsers_Call struct {
+	*mock.Call
+}
+
+// DueDigestUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+func (_e *DigestRepo_Expecter) DueDigestUsers(ctx interface{}, now interface{}) *DigestRepo_DueDigestUsers_Call {
+	return &DigestRepo_DueDigestUsers_Call{Call: _e.mock.On("DueDigestUsers", ctx, now)}
+}
+
+func (_c *DigestRepo_DueDigestUsers_Call) Run(run func(ctx context.Context, now time.Time)) *DigestRepo_DueDigestUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *DigestRepo_DueDigestUsers_Call) Return(_a0 []int64, _a1 error) *DigestRepo_DueDigestUsers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DigestRepo_DueDigestUsers_Call) RunAndReturn(run func(context.Context, time.Time) ([]int64, error)) *DigestRepo_DueDigestUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkDigestSent provides a mock function with given fields: ctx, user, sentAt
+func (_m *DigestRepo) MarkDigestSent(ctx context.Context, user int64, sentAt time.Time) error {
+	ret := _m.Called(ctx, user, sentAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkDigestSent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time) error); ok {
+		r0 = rf(ctx, user, sentAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DigestRepo_MarkDigestSent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkDigestSent'
+type DigestRepo_MarkDigestSent_Call struct {
+	*mock.Call
+}
+
+// MarkDigestSent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user int64
+//   - sentAt time.Time
+func (_e *DigestRepo_Expecter) MarkDigestSent(ctx interface{}, user interface{}, sentAt interface{}) *DigestRepo_MarkDigestSent_Call {
+	return &DigestRepo_MarkDigestSent_Call{Call: _e.mock.On("MarkDigestSent", ctx, user, sentAt)}
+}
+
+func (_c *DigestRepo_MarkDigestSent_Call) Run(run func(ctx context.Context, user int64, sentAt time.Time)) *DigestRepo_MarkDigestSent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *DigestRepo_MarkDigestSent_Call) Return(_a0 error) *DigestRepo_MarkDigestSent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DigestRepo_MarkDigestSent_Call) RunAndReturn(run func(context.Context, int64, time.Time) error) *DigestRepo_MarkDigestSent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PendingUpdates provides a mock function with given fields: ctx, user
+func (_m *DigestRepo) PendingUpdates(ctx context.Context, user int64) ([]*scrapper.PendingUpdate, error) {
+	ret := _m.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PendingUpdates")
+	}
+
+	var r0 []*scrapper.PendingUpdate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]*scrapper.PendingUpdate, error)); ok {
+		return rf(ctx, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []*scrapper.PendingUpdate); ok {
+		r0 = rf(ctx, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*scrapper.PendingUpdate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DigestRepo_PendingUpdates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PendingUpdates'
+type DigestRepo_PendingUpdates_Call struct {
+	*mock.Call
+}
+
+// PendingUpdates is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user int64
+func (_e *DigestRepo_Expecter) PendingUpdates(ctx interface{}, user interface{}) *DigestRepo_PendingUpdates_Call {
+	return &DigestRepo_PendingUpdates_Call{Call: _e.mock.On("PendingUpdates", ctx, user)}
+}
+
+func (_c *DigestRepo_PendingUpdates_Call) Run(run func(ctx context.Context, user int64)) *DigestRepo_PendingUpdates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *DigestRepo_PendingUpdates_Call) Return(_a0 []*scrapper.PendingUpdate, _a1 error) *DigestRepo_PendingUpdates_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DigestRepo_PendingUpdates_Call) RunAndReturn(run func(context.Context, int64) ([]*scrapper.PendingUpdate, error)) *DigestRepo_PendingUpdates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SavePendingUpdate provides a mock function with given fields: ctx, linkID, update, users
+func (_m *DigestRepo) SavePendingUpdate(ctx context.Context, linkID int64, update *scrapper.LinkUpdate, users []int64) error {
+	ret := _m.Called(ctx, linkID, update, users)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SavePendingUpdate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *scrapper.LinkUpdate, []int64) error); ok {
+		r0 = rf(ctx, linkID, update, users)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DigestRepo_SavePendingUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SavePendingUpdate'
+type DigestRepo_SavePendingUpdate_Call struct {
+	*mock.Call
+}
+
+// SavePendingUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - linkID int64
+//   - update *scrapper.LinkUpdate
+//   - users []int64
+func (_e *DigestRepo_Expecter) SavePendingUpdate(ctx interface{}, linkID interface{}, update interface{}, users interface{}) *DigestRepo_SavePendingUpdate_Call {
+	return &DigestRepo_SavePendingUpdate_Call{Call: _e.mock.On("SavePendingUpdate", ctx, linkID, update, users)}
+}
+
+func (_c *DigestRepo_SavePendingUpdate_Call) Run(run func(ctx context.Context, linkID int64, update *scrapper.LinkUpdate, users []int64)) *DigestRepo_SavePendingUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(*scrapper.LinkUpdate), args[3].([]int64))
+	})
+	return _c
+}
+
+func (_c *DigestRepo_SavePendingUpdate_Call) Return(_a0 error) *DigestRepo_SavePendingUpdate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DigestRepo_SavePendingUpdate_Call) RunAndReturn(run func(context.Context, int64, *scrapper.LinkUpdate, []int64) error) *DigestRepo_SavePendingUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewDigestRepo creates a new instance of DigestRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDigestRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DigestRepo {
+	mock := &DigestRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
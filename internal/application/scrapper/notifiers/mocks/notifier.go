@@ -0,0 +1,135 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	scrapper "linkTraccer/internal/domain/scrapper"
+)
+
+// Notifier is an autogenerated mock type for the Notifier type
+type Notifier struct {
+	mock.Mock
+}
+
+type Notifier_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Notifier) EXPECT() *Notifier_Expecter {
+	return &Notifier_Expecter{mock: &_m.Mock}
+}
+
+// SendDigest provides a mock function with given fields: ctx, user, _a2
+func (_m *Notifier) SendDigest(ctx context.Context, user int64, _a2 []*scrapper.LinkDigest) error {
+	ret := _m.Called(ctx, user, _a2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendDigest")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []*scrapper.LinkDigest) error); ok {
+		r0 = rf(ctx, user, _a2)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Notifier_SendDigest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendDigest'
+type Notifier_SendDigest_Call struct {
+	*mock.Call
+}
+
+// SendDigest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user int64
+//   - _a2 []*scrapper.LinkDigest
+func (_e *Notifier_Expecter) SendDigest(ctx interface{}, user interface{}, _a2 interface{}) *Notifier_SendDigest_Call {
+	return &Notifier_SendDigest_Call{Call: _e.mock.On("SendDigest", ctx, user, _a2)}
+}
+
+func (_c *Notifier_SendDigest_Call) Run(run func(ctx context.Context, user int64, _a2 []*scrapper.LinkDigest)) *Notifier_SendDigest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].([]*scrapper.LinkDigest))
+	})
+	return _c
+}
+
+func (_c *Notifier_SendDigest_Call) Return(_a0 error) *Notifier_SendDigest_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Notifier_SendDigest_Call) RunAndReturn(run func(context.Context, int64, []*scrapper.LinkDigest) error) *Notifier_SendDigest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendUpdate provides a mock function with given fields: ctx, linkInfo, linkUpdate, users
+func (_m *Notifier) SendUpdate(ctx context.Context, linkInfo *scrapper.LinkInfo, linkUpdate *scrapper.LinkUpdate, users []int64) error {
+	ret := _m.Called(ctx, linkInfo, linkUpdate, users)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendUpdate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *scrapper.LinkInfo, *scrapper.LinkUpdate, []int64) error); ok {
+		r0 = rf(ctx, linkInfo, linkUpdate, users)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Notifier_SendUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendUpdate'
+type Notifier_SendUpdate_Call struct {
+	*mock.Call
+}
+
+// SendUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - linkInfo *scrapper.LinkInfo
+//   - linkUpdate *scrapper.LinkUpdate
+//   - users []int64
+func (_e *Notifier_Expecter) SendUpdate(ctx interface{}, linkInfo interface{}, linkUpdate interface{}, users interface{}) *Notifier_SendUpdate_Call {
+	return &Notifier_SendUpdate_Call{Call: _e.mock.On("SendUpdate", ctx, linkInfo, linkUpdate, users)}
+}
+
+func (_c *Notifier_SendUpdate_Call) Run(run func(ctx context.Context, linkInfo *scrapper.LinkInfo, linkUpdate *scrapper.LinkUpdate, users []int64)) *Notifier_SendUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*scrapper.LinkInfo), args[2].(*scrapper.LinkUpdate), args[3].([]int64))
+	})
+	return _c
+}
+
+func (_c *Notifier_SendUpdate_Call) Return(_a0 error) *Notifier_SendUpdate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Notifier_SendUpdate_Call) RunAndReturn(run func(context.Context, *scrapper.LinkInfo, *scrapper.LinkUpdate, []int64) error) *Notifier_SendUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewNotifier creates a new instance of Notifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewNotifier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Notifier {
+	mock := &Notifier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
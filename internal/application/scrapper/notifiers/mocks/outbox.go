@@ -0,0 +1,84 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	scrapper "linkTraccer/internal/domain/scrapper"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Outbox is an autogenerated mock type for the Outbox type
+type Outbox struct {
+	mock.Mock
+}
+
+type Outbox_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Outbox) EXPECT() *Outbox_Expecter {
+	return &Outbox_Expecter{mock: &_m.Mock}
+}
+
+// SaveOutboxUpdate provides a mock function with given fields: ctx, update
+func (_m *Outbox) SaveOutboxUpdate(ctx context.Context, update *scrapper.OutboxUpdate) error {
+	ret := _m.Called(ctx, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveOutboxUpdate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *scrapper.OutboxUpdate) error); ok {
+		r0 = rf(ctx, update)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Outbox_SaveOutboxUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveOutboxUpdate'
+type Outbox_SaveOutboxUpdate_Call struct {
+	*mock.Call
+}
+
+// SaveOutboxUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - update *scrapper.OutboxUpdate
+func (_e *Outbox_Expecter) SaveOutboxUpdate(ctx interface{}, update interface{}) *Outbox_SaveOutboxUpdate_Call {
+	return &Outbox_SaveOutboxUpdate_Call{Call: _e.mock.On("SaveOutboxUpdate", ctx, update)}
+}
+
+func (_c *Outbox_SaveOutboxUpdate_Call) Run(run func(ctx context.Context, update *scrapper.OutboxUpdate)) *Outbox_SaveOutboxUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*scrapper.OutboxUpdate))
+	})
+	return _c
+}
+
+func (_c *Outbox_SaveOutboxUpdate_Call) Return(_a0 error) *Outbox_SaveOutboxUpdate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Outbox_SaveOutboxUpdate_Call) RunAndReturn(run func(context.Context, *scrapper.OutboxUpdate) error) *Outbox_SaveOutboxUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewOutbox creates a new instance of Outbox. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOutbox(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Outbox {
+	mock := &Outbox{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
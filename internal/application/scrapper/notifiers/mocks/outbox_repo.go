@@ -0,0 +1,290 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	scrapper "linkTraccer/internal/domain/scrapper"
+
+	time "time"
+)
+
+// OutboxRepo is an autogenerated mock type for the Repo type
+type OutboxRepo struct {
+	mock.Mock
+}
+
+type OutboxRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *OutboxRepo) EXPECT() *OutboxRepo_Expecter {
+	return &OutboxRepo_Expecter{mock: &_m.Mock}
+}
+
+// ClaimOutboxUpdates provides a mock function with given fields: ctx, limit, lease
+func (_m *OutboxRepo) ClaimOutboxUpdates(ctx context.Context, limit uint, lease time.Duration) ([]*scrapper.OutboxUpdate, error) {
+	ret := _m.Called(ctx, limit, lease)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimOutboxUpdates")
+	}
+
+	var r0 []*scrapper.OutboxUpdate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, time.Duration) ([]*scrapper.OutboxUpdate, error)); ok {
+		return rf(ctx, limit, lease)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, time.Duration) []*scrapper.OutboxUpdate); ok {
+		r0 = rf(ctx, limit, lease)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*scrapper.OutboxUpdate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, time.Duration) error); ok {
+		r1 = rf(ctx, limit, lease)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OutboxRepo_ClaimOutboxUpdates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimOutboxUpdates'
+type OutboxRepo_ClaimOutboxUpdates_Call struct {
+	*mock.Call
+}
+
+// ClaimOutboxUpdates is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit uint
+//   - lease time.Duration
+func (_e *OutboxRepo_Expecter) ClaimOutboxUpdates(ctx interface{}, limit interface{}, lease interface{}) *OutboxRepo_ClaimOutboxUpdates_Call {
+	return &OutboxRepo_ClaimOutboxUpdates_Call{Call: _e.mock.On("ClaimOutboxUpdates", ctx, limit, lease)}
+}
+
+func (_c *OutboxRepo_ClaimOutboxUpdates_Call) Run(run func(ctx context.Context, limit uint, lease time.Duration)) *OutboxRepo_ClaimOutboxUpdates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *OutboxRepo_ClaimOutboxUpdates_Call) Return(_a0 []*scrapper.OutboxUpdate, _a1 error) *OutboxRepo_ClaimOutboxUpdates_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *OutboxRepo_ClaimOutboxUpdates_Call) RunAndReturn(run func(context.Context, uint, time.Duration) ([]*scrapper.OutboxUpdate, error)) *OutboxRepo_ClaimOutboxUpdates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteSentOutbox provides a mock function with given fields: ctx, retention
+func (_m *OutboxRepo) DeleteSentOutbox(ctx context.Context, retention time.Duration) error {
+	ret := _m.Called(ctx, retention)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSentOutbox")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) error); ok {
+		r0 = rf(ctx, retention)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OutboxRepo_DeleteSentOutbox_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSentOutbox'
+type OutboxRepo_DeleteSentOutbox_Call struct {
+	*mock.Call
+}
+
+// DeleteSentOutbox is a helper method to define mock.On call
+//   - ctx context.Context
+//   - retention time.Duration
+func (_e *OutboxRepo_Expecter) DeleteSentOutbox(ctx interface{}, retention interface{}) *OutboxRepo_DeleteSentOutbox_Call {
+	return &OutboxRepo_DeleteSentOutbox_Call{Call: _e.mock.On("DeleteSentOutbox", ctx, retention)}
+}
+
+func (_c *OutboxRepo_DeleteSentOutbox_Call) Run(run func(ctx context.Context, retention time.Duration)) *OutboxRepo_DeleteSentOutbox_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *OutboxRepo_DeleteSentOutbox_Call) Return(_a0 error) *OutboxRepo_DeleteSentOutbox_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *OutboxRepo_DeleteSentOutbox_Call) RunAndReturn(run func(context.Context, time.Duration) error) *OutboxRepo_DeleteSentOutbox_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkOutboxFailed provides a mock function with given fields: ctx, id, lastErr, park
+func (_m *OutboxRepo) MarkOutboxFailed(ctx context.Context, id int64, lastErr string, park bool) error {
+	ret := _m.Called(ctx, id, lastErr, park)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkOutboxFailed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, bool) error); ok {
+		r0 = rf(ctx, id, lastErr, park)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OutboxRepo_MarkOutboxFailed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkOutboxFailed'
+type OutboxRepo_MarkOutboxFailed_Call struct {
+	*mock.Call
+}
+
+// MarkOutboxFailed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - lastErr string
+//   - park bool
+func (_e *OutboxRepo_Expecter) MarkOutboxFailed(ctx interface{}, id interface{}, lastErr interface{}, park interface{}) *OutboxRepo_MarkOutboxFailed_Call {
+	return &OutboxRepo_MarkOutboxFailed_Call{Call: _e.mock.On("MarkOutboxFailed", ctx, id, lastErr, park)}
+}
+
+func (_c *OutboxRepo_MarkOutboxFailed_Call) Run(run func(ctx context.Context, id int64, lastErr string, park bool)) *OutboxRepo_MarkOutboxFailed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string), args[3].(bool))
+	})
+	return _c
+}
+
+func (_c *OutboxRepo_MarkOutboxFailed_Call) Return(_a0 error) *OutboxRepo_MarkOutboxFailed_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *OutboxRepo_MarkOutboxFailed_Call) RunAndReturn(run func(context.Context, int64, string, bool) error) *OutboxRepo_MarkOutboxFailed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkOutboxSent provides a mock function with given fields: ctx, ids
+func (_m *OutboxRepo) MarkOutboxSent(ctx context.Context, ids []int64) error {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkOutboxSent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) error); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OutboxRepo_MarkOutboxSent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkOutboxSent'
+type OutboxRepo_MarkOutboxSent_Call struct {
+	*mock.Call
+}
+
+// MarkOutboxSent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []int64
+func (_e *OutboxRepo_Expecter) MarkOutboxSent(ctx interface{}, ids interface{}) *OutboxRepo_MarkOutboxSent_Call {
+	return &OutboxRepo_MarkOutboxSent_Call{Call: _e.mock.On("MarkOutboxSent", ctx, ids)}
+}
+
+func (_c *OutboxRepo_MarkOutboxSent_Call) Run(run func(ctx context.Context, ids []int64)) *OutboxRepo_MarkOutboxSent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int64))
+	})
+	return _c
+}
+
+func (_c *OutboxRepo_MarkOutboxSent_Call) Return(_a0 error) *OutboxRepo_MarkOutboxSent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *OutboxRepo_MarkOutboxSent_Call) RunAndReturn(run func(context.Context, []int64) error) *OutboxRepo_MarkOutboxSent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleaseOutbox provides a mock function with given fields: ctx, ids
+func (_m *OutboxRepo) ReleaseOutbox(ctx context.Context, ids []int64) error {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseOutbox")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) error); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// OutboxRepo_ReleaseOutbox_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseOutbox'
+type OutboxRepo_ReleaseOutbox_Call struct {
+	*mock.Call
+}
+
+// ReleaseOutbox is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []int64
+func (_e *OutboxRepo_Expecter) ReleaseOutbox(ctx interface{}, ids interface{}) *OutboxRepo_ReleaseOutbox_Call {
+	return &OutboxRepo_ReleaseOutbox_Call{Call: _e.mock.On("ReleaseOutbox", ctx, ids)}
+}
+
+func (_c *OutboxRepo_ReleaseOutbox_Call) Run(run func(ctx context.Context, ids []int64)) *OutboxRepo_ReleaseOutbox_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int64))
+	})
+	return _c
+}
+
+func (_c *OutboxRepo_ReleaseOutbox_Call) Return(_a0 error) *OutboxRepo_ReleaseOutbox_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *OutboxRepo_ReleaseOutbox_Call) RunAndReturn(run func(context.Context, []int64) error) *OutboxRepo_ReleaseOutbox_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewOutboxRepo creates a new instance of OutboxRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOutboxRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OutboxRepo {
+	mock := &OutboxRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
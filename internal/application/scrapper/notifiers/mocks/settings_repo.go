@@ -0,0 +1,428 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	scrapper "linkTraccer/internal/domain/scrapper"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SettingsRepo is an autogenerated mock type for the SettingsRepo type
+type SettingsRepo struct {
+	mock.Mock
+}
+
+type SettingsRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *SettingsRepo) EXPECT() *SettingsRepo_Expecter {
+	return &SettingsRepo_Expecter{mock: &_m.Mock}
+}
+
+// DeleteHeldUpdates provides a mock function with given fields: ctx, ids
+func (_m *SettingsRepo) DeleteHeldUpdates(ctx context.Context, ids []int64) error {
+	ret := _m.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteHeldUpdates")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) error); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SettingsRepo_DeleteHeldUpdates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteHeldUpdates'
+type SettingsRepo_DeleteHeldUpdates_Call struct {
+	*mock.Call
+}
+
+// DeleteHeldUpdates is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []int64
+func (_e *SettingsRepo_Expecter) DeleteHeldUpdates(ctx interface{}, ids interface{}) *SettingsRepo_DeleteHeldUpdates_Call {
+	return &SettingsRepo_DeleteHeldUpdates_Call{Call: _e.mock.On("DeleteHeldUpdates", ctx, ids)}
+}
+
+func (_c *SettingsRepo_DeleteHeldUpdates_Call) Run(run func(ctx context.Context, ids []int64)) *SettingsRepo_DeleteHeldUpdates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int64))
+	})
+	return _c
+}
+
+func (_c *SettingsRepo_DeleteHeldUpdates_Call) Return(_a0 error) *SettingsRepo_DeleteHeldUpdates_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SettingsRepo_DeleteHeldUpdates_Call) RunAndReturn(run func(context.Context, []int64) error) *SettingsRepo_DeleteHeldUpdates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HeldUpdates provides a mock function with given fields: ctx, user
+func (_m *SettingsRepo) HeldUpdates(ctx context.Context, user int64) ([]*scrapper.HeldUpdate, error) {
+	ret := _m.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HeldUpdates")
+	}
+
+	var r0 []*scrapper.HeldUpdate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]*scrapper.HeldUpdate, error)); ok {
+		return rf(ctx, user)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []*scrapper.HeldUpdate); ok {
+		r0 = rf(ctx, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*scrapper.HeldUpdate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SettingsRepo_HeldUpdates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HeldUpdates'
+type SettingsRepo_HeldUpdates_Call struct {
+	*mock.Call
+}
+
+// HeldUpdates is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user int64
+func (_e *SettingsRepo_Expecter) HeldUpdates(ctx interface{}, user interface{}) *SettingsRepo_HeldUpdates_Call {
+	return &SettingsRepo_HeldUpdates_Call{Call: _e.mock.On("HeldUpdates", ctx, user)}
+}
+
+func (_c *SettingsRepo_HeldUpdates_Call) Run(run func(ctx context.Context, user int64)) *SettingsRepo_HeldUpdates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *SettingsRepo_HeldUpdates_Call) Return(_a0 []*scrapper.HeldUpdate, _a1 error) *SettingsRepo_HeldUpdates_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SettingsRepo_HeldUpdates_Call) RunAndReturn(run func(context.Context, int64) ([]*scrapper.HeldUpdate, error)) *SettingsRepo_HeldUpdates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HeldUsers provides a mock function with given fields: ctx
+func (_m *SettingsRepo) HeldUsers(ctx context.Context) ([]int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HeldUsers")
+	}
+
+	var r0 []int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []int64); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int64)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SettingsRepo_HeldUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HeldUsers'
+type SettingsRepo_HeldUsers_Call struct {
+	*mock.Call
+}
+
+// HeldUsers is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *SettingsRepo_Expecter) HeldUsers(ctx interface{}) *SettingsRepo_HeldUsers_Call {
+	return &SettingsRepo_HeldUsers_Call{Call: _e.mock.On("HeldUsers", ctx)}
+}
+
+func (_c *SettingsRepo_HeldUsers_Call) Run(run func(ctx context.Context)) *SettingsRepo_HeldUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *SettingsRepo_HeldUsers_Call) Return(_a0 []int64, _a1 error) *SettingsRepo_HeldUsers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SettingsRepo_HeldUsers_Call) RunAndReturn(run func(context.Context) ([]int64, error)) *SettingsRepo_HeldUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HoldUpdate provides a mock function with given fields: ctx, linkID, eventID, content, users
+func (_m *SettingsRepo) HoldUpdate(ctx context.Context, linkID int64, eventID string, content *scrapper.Notification, users []int64) error {
+	ret := _m.Called(ctx, linkID, eventID, content, users)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HoldUpdate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, *scrapper.Notification, []int64) error); ok {
+		r0 = rf(ctx, linkID, eventID, content, users)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SettingsRepo_HoldUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HoldUpdate'
+type SettingsRepo_HoldUpdate_Call struct {
+	*mock.Call
+}
+
+// HoldUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - linkID int64
+//   - eventID string
+//   - content *scrapper.Notification
+//   - users []int64
+func (_e *SettingsRepo_Expecter) HoldUpdate(ctx interface{}, linkID interface{}, eventID interface{}, content interface{}, users interface{}) *SettingsRepo_HoldUpdate_Call {
+	return &SettingsRepo_HoldUpdate_Call{Call: _e.mock.On("HoldUpdate", ctx, linkID, eventID, content, users)}
+}
+
+func (_c *SettingsRepo_HoldUpdate_Call) Run(run func(ctx context.Context, linkID int64, eventID string, content *scrapper.Notification, users []int64)) *SettingsRepo_HoldUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string), args[3].(*scrapper.Notification), args[4].([]int64))
+	})
+	return _c
+}
+
+func (_c *SettingsRepo_HoldUpdate_Call) Return(_a0 error) *SettingsRepo_HoldUpdate_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SettingsRepo_HoldUpdate_Call) RunAndReturn(run func(context.Context, int64, string, *scrapper.Notification, []int64) error) *SettingsRepo_HoldUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// QuietHours provides a mock function with given fields: ctx, users
+func (_m *SettingsRepo) QuietHours(ctx context.Context, users []int64) (map[int64]*scrapper.QuietHours, error) {
+	ret := _m.Called(ctx, users)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QuietHours")
+	}
+
+	var r0 map[int64]*scrapper.QuietHours
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) (map[int64]*scrapper.QuietHours, error)); ok {
+		return rf(ctx, users)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) map[int64]*scrapper.QuietHours); ok {
+		r0 = rf(ctx, users)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int64]*scrapper.QuietHours)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int64) error); ok {
+		r1 = rf(ctx, users)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SettingsRepo_QuietHours_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QuietHours'
+type SettingsRepo_QuietHours_Call struct {
+	*mock.Call
+}
+
+// QuietHours is a helper method to define mock.On call
+//   - ctx context.Context
+//   - users []int64
+func (_e *SettingsRepo_Expecter) QuietHours(ctx interface{}, users interface{}) *SettingsRepo_QuietHours_Call {
+	return &SettingsRepo_QuietHours_Call{Call: _e.mock.On("QuietHours", ctx, users)}
+}
+
+func (_c *SettingsRepo_QuietHours_Call) Run(run func(ctx context.Context, users []int64)) *SettingsRepo_QuietHours_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int64))
+	})
+	return _c
+}
+
+func (_c *SettingsRepo_QuietHours_Call) Return(_a0 map[int64]*scrapper.QuietHours, _a1 error) *SettingsRepo_QuietHours_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SettingsRepo_QuietHours_Call) RunAndReturn(run func(context.Context, []int64) (map[int64]*scrapper.QuietHours, error)) *SettingsRepo_QuietHours_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UsersLanguages provides a mock function with given fields: ctx, users
+func (_m *SettingsRepo) UsersLanguages(ctx context.Context, users []int64) (map[int64]string, error) {
+	ret := _m.Called(ctx, users)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UsersLanguages")
+	}
+
+	var r0 map[int64]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) (map[int64]string, error)); ok {
+		return rf(ctx, users)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) map[int64]string); ok {
+		r0 = rf(ctx, users)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int64]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int64) error); ok {
+		r1 = rf(ctx, users)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SettingsRepo_UsersLanguages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UsersLanguages'
+type SettingsRepo_UsersLanguages_Call struct {
+	*mock.Call
+}
+
+// UsersLanguages is a helper method to define mock.On call
+//   - ctx context.Context
+//   - users []int64
+func (_e *SettingsRepo_Expecter) UsersLanguages(ctx interface{}, users interface{}) *SettingsRepo_UsersLanguages_Call {
+	return &SettingsRepo_UsersLanguages_Call{Call: _e.mock.On("UsersLanguages", ctx, users)}
+}
+
+func (_c *SettingsRepo_UsersLanguages_Call) Run(run func(ctx context.Context, users []int64)) *SettingsRepo_UsersLanguages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int64))
+	})
+	return _c
+}
+
+func (_c *SettingsRepo_UsersLanguages_Call) Return(_a0 map[int64]string, _a1 error) *SettingsRepo_UsersLanguages_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SettingsRepo_UsersLanguages_Call) RunAndReturn(run func(context.Context, []int64) (map[int64]string, error)) *SettingsRepo_UsersLanguages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UsersTimezones provides a mock function with given fields: ctx, users
+func (_m *SettingsRepo) UsersTimezones(ctx context.Context, users []int64) (map[int64]string, error) {
+	ret := _m.Called(ctx, users)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UsersTimezones")
+	}
+
+	var r0 map[int64]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) (map[int64]string, error)); ok {
+		return rf(ctx, users)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []int64) map[int64]string); ok {
+		r0 = rf(ctx, users)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int64]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []int64) error); ok {
+		r1 = rf(ctx, users)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SettingsRepo_UsersTimezones_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UsersTimezones'
+type SettingsRepo_UsersTimezones_Call struct {
+	*mock.Call
+}
+
+// UsersTimezones is a helper method to define mock.On call
+//   - ctx context.Context
+//   - users []int64
+func (_e *SettingsRepo_Expecter) UsersTimezones(ctx interface{}, users interface{}) *SettingsRepo_UsersTimezones_Call {
+	return &SettingsRepo_UsersTimezones_Call{Call: _e.mock.On("UsersTimezones", ctx, users)}
+}
+
+func (_c *SettingsRepo_UsersTimezones_Call) Run(run func(ctx context.Context, users []int64)) *SettingsRepo_UsersTimezones_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]int64))
+	})
+	return _c
+}
+
+func (_c *SettingsRepo_UsersTimezones_Call) Return(_a0 map[int64]string, _a1 error) *SettingsRepo_UsersTimezones_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SettingsRepo_UsersTimezones_Call) RunAndReturn(run func(context.Context, []int64) (map[int64]string, error)) *SettingsRepo_UsersTimezones_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewSettingsRepo creates a new instance of SettingsRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSettingsRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SettingsRepo {
+	mock := &SettingsRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,192 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"linkTraccer/internal/domain/dto"
+	"linkTraccer/internal/domain/scrapper"
+	"log/slog"
+	"time"
+)
+
+type BotClient interface {
+	SendLinkUpdates(ctx context.Context, update *dto.LinkUpdate) error
+}
+
+type Repo interface {
+	ClaimOutboxUpdates(ctx context.Context, limit uint, lease time.Duration) ([]*scrapper.OutboxUpdate, error)
+	MarkOutboxSent(ctx context.Context, ids []int64) error
+	MarkOutboxFailed(ctx context.Context, id int64, lastErr string, park bool) error
+	ReleaseOutbox(ctx context.Context, ids []int64) error
+	DeleteSentOutbox(ctx context.Context, retention time.Duration) error
+}
+
+// answerError - бот доступен, но не принял уведомление.
+type answerError interface {
+	error
+	Permanent() bool
+}
+
+type Config struct {
+	BatchSize   uint
+	Retention   time.Duration
+	Lease       time.Duration
+	MaxAttempts int
+}
+
+// Relay помечает уведомление отправленным только после отправки, поэтому доставка хотя бы однократная.
+type Relay struct {
+	repo        Repo
+	botClient   BotClient
+	batchSize   uint
+	retention   time.Duration
+	lease       time.Duration
+	maxAttempts int
+	log         *slog.Logger
+}
+
+func New(repo Repo, botClient BotClient, cfg *Config, log *slog.Logger) *Relay {
+	return &Relay{
+		repo:        repo,
+		botClient:   botClient,
+		batchSize:   max(1, cfg.BatchSize),
+		retention:   cfg.Retention,
+		lease:       cfg.Lease,
+		maxAttempts: max(1, cfg.MaxAttempts),
+		log:         log,
+	}
+}
+
+// Publish отправляет пачки, пока они не кончатся или транспорт не станет недоступен.
+func (r *Relay) Publish(ctx context.Context) {
+	for {
+		fetched, err := r.publishBatch(ctx)
+		if err != nil {
+			r.log.Error("ошибка при отправке уведомлений из outbox", "err", err.Error())
+
+			break
+		}
+
+		if fetched < r.batchSize {
+			break
+		}
+	}
+
+	if err := r.repo.DeleteSentOutbox(ctx, r.retention); err != nil {
+		r.log.Error("ошибка при очистке outbox", "err", err.Error())
+	}
+}
+
+// publishBatch при ошибке транспорта снимает захват с оставшихся уведомлений пачки.
+func (r *Relay) publishBatch(ctx context.Context) (uint, error) {
+	updates, err := r.repo.ClaimOutboxUpdates(ctx, r.batchSize, r.lease)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := make([]int64, 0, len(updates))
+
+	for i, update := range updates {
+		sendErr := r.botClient.SendLinkUpdates(ctx, toLinkUpdate(update))
+		if sendErr == nil {
+			sent = append(sent, update.ID)
+
+			continue
+		}
+
+		var answerErr answerError
+
+		if !errors.As(sendErr, &answerErr) {
+			return uint(len(updates)), errors.Join(sendErr, r.markSent(ctx, sent), r.release(ctx, updates[i:]))
+		}
+
+		if err = r.markFailed(ctx, update, answerErr); err != nil {
+			return uint(len(updates)), errors.Join(err, r.markSent(ctx, sent), r.release(ctx, updates[i+1:]))
+		}
+	}
+
+	return uint(len(updates)), r.markSent(ctx, sent)
+}
+
+func (r *Relay) markFailed(ctx context.Context, update *scrapper.OutboxUpdate, sendErr answerError) error {
+	park := sendErr.Permanent() || update.Attempts+1 >= r.maxAttempts
+
+	if park {
+		r.log.Warn("уведомление из outbox отложено и больше не отправляется", "id", update.ID,
+			"attempts", update.Attempts+1, "err", sendErr.Error())
+	}
+
+	return r.repo.MarkOutboxFailed(ctx, update.ID, sendErr.Error(), park)
+}
+
+func (r *Relay) markSent(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	if err := r.repo.MarkOutboxSent(ctx, ids); err != nil {
+		return fmt.Errorf("ошибка при отметке отправленных уведомлений: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Relay) release(ctx context.Context, updates []*scrapper.OutboxUpdate) error {
+	if len(updates) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(updates))
+
+	for _, update := range updates {
+		ids = append(ids, update.ID)
+	}
+
+	return r.repo.ReleaseOutbox(ctx, ids)
+}
+
+// toLinkUpdate переводит уведомление из outbox в формат API бота.
+func toLinkUpdate(update *scrapper.OutboxUpdate) *dto.LinkUpdate {
+	linkUpdate := &dto.LinkUpdate{
+		ID:          update.LinkID,
+		URL:         update.URL,
+		EventID:     update.EventID,
+		Description: update.Description,
+		TgChatIDs:   update.TgChatIDs,
+	}
+
+	if update.Content == nil {
+		return linkUpdate
+	}
+
+	linkUpdate.Language, linkUpdate.Timezone = update.Content.Language, update.Content.Timezone
+
+	if update.Content.Update != nil {
+		linkUpdate.Update = toUpdateItem(update.Content.Update)
+	}
+
+	for _, linkDigest := range update.Content.Digest {
+		digest := &dto.LinkDigest{URL: linkDigest.URL, Updates: make([]*dto.UpdateItem, 0, len(linkDigest.Updates))}
+
+		for _, item := range linkDigest.Updates {
+			digest.Updates = append(digest.Updates, toUpdateItem(item))
+		}
+
+		linkUpdate.Digest = append(linkUpdate.Digest, digest)
+	}
+
+	return linkUpdate
+}
+
+func toUpdateItem(update *scrapper.LinkUpdate) *dto.UpdateItem {
+	return &dto.UpdateItem{
+		Site:      update.Site,
+		Type:      update.Type,
+		URL:       update.ItemURL,
+		Author:    update.Author,
+		CreatedAt: update.ItemTime,
+		Title:     update.Title,
+		Excerpt:   update.Excerpt,
+	}
+}
@@ -0,0 +1,190 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"linkTraccer/internal/application/scrapper/notifiers/mocks"
+	"linkTraccer/internal/application/scrapper/notifiers/outbox"
+	"linkTraccer/internal/domain/dto"
+	"linkTraccer/internal/domain/scrapper"
+	"linkTraccer/internal/infrastructure/botclient"
+	"linkTraccer/internal/infrastructure/bothandler"
+	botmocks "linkTraccer/internal/infrastructure/bothandler/mocks"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	retention = time.Hour
+	lease     = time.Minute
+)
+
+var (
+	errRepo      = errors.New("ошибка в репозитории")
+	errTransport = errors.New("бот недоступен")
+	errRejected  = &answerErr{permanent: true}
+	errServer    = &answerErr{permanent: false}
+	log          = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	first  = &scrapper.OutboxUpdate{ID: 1, LinkID: 10, URL: "github.com", Description: "first", TgChatIDs: []scrapper.User{1}}
+	second = &scrapper.OutboxUpdate{ID: 2, Description: "digest", TgChatIDs: []scrapper.User{2}}
+	third  = &scrapper.OutboxUpdate{ID: 3, LinkID: 10, URL: "github.com", Description: "third", TgChatIDs: []scrapper.User{1},
+		Attempts: 2}
+)
+
+type answerErr struct {
+	permanent bool
+}
+
+func (err *answerErr) Error() string {
+	return "бот не принял уведомление"
+}
+
+func (err *answerErr) Permanent() bool {
+	return err.permanent
+}
+
+func toDTO(update *scrapper.OutboxUpdate) *dto.LinkUpdate {
+	return &dto.LinkUpdate{
+		ID:          update.LinkID,
+		URL:         update.URL,
+		Description: update.Description,
+		TgChatIDs:   update.TgChatIDs,
+	}
+}
+
+func TestRelay_Publish(t *testing.T) {
+	type TestCase struct {
+		name    string
+		prepare func(repo *mocks.OutboxRepo, botClient *mocks.BotClient)
+	}
+
+	tests := []TestCase{
+		{
+			name: "уведомления отправляются пачками, пока не закончатся",
+			prepare: func(repo *mocks.OutboxRepo, botClient *mocks.BotClient) {
+				repo.On("ClaimOutboxUpdates", mock.Anything, uint(2), lease).
+					Return([]*scrapper.OutboxUpdate{first, second}, nil).Once()
+				repo.On("ClaimOutboxUpdates", mock.Anything, uint(2), lease).
+					Return([]*scrapper.OutboxUpdate{third}, nil).Once()
+				botClient.On("SendLinkUpdates", mock.Anything, toDTO(first)).Return(nil).Once()
+				botClient.On("SendLinkUpdates", mock.Anything, toDTO(second)).Return(nil).Once()
+				botClient.On("SendLinkUpdates", mock.Anything, toDTO(third)).Return(nil).Once()
+				repo.On("MarkOutboxSent", mock.Anything, []int64{1, 2}).Return(nil).Once()
+				repo.On("MarkOutboxSent", mock.Anything, []int64{3}).Return(nil).Once()
+			},
+		},
+		{
+			name: "транспорт недоступен, отправленные помечаются, с остальных снимается захват",
+			prepare: func(repo *mocks.OutboxRepo, botClient *mocks.BotClient) {
+				repo.On("ClaimOutboxUpdates", mock.Anything, uint(2), lease).
+					Return([]*scrapper.OutboxUpdate{first, second}, nil).Once()
+				botClient.On("SendLinkUpdates", mock.Anything, toDTO(first)).Return(nil).Once()
+				botClient.On("SendLinkUpdates", mock.Anything, toDTO(second)).Return(errTransport).Once()
+				repo.On("MarkOutboxSent", mock.Anything, []int64{1}).Return(nil).Once()
+				repo.On("ReleaseOutbox", mock.Anything, []int64{2}).Return(nil).Once()
+			},
+		},
+		{
+			name: "отклоненное ботом уведомление откладывается, пачка отправляется дальше",
+			prepare: func(repo *mocks.OutboxRepo, botClient *mocks.BotClient) {
+				repo.On("ClaimOutboxUpdates", mock.Anything, uint(2), lease).
+					Return([]*scrapper.OutboxUpdate{first, second}, nil).Once()
+				repo.On("ClaimOutboxUpdates", mock.Anything, uint(2), lease).Return(nil, nil).Once()
+				botClient.On("SendLinkUpdates", mock.Anything, toDTO(first)).Return(errRejected).Once()
+				botClient.On("SendLinkUpdates", mock.Anything, toDTO(second)).Return(nil).Once()
+				repo.On("MarkOutboxFailed", mock.Anything, int64(1), errRejected.Error(), true).Return(nil).Once()
+				repo.On("MarkOutboxSent", mock.Anything, []int64{2}).Return(nil).Once()
+			},
+		},
+		{
+			name: "ошибка бота засчитывается как попытка, после последней попытки уведомление откладывается",
+			prepare: func(repo *mocks.OutboxRepo, botClient *mocks.BotClient) {
+				repo.On("ClaimOutboxUpdates", mock.Anything, uint(2), lease).
+					Return([]*scrapper.OutboxUpdate{first, third}, nil).Once()
+				repo.On("ClaimOutboxUpdates", mock.Anything, uint(2), lease).Return(nil, nil).Once()
+				botClient.On("SendLinkUpdates", mock.Anything, toDTO(first)).Return(errServer).Once()
+				botClient.On("SendLinkUpdates", mock.Anything, toDTO(third)).Return(errServer).Once()
+				repo.On("MarkOutboxFailed", mock.Anything, int64(1), errServer.Error(), false).Return(nil).Once()
+				repo.On("MarkOutboxFailed", mock.Anything, int64(3), errServer.Error(), true).Return(nil).Once()
+			},
+		},
+		{
+			name: "ошибка при получении уведомлений",
+			prepare: func(repo *mocks.OutboxRepo, _ *mocks.BotClient) {
+				repo.On("ClaimOutboxUpdates", mock.Anything, uint(2), lease).Return(nil, errRepo).Once()
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			repo := mocks.NewOutboxRepo(t)
+			botClient := mocks.NewBotClient(t)
+
+			test.prepare(repo, botClient)
+			repo.On("DeleteSentOutbox", mock.Anything, retention).Return(nil).Once()
+
+			relay := outbox.New(repo, botClient, &outbox.Config{BatchSize: 2, Retention: retention, Lease: lease,
+				MaxAttempts: 3}, log)
+			relay.Publish(context.Background())
+		})
+	}
+}
+
+func TestRelay_PublishContent(t *testing.T) {
+	repo := mocks.NewOutboxRepo(t)
+	botClient := mocks.NewBotClient(t)
+	createdAt := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+	answer := &scrapper.LinkUpdate{Site: scrapper.StackOverflowSite, Type: scrapper.AnswerUpdate, ItemID: 7,
+		ItemURL: "https://stackoverflow.com/a/7", Author: "orlov4919", ItemTime: createdAt, Title: "Вопрос", Excerpt: "Ответ"}
+	item := &dto.UpdateItem{Site: scrapper.StackOverflowSite, Type: scrapper.AnswerUpdate, URL: "https://stackoverflow.com/a/7",
+		Author: "orlov4919", CreatedAt: createdAt, Title: "Вопрос", Excerpt: "Ответ"}
+
+	update := &scrapper.OutboxUpdate{ID: 1, LinkID: 10, URL: "stackoverflow.com", EventID: "event", TgChatIDs: []scrapper.User{1},
+		Content: &scrapper.Notification{Update: answer, Language: "en", Timezone: "UTC"}}
+	digest := &scrapper.OutboxUpdate{ID: 2, TgChatIDs: []scrapper.User{2}, Content: &scrapper.Notification{
+		Digest:   []*scrapper.LinkDigest{{URL: "stackoverflow.com", Updates: scrapper.LinkUpdates{answer}}},
+		Language: "ru", Timezone: scrapper.DefaultTimezone}}
+
+	repo.On("ClaimOutboxUpdates", mock.Anything, uint(10), lease).Return([]*scrapper.OutboxUpdate{update, digest}, nil).Once()
+	botClient.On("SendLinkUpdates", mock.Anything, &dto.LinkUpdate{ID: 10, URL: "stackoverflow.com", EventID: "event", Update: item,
+		Language: "en", Timezone: "UTC", TgChatIDs: []int64{1}}).Return(nil).Once()
+	botClient.On("SendLinkUpdates", mock.Anything, &dto.LinkUpdate{
+		Digest:   []*dto.LinkDigest{{URL: "stackoverflow.com", Updates: []*dto.UpdateItem{item}}},
+		Language: "ru", Timezone: scrapper.DefaultTimezone, TgChatIDs: []int64{2}}).Return(nil).Once()
+	repo.On("MarkOutboxSent", mock.Anything, []int64{1, 2}).Return(nil).Once()
+	repo.On("DeleteSentOutbox", mock.Anything, retention).Return(nil).Once()
+
+	relay := outbox.New(repo, botClient, &outbox.Config{BatchSize: 10, Retention: retention, Lease: lease}, log)
+	relay.Publish(context.Background())
+}
+
+func TestRelay_PublishBotSendFailure(t *testing.T) {
+	repo := mocks.NewOutboxRepo(t)
+	tgClient := botmocks.NewTgClient(t)
+
+	tgClient.On("SendMessage", mock.Anything, int64(1), mock.Anything).Return(errors.New("telegram недоступен")).Once()
+
+	bot := httptest.NewServer(http.HandlerFunc(
+		bothandler.New(tgClient, botmocks.NewChatRemover(t), botmocks.NewDeliveryLog(t), log).HandleLinkUpdates))
+	defer bot.Close()
+
+	// бот не смог отправить сообщение в telegram, поэтому уведомление не помечается отправленным и уйдет еще раз
+	repo.On("ClaimOutboxUpdates", mock.Anything, uint(2), lease).Return([]*scrapper.OutboxUpdate{first}, nil).Once()
+	repo.On("MarkOutboxFailed", mock.Anything, int64(1), mock.Anything, false).Return(nil).Once()
+	repo.On("DeleteSentOutbox", mock.Anything, retention).Return(nil).Once()
+
+	botClient := botclient.New(strings.TrimPrefix(bot.URL, "http://"), bot.Client())
+	relay := outbox.New(repo, botClient, &outbox.Config{BatchSize: 2, Retention: retention, Lease: lease, MaxAttempts: 3}, log)
+	relay.Publish(context.Background())
+
+	repo.AssertNotCalled(t, "MarkOutboxSent", mock.Anything, mock.Anything)
+}
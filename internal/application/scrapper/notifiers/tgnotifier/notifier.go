@@ -1,55 +1,226 @@
 package tgnotifier
 
 import (
+	"context"
 	"fmt"
 	"linkTraccer/internal/application/scrapper/scrapservice"
-	"linkTraccer/internal/domain/dto"
+	"linkTraccer/internal/domain/i18n"
 	"linkTraccer/internal/domain/scrapper"
+	"log/slog"
+	"slices"
+	"time"
 )
 
-const (
-	descriptionFormat = "Пришло новое уведомление 🔥\n\nСобытие: %s\nПользователь: %s\nВремя создания: %s\nПревью: %s\n\n"
-)
+// Outbox сохраняет уведомления, которые затем отправляет боту релей.
+type Outbox interface {
+	SaveOutboxUpdate(ctx context.Context, update *scrapper.OutboxUpdate) error
+}
 
-type BotClient interface {
-	SendLinkUpdates(update *dto.LinkUpdate) error
+type SettingsRepo interface {
+	UsersLanguages(ctx context.Context, users []scrapper.User) (map[scrapper.User]i18n.Lang, error)
+	UsersTimezones(ctx context.Context, users []scrapper.User) (map[scrapper.User]string, error)
+	QuietHours(ctx context.Context, users []scrapper.User) (map[scrapper.User]*scrapper.QuietHours, error)
+	HoldUpdate(ctx context.Context, linkID scrapper.LinkID, eventID string, content *scrapper.Notification,
+		users []scrapper.User) error
+	HeldUsers(ctx context.Context) ([]scrapper.User, error)
+	HeldUpdates(ctx context.Context, user scrapper.User) ([]*scrapper.HeldUpdate, error)
+	DeleteHeldUpdates(ctx context.Context, ids []int64) error
 }
 
+// TgNotifier сохраняет уведомления в outbox в транзакции из ctx, сообщение формирует бот.
 type TgNotifier struct {
-	botClient BotClient
-	userRepo  scrapservice.UserRepo
+	outbox     Outbox
+	repo       SettingsRepo
+	transactor scrapservice.Transactor
+	log        *slog.Logger
 }
 
-func New(userRepo scrapservice.UserRepo, botClient BotClient) *TgNotifier {
+func New(outbox Outbox, repo SettingsRepo, transactor scrapservice.Transactor, log *slog.Logger) *TgNotifier {
 	return &TgNotifier{
-		botClient: botClient,
-		userRepo:  userRepo,
+		outbox:     outbox,
+		repo:       repo,
+		transactor: transactor,
+		log:        log,
 	}
 }
 
-func (t *TgNotifier) SendUpdates(linkInfo *scrapper.LinkInfo, linkUpdates scrapper.LinkUpdates) error {
-	users, err := t.userRepo.UsersWhoTrackLink(linkInfo.ID)
+func (t *TgNotifier) SendUpdate(ctx context.Context, linkInfo *scrapper.LinkInfo, linkUpdate *scrapper.LinkUpdate,
+	users []scrapper.User) error {
+	err := t.send(ctx, &scrapper.OutboxUpdate{
+		LinkID:    linkInfo.ID,
+		URL:       linkInfo.URL,
+		EventID:   scrapper.EventID(linkInfo.URL, linkUpdate),
+		TgChatIDs: users}, scrapper.Notification{Update: linkUpdate})
+
+	if err != nil {
+		return fmt.Errorf("не удалось отправить обновление ссылки  : %w", err)
+	}
+
+	return nil
+}
+
+// SendDigest отправляет пользователю одно сообщение со всеми накопленными обновлениями, сгруппированными по ссылкам.
+func (t *TgNotifier) SendDigest(ctx context.Context, user scrapper.User, digest []*scrapper.LinkDigest) error {
+	update := &scrapper.OutboxUpdate{EventID: scrapper.DigestEventID(user, digest), TgChatIDs: []scrapper.User{user}}
+
+	err := t.send(ctx, update, scrapper.Notification{Digest: digest})
+
+	if err != nil {
+		return fmt.Errorf("не удалось отправить дайджест пользователю %d: %w", user, err)
+	}
+
+	return nil
+}
+
+// audience - получатели уведомления с одинаковым языком и часовым поясом, им уходит одно и то же уведомление.
+type audience struct {
+	lang     i18n.Lang
+	timezone string
+}
 
+// send сохраняет уведомление для каждой пары язык - часовой пояс и задерживает его на тихие часы.
+func (t *TgNotifier) send(ctx context.Context, update *scrapper.OutboxUpdate, content scrapper.Notification) error {
+	languages, err := t.repo.UsersLanguages(ctx, update.TgChatIDs)
 	if err != nil {
-		return fmt.Errorf("при получении всех пользователей произошла ошибка : %w", err)
+		return fmt.Errorf("ошибка при получении языков пользователей: %w", err)
+	}
+
+	timezones, err := t.repo.UsersTimezones(ctx, update.TgChatIDs)
+	if err != nil {
+		return fmt.Errorf("ошибка при получении часовых поясов пользователей: %w", err)
+	}
+
+	quietHours, err := t.repo.QuietHours(ctx, update.TgChatIDs)
+	if err != nil {
+		return fmt.Errorf("ошибка при получении тихих часов: %w", err)
+	}
+
+	now := time.Now()
+	audiences := make([]audience, 0, len(i18n.Languages))
+	awakeUsers := make(map[audience][]scrapper.User, len(i18n.Languages))
+	sleepingUsers := make(map[audience][]scrapper.User, len(i18n.Languages))
+
+	for _, user := range update.TgChatIDs {
+		lang := languages[user]
+		if !i18n.Supported(lang) {
+			lang = i18n.Default
+		}
+
+		timezone := timezones[user]
+		if timezone == "" {
+			timezone = scrapper.DefaultTimezone
+		}
+
+		key := audience{lang: lang, timezone: timezone}
+		if !slices.Contains(audiences, key) {
+			audiences = append(audiences, key)
+		}
+
+		if hours, ok := quietHours[user]; ok && hours.Active(now) {
+			sleepingUsers[key] = append(sleepingUsers[key], user)
+		} else {
+			awakeUsers[key] = append(awakeUsers[key], user)
+		}
 	}
 
-	for _, update := range linkUpdates {
-		err := t.botClient.SendLinkUpdates(&dto.LinkUpdate{
-			ID:          linkInfo.ID,
-			URL:         linkInfo.URL,
-			Description: t.notifyMsg(update),
-			TgChatIDs:   users})
+	slices.SortStableFunc(audiences, func(a, b audience) int {
+		return slices.Index(i18n.Languages, a.lang) - slices.Index(i18n.Languages, b.lang)
+	})
+
+	for _, key := range audiences {
+		notification := content
+		notification.Language, notification.Timezone = key.lang, key.timezone
+
+		if len(sleepingUsers[key]) > 0 {
+			if err = t.repo.HoldUpdate(ctx, update.LinkID, update.EventID, &notification, sleepingUsers[key]); err != nil {
+				return fmt.Errorf("ошибка при задержке уведомления до конца тихих часов: %w", err)
+			}
+		}
+
+		if len(awakeUsers[key]) == 0 {
+			continue
+		}
+
+		err = t.outbox.SaveOutboxUpdate(ctx, &scrapper.OutboxUpdate{
+			LinkID:    update.LinkID,
+			URL:       update.URL,
+			EventID:   update.EventID,
+			Content:   &notification,
+			TgChatIDs: awakeUsers[key]})
 
 		if err != nil {
-			return fmt.Errorf("не удалось отправить обновление ссылки  : %w", err)
+			return fmt.Errorf("ошибка при сохранении уведомления в outbox: %w", err)
 		}
 	}
 
 	return nil
 }
 
-func (t *TgNotifier) notifyMsg(linkUpdate *scrapper.LinkUpdate) string {
-	return fmt.Sprintf(descriptionFormat, linkUpdate.Header, linkUpdate.UserName,
-		linkUpdate.CreateTime, linkUpdate.Preview)
+// ReleaseHeldUpdates отправляет задержанные уведомления пользователям, у которых закончились тихие часы.
+func (t *TgNotifier) ReleaseHeldUpdates(ctx context.Context) {
+	users, err := t.repo.HeldUsers(ctx)
+	if err != nil {
+		t.log.Error("ошибка при получении пользователей с задержанными уведомлениями", "err", err.Error())
+
+		return
+	}
+
+	if len(users) == 0 {
+		return
+	}
+
+	quietHours, err := t.repo.QuietHours(ctx, users)
+	if err != nil {
+		t.log.Error("ошибка при получении тихих часов", "err", err.Error())
+
+		return
+	}
+
+	now := time.Now()
+
+	for _, user := range users {
+		if hours, ok := quietHours[user]; ok && hours.Active(now) {
+			continue
+		}
+
+		if err = t.releaseUserUpdates(ctx, user); err != nil {
+			t.log.Error(fmt.Sprintf("ошибка при отправке задержанных уведомлений пользователю %d", user),
+				"err", err.Error())
+		}
+	}
+}
+
+// releaseUserUpdates переносит уведомления в outbox и удаляет их в одной транзакции. HeldUpdates блокирует
+// строки, поэтому другая реплика пропустит уже переносимые уведомления.
+func (t *TgNotifier) releaseUserUpdates(ctx context.Context, user scrapper.User) error {
+	return t.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		heldUpdates, err := t.repo.HeldUpdates(ctx, user)
+		if err != nil {
+			return err
+		}
+
+		if len(heldUpdates) == 0 {
+			return nil
+		}
+
+		ids := make([]int64, 0, len(heldUpdates))
+
+		for _, held := range heldUpdates {
+			err = t.outbox.SaveOutboxUpdate(ctx, &scrapper.OutboxUpdate{
+				LinkID:      held.LinkID,
+				URL:         held.URL,
+				EventID:     held.EventID,
+				Description: held.Description,
+				Content:     held.Content,
+				TgChatIDs:   []scrapper.User{user}})
+
+			if err != nil {
+				return err
+			}
+
+			ids = append(ids, held.ID)
+		}
+
+		return t.repo.DeleteHeldUpdates(ctx, ids)
+	})
 }
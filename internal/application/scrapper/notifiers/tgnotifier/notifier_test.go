@@ -1,10 +1,13 @@
 package tgnotifier_test
 
 import (
+	"context"
+	"io"
 	"linkTraccer/internal/application/scrapper/notifiers/mocks"
 	"linkTraccer/internal/application/scrapper/notifiers/tgnotifier"
-	"linkTraccer/internal/application/scrapper/scrapservice"
+	"linkTraccer/internal/domain/i18n"
 	"linkTraccer/internal/domain/scrapper"
+	"log/slog"
 	"testing"
 	"time"
 
@@ -14,55 +17,55 @@ import (
 )
 
 var (
-	errRepo     = errors.New("ошибка в репозитории")
-	errClient   = errors.New("ошибка в клиенте")
-	linkInfo    = &scrapper.LinkInfo{ID: 1, URL: "github.com", LastUpdate: time.Now()}
-	linkUpdates = scrapper.LinkUpdates{&scrapper.LinkUpdate{}}
+	errClient  = errors.New("ошибка в клиенте")
+	linkInfo   = &scrapper.LinkInfo{ID: 1, URL: "github.com", LastUpdate: time.Now()}
+	linkUpdate = &scrapper.LinkUpdate{}
+	users      = []scrapper.User{1, 2}
+	log        = slog.New(slog.NewTextHandler(io.Discard, nil))
+	eventID    = scrapper.EventID(linkInfo.URL, linkUpdate)
 )
 
-func TestTgNotifier_SendUpdates(t *testing.T) {
-	repoWithErr := mocks.NewUserRepo(t)
-	repoWithoutErr := mocks.NewUserRepo(t)
-	botClientWithErr := mocks.NewBotClient(t)
-	botClientWithoutErr := mocks.NewBotClient(t)
+// awakeRepo - репозиторий, в котором ни у кого нет тихих часов, а язык не выбран.
+func awakeRepo(t *testing.T) *mocks.SettingsRepo {
+	repo := mocks.NewSettingsRepo(t)
 
-	repoWithoutErr.On("UsersWhoTrackLink", mock.Anything).Return(nil, nil)
-	repoWithErr.On("UsersWhoTrackLink", mock.Anything).Return(nil, errRepo)
-	botClientWithErr.On("SendLinkUpdates", mock.Anything).Return(errClient)
-	botClientWithoutErr.On("SendLinkUpdates", mock.Anything).Return(nil)
+	repo.On("UsersLanguages", mock.Anything, mock.Anything).Return(map[scrapper.User]i18n.Lang{}, nil)
+	repo.On("UsersTimezones", mock.Anything, mock.Anything).Return(map[scrapper.User]string{}, nil)
+	repo.On("QuietHours", mock.Anything, mock.Anything).Return(map[scrapper.User]*scrapper.QuietHours{}, nil)
+
+	return repo
+}
+
+func TestTgNotifier_SendUpdate(t *testing.T) {
+	outboxWithErr := mocks.NewOutbox(t)
+	outboxWithoutErr := mocks.NewOutbox(t)
+
+	outboxWithErr.On("SaveOutboxUpdate", mock.Anything, mock.Anything).Return(errClient)
+	outboxWithoutErr.On("SaveOutboxUpdate", mock.Anything, mock.Anything).Return(nil)
 
 	type TestCase struct {
-		name      string
-		botClient tgnotifier.BotClient
-		userRepo  scrapservice.UserRepo
-		correct   bool
+		name    string
+		outbox  tgnotifier.Outbox
+		correct bool
 	}
 
 	tests := []TestCase{
 		{
-			name:      "ошибка при получении всех пользователей отслеживающих ссылку",
-			botClient: botClientWithErr,
-			userRepo:  repoWithErr,
-			correct:   false,
-		},
-		{
-			name:      "ошибка при отправке апдейтов",
-			botClient: botClientWithErr,
-			userRepo:  repoWithoutErr,
-			correct:   false,
+			name:    "ошибка при сохранении апдейтов в outbox",
+			outbox:  outboxWithErr,
+			correct: false,
 		},
 		{
-			name:      "апдейты успешно отправлены",
-			botClient: botClientWithoutErr,
-			userRepo:  repoWithoutErr,
-			correct:   true,
+			name:    "апдейты успешно сохранены в outbox",
+			outbox:  outboxWithoutErr,
+			correct: true,
 		},
 	}
 
 	for _, test := range tests {
-		notifier := tgnotifier.New(test.userRepo, test.botClient)
+		notifier := tgnotifier.New(test.outbox, awakeRepo(t), mocks.NewTransactor(t), log)
 
-		err := notifier.SendUpdates(linkInfo, linkUpdates)
+		err := notifier.SendUpdate(context.Background(), linkInfo, linkUpdate, users)
 
 		if test.correct {
 			assert.NoError(t, err)
@@ -71,3 +74,293 @@ func TestTgNotifier_SendUpdates(t *testing.T) {
 		}
 	}
 }
+
+func TestTgNotifier_SendDigest(t *testing.T) {
+	outbox := mocks.NewOutbox(t)
+
+	notifier := tgnotifier.New(outbox, awakeRepo(t), mocks.NewTransactor(t), log)
+	digest := []*scrapper.LinkDigest{{
+		URL:     "github.com",
+		Updates: scrapper.LinkUpdates{{Type: scrapper.IssueUpdate, Title: "new feature", Author: "orlov4919"}},
+	}}
+
+	outbox.On("SaveOutboxUpdate", mock.Anything, mock.MatchedBy(func(update *scrapper.OutboxUpdate) bool {
+		return update.TgChatIDs[0] == 1 && update.EventID != "" && update.EventID != eventID &&
+			assert.ObjectsAreEqual(&scrapper.Notification{Digest: digest, Language: i18n.Default,
+				Timezone: scrapper.DefaultTimezone}, update.Content)
+	})).Return(nil).Once()
+	outbox.On("SaveOutboxUpdate", mock.Anything, mock.Anything).Return(errClient).Once()
+
+	assert.NoError(t, notifier.SendDigest(context.Background(), 1, digest))
+	assert.Error(t, notifier.SendDigest(context.Background(), 2, digest))
+}
+
+func TestTgNotifier_SendUpdateQuietHours(t *testing.T) {
+	// тихие часы, которые идут прямо сейчас, и тихие часы, которые уже закончились
+	now := time.Now().UTC()
+	activeHours := &scrapper.QuietHours{
+		Start:    now.Add(-time.Hour).Format(scrapper.DigestTimeLayout),
+		End:      now.Add(time.Hour).Format(scrapper.DigestTimeLayout),
+		Timezone: "UTC",
+	}
+	passedHours := &scrapper.QuietHours{
+		Start:    now.Add(-3 * time.Hour).Format(scrapper.DigestTimeLayout),
+		End:      now.Add(-2 * time.Hour).Format(scrapper.DigestTimeLayout),
+		Timezone: "UTC",
+	}
+
+	type TestCase struct {
+		name    string
+		prepare func(repo *mocks.SettingsRepo, outbox *mocks.Outbox)
+		correct bool
+	}
+
+	tests := []TestCase{
+		{
+			name: "ошибка при получении языков пользователей",
+			prepare: func(repo *mocks.SettingsRepo, _ *mocks.Outbox) {
+				repo.On("UsersLanguages", mock.Anything, users).Return(nil, errClient)
+			},
+			correct: false,
+		},
+		{
+			name: "ошибка при получении тихих часов",
+			prepare: func(repo *mocks.SettingsRepo, _ *mocks.Outbox) {
+				repo.On("UsersLanguages", mock.Anything, users).Return(map[scrapper.User]i18n.Lang{}, nil)
+				repo.On("UsersTimezones", mock.Anything, users).Return(map[scrapper.User]string{}, nil)
+				repo.On("QuietHours", mock.Anything, users).Return(nil, errClient)
+			},
+			correct: false,
+		},
+		{
+			name: "у первого пользователя тихие часы, уведомление задерживается только для него",
+			prepare: func(repo *mocks.SettingsRepo, outbox *mocks.Outbox) {
+				repo.On("UsersLanguages", mock.Anything, users).Return(map[scrapper.User]i18n.Lang{}, nil)
+				repo.On("UsersTimezones", mock.Anything, users).Return(map[scrapper.User]string{}, nil)
+				repo.On("QuietHours", mock.Anything, users).Return(map[scrapper.User]*scrapper.QuietHours{
+					1: activeHours,
+					2: passedHours,
+				}, nil)
+				repo.On("HoldUpdate", mock.Anything, linkInfo.ID, eventID, mock.Anything, []scrapper.User{1}).Return(nil)
+				outbox.On("SaveOutboxUpdate", mock.Anything, mock.MatchedBy(func(update *scrapper.OutboxUpdate) bool {
+					return assert.ObjectsAreEqual([]scrapper.User{2}, update.TgChatIDs) && update.EventID == eventID
+				})).Return(nil)
+			},
+			correct: true,
+		},
+		{
+			name: "у всех пользователей тихие часы, в outbox ничего не сохраняется",
+			prepare: func(repo *mocks.SettingsRepo, _ *mocks.Outbox) {
+				repo.On("UsersLanguages", mock.Anything, users).Return(map[scrapper.User]i18n.Lang{}, nil)
+				repo.On("UsersTimezones", mock.Anything, users).Return(map[scrapper.User]string{}, nil)
+				repo.On("QuietHours", mock.Anything, users).Return(map[scrapper.User]*scrapper.QuietHours{
+					1: activeHours,
+					2: activeHours,
+				}, nil)
+				repo.On("HoldUpdate", mock.Anything, linkInfo.ID, eventID, mock.Anything, users).Return(nil)
+			},
+			correct: true,
+		},
+		{
+			name: "ошибка при сохранении задержанного уведомления",
+			prepare: func(repo *mocks.SettingsRepo, _ *mocks.Outbox) {
+				repo.On("UsersLanguages", mock.Anything, users).Return(map[scrapper.User]i18n.Lang{}, nil)
+				repo.On("UsersTimezones", mock.Anything, users).Return(map[scrapper.User]string{}, nil)
+				repo.On("QuietHours", mock.Anything, users).Return(map[scrapper.User]*scrapper.QuietHours{1: activeHours}, nil)
+				repo.On("HoldUpdate", mock.Anything, linkInfo.ID, eventID, mock.Anything, []scrapper.User{1}).Return(errClient)
+			},
+			correct: false,
+		},
+	}
+
+	for _, test := range tests {
+		repo := mocks.NewSettingsRepo(t)
+		outbox := mocks.NewOutbox(t)
+		test.prepare(repo, outbox)
+
+		notifier := tgnotifier.New(outbox, repo, mocks.NewTransactor(t), log)
+		err := notifier.SendUpdate(context.Background(), linkInfo, linkUpdate, users)
+
+		if test.correct {
+			assert.NoError(t, err, test.name)
+		} else {
+			assert.Error(t, err, test.name)
+		}
+	}
+}
+
+func TestTgNotifier_SendUpdateLanguages(t *testing.T) {
+	repo := mocks.NewSettingsRepo(t)
+	outbox := mocks.NewOutbox(t)
+	recipients := []scrapper.User{1, 2, 3}
+
+	// у третьего пользователя язык неизвестен, уведомление уходит на языке по умолчанию
+	repo.On("UsersLanguages", mock.Anything, recipients).Return(map[scrapper.User]i18n.Lang{1: i18n.English, 2: i18n.Russian, 3: "fr"}, nil)
+	repo.On("UsersTimezones", mock.Anything, recipients).Return(map[scrapper.User]string{}, nil)
+	repo.On("QuietHours", mock.Anything, recipients).Return(map[scrapper.User]*scrapper.QuietHours{}, nil)
+
+	outbox.On("SaveOutboxUpdate", mock.Anything, mock.MatchedBy(func(update *scrapper.OutboxUpdate) bool {
+		return assert.ObjectsAreEqual([]scrapper.User{2, 3}, update.TgChatIDs) && update.Content.Language == i18n.Russian
+	})).Return(nil).Once()
+	outbox.On("SaveOutboxUpdate", mock.Anything, mock.MatchedBy(func(update *scrapper.OutboxUpdate) bool {
+		return assert.ObjectsAreEqual([]scrapper.User{1}, update.TgChatIDs) && update.Content.Language == i18n.English
+	})).Return(errClient).Once()
+
+	notifier := tgnotifier.New(outbox, repo, mocks.NewTransactor(t), log)
+	err := notifier.SendUpdate(context.Background(), linkInfo, &scrapper.LinkUpdate{Title: "new feature"}, recipients)
+
+	assert.Error(t, err, "ошибка при сохранении уведомления на одном из языков")
+}
+
+func TestTgNotifier_SendUpdateTimezones(t *testing.T) {
+	repo := mocks.NewSettingsRepo(t)
+	outbox := mocks.NewOutbox(t)
+	recipients := []scrapper.User{1, 2, 3}
+	issue := &scrapper.LinkUpdate{Title: "new feature", ItemTime: time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)}
+
+	// у третьего пользователя часовой пояс не задан, для него используется пояс по умолчанию
+	repo.On("UsersLanguages", mock.Anything, recipients).Return(map[scrapper.User]i18n.Lang{}, nil)
+	repo.On("UsersTimezones", mock.Anything, recipients).Return(map[scrapper.User]string{1: "UTC", 2: scrapper.DefaultTimezone}, nil)
+	repo.On("QuietHours", mock.Anything, recipients).Return(map[scrapper.User]*scrapper.QuietHours{}, nil)
+
+	outbox.On("SaveOutboxUpdate", mock.Anything, mock.MatchedBy(func(update *scrapper.OutboxUpdate) bool {
+		return assert.ObjectsAreEqual([]scrapper.User{1}, update.TgChatIDs) && update.Content.Timezone == "UTC"
+	})).Return(nil).Once()
+	outbox.On("SaveOutboxUpdate", mock.Anything, mock.MatchedBy(func(update *scrapper.OutboxUpdate) bool {
+		return assert.ObjectsAreEqual([]scrapper.User{2, 3}, update.TgChatIDs) &&
+			update.Content.Timezone == scrapper.DefaultTimezone && update.Content.Update == issue
+	})).Return(nil).Once()
+
+	notifier := tgnotifier.New(outbox, repo, mocks.NewTransactor(t), log)
+
+	assert.NoError(t, notifier.SendUpdate(context.Background(), linkInfo, issue, recipients))
+}
+
+func TestTgNotifier_ReleaseHeldUpdates(t *testing.T) {
+	now := time.Now().UTC()
+	activeHours := &scrapper.QuietHours{
+		Start:    now.Add(-time.Hour).Format(scrapper.DigestTimeLayout),
+		End:      now.Add(time.Hour).Format(scrapper.DigestTimeLayout),
+		Timezone: "UTC",
+	}
+
+	repo := mocks.NewSettingsRepo(t)
+	outbox := mocks.NewOutbox(t)
+	transactor := mocks.NewTransactor(t)
+
+	transactor.On("WithTransaction", mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		})
+
+	repo.On("HeldUsers", mock.Anything).Return([]scrapper.User{1, 2, 3}, nil)
+	repo.On("QuietHours", mock.Anything, []scrapper.User{1, 2, 3}).Return(map[scrapper.User]*scrapper.QuietHours{
+		3: activeHours,
+	}, nil)
+
+	// первому пользователю отправляются оба уведомления
+	repo.On("HeldUpdates", mock.Anything, scrapper.User(1)).Return([]*scrapper.HeldUpdate{
+		{ID: 1, LinkID: 1, URL: "github.com", EventID: "first", Description: "first"},
+		{ID: 4, LinkID: 1, URL: "github.com", Description: "second"},
+	}, nil)
+	outbox.On("SaveOutboxUpdate", mock.Anything, &scrapper.OutboxUpdate{LinkID: 1, URL: "github.com", EventID: "first",
+		Description: "first", TgChatIDs: []scrapper.User{1}}).Return(nil)
+	outbox.On("SaveOutboxUpdate", mock.Anything, &scrapper.OutboxUpdate{LinkID: 1, URL: "github.com", Description: "second",
+		TgChatIDs: []scrapper.User{1}}).Return(nil)
+	repo.On("DeleteHeldUpdates", mock.Anything, []int64{1, 4}).Return(nil).Once()
+
+	// второму пользователю не удалось перенести второе уведомление, транзакция откатывается и ничего не удаляется
+	repo.On("HeldUpdates", mock.Anything, scrapper.User(2)).Return([]*scrapper.HeldUpdate{
+		{ID: 2, Description: "digest"},
+		{ID: 3, Description: "after digest"},
+	}, nil)
+	outbox.On("SaveOutboxUpdate", mock.Anything, &scrapper.OutboxUpdate{Description: "digest",
+		TgChatIDs: []scrapper.User{2}}).Return(nil)
+	outbox.On("SaveOutboxUpdate", mock.Anything, &scrapper.OutboxUpdate{Description: "after digest",
+		TgChatIDs: []scrapper.User{2}}).Return(errClient)
+
+	notifier := tgnotifier.New(outbox, repo, transactor, log)
+	notifier.ReleaseHeldUpdates(context.Background())
+
+	repo.AssertNotCalled(t, "HeldUpdates", scrapper.User(3))
+	repo.AssertNotCalled(t, "DeleteHeldUpdates", []int64{2})
+}
+
+func TestEventID(t *testing.T) {
+	update := &scrapper.LinkUpdate{Type: scrapper.IssueUpdate, ItemID: 1, Title: "new feature", Author: "orlov4919",
+		ItemTime: time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)}
+	edited := *update
+	edited.Title, edited.Excerpt = "заголовок после правки", "текст после правки"
+	inOtherZone := *update
+	inOtherZone.ItemTime = update.ItemTime.In(time.FixedZone("UTC+3", 3*60*60))
+	other := *update
+	other.ItemID = 2
+
+	assert.Equal(t, scrapper.EventID("github.com", update), scrapper.EventID("github.com", &edited),
+		"правка текста не делает событие новым")
+	assert.Equal(t, scrapper.EventID("github.com", update), scrapper.EventID("github.com", &inOtherZone),
+		"идентификатор не зависит от часового пояса времени события")
+	assert.NotEqual(t, scrapper.EventID("github.com", update), scrapper.EventID("github.com", &other))
+	assert.NotEqual(t, scrapper.EventID("github.com", update), scrapper.EventID("stackoverflow.com", update))
+
+	digest := []*scrapper.LinkDigest{{URL: "github.com", Updates: scrapper.LinkUpdates{update}}}
+
+	assert.NotEqual(t, scrapper.DigestEventID(1, digest), scrapper.DigestEventID(2, digest),
+		"дайджесты разных пользователей - разные события")
+}
+
+func TestQuietHours_Active(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2025, 4, 1, hour, minute, 0, 0, time.UTC)
+	}
+
+	type TestCase struct {
+		name   string
+		hours  *scrapper.QuietHours
+		now    time.Time
+		active bool
+	}
+
+	tests := []TestCase{
+		{
+			name:   "интервал внутри суток, время внутри",
+			hours:  &scrapper.QuietHours{Start: "13:00", End: "15:00", Timezone: "UTC"},
+			now:    at(14, 0),
+			active: true,
+		},
+		{
+			name:   "конец интервала не входит в тихие часы",
+			hours:  &scrapper.QuietHours{Start: "13:00", End: "15:00", Timezone: "UTC"},
+			now:    at(15, 0),
+			active: false,
+		},
+		{
+			name:   "интервал через полночь, время после полуночи",
+			hours:  &scrapper.QuietHours{Start: "23:00", End: "08:00", Timezone: "UTC"},
+			now:    at(3, 30),
+			active: true,
+		},
+		{
+			name:   "интервал через полночь, время днем",
+			hours:  &scrapper.QuietHours{Start: "23:00", End: "08:00", Timezone: "UTC"},
+			now:    at(12, 0),
+			active: false,
+		},
+		{
+			name:   "учитывается часовой пояс пользователя",
+			hours:  &scrapper.QuietHours{Start: "23:00", End: "08:00", Timezone: "Europe/Moscow"},
+			now:    at(21, 0),
+			active: true,
+		},
+		{
+			name:   "неизвестный часовой пояс, уведомления не задерживаются",
+			hours:  &scrapper.QuietHours{Start: "00:00", End: "23:59", Timezone: "Mars/Olympus"},
+			now:    at(12, 0),
+			active: false,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.active, test.hours.Active(test.now), test.name)
+	}
+}
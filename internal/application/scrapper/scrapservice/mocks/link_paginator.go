@@ -0,0 +1,140 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	scrapper "linkTraccer/internal/domain/scrapper"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// LinkPaginator is an autogenerated mock type for the LinkPaginator type
+type LinkPaginator struct {
+	mock.Mock
+}
+
+type LinkPaginator_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *LinkPaginator) EXPECT() *LinkPaginator_Expecter {
+	return &LinkPaginator_Expecter{mock: &_m.Mock}
+}
+
+// HasLinks provides a mock function with no fields
+func (_m *LinkPaginator) HasLinks() bool {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasLinks")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// LinkPaginator_HasLinks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HasLinks'
+type LinkPaginator_HasLinks_Call struct {
+	*mock.Call
+}
+
+// HasLinks is a helper method to define mock.On call
+func (_e *LinkPaginator_Expecter) HasLinks() *LinkPaginator_HasLinks_Call {
+	return &LinkPaginator_HasLinks_Call{Call: _e.mock.On("HasLinks")}
+}
+
+func (_c *LinkPaginator_HasLinks_Call) Run(run func()) *LinkPaginator_HasLinks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *LinkPaginator_HasLinks_Call) Return(_a0 bool) *LinkPaginator_HasLinks_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *LinkPaginator_HasLinks_Call) RunAndReturn(run func() bool) *LinkPaginator_HasLinks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LinksBatch provides a mock function with given fields: ctx
+func (_m *LinkPaginator) LinksBatch(ctx context.Context) ([]*scrapper.LinkInfo, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LinksBatch")
+	}
+
+	var r0 []*scrapper.LinkInfo
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]*scrapper.LinkInfo, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []*scrapper.LinkInfo); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*scrapper.LinkInfo)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LinkPaginator_LinksBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LinksBatch'
+type LinkPaginator_LinksBatch_Call struct {
+	*mock.Call
+}
+
+// LinksBatch is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *LinkPaginator_Expecter) LinksBatch(ctx interface{}) *LinkPaginator_LinksBatch_Call {
+	return &LinkPaginator_LinksBatch_Call{Call: _e.mock.On("LinksBatch", ctx)}
+}
+
+func (_c *LinkPaginator_LinksBatch_Call) Run(run func(ctx context.Context)) *LinkPaginator_LinksBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *LinkPaginator_LinksBatch_Call) Return(_a0 []*scrapper.LinkInfo, _a1 error) *LinkPaginator_LinksBatch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *LinkPaginator_LinksBatch_Call) RunAndReturn(run func(context.Context) ([]*scrapper.LinkInfo, error)) *LinkPaginator_LinksBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewLinkPaginator creates a new instance of LinkPaginator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLinkPaginator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LinkPaginator {
+	mock := &LinkPaginator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -2,7 +2,12 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+	scrapper "linkTraccer/internal/domain/scrapper"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // SiteClient is an autogenerated mock type for the SiteClient type
 type SiteClient struct {
@@ -17,73 +22,74 @@ func (_m *SiteClient) EXPECT() *SiteClient_Expecter {
 	return &SiteClient_Expecter{mock: &_m.Mock}
 }
 
-// CanTrack provides a mock function with given fields: link
-func (_m *SiteClient) CanTrack(link string) bool {
-	ret := _m.Called(link)
+// Host provides a mock function with no fields
+func (_m *SiteClient) Host() string {
+	ret := _m.Called()
 
 	if len(ret) == 0 {
-		panic("no return value specified for CanTrack")
+		panic("no return value specified for Host")
 	}
 
-	var r0 bool
-	if rf, ok := ret.Get(0).(func(string) bool); ok {
-		r0 = rf(link)
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
 	} else {
-		r0 = ret.Get(0).(bool)
+		r0 = ret.Get(0).(string)
 	}
 
 	return r0
 }
 
-// SiteClient_CanTrack_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CanTrack'
-type SiteClient_CanTrack_Call struct {
+// SiteClient_Host_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Host'
+type SiteClient_Host_Call struct {
 	*mock.Call
 }
 
-// CanTrack is a helper method to define mock.On call
-//   - link string
-func (_e *SiteClient_Expecter) CanTrack(link interface{}) *SiteClient_CanTrack_Call {
-	return &SiteClient_CanTrack_Call{Call: _e.mock.On("CanTrack", link)}
+// Host is a helper method to define mock.On call
+func (_e *SiteClient_Expecter) Host() *SiteClient_Host_Call {
+	return &SiteClient_Host_Call{Call: _e.mock.On("Host")}
 }
 
-func (_c *SiteClient_CanTrack_Call) Run(run func(link string)) *SiteClient_CanTrack_Call {
+func (_c *SiteClient_Host_Call) Run(run func()) *SiteClient_Host_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run()
 	})
 	return _c
 }
 
-func (_c *SiteClient_CanTrack_Call) Return(_a0 bool) *SiteClient_CanTrack_Call {
+func (_c *SiteClient_Host_Call) Return(_a0 string) *SiteClient_Host_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *SiteClient_CanTrack_Call) RunAndReturn(run func(string) bool) *SiteClient_CanTrack_Call {
+func (_c *SiteClient_Host_Call) RunAndReturn(run func() string) *SiteClient_Host_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// LinkLastCheck provides a mock function with given fields: link
-func (_m *SiteClient) LinkUpdates(link string) (string, error) {
-	ret := _m.Called(link)
+// LinkUpdates provides a mock function with given fields: ctx, link, cursors
+func (_m *SiteClient) LinkUpdates(ctx context.Context, link string, cursors *scrapper.LinkCursors) ([]*scrapper.LinkUpdate, error) {
+	ret := _m.Called(ctx, link, cursors)
 
 	if len(ret) == 0 {
 		panic("no return value specified for LinkUpdates")
 	}
 
-	var r0 string
+	var r0 []*scrapper.LinkUpdate
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
-		return rf(link)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *scrapper.LinkCursors) ([]*scrapper.LinkUpdate, error)); ok {
+		return rf(ctx, link, cursors)
 	}
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(link)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *scrapper.LinkCursors) []*scrapper.LinkUpdate); ok {
+		r0 = rf(ctx, link, cursors)
 	} else {
-		r0 = ret.Get(0).(string)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*scrapper.LinkUpdate)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(link)
+	if rf, ok := ret.Get(1).(func(context.Context, string, *scrapper.LinkCursors) error); ok {
+		r1 = rf(ctx, link, cursors)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -91,30 +97,78 @@ func (_m *SiteClient) LinkUpdates(link string) (string, error) {
 	return r0, r1
 }
 
-// SiteClient_LinkState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LinkUpdates'
-type SiteClient_LinkState_Call struct {
+// SiteClient_LinkUpdates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LinkUpdates'
+type SiteClient_LinkUpdates_Call struct {
 	*mock.Call
 }
 
-// LinkState is a helper method to define mock.On call
+// LinkUpdates is a helper method to define mock.On call
+//   - ctx context.Context
 //   - link string
-func (_e *SiteClient_Expecter) LinkState(link interface{}) *SiteClient_LinkState_Call {
-	return &SiteClient_LinkState_Call{Call: _e.mock.On("LinkUpdates", link)}
+//   - cursors *scrapper.LinkCursors
+func (_e *SiteClient_Expecter) LinkUpdates(ctx interface{}, link interface{}, cursors interface{}) *SiteClient_LinkUpdates_Call {
+	return &SiteClient_LinkUpdates_Call{Call: _e.mock.On("LinkUpdates", ctx, link, cursors)}
 }
 
-func (_c *SiteClient_LinkState_Call) Run(run func(link string)) *SiteClient_LinkState_Call {
+func (_c *SiteClient_LinkUpdates_Call) Run(run func(ctx context.Context, link string, cursors *scrapper.LinkCursors)) *SiteClient_LinkUpdates_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(*scrapper.LinkCursors))
 	})
 	return _c
 }
 
-func (_c *SiteClient_LinkState_Call) Return(_a0 string, _a1 error) *SiteClient_LinkState_Call {
+func (_c *SiteClient_LinkUpdates_Call) Return(_a0 []*scrapper.LinkUpdate, _a1 error) *SiteClient_LinkUpdates_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *SiteClient_LinkState_Call) RunAndReturn(run func(string) (string, error)) *SiteClient_LinkState_Call {
+func (_c *SiteClient_LinkUpdates_Call) RunAndReturn(run func(context.Context, string, *scrapper.LinkCursors) ([]*scrapper.LinkUpdate, error)) *SiteClient_LinkUpdates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Supports provides a mock function with given fields: link
+func (_m *SiteClient) Supports(link string) bool {
+	ret := _m.Called(link)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Supports")
+	}
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(link)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
+// SiteClient_Supports_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Supports'
+type SiteClient_Supports_Call struct {
+	*mock.Call
+}
+
+// Supports is a helper method to define mock.On call
+//   - link string
+func (_e *SiteClient_Expecter) Supports(link interface{}) *SiteClient_Supports_Call {
+	return &SiteClient_Supports_Call{Call: _e.mock.On("Supports", link)}
+}
+
+func (_c *SiteClient_Supports_Call) Run(run func(link string)) *SiteClient_Supports_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *SiteClient_Supports_Call) Return(_a0 bool) *SiteClient_Supports_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SiteClient_Supports_Call) RunAndReturn(run func(string) bool) *SiteClient_Supports_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -2,7 +2,16 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+	scrapper "linkTraccer/internal/domain/scrapper"
+
+	mock "github.com/stretchr/testify/mock"
+
+	scrapservice "linkTraccer/internal/application/scrapper/scrapservice"
+
+	time "time"
+)
 
 // UserRepo is an autogenerated mock type for the UserRepo type
 type UserRepo struct {
@@ -17,76 +26,127 @@ func (_m *UserRepo) EXPECT() *UserRepo_Expecter {
 	return &UserRepo_Expecter{mock: &_m.Mock}
 }
 
-// AllLinks provides a mock function with no fields
-func (_m *UserRepo) AllLinks() []string {
-	ret := _m.Called()
+// AddLinkFilters provides a mock function with given fields: ctx, userID, link, filters
+func (_m *UserRepo) AddLinkFilters(ctx context.Context, userID int64, link string, filters []string) error {
+	ret := _m.Called(ctx, userID, link, filters)
 
 	if len(ret) == 0 {
-		panic("no return value specified for LinksBatch")
+		panic("no return value specified for AddLinkFilters")
 	}
 
-	var r0 []string
-	if rf, ok := ret.Get(0).(func() []string); ok {
-		r0 = rf()
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, []string) error); ok {
+		r0 = rf(ctx, userID, link, filters)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
-		}
+		r0 = ret.Error(0)
 	}
 
 	return r0
 }
 
-// UserRepo_AllLinks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LinksBatch'
-type UserRepo_AllLinks_Call struct {
+// UserRepo_AddLinkFilters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddLinkFilters'
+type UserRepo_AddLinkFilters_Call struct {
 	*mock.Call
 }
 
-// AllLinks is a helper method to define mock.On call
-func (_e *UserRepo_Expecter) AllLinks() *UserRepo_AllLinks_Call {
-	return &UserRepo_AllLinks_Call{Call: _e.mock.On("LinksBatch")}
+// AddLinkFilters is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - link string
+//   - filters []string
+func (_e *UserRepo_Expecter) AddLinkFilters(ctx interface{}, userID interface{}, link interface{}, filters interface{}) *UserRepo_AddLinkFilters_Call {
+	return &UserRepo_AddLinkFilters_Call{Call: _e.mock.On("AddLinkFilters", ctx, userID, link, filters)}
 }
 
-func (_c *UserRepo_AllLinks_Call) Run(run func()) *UserRepo_AllLinks_Call {
+func (_c *UserRepo_AddLinkFilters_Call) Run(run func(ctx context.Context, userID int64, link string, filters []string)) *UserRepo_AddLinkFilters_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(context.Context), args[1].(int64), args[2].(string), args[3].([]string))
+	})
+	return _c
+}
+
+func (_c *UserRepo_AddLinkFilters_Call) Return(_a0 error) *UserRepo_AddLinkFilters_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepo_AddLinkFilters_Call) RunAndReturn(run func(context.Context, int64, string, []string) error) *UserRepo_AddLinkFilters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddLinkTags provides a mock function with given fields: ctx, userID, link, tags
+func (_m *UserRepo) AddLinkTags(ctx context.Context, userID int64, link string, tags []string) error {
+	ret := _m.Called(ctx, userID, link, tags)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddLinkTags")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, []string) error); ok {
+		r0 = rf(ctx, userID, link, tags)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepo_AddLinkTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddLinkTags'
+type UserRepo_AddLinkTags_Call struct {
+	*mock.Call
+}
+
+// AddLinkTags is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - link string
+//   - tags []string
+func (_e *UserRepo_Expecter) AddLinkTags(ctx interface{}, userID interface{}, link interface{}, tags interface{}) *UserRepo_AddLinkTags_Call {
+	return &UserRepo_AddLinkTags_Call{Call: _e.mock.On("AddLinkTags", ctx, userID, link, tags)}
+}
+
+func (_c *UserRepo_AddLinkTags_Call) Run(run func(ctx context.Context, userID int64, link string, tags []string)) *UserRepo_AddLinkTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string), args[3].([]string))
 	})
 	return _c
 }
 
-func (_c *UserRepo_AllLinks_Call) Return(_a0 []string) *UserRepo_AllLinks_Call {
+func (_c *UserRepo_AddLinkTags_Call) Return(_a0 error) *UserRepo_AddLinkTags_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *UserRepo_AllLinks_Call) RunAndReturn(run func() []string) *UserRepo_AllLinks_Call {
+func (_c *UserRepo_AddLinkTags_Call) RunAndReturn(run func(context.Context, int64, string, []string) error) *UserRepo_AddLinkTags_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// AllUserLinks provides a mock function with given fields: user
-func (_m *UserRepo) AllUserLinks(user int) ([]string, error) {
-	ret := _m.Called(user)
+// AllUserLinks provides a mock function with given fields: ctx, userID
+func (_m *UserRepo) AllUserLinks(ctx context.Context, userID int64) ([]*scrapper.UserLink, error) {
+	ret := _m.Called(ctx, userID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AllUserLinks")
 	}
 
-	var r0 []string
+	var r0 []*scrapper.UserLink
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int) ([]string, error)); ok {
-		return rf(user)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]*scrapper.UserLink, error)); ok {
+		return rf(ctx, userID)
 	}
-	if rf, ok := ret.Get(0).(func(int) []string); ok {
-		r0 = rf(user)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []*scrapper.UserLink); ok {
+		r0 = rf(ctx, userID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
+			r0 = ret.Get(0).([]*scrapper.UserLink)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(user)
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -100,39 +160,40 @@ type UserRepo_AllUserLinks_Call struct {
 }
 
 // AllUserLinks is a helper method to define mock.On call
-//   - user int
-func (_e *UserRepo_Expecter) AllUserLinks(user interface{}) *UserRepo_AllUserLinks_Call {
-	return &UserRepo_AllUserLinks_Call{Call: _e.mock.On("AllUserLinks", user)}
+//   - ctx context.Context
+//   - userID int64
+func (_e *UserRepo_Expecter) AllUserLinks(ctx interface{}, userID interface{}) *UserRepo_AllUserLinks_Call {
+	return &UserRepo_AllUserLinks_Call{Call: _e.mock.On("AllUserLinks", ctx, userID)}
 }
 
-func (_c *UserRepo_AllUserLinks_Call) Run(run func(user int)) *UserRepo_AllUserLinks_Call {
+func (_c *UserRepo_AllUserLinks_Call) Run(run func(ctx context.Context, userID int64)) *UserRepo_AllUserLinks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int))
+		run(args[0].(context.Context), args[1].(int64))
 	})
 	return _c
 }
 
-func (_c *UserRepo_AllUserLinks_Call) Return(_a0 []string, _a1 error) *UserRepo_AllUserLinks_Call {
+func (_c *UserRepo_AllUserLinks_Call) Return(_a0 []*scrapper.UserLink, _a1 error) *UserRepo_AllUserLinks_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *UserRepo_AllUserLinks_Call) RunAndReturn(run func(int) ([]string, error)) *UserRepo_AllUserLinks_Call {
+func (_c *UserRepo_AllUserLinks_Call) RunAndReturn(run func(context.Context, int64) ([]*scrapper.UserLink, error)) *UserRepo_AllUserLinks_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ChangeLinkState provides a mock function with given fields: link, newState
-func (_m *UserRepo) ChangeLinkLastCheck(link string, newState string) error {
-	ret := _m.Called(link, newState)
+// ChangeLastCheckTime provides a mock function with given fields: ctx, link, checkTime
+func (_m *UserRepo) ChangeLastCheckTime(ctx context.Context, link string, checkTime time.Time) error {
+	ret := _m.Called(ctx, link, checkTime)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ChangeLinkLastCheck")
+		panic("no return value specified for ChangeLastCheckTime")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = rf(link, newState)
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, link, checkTime)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -140,46 +201,93 @@ func (_m *UserRepo) ChangeLinkLastCheck(link string, newState string) error {
 	return r0
 }
 
-// UserRepo_ChangeLinkState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangeLinkLastCheck'
-type UserRepo_ChangeLinkState_Call struct {
+// UserRepo_ChangeLastCheckTime_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ChangeLastCheckTime'
+type UserRepo_ChangeLastCheckTime_Call struct {
 	*mock.Call
 }
 
-// ChangeLinkState is a helper method to define mock.On call
+// ChangeLastCheckTime is a helper method to define mock.On call
+//   - ctx context.Context
 //   - link string
-//   - newState string
-func (_e *UserRepo_Expecter) ChangeLinkState(link interface{}, newState interface{}) *UserRepo_ChangeLinkState_Call {
-	return &UserRepo_ChangeLinkState_Call{Call: _e.mock.On("ChangeLinkLastCheck", link, newState)}
+//   - checkTime time.Time
+func (_e *UserRepo_Expecter) ChangeLastCheckTime(ctx interface{}, link interface{}, checkTime interface{}) *UserRepo_ChangeLastCheckTime_Call {
+	return &UserRepo_ChangeLastCheckTime_Call{Call: _e.mock.On("ChangeLastCheckTime", ctx, link, checkTime)}
+}
+
+func (_c *UserRepo_ChangeLastCheckTime_Call) Run(run func(ctx context.Context, link string, checkTime time.Time)) *UserRepo_ChangeLastCheckTime_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *UserRepo_ChangeLastCheckTime_Call) Return(_a0 error) *UserRepo_ChangeLastCheckTime_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepo_ChangeLastCheckTime_Call) RunAndReturn(run func(context.Context, string, time.Time) error) *UserRepo_ChangeLastCheckTime_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteUntrackedLinks provides a mock function with given fields: ctx
+func (_m *UserRepo) DeleteUntrackedLinks(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUntrackedLinks")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
 }
 
-func (_c *UserRepo_ChangeLinkState_Call) Run(run func(link string, newState string)) *UserRepo_ChangeLinkState_Call {
+// UserRepo_DeleteUntrackedLinks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUntrackedLinks'
+type UserRepo_DeleteUntrackedLinks_Call struct {
+	*mock.Call
+}
+
+// DeleteUntrackedLinks is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *UserRepo_Expecter) DeleteUntrackedLinks(ctx interface{}) *UserRepo_DeleteUntrackedLinks_Call {
+	return &UserRepo_DeleteUntrackedLinks_Call{Call: _e.mock.On("DeleteUntrackedLinks", ctx)}
+}
+
+func (_c *UserRepo_DeleteUntrackedLinks_Call) Run(run func(ctx context.Context)) *UserRepo_DeleteUntrackedLinks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *UserRepo_ChangeLinkState_Call) Return(_a0 error) *UserRepo_ChangeLinkState_Call {
+func (_c *UserRepo_DeleteUntrackedLinks_Call) Return(_a0 error) *UserRepo_DeleteUntrackedLinks_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *UserRepo_ChangeLinkState_Call) RunAndReturn(run func(string, string) error) *UserRepo_ChangeLinkState_Call {
+func (_c *UserRepo_DeleteUntrackedLinks_Call) RunAndReturn(run func(context.Context) error) *UserRepo_DeleteUntrackedLinks_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteUser provides a mock function with given fields: userID
-func (_m *UserRepo) DeleteUser(userID int) error {
-	ret := _m.Called(userID)
+// DeleteUser provides a mock function with given fields: ctx, user
+func (_m *UserRepo) DeleteUser(ctx context.Context, user int64) error {
+	ret := _m.Called(ctx, user)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteUser")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int) error); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, user)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -193,14 +301,15 @@ type UserRepo_DeleteUser_Call struct {
 }
 
 // DeleteUser is a helper method to define mock.On call
-//   - userID int
-func (_e *UserRepo_Expecter) DeleteUser(userID interface{}) *UserRepo_DeleteUser_Call {
-	return &UserRepo_DeleteUser_Call{Call: _e.mock.On("DeleteUser", userID)}
+//   - ctx context.Context
+//   - user int64
+func (_e *UserRepo_Expecter) DeleteUser(ctx interface{}, user interface{}) *UserRepo_DeleteUser_Call {
+	return &UserRepo_DeleteUser_Call{Call: _e.mock.On("DeleteUser", ctx, user)}
 }
 
-func (_c *UserRepo_DeleteUser_Call) Run(run func(userID int)) *UserRepo_DeleteUser_Call {
+func (_c *UserRepo_DeleteUser_Call) Run(run func(ctx context.Context, user int64)) *UserRepo_DeleteUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int))
+		run(args[0].(context.Context), args[1].(int64))
 	})
 	return _c
 }
@@ -210,32 +319,32 @@ func (_c *UserRepo_DeleteUser_Call) Return(_a0 error) *UserRepo_DeleteUser_Call
 	return _c
 }
 
-func (_c *UserRepo_DeleteUser_Call) RunAndReturn(run func(int) error) *UserRepo_DeleteUser_Call {
+func (_c *UserRepo_DeleteUser_Call) RunAndReturn(run func(context.Context, int64) error) *UserRepo_DeleteUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// LinkState provides a mock function with given fields: link
-func (_m *UserRepo) LinkLastCheck(link string) (string, error) {
-	ret := _m.Called(link)
+// ExtendLinkLease provides a mock function with given fields: ctx, linkInfo
+func (_m *UserRepo) ExtendLinkLease(ctx context.Context, linkInfo *scrapper.LinkInfo) (bool, error) {
+	ret := _m.Called(ctx, linkInfo)
 
 	if len(ret) == 0 {
-		panic("no return value specified for LinkUpdates")
+		panic("no return value specified for ExtendLinkLease")
 	}
 
-	var r0 string
+	var r0 bool
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
-		return rf(link)
+	if rf, ok := ret.Get(0).(func(context.Context, *scrapper.LinkInfo) (bool, error)); ok {
+		return rf(ctx, linkInfo)
 	}
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(link)
+	if rf, ok := ret.Get(0).(func(context.Context, *scrapper.LinkInfo) bool); ok {
+		r0 = rf(ctx, linkInfo)
 	} else {
-		r0 = ret.Get(0).(string)
+		r0 = ret.Get(0).(bool)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(link)
+	if rf, ok := ret.Get(1).(func(context.Context, *scrapper.LinkInfo) error); ok {
+		r1 = rf(ctx, linkInfo)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -243,45 +352,152 @@ func (_m *UserRepo) LinkLastCheck(link string) (string, error) {
 	return r0, r1
 }
 
-// UserRepo_LinkState_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LinkUpdates'
-type UserRepo_LinkState_Call struct {
+// UserRepo_ExtendLinkLease_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExtendLinkLease'
+type UserRepo_ExtendLinkLease_Call struct {
 	*mock.Call
 }
 
-// LinkState is a helper method to define mock.On call
-//   - link string
-func (_e *UserRepo_Expecter) LinkState(link interface{}) *UserRepo_LinkState_Call {
-	return &UserRepo_LinkState_Call{Call: _e.mock.On("LinkUpdates", link)}
+// ExtendLinkLease is a helper method to define mock.On call
+//   - ctx context.Context
+//   - linkInfo *scrapper.LinkInfo
+func (_e *UserRepo_Expecter) ExtendLinkLease(ctx interface{}, linkInfo interface{}) *UserRepo_ExtendLinkLease_Call {
+	return &UserRepo_ExtendLinkLease_Call{Call: _e.mock.On("ExtendLinkLease", ctx, linkInfo)}
+}
+
+func (_c *UserRepo_ExtendLinkLease_Call) Run(run func(ctx context.Context, linkInfo *scrapper.LinkInfo)) *UserRepo_ExtendLinkLease_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*scrapper.LinkInfo))
+	})
+	return _c
+}
+
+func (_c *UserRepo_ExtendLinkLease_Call) Return(_a0 bool, _a1 error) *UserRepo_ExtendLinkLease_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepo_ExtendLinkLease_Call) RunAndReturn(run func(context.Context, *scrapper.LinkInfo) (bool, error)) *UserRepo_ExtendLinkLease_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LinkCursors provides a mock function with given fields: ctx, linkID
+func (_m *UserRepo) LinkCursors(ctx context.Context, linkID int64) (map[string]*scrapper.Cursor, error) {
+	ret := _m.Called(ctx, linkID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LinkCursors")
+	}
+
+	var r0 map[string]*scrapper.Cursor
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (map[string]*scrapper.Cursor, error)); ok {
+		return rf(ctx, linkID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) map[string]*scrapper.Cursor); ok {
+		r0 = rf(ctx, linkID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]*scrapper.Cursor)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, linkID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-func (_c *UserRepo_LinkState_Call) Run(run func(link string)) *UserRepo_LinkState_Call {
+// UserRepo_LinkCursors_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LinkCursors'
+type UserRepo_LinkCursors_Call struct {
+	*mock.Call
+}
+
+// LinkCursors is a helper method to define mock.On call
+//   - ctx context.Context
+//   - linkID int64
+func (_e *UserRepo_Expecter) LinkCursors(ctx interface{}, linkID interface{}) *UserRepo_LinkCursors_Call {
+	return &UserRepo_LinkCursors_Call{Call: _e.mock.On("LinkCursors", ctx, linkID)}
+}
+
+func (_c *UserRepo_LinkCursors_Call) Run(run func(ctx context.Context, linkID int64)) *UserRepo_LinkCursors_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(int64))
 	})
 	return _c
 }
 
-func (_c *UserRepo_LinkState_Call) Return(_a0 string, _a1 error) *UserRepo_LinkState_Call {
+func (_c *UserRepo_LinkCursors_Call) Return(_a0 map[string]*scrapper.Cursor, _a1 error) *UserRepo_LinkCursors_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *UserRepo_LinkState_Call) RunAndReturn(run func(string) (string, error)) *UserRepo_LinkState_Call {
+func (_c *UserRepo_LinkCursors_Call) RunAndReturn(run func(context.Context, int64) (map[string]*scrapper.Cursor, error)) *UserRepo_LinkCursors_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewLinksPaginator provides a mock function with no fields
+func (_m *UserRepo) NewLinksPaginator() scrapservice.LinkPaginator {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for NewLinksPaginator")
+	}
+
+	var r0 scrapservice.LinkPaginator
+	if rf, ok := ret.Get(0).(func() scrapservice.LinkPaginator); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(scrapservice.LinkPaginator)
+		}
+	}
+
+	return r0
+}
+
+// UserRepo_NewLinksPaginator_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NewLinksPaginator'
+type UserRepo_NewLinksPaginator_Call struct {
+	*mock.Call
+}
+
+// NewLinksPaginator is a helper method to define mock.On call
+func (_e *UserRepo_Expecter) NewLinksPaginator() *UserRepo_NewLinksPaginator_Call {
+	return &UserRepo_NewLinksPaginator_Call{Call: _e.mock.On("NewLinksPaginator")}
+}
+
+func (_c *UserRepo_NewLinksPaginator_Call) Run(run func()) *UserRepo_NewLinksPaginator_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *UserRepo_NewLinksPaginator_Call) Return(_a0 scrapservice.LinkPaginator) *UserRepo_NewLinksPaginator_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepo_NewLinksPaginator_Call) RunAndReturn(run func() scrapservice.LinkPaginator) *UserRepo_NewLinksPaginator_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RegUser provides a mock function with given fields: userID
-func (_m *UserRepo) RegUser(userID int) error {
-	ret := _m.Called(userID)
+// RegUser provides a mock function with given fields: ctx, UserID
+func (_m *UserRepo) RegUser(ctx context.Context, UserID int64) error {
+	ret := _m.Called(ctx, UserID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for RegUser")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int) error); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, UserID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -295,14 +511,15 @@ type UserRepo_RegUser_Call struct {
 }
 
 // RegUser is a helper method to define mock.On call
-//   - userID int
-func (_e *UserRepo_Expecter) RegUser(userID interface{}) *UserRepo_RegUser_Call {
-	return &UserRepo_RegUser_Call{Call: _e.mock.On("RegUser", userID)}
+//   - ctx context.Context
+//   - UserID int64
+func (_e *UserRepo_Expecter) RegUser(ctx interface{}, UserID interface{}) *UserRepo_RegUser_Call {
+	return &UserRepo_RegUser_Call{Call: _e.mock.On("RegUser", ctx, UserID)}
 }
 
-func (_c *UserRepo_RegUser_Call) Run(run func(userID int)) *UserRepo_RegUser_Call {
+func (_c *UserRepo_RegUser_Call) Run(run func(ctx context.Context, UserID int64)) *UserRepo_RegUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int))
+		run(args[0].(context.Context), args[1].(int64))
 	})
 	return _c
 }
@@ -312,22 +529,167 @@ func (_c *UserRepo_RegUser_Call) Return(_a0 error) *UserRepo_RegUser_Call {
 	return _c
 }
 
-func (_c *UserRepo_RegUser_Call) RunAndReturn(run func(int) error) *UserRepo_RegUser_Call {
+func (_c *UserRepo_RegUser_Call) RunAndReturn(run func(context.Context, int64) error) *UserRepo_RegUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// TrackLink provides a mock function with given fields: userID, userLink, initialState
-func (_m *UserRepo) TrackLink(userID int, userLink string, initialState string) error {
-	ret := _m.Called(userID, userLink, initialState)
+// SaveLinkCursors provides a mock function with given fields: ctx, linkID, cursors
+func (_m *UserRepo) SaveLinkCursors(ctx context.Context, linkID int64, cursors map[string]*scrapper.Cursor) error {
+	ret := _m.Called(ctx, linkID, cursors)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveLinkCursors")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, map[string]*scrapper.Cursor) error); ok {
+		r0 = rf(ctx, linkID, cursors)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepo_SaveLinkCursors_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveLinkCursors'
+type UserRepo_SaveLinkCursors_Call struct {
+	*mock.Call
+}
+
+// SaveLinkCursors is a helper method to define mock.On call
+//   - ctx context.Context
+//   - linkID int64
+//   - cursors map[string]*scrapper.Cursor
+func (_e *UserRepo_Expecter) SaveLinkCursors(ctx interface{}, linkID interface{}, cursors interface{}) *UserRepo_SaveLinkCursors_Call {
+	return &UserRepo_SaveLinkCursors_Call{Call: _e.mock.On("SaveLinkCursors", ctx, linkID, cursors)}
+}
+
+func (_c *UserRepo_SaveLinkCursors_Call) Run(run func(ctx context.Context, linkID int64, cursors map[string]*scrapper.Cursor)) *UserRepo_SaveLinkCursors_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(map[string]*scrapper.Cursor))
+	})
+	return _c
+}
+
+func (_c *UserRepo_SaveLinkCursors_Call) Return(_a0 error) *UserRepo_SaveLinkCursors_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepo_SaveLinkCursors_Call) RunAndReturn(run func(context.Context, int64, map[string]*scrapper.Cursor) error) *UserRepo_SaveLinkCursors_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ScheduleNextCheck provides a mock function with given fields: ctx, linkID, schedule
+func (_m *UserRepo) ScheduleNextCheck(ctx context.Context, linkID int64, schedule *scrapper.LinkSchedule) error {
+	ret := _m.Called(ctx, linkID, schedule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScheduleNextCheck")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *scrapper.LinkSchedule) error); ok {
+		r0 = rf(ctx, linkID, schedule)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepo_ScheduleNextCheck_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ScheduleNextCheck'
+type UserRepo_ScheduleNextCheck_Call struct {
+	*mock.Call
+}
+
+// ScheduleNextCheck is a helper method to define mock.On call
+//   - ctx context.Context
+//   - linkID int64
+//   - schedule *scrapper.LinkSchedule
+func (_e *UserRepo_Expecter) ScheduleNextCheck(ctx interface{}, linkID interface{}, schedule interface{}) *UserRepo_ScheduleNextCheck_Call {
+	return &UserRepo_ScheduleNextCheck_Call{Call: _e.mock.On("ScheduleNextCheck", ctx, linkID, schedule)}
+}
+
+func (_c *UserRepo_ScheduleNextCheck_Call) Run(run func(ctx context.Context, linkID int64, schedule *scrapper.LinkSchedule)) *UserRepo_ScheduleNextCheck_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(*scrapper.LinkSchedule))
+	})
+	return _c
+}
+
+func (_c *UserRepo_ScheduleNextCheck_Call) Return(_a0 error) *UserRepo_ScheduleNextCheck_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepo_ScheduleNextCheck_Call) RunAndReturn(run func(context.Context, int64, *scrapper.LinkSchedule) error) *UserRepo_ScheduleNextCheck_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetCheckInterval provides a mock function with given fields: ctx, userID, link, interval
+func (_m *UserRepo) SetCheckInterval(ctx context.Context, userID int64, link string, interval time.Duration) error {
+	ret := _m.Called(ctx, userID, link, interval)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetCheckInterval")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, time.Duration) error); ok {
+		r0 = rf(ctx, userID, link, interval)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepo_SetCheckInterval_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetCheckInterval'
+type UserRepo_SetCheckInterval_Call struct {
+	*mock.Call
+}
+
+// SetCheckInterval is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - link string
+//   - interval time.Duration
+func (_e *UserRepo_Expecter) SetCheckInterval(ctx interface{}, userID interface{}, link interface{}, interval interface{}) *UserRepo_SetCheckInterval_Call {
+	return &UserRepo_SetCheckInterval_Call{Call: _e.mock.On("SetCheckInterval", ctx, userID, link, interval)}
+}
+
+func (_c *UserRepo_SetCheckInterval_Call) Run(run func(ctx context.Context, userID int64, link string, interval time.Duration)) *UserRepo_SetCheckInterval_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *UserRepo_SetCheckInterval_Call) Return(_a0 error) *UserRepo_SetCheckInterval_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepo_SetCheckInterval_Call) RunAndReturn(run func(context.Context, int64, string, time.Duration) error) *UserRepo_SetCheckInterval_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TrackLink provides a mock function with given fields: ctx, userID, link, update
+func (_m *UserRepo) TrackLink(ctx context.Context, userID int64, link string, update time.Time) error {
+	ret := _m.Called(ctx, userID, link, update)
 
 	if len(ret) == 0 {
 		panic("no return value specified for TrackLink")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int, string, string) error); ok {
-		r0 = rf(userID, userLink, initialState)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, time.Time) error); ok {
+		r0 = rf(ctx, userID, link, update)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -341,16 +703,17 @@ type UserRepo_TrackLink_Call struct {
 }
 
 // TrackLink is a helper method to define mock.On call
-//   - userID int
-//   - userLink string
-//   - initialState string
-func (_e *UserRepo_Expecter) TrackLink(userID interface{}, userLink interface{}, initialState interface{}) *UserRepo_TrackLink_Call {
-	return &UserRepo_TrackLink_Call{Call: _e.mock.On("TrackLink", userID, userLink, initialState)}
+//   - ctx context.Context
+//   - userID int64
+//   - link string
+//   - update time.Time
+func (_e *UserRepo_Expecter) TrackLink(ctx interface{}, userID interface{}, link interface{}, update interface{}) *UserRepo_TrackLink_Call {
+	return &UserRepo_TrackLink_Call{Call: _e.mock.On("TrackLink", ctx, userID, link, update)}
 }
 
-func (_c *UserRepo_TrackLink_Call) Run(run func(userID int, userLink string, initialState string)) *UserRepo_TrackLink_Call {
+func (_c *UserRepo_TrackLink_Call) Run(run func(ctx context.Context, userID int64, link string, update time.Time)) *UserRepo_TrackLink_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(int64), args[2].(string), args[3].(time.Time))
 	})
 	return _c
 }
@@ -360,22 +723,22 @@ func (_c *UserRepo_TrackLink_Call) Return(_a0 error) *UserRepo_TrackLink_Call {
 	return _c
 }
 
-func (_c *UserRepo_TrackLink_Call) RunAndReturn(run func(int, string, string) error) *UserRepo_TrackLink_Call {
+func (_c *UserRepo_TrackLink_Call) RunAndReturn(run func(context.Context, int64, string, time.Time) error) *UserRepo_TrackLink_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UntrackLink provides a mock function with given fields: user, link
-func (_m *UserRepo) UntrackLink(user int, link string) error {
-	ret := _m.Called(user, link)
+// UntrackLink provides a mock function with given fields: ctx, user, link
+func (_m *UserRepo) UntrackLink(ctx context.Context, user int64, link string) error {
+	ret := _m.Called(ctx, user, link)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UntrackLink")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int, string) error); ok {
-		r0 = rf(user, link)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, user, link)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -389,15 +752,16 @@ type UserRepo_UntrackLink_Call struct {
 }
 
 // UntrackLink is a helper method to define mock.On call
-//   - user int
+//   - ctx context.Context
+//   - user int64
 //   - link string
-func (_e *UserRepo_Expecter) UntrackLink(user interface{}, link interface{}) *UserRepo_UntrackLink_Call {
-	return &UserRepo_UntrackLink_Call{Call: _e.mock.On("UntrackLink", user, link)}
+func (_e *UserRepo_Expecter) UntrackLink(ctx interface{}, user interface{}, link interface{}) *UserRepo_UntrackLink_Call {
+	return &UserRepo_UntrackLink_Call{Call: _e.mock.On("UntrackLink", ctx, user, link)}
 }
 
-func (_c *UserRepo_UntrackLink_Call) Run(run func(user int, link string)) *UserRepo_UntrackLink_Call {
+func (_c *UserRepo_UntrackLink_Call) Run(run func(ctx context.Context, user int64, link string)) *UserRepo_UntrackLink_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int), args[1].(string))
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
 	})
 	return _c
 }
@@ -407,27 +771,37 @@ func (_c *UserRepo_UntrackLink_Call) Return(_a0 error) *UserRepo_UntrackLink_Cal
 	return _c
 }
 
-func (_c *UserRepo_UntrackLink_Call) RunAndReturn(run func(int, string) error) *UserRepo_UntrackLink_Call {
+func (_c *UserRepo_UntrackLink_Call) RunAndReturn(run func(context.Context, int64, string) error) *UserRepo_UntrackLink_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UserExist provides a mock function with given fields: userID
-func (_m *UserRepo) UserExist(userID int) bool {
-	ret := _m.Called(userID)
+// UserExist provides a mock function with given fields: ctx, UserID
+func (_m *UserRepo) UserExist(ctx context.Context, UserID int64) (bool, error) {
+	ret := _m.Called(ctx, UserID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UserExist")
 	}
 
 	var r0 bool
-	if rf, ok := ret.Get(0).(func(int) bool); ok {
-		r0 = rf(userID)
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (bool, error)); ok {
+		return rf(ctx, UserID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) bool); ok {
+		r0 = rf(ctx, UserID)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, UserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // UserRepo_UserExist_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserExist'
@@ -436,44 +810,55 @@ type UserRepo_UserExist_Call struct {
 }
 
 // UserExist is a helper method to define mock.On call
-//   - userID int
-func (_e *UserRepo_Expecter) UserExist(userID interface{}) *UserRepo_UserExist_Call {
-	return &UserRepo_UserExist_Call{Call: _e.mock.On("UserExist", userID)}
+//   - ctx context.Context
+//   - UserID int64
+func (_e *UserRepo_Expecter) UserExist(ctx interface{}, UserID interface{}) *UserRepo_UserExist_Call {
+	return &UserRepo_UserExist_Call{Call: _e.mock.On("UserExist", ctx, UserID)}
 }
 
-func (_c *UserRepo_UserExist_Call) Run(run func(userID int)) *UserRepo_UserExist_Call {
+func (_c *UserRepo_UserExist_Call) Run(run func(ctx context.Context, UserID int64)) *UserRepo_UserExist_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int))
+		run(args[0].(context.Context), args[1].(int64))
 	})
 	return _c
 }
 
-func (_c *UserRepo_UserExist_Call) Return(_a0 bool) *UserRepo_UserExist_Call {
-	_c.Call.Return(_a0)
+func (_c *UserRepo_UserExist_Call) Return(_a0 bool, _a1 error) *UserRepo_UserExist_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *UserRepo_UserExist_Call) RunAndReturn(run func(int) bool) *UserRepo_UserExist_Call {
+func (_c *UserRepo_UserExist_Call) RunAndReturn(run func(context.Context, int64) (bool, error)) *UserRepo_UserExist_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UserTrackLink provides a mock function with given fields: user, link
-func (_m *UserRepo) UserTrackLink(user int, link string) bool {
-	ret := _m.Called(user, link)
+// UserTrackLink provides a mock function with given fields: ctx, userID, URL
+func (_m *UserRepo) UserTrackLink(ctx context.Context, userID int64, URL string) (bool, error) {
+	ret := _m.Called(ctx, userID, URL)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UserTrackLink")
 	}
 
 	var r0 bool
-	if rf, ok := ret.Get(0).(func(int, string) bool); ok {
-		r0 = rf(user, link)
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) (bool, error)); ok {
+		return rf(ctx, userID, URL)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) bool); ok {
+		r0 = rf(ctx, userID, URL)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, userID, URL)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // UserRepo_UserTrackLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserTrackLink'
@@ -482,47 +867,117 @@ type UserRepo_UserTrackLink_Call struct {
 }
 
 // UserTrackLink is a helper method to define mock.On call
-//   - user int
-//   - link string
-func (_e *UserRepo_Expecter) UserTrackLink(user interface{}, link interface{}) *UserRepo_UserTrackLink_Call {
-	return &UserRepo_UserTrackLink_Call{Call: _e.mock.On("UserTrackLink", user, link)}
+//   - ctx context.Context
+//   - userID int64
+//   - URL string
+func (_e *UserRepo_Expecter) UserTrackLink(ctx interface{}, userID interface{}, URL interface{}) *UserRepo_UserTrackLink_Call {
+	return &UserRepo_UserTrackLink_Call{Call: _e.mock.On("UserTrackLink", ctx, userID, URL)}
 }
 
-func (_c *UserRepo_UserTrackLink_Call) Run(run func(user int, link string)) *UserRepo_UserTrackLink_Call {
+func (_c *UserRepo_UserTrackLink_Call) Run(run func(ctx context.Context, userID int64, URL string)) *UserRepo_UserTrackLink_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int), args[1].(string))
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
 	})
 	return _c
 }
 
-func (_c *UserRepo_UserTrackLink_Call) Return(_a0 bool) *UserRepo_UserTrackLink_Call {
-	_c.Call.Return(_a0)
+func (_c *UserRepo_UserTrackLink_Call) Return(_a0 bool, _a1 error) *UserRepo_UserTrackLink_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *UserRepo_UserTrackLink_Call) RunAndReturn(run func(int, string) bool) *UserRepo_UserTrackLink_Call {
+func (_c *UserRepo_UserTrackLink_Call) RunAndReturn(run func(context.Context, int64, string) (bool, error)) *UserRepo_UserTrackLink_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UsersWhoTrackLink provides a mock function with given fields: userLink
-func (_m *UserRepo) UsersWhoTrackLink(userLink string) []int {
-	ret := _m.Called(userLink)
+// UsersFilters provides a mock function with given fields: ctx, linkID
+func (_m *UserRepo) UsersFilters(ctx context.Context, linkID int64) (map[int64][]string, error) {
+	ret := _m.Called(ctx, linkID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UsersFilters")
+	}
+
+	var r0 map[int64][]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (map[int64][]string, error)); ok {
+		return rf(ctx, linkID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) map[int64][]string); ok {
+		r0 = rf(ctx, linkID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int64][]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, linkID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepo_UsersFilters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UsersFilters'
+type UserRepo_UsersFilters_Call struct {
+	*mock.Call
+}
+
+// UsersFilters is a helper method to define mock.On call
+//   - ctx context.Context
+//   - linkID int64
+func (_e *UserRepo_Expecter) UsersFilters(ctx interface{}, linkID interface{}) *UserRepo_UsersFilters_Call {
+	return &UserRepo_UsersFilters_Call{Call: _e.mock.On("UsersFilters", ctx, linkID)}
+}
+
+func (_c *UserRepo_UsersFilters_Call) Run(run func(ctx context.Context, linkID int64)) *UserRepo_UsersFilters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *UserRepo_UsersFilters_Call) Return(_a0 map[int64][]string, _a1 error) *UserRepo_UsersFilters_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepo_UsersFilters_Call) RunAndReturn(run func(context.Context, int64) (map[int64][]string, error)) *UserRepo_UsersFilters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UsersWhoTrackLink provides a mock function with given fields: ctx, linkID
+func (_m *UserRepo) UsersWhoTrackLink(ctx context.Context, linkID int64) ([]int64, error) {
+	ret := _m.Called(ctx, linkID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UsersWhoTrackLink")
 	}
 
-	var r0 []int
-	if rf, ok := ret.Get(0).(func(string) []int); ok {
-		r0 = rf(userLink)
+	var r0 []int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]int64, error)); ok {
+		return rf(ctx, linkID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []int64); ok {
+		r0 = rf(ctx, linkID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]int)
+			r0 = ret.Get(0).([]int64)
 		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, linkID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // UserRepo_UsersWhoTrackLink_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UsersWhoTrackLink'
@@ -531,24 +986,25 @@ type UserRepo_UsersWhoTrackLink_Call struct {
 }
 
 // UsersWhoTrackLink is a helper method to define mock.On call
-//   - userLink string
-func (_e *UserRepo_Expecter) UsersWhoTrackLink(userLink interface{}) *UserRepo_UsersWhoTrackLink_Call {
-	return &UserRepo_UsersWhoTrackLink_Call{Call: _e.mock.On("UsersWhoTrackLink", userLink)}
+//   - ctx context.Context
+//   - linkID int64
+func (_e *UserRepo_Expecter) UsersWhoTrackLink(ctx interface{}, linkID interface{}) *UserRepo_UsersWhoTrackLink_Call {
+	return &UserRepo_UsersWhoTrackLink_Call{Call: _e.mock.On("UsersWhoTrackLink", ctx, linkID)}
 }
 
-func (_c *UserRepo_UsersWhoTrackLink_Call) Run(run func(userLink string)) *UserRepo_UsersWhoTrackLink_Call {
+func (_c *UserRepo_UsersWhoTrackLink_Call) Run(run func(ctx context.Context, linkID int64)) *UserRepo_UsersWhoTrackLink_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(int64))
 	})
 	return _c
 }
 
-func (_c *UserRepo_UsersWhoTrackLink_Call) Return(_a0 []int) *UserRepo_UsersWhoTrackLink_Call {
-	_c.Call.Return(_a0)
+func (_c *UserRepo_UsersWhoTrackLink_Call) Return(_a0 []int64, _a1 error) *UserRepo_UsersWhoTrackLink_Call {
+	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *UserRepo_UsersWhoTrackLink_Call) RunAndReturn(run func(string) []int) *UserRepo_UsersWhoTrackLink_Call {
+func (_c *UserRepo_UsersWhoTrackLink_Call) RunAndReturn(run func(context.Context, int64) ([]int64, error)) *UserRepo_UsersWhoTrackLink_Call {
 	_c.Call.Return(run)
 	return _c
 }
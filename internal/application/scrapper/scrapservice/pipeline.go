@@ -0,0 +1,64 @@
+package scrapservice
+
+import (
+	"linkTraccer/internal/domain/scrapper"
+
+	"golang.org/x/time/rate"
+)
+
+type HostBudget struct {
+	Concurrency int
+	RPS         float64 // нулевой RPS не ограничивает частоту запросов
+}
+
+type PipelineConfig struct {
+	QueueSize     int // размер очереди каждого хоста
+	Hosts         map[string]HostBudget
+	DefaultBudget HostBudget // для хостов, которых нет в Hosts
+}
+
+// hostLimiter общий для всех циклов проверки, чтобы RPS хоста не превышался между циклами.
+type hostLimiter struct {
+	workers int
+	limiter *rate.Limiter
+}
+
+func newHostLimiter(budget HostBudget) *hostLimiter {
+	limit := rate.Inf
+	if budget.RPS > 0 {
+		limit = rate.Limit(budget.RPS)
+	}
+
+	return &hostLimiter{
+		workers: max(1, budget.Concurrency),
+		limiter: rate.NewLimiter(limit, max(1, int(budget.RPS))),
+	}
+}
+
+// linkCheck - ссылка в очереди хоста вместе с клиентом, который ее проверит.
+type linkCheck struct {
+	linkInfo   *scrapper.LinkInfo
+	siteClient SiteClient
+}
+
+// hostLimiters выделяет клиентам одного хоста общий бюджет.
+func hostLimiters(config *PipelineConfig, siteClients []SiteClient) map[string]*hostLimiter {
+	limiters := make(map[string]*hostLimiter, len(siteClients))
+
+	for _, siteClient := range siteClients {
+		host := siteClient.Host()
+
+		if _, ok := limiters[host]; ok {
+			continue
+		}
+
+		budget, ok := config.Hosts[host]
+		if !ok {
+			budget = config.DefaultBudget
+		}
+
+		limiters[host] = newHostLimiter(budget)
+	}
+
+	return limiters
+}
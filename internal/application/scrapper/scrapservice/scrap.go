@@ -2,6 +2,7 @@ package scrapservice
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"linkTraccer/internal/domain/scrapper"
 	"log/slog"
@@ -9,139 +10,287 @@ import (
 	"time"
 )
 
-const (
-	workersNum = 4
-)
-
-var (
-	MoskowTime = time.FixedZone("UTC+3", 3*60*60)
-)
-
 type LinkPaginator interface {
-	LinksBatch() ([]*scrapper.LinkInfo, error)
+	LinksBatch(ctx context.Context) ([]*scrapper.LinkInfo, error)
 	HasLinks() bool
 }
 
 type UserRepo interface {
 	NewLinksPaginator() LinkPaginator
 	TrackLink(ctx context.Context, userID scrapper.User, link scrapper.Link, update time.Time) error
-	ChangeLastCheckTime(link scrapper.Link, checkTime time.Time) error
-	UsersWhoTrackLink(linkID scrapper.LinkID) ([]scrapper.User, error)
-	AllUserLinks(userID scrapper.User) ([]scrapper.Link, error)
-	UserTrackLink(userID scrapper.User, URL scrapper.Link) (bool, error)
-	UntrackLink(user scrapper.User, link scrapper.Link) error
-	UserExist(UserID scrapper.User) (bool, error)
-	RegUser(UserID scrapper.User) error
+	ChangeLastCheckTime(ctx context.Context, link scrapper.Link, checkTime time.Time) error
+	ScheduleNextCheck(ctx context.Context, linkID scrapper.LinkID, schedule *scrapper.LinkSchedule) error
+	ExtendLinkLease(ctx context.Context, linkInfo *scrapper.LinkInfo) (bool, error)
+	SetCheckInterval(ctx context.Context, userID scrapper.User, link scrapper.Link, interval time.Duration) error
+	LinkCursors(ctx context.Context, linkID scrapper.LinkID) (map[scrapper.UpdateType]*scrapper.Cursor, error)
+	SaveLinkCursors(ctx context.Context, linkID scrapper.LinkID, cursors map[scrapper.UpdateType]*scrapper.Cursor) error
+	UsersWhoTrackLink(ctx context.Context, linkID scrapper.LinkID) ([]scrapper.User, error)
+	UsersFilters(ctx context.Context, linkID scrapper.LinkID) (map[scrapper.User][]scrapper.Filter, error)
+	AddLinkTags(ctx context.Context, userID scrapper.User, link scrapper.Link, tags []scrapper.Tag) error
+	AddLinkFilters(ctx context.Context, userID scrapper.User, link scrapper.Link, filters []scrapper.Filter) error
+	AllUserLinks(ctx context.Context, userID scrapper.User) ([]*scrapper.UserLink, error)
+	UserTrackLink(ctx context.Context, userID scrapper.User, URL scrapper.Link) (bool, error)
+	UntrackLink(ctx context.Context, user scrapper.User, link scrapper.Link) error
+	UserExist(ctx context.Context, UserID scrapper.User) (bool, error)
+	RegUser(ctx context.Context, UserID scrapper.User) error
 	DeleteUser(ctx context.Context, user scrapper.User) error
+	DeleteUntrackedLinks(ctx context.Context) error
 }
 
+// SiteClient получает бюджет запросов по Host, а Supports проверяет ссылку без запросов к сайту.
 type SiteClient interface {
-	CanTrack(link scrapper.Link) bool
-	LinkUpdates(link scrapper.Link, updatesSince time.Time) (scrapper.LinkUpdates, error)
+	Host() string
+	Supports(link scrapper.Link) bool
+	LinkUpdates(ctx context.Context, link scrapper.Link, cursors *scrapper.LinkCursors) (scrapper.LinkUpdates, error)
+}
+
+// RetryLater - сайт просит проверить ссылку не раньше RetryAt.
+type RetryLater interface {
+	error
+	RetryAt() time.Time
 }
 
 type NotifyService interface {
-	SendUpdates(linkInfo *scrapper.LinkInfo, linkUpdates scrapper.LinkUpdates) error
+	SendUpdate(ctx context.Context, linkInfo *scrapper.LinkInfo, linkUpdate *scrapper.LinkUpdate, users []scrapper.User) error
 }
 
 type FilterService interface {
-	FilterByCreator()
+	Users(ctx context.Context, linkInfo *scrapper.LinkInfo,
+		linkUpdates scrapper.LinkUpdates) (map[*scrapper.LinkUpdate][]scrapper.User, error)
 }
 
 type Transactor interface {
 	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
+// Scrapper проверяет наступившие ссылки конвейером: у каждого хоста API своя очередь и свои воркеры.
 type Scrapper struct {
 	userRepo      UserRepo
 	siteClients   []SiteClient
 	notifyService NotifyService
+	filterService FilterService
+	transactor    Transactor
+	policy        *scrapper.CheckPolicy
+	pipeline      *PipelineConfig
+	hosts         map[string]*hostLimiter
 	log           *slog.Logger
 }
 
-func New(userRepo UserRepo, notifyService NotifyService, log *slog.Logger, siteClients ...SiteClient) *Scrapper {
+func New(userRepo UserRepo, notifyService NotifyService, filterService FilterService, transactor Transactor,
+	policy *scrapper.CheckPolicy, pipeline *PipelineConfig, log *slog.Logger, siteClients ...SiteClient) *Scrapper {
 	return &Scrapper{
 		userRepo:      userRepo,
 		notifyService: notifyService,
+		filterService: filterService,
+		transactor:    transactor,
+		policy:        policy,
+		pipeline:      pipeline,
+		hosts:         hostLimiters(pipeline, siteClients),
 		siteClients:   siteClients,
 		log:           log,
 	}
 }
 
-func (scrap *Scrapper) LinksUpdates() {
+// LinksUpdates после отмены ctx дожидается начатых проверок, остальные ссылки заберет следующий цикл.
+func (scrap *Scrapper) LinksUpdates(ctx context.Context) {
+	queues := make(map[string]chan linkCheck, len(scrap.hosts))
+
+	wg := &sync.WaitGroup{}
+
+	for host, limiter := range scrap.hosts {
+		queues[host] = make(chan linkCheck, max(0, scrap.pipeline.QueueSize))
+
+		for worker := 0; worker < limiter.workers; worker++ {
+			wg.Add(1)
+
+			go scrap.checkLinks(ctx, wg, queues[host], limiter)
+		}
+	}
+
+	scrap.streamLinks(ctx, queues)
+
+	for _, queue := range queues {
+		close(queue)
+	}
+
+	wg.Wait()
+}
+
+// streamLinks не читает следующие ссылки, пока очередь хоста заполнена.
+func (scrap *Scrapper) streamLinks(ctx context.Context, queues map[string]chan linkCheck) {
 	linksPaginator := scrap.userRepo.NewLinksPaginator()
 
-	for linksPaginator.HasLinks() {
-		links, err := linksPaginator.LinksBatch()
+	for linksPaginator.HasLinks() && ctx.Err() == nil {
+		links, err := linksPaginator.LinksBatch(ctx)
 		if err != nil {
 			scrap.log.Error("ошибка при получении батча ссылок", "err", err.Error())
 
 			continue
 		}
 
-		linksChan := make(chan *scrapper.LinkInfo, len(links))
+		for _, link := range links {
+			siteClient := scrap.siteClient(link.URL)
+			if siteClient == nil {
+				scrap.log.Warn("ни один клиент не может проверить ссылку", "link", link.URL)
+
+				continue
+			}
 
-		go linksToChan(links, linksChan)
+			select {
+			case queues[siteClient.Host()] <- linkCheck{linkInfo: link, siteClient: siteClient}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
 
-		wg := &sync.WaitGroup{}
+func (scrap *Scrapper) checkLinks(ctx context.Context, wg *sync.WaitGroup, queue <-chan linkCheck, host *hostLimiter) {
+	defer wg.Done()
 
-		wg.Add(workersNum)
+	for check := range queue {
+		if err := host.limiter.Wait(ctx); err != nil {
+			continue
+		}
 
-		for worker := 0; worker < workersNum; worker++ {
-			go scrap.findUpdates(wg, linksChan)
+		if !scrap.extendLease(ctx, check.linkInfo) {
+			continue
 		}
 
-		wg.Wait()
+		scrap.checkLink(ctx, check.linkInfo, check.siteClient)
 	}
 }
 
-func linksToChan(links []*scrapper.LinkInfo, out chan<- *scrapper.LinkInfo) {
-	for _, link := range links {
-		out <- link
+// extendLease продлевает аренду ссылки, которая могла истечь, пока ссылка ждала в очереди хоста.
+// Если аренду уже забрала другая реплика, ссылку проверит она.
+func (scrap *Scrapper) extendLease(ctx context.Context, linkInfo *scrapper.LinkInfo) bool {
+	extended, err := scrap.userRepo.ExtendLinkLease(ctx, linkInfo)
+	if err != nil {
+		scrap.log.Error("ошибка при продлении аренды ссылки", "link", linkInfo.URL, "err", err.Error())
+		return false
 	}
 
-	close(out)
+	if !extended {
+		scrap.log.Warn("аренда ссылки истекла в очереди хоста", "link", linkInfo.URL)
+	}
+
+	return extended
 }
 
-func (scrap *Scrapper) findUpdates(wg *sync.WaitGroup, linksChan <-chan *scrapper.LinkInfo) {
-	defer wg.Done()
+func (scrap *Scrapper) siteClient(link scrapper.Link) SiteClient {
+	for _, siteClient := range scrap.siteClients {
+		if siteClient.Supports(link) {
+			return siteClient
+		}
+	}
 
-	for linkInfo := range linksChan {
-		for _, siteClient := range scrap.siteClients {
+	return nil
+}
 
-			if !siteClient.CanTrack(linkInfo.URL) {
-				continue
-			}
+func (scrap *Scrapper) checkLink(ctx context.Context, linkInfo *scrapper.LinkInfo, siteClient SiteClient) {
+	t := time.Now().UTC().Truncate(time.Second)
+
+	cursors, err := scrap.linkCursors(ctx, linkInfo)
+	if err != nil {
+		scrap.log.Error("ошибка при получении курсоров ссылки", "err", err.Error())
+		return
+	}
 
-			t := time.Now().In(MoskowTime).Truncate(time.Second)
+	linkUpdates, err := siteClient.LinkUpdates(ctx, linkInfo.URL, cursors)
+	if err != nil && ctx.Err() != nil {
+		return
+	}
 
-			linkUpdates, err := siteClient.LinkUpdates(linkInfo.URL, linkInfo.LastUpdate)
-			if err != nil {
-				scrap.log.Error("при получении обновлений ссылки произошла ошибка", "err", err.Error())
-				break
-			}
+	var retryLater RetryLater
 
-			if err = scrap.userRepo.ChangeLastCheckTime(linkInfo.URL, t); err != nil {
-				scrap.log.Error("ошибка при изменении даты последней проверки ссылки", "err", err.Error())
-				break
-			}
+	if errors.As(err, &retryLater) {
+		scrap.log.Warn("проверка ссылки отложена по требованию сайта", "err", err.Error())
+		scrap.deferCheck(ctx, linkInfo, retryLater.RetryAt())
 
-			if len(linkUpdates) == 0 {
-				break
-			}
+		return
+	}
 
-			scrap.log.Info(fmt.Sprintf("произошло %d обновлений по ссылке %s", len(linkUpdates), linkInfo.URL))
+	if err != nil {
+		scrap.log.Error("при получении обновлений ссылки произошла ошибка", "err", err.Error())
+		scrap.postponeCheck(ctx, linkInfo, t)
 
-			 users, err := scrap.userRepo.UsersWhoTrackLink(linkInfo.ID) делаем это в фильтре
+		return
+	}
 
-			for _, linkUpdate := range linkUpdates {
-				users, err := scrap.filter.Users(linkInfo, linkUpdates)
+	err = scrap.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		return scrap.saveUpdates(ctx, linkInfo, cursors, linkUpdates, t)
+	})
 
-				if err = scrap.notifyService.SendUpdates(linkInfo, linkUpdates); err != nil {
-					scrap.log.Error("ошибка при отправке обновлений", "err", err.Error())
-				}
+	if err != nil {
+		scrap.log.Error("ошибка при сохранении обновлений ссылки", "err", err.Error())
+	}
+}
 
-			}
+// postponeCheck откладывает следующую проверку ссылки, которую не удалось проверить.
+func (scrap *Scrapper) postponeCheck(ctx context.Context, linkInfo *scrapper.LinkInfo, now time.Time) {
+	schedule := scrap.policy.Failed(linkInfo.Schedule, now)
+
+	if err := scrap.userRepo.ScheduleNextCheck(ctx, linkInfo.ID, &schedule); err != nil {
+		scrap.log.Error("ошибка при переносе проверки ссылки", "err", err.Error())
+	}
+}
+
+// deferCheck переносит проверку на время, когда сайт снова будет принимать запросы.
+func (scrap *Scrapper) deferCheck(ctx context.Context, linkInfo *scrapper.LinkInfo, retryAt time.Time) {
+	schedule := scrap.policy.Deferred(linkInfo.Schedule, retryAt)
+
+	if err := scrap.userRepo.ScheduleNextCheck(ctx, linkInfo.ID, &schedule); err != nil {
+		scrap.log.Error("ошибка при переносе проверки ссылки", "err", err.Error())
+	}
+}
+
+// linkCursors для новых типов элементов считает обновлениями все, что появилось после прошлой проверки.
+func (scrap *Scrapper) linkCursors(ctx context.Context, linkInfo *scrapper.LinkInfo) (*scrapper.LinkCursors, error) {
+	byType, err := scrap.userRepo.LinkCursors(ctx, linkInfo.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &scrapper.LinkCursors{Since: linkInfo.LastUpdate, ByType: byType}, nil
+}
+
+// saveUpdates вызывается в транзакции, чтобы при ошибке курсоры не сдвинулись и обновления нашлись снова.
+func (scrap *Scrapper) saveUpdates(ctx context.Context, linkInfo *scrapper.LinkInfo, cursors *scrapper.LinkCursors,
+	linkUpdates scrapper.LinkUpdates, checkTime time.Time) error {
+	if err := scrap.userRepo.ChangeLastCheckTime(ctx, linkInfo.URL, checkTime); err != nil {
+		return fmt.Errorf("ошибка при изменении даты последней проверки ссылки: %w", err)
+	}
+
+	schedule := scrap.policy.Checked(linkInfo.Schedule, checkTime, len(linkUpdates))
+
+	if err := scrap.userRepo.ScheduleNextCheck(ctx, linkInfo.ID, &schedule); err != nil {
+		return fmt.Errorf("ошибка при назначении следующей проверки ссылки: %w", err)
+	}
+
+	if len(linkUpdates) == 0 {
+		return nil
+	}
+
+	if err := scrap.userRepo.SaveLinkCursors(ctx, linkInfo.ID, cursors.Advance(linkUpdates)); err != nil {
+		return fmt.Errorf("ошибка при сдвиге курсоров ссылки: %w", err)
+	}
+
+	scrap.log.Info(fmt.Sprintf("произошло %d обновлений по ссылке %s", len(linkUpdates), linkInfo.URL))
+
+	usersToNotify, err := scrap.filterService.Users(ctx, linkInfo, linkUpdates)
+	if err != nil {
+		return fmt.Errorf("ошибка при фильтрации обновлений: %w", err)
+	}
+
+	for _, linkUpdate := range linkUpdates {
+		if len(usersToNotify[linkUpdate]) == 0 {
+			continue
+		}
+
+		if err = scrap.notifyService.SendUpdate(ctx, linkInfo, linkUpdate, usersToNotify[linkUpdate]); err != nil {
+			return fmt.Errorf("ошибка при отправке обновлений: %w", err)
 		}
 	}
+
+	return nil
 }
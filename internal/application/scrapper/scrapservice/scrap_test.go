@@ -0,0 +1,298 @@
+package scrapservice_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"linkTraccer/internal/application/scrapper/scrapservice"
+	"linkTraccer/internal/application/scrapper/scrapservice/mocks"
+	"linkTraccer/internal/domain/scrapper"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	gitHubAPI = "api.github.com"
+	stackAPI  = "api.stackexchange.com"
+)
+
+var (
+	errSite    = errors.New("сайт недоступен")
+	discardLog = slog.New(slog.NewTextHandler(io.Discard, nil))
+	policy     = &scrapper.CheckPolicy{MinInterval: time.Minute, MaxInterval: time.Hour}
+)
+
+// retryLaterErr - ошибка сайта, который просит повторить запрос не раньше retryAt.
+type retryLaterErr struct {
+	retryAt time.Time
+}
+
+func (e *retryLaterErr) Error() string {
+	return "сайт ограничил частоту запросов"
+}
+
+func (e *retryLaterErr) RetryAt() time.Time {
+	return e.retryAt
+}
+
+// siteClient считает одновременные проверки своего хоста.
+type siteClient struct {
+	host    string
+	prefix  string
+	delay   time.Duration
+	wait    chan struct{}
+	err     error
+	mu      sync.Mutex
+	running int
+	peak    int
+	checked atomic.Int32
+}
+
+func (s *siteClient) Host() string {
+	return s.host
+}
+
+func (s *siteClient) Supports(link scrapper.Link) bool {
+	return strings.HasPrefix(link, s.prefix)
+}
+
+func (s *siteClient) LinkUpdates(_ context.Context, _ scrapper.Link, _ *scrapper.LinkCursors) (scrapper.LinkUpdates, error) {
+	s.mu.Lock()
+	s.running++
+	s.peak = max(s.peak, s.running)
+	s.mu.Unlock()
+
+	time.Sleep(s.delay)
+
+	if s.wait != nil {
+		<-s.wait
+	}
+
+	s.mu.Lock()
+	s.running--
+	s.mu.Unlock()
+
+	s.checked.Add(1)
+
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	return nil, errSite
+}
+
+func links(prefix string, count int) []*scrapper.LinkInfo {
+	result := make([]*scrapper.LinkInfo, 0, count)
+
+	for i := 0; i < count; i++ {
+		result = append(result, &scrapper.LinkInfo{ID: int64(i), URL: prefix + string(rune('a'+i))})
+	}
+
+	return result
+}
+
+func TestScrapper_LinksUpdatesHostBudget(t *testing.T) {
+	repo, paginator := mocks.NewUserRepo(t), mocks.NewLinkPaginator(t)
+	github := &siteClient{host: gitHubAPI, prefix: "https://github.com/", delay: 20 * time.Millisecond}
+	stack := &siteClient{host: stackAPI, prefix: "https://stackoverflow.com/", delay: 20 * time.Millisecond}
+
+	repo.On("NewLinksPaginator").Return(paginator).Once()
+	repo.On("ExtendLinkLease", mock.Anything, mock.Anything).Return(true, nil)
+	repo.On("LinkCursors", mock.Anything, mock.Anything).Return(nil, nil)
+	repo.On("ScheduleNextCheck", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	paginator.On("HasLinks").Return(true).Twice()
+	paginator.On("HasLinks").Return(false).Once()
+	paginator.On("LinksBatch", mock.Anything).
+		Return(append(links("https://github.com/", 6), links("https://stackoverflow.com/", 6)...), nil).Once()
+	paginator.On("LinksBatch", mock.Anything).Return([]*scrapper.LinkInfo{}, nil).Once()
+
+	pipeline := &scrapservice.PipelineConfig{
+		QueueSize:     2,
+		Hosts:         map[string]scrapservice.HostBudget{gitHubAPI: {Concurrency: 1}},
+		DefaultBudget: scrapservice.HostBudget{Concurrency: 3},
+	}
+
+	scrap := scrapservice.New(repo, nil, nil, nil, policy, pipeline, discardLog, github, stack)
+
+	scrap.LinksUpdates(context.Background())
+
+	assert.Equal(t, int32(6), github.checked.Load())
+	assert.Equal(t, int32(6), stack.checked.Load())
+	assert.Equal(t, 1, github.peak, "ссылки github проверяются по одной")
+	assert.LessOrEqual(t, stack.peak, 3, "бюджет хоста по умолчанию")
+	assert.Greater(t, stack.peak, 1, "медленный хост не задерживает остальные")
+}
+
+func TestScrapper_LinksUpdatesSlowHost(t *testing.T) {
+	repo, paginator := mocks.NewUserRepo(t), mocks.NewLinkPaginator(t)
+	github := &siteClient{host: gitHubAPI, prefix: "https://github.com/", wait: make(chan struct{})}
+	stack := &siteClient{host: stackAPI, prefix: "https://stackoverflow.com/"}
+
+	repo.On("NewLinksPaginator").Return(paginator).Once()
+	repo.On("ExtendLinkLease", mock.Anything, mock.Anything).Return(true, nil)
+	repo.On("LinkCursors", mock.Anything, mock.Anything).Return(nil, nil)
+	repo.On("ScheduleNextCheck", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	paginator.On("HasLinks").Return(true).Once()
+	paginator.On("HasLinks").Return(false).Once()
+	paginator.On("LinksBatch", mock.Anything).
+		Return(append(links("https://github.com/", 3), links("https://stackoverflow.com/", 4)...), nil).Once()
+
+	pipeline := &scrapservice.PipelineConfig{
+		QueueSize:     2,
+		Hosts:         map[string]scrapservice.HostBudget{gitHubAPI: {Concurrency: 1}},
+		DefaultBudget: scrapservice.HostBudget{Concurrency: 2},
+	}
+
+	scrap := scrapservice.New(repo, nil, nil, nil, policy, pipeline, discardLog, github, stack)
+	done := make(chan struct{})
+
+	go func() {
+		scrap.LinksUpdates(context.Background())
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool { return stack.checked.Load() == 4 }, time.Second, time.Millisecond,
+		"ссылки одного хоста проверяются, пока воркеры другого ждут ответа")
+	assert.Zero(t, github.checked.Load())
+
+	close(github.wait)
+	<-done
+
+	assert.Equal(t, int32(3), github.checked.Load())
+}
+
+func TestScrapper_LinksUpdatesSaturatedHostQueue(t *testing.T) {
+	repo, paginator := mocks.NewUserRepo(t), mocks.NewLinkPaginator(t)
+	github := &siteClient{host: gitHubAPI, prefix: "https://github.com/", wait: make(chan struct{})}
+	batch := links("https://github.com/", 3)
+
+	var extended atomic.Int32
+
+	countExtend := func(mock.Arguments) { extended.Add(1) }
+
+	repo.On("NewLinksPaginator").Return(paginator).Once()
+	repo.On("ExtendLinkLease", mock.Anything, batch[0]).Return(true, nil).Run(countExtend).Once()
+	repo.On("ExtendLinkLease", mock.Anything, batch[1]).Return(false, nil).Run(countExtend).Once()
+	repo.On("ExtendLinkLease", mock.Anything, batch[2]).Return(true, nil).Run(countExtend).Once()
+	repo.On("LinkCursors", mock.Anything, mock.Anything).Return(nil, nil).Twice()
+	repo.On("ScheduleNextCheck", mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+	paginator.On("HasLinks").Return(true).Once()
+	paginator.On("HasLinks").Return(false).Once()
+	paginator.On("LinksBatch", mock.Anything).Return(batch, nil).Once()
+
+	pipeline := &scrapservice.PipelineConfig{
+		QueueSize: 1,
+		Hosts:     map[string]scrapservice.HostBudget{gitHubAPI: {Concurrency: 1}},
+	}
+
+	scrap := scrapservice.New(repo, nil, nil, nil, policy, pipeline, discardLog, github)
+	done := make(chan struct{})
+
+	go func() {
+		scrap.LinksUpdates(context.Background())
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool {
+		github.mu.Lock()
+		defer github.mu.Unlock()
+
+		return github.running == 1
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, int32(1), extended.Load(), "аренда продлевается, когда воркер берет ссылку, а не при постановке в очередь")
+
+	close(github.wait)
+	<-done
+
+	assert.Equal(t, int32(3), extended.Load())
+	assert.Equal(t, int32(2), github.checked.Load(), "ссылку с истекшей в очереди арендой проверяет другая реплика")
+}
+
+func TestScrapper_LinksUpdatesCanceled(t *testing.T) {
+	repo, paginator := mocks.NewUserRepo(t), mocks.NewLinkPaginator(t)
+	github := &siteClient{host: gitHubAPI, prefix: "https://github.com/"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	cancel()
+
+	repo.On("NewLinksPaginator").Return(paginator).Once()
+	paginator.On("HasLinks").Return(true).Maybe()
+
+	pipeline := &scrapservice.PipelineConfig{QueueSize: 2}
+
+	scrap := scrapservice.New(repo, nil, nil, nil, policy, pipeline, discardLog, github)
+
+	scrap.LinksUpdates(ctx)
+
+	assert.Zero(t, github.checked.Load(), "после отмены ссылки не читаются и не проверяются")
+}
+
+func TestScrapper_LinksUpdatesRetryLater(t *testing.T) {
+	repo, paginator := mocks.NewUserRepo(t), mocks.NewLinkPaginator(t)
+	retryAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	github := &siteClient{
+		host:   gitHubAPI,
+		prefix: "https://github.com/",
+		err:    fmt.Errorf("ошибка при запросе: %w", &retryLaterErr{retryAt: retryAt}),
+	}
+	link := &scrapper.LinkInfo{ID: 1, URL: "https://github.com/a", Schedule: scrapper.LinkSchedule{Interval: time.Minute, Failures: 1}}
+
+	repo.On("NewLinksPaginator").Return(paginator).Once()
+	repo.On("ExtendLinkLease", mock.Anything, link).Return(true, nil).Once()
+	repo.On("LinkCursors", mock.Anything, link.ID).Return(nil, nil).Once()
+	repo.On("ScheduleNextCheck", mock.Anything, link.ID,
+		&scrapper.LinkSchedule{Interval: time.Minute, Failures: 1, NextCheck: retryAt}).Return(nil).Once()
+	paginator.On("HasLinks").Return(true).Once()
+	paginator.On("HasLinks").Return(false).Once()
+	paginator.On("LinksBatch", mock.Anything).Return([]*scrapper.LinkInfo{link}, nil).Once()
+
+	pipeline := &scrapservice.PipelineConfig{QueueSize: 1}
+
+	scrap := scrapservice.New(repo, nil, nil, nil, policy, pipeline, discardLog, github)
+
+	scrap.LinksUpdates(context.Background())
+
+	assert.Equal(t, int32(1), github.checked.Load())
+}
+
+func TestScrapper_LinksUpdatesPausedHost(t *testing.T) {
+	repo, paginator := mocks.NewUserRepo(t), mocks.NewLinkPaginator(t)
+	pausedUntil := time.Now().Add(time.Hour).Truncate(time.Second)
+	github := &siteClient{host: gitHubAPI, prefix: "https://github.com/", err: &retryLaterErr{retryAt: pausedUntil}}
+	stack := &siteClient{host: stackAPI, prefix: "https://stackoverflow.com/"}
+	batch := []*scrapper.LinkInfo{
+		{ID: 1, URL: "https://github.com/a", Schedule: scrapper.LinkSchedule{Interval: time.Minute}},
+		{ID: 2, URL: "https://github.com/b", Schedule: scrapper.LinkSchedule{Interval: time.Minute}},
+		{ID: 3, URL: "https://stackoverflow.com/questions/1", Schedule: scrapper.LinkSchedule{Interval: time.Minute}},
+	}
+
+	repo.On("NewLinksPaginator").Return(paginator).Once()
+	repo.On("ExtendLinkLease", mock.Anything, mock.Anything).Return(true, nil).Times(3)
+	repo.On("LinkCursors", mock.Anything, mock.Anything).Return(nil, nil).Times(3)
+	repo.On("ScheduleNextCheck", mock.Anything, int64(1),
+		&scrapper.LinkSchedule{Interval: time.Minute, NextCheck: pausedUntil}).Return(nil).Once()
+	repo.On("ScheduleNextCheck", mock.Anything, int64(2),
+		&scrapper.LinkSchedule{Interval: time.Minute, NextCheck: pausedUntil}).Return(nil).Once()
+	repo.On("ScheduleNextCheck", mock.Anything, int64(3), mock.MatchedBy(func(schedule *scrapper.LinkSchedule) bool {
+		return schedule.Failures == 1
+	})).Return(nil).Once()
+	paginator.On("HasLinks").Return(true).Once()
+	paginator.On("HasLinks").Return(false).Once()
+	paginator.On("LinksBatch", mock.Anything).Return(batch, nil).Once()
+
+	scrap := scrapservice.New(repo, nil, nil, nil, policy, &scrapservice.PipelineConfig{QueueSize: 1}, discardLog, github, stack)
+
+	scrap.LinksUpdates(context.Background())
+
+	assert.Equal(t, int32(2), github.checked.Load(), "ссылки приостановленного хоста переносятся, а не пропускаются")
+	assert.Equal(t, int32(1), stack.checked.Load())
+}
@@ -0,0 +1,125 @@
+package singleton
+
+import (
+	"context"
+	"expvar"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Ключи метрик задачи в Stats.
+
+const (
+	StartedRuns   = "started"
+	LockedRuns    = "skipped_locked"
+	OverrunRuns   = "skipped_overrun"
+	FailedLocks   = "lock_errors"
+	LastDuration  = "last_duration_seconds"
+	OverrunCycles = "overrun_cycles"
+)
+
+// Locker не ждет блокировку: если ее держит другая реплика, TryLock возвращает false.
+type Locker interface {
+	TryLock(ctx context.Context, key string) (release func() error, acquired bool, err error)
+}
+
+// Job запускает run так, чтобы на всех репликах одновременно шел один цикл, лишние запуски пропускаются.
+type Job struct {
+	name   string
+	run    func(ctx context.Context)
+	locker Locker
+	log    *slog.Logger
+	stats  *expvar.Map
+
+	mu       sync.Mutex
+	running  bool
+	started  time.Time
+	overruns int
+}
+
+func New(name string, run func(ctx context.Context), locker Locker, log *slog.Logger) *Job {
+	return &Job{
+		name:   name,
+		run:    run,
+		locker: locker,
+		log:    log,
+		stats:  new(expvar.Map).Init(),
+	}
+}
+
+// Stats возвращает счетчики задачи, их можно опубликовать через expvar.Publish.
+func (j *Job) Stats() *expvar.Map {
+	return j.stats
+}
+
+func (j *Job) Run(ctx context.Context) {
+	if !j.start() {
+		return
+	}
+
+	defer j.finish()
+
+	release, acquired, err := j.locker.TryLock(ctx, j.name)
+	if err != nil {
+		j.stats.Add(FailedLocks, 1)
+		j.log.Error("ошибка при захвате блокировки задачи", "job", j.name, "err", err.Error())
+
+		return
+	}
+
+	if !acquired {
+		j.stats.Add(LockedRuns, 1)
+		j.log.Info("задача уже выполняется другой репликой, запуск пропущен", "job", j.name)
+
+		return
+	}
+
+	defer func() {
+		if err := release(); err != nil {
+			j.log.Error("ошибка при освобождении блокировки задачи", "job", j.name, "err", err.Error())
+		}
+	}()
+
+	j.stats.Add(StartedRuns, 1)
+
+	j.run(ctx)
+}
+
+// start отмечает начало цикла, а если предыдущий цикл еще идет, считает запуск пропущенным.
+func (j *Job) start() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.running {
+		j.overruns++
+		j.stats.Add(OverrunRuns, 1)
+		j.log.Warn("предыдущий цикл задачи еще не закончился, запуск пропущен", "job", j.name,
+			"running", time.Since(j.started).String())
+
+		return false
+	}
+
+	j.running, j.started, j.overruns = true, time.Now(), 0
+
+	return true
+}
+
+func (j *Job) finish() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	duration := time.Since(j.started)
+
+	durationVar := new(expvar.Float)
+	durationVar.Set(duration.Seconds())
+	j.stats.Set(LastDuration, durationVar)
+
+	if j.overruns > 0 {
+		j.stats.Add(OverrunCycles, 1)
+		j.log.Warn("цикл задачи не уложился до следующего запуска", "job", j.name, "duration", duration.String(),
+			"skipped", j.overruns)
+	}
+
+	j.running = false
+}
@@ -0,0 +1,100 @@
+package singleton_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"linkTraccer/internal/application/scrapper/singleton"
+	"linkTraccer/internal/application/scrapper/singleton/mocks"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const jobName = "links_updates"
+
+var discardLog = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+func TestJob_Run(t *testing.T) {
+	type TestCase struct {
+		name     string
+		acquired bool
+		lockErr  error
+		runs     int
+		metric   string
+	}
+
+	tests := []TestCase{
+		{
+			name:     "блокировка захвачена, цикл выполняется",
+			acquired: true,
+			runs:     1,
+			metric:   singleton.StartedRuns,
+		},
+		{
+			name:   "блокировку держит другая реплика, запуск пропускается",
+			metric: singleton.LockedRuns,
+		},
+		{
+			name:    "ошибка при захвате блокировки, запуск пропускается",
+			lockErr: errors.New("база недоступна"),
+			metric:  singleton.FailedLocks,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			locker := mocks.NewLocker(t)
+			runs, released := 0, false
+
+			var release func() error
+
+			if test.acquired {
+				release = func() error {
+					released = true
+					return nil
+				}
+			}
+
+			locker.On("TryLock", mock.Anything, jobName).Return(release, test.acquired, test.lockErr).Once()
+
+			job := singleton.New(jobName, func(context.Context) { runs++ }, locker, discardLog)
+
+			job.Run(context.Background())
+
+			assert.Equal(t, test.runs, runs)
+			assert.Equal(t, test.acquired, released, "блокировка освобождается после цикла")
+			assert.Equal(t, "1", job.Stats().Get(test.metric).String())
+		})
+	}
+}
+
+func TestJob_RunOverrun(t *testing.T) {
+	locker := mocks.NewLocker(t)
+	started, finish, done := make(chan struct{}), make(chan struct{}), make(chan struct{})
+
+	locker.On("TryLock", mock.Anything, jobName).Return(func() error { return nil }, true, nil).Once()
+
+	job := singleton.New(jobName, func(context.Context) {
+		close(started)
+		<-finish
+	}, locker, discardLog)
+
+	go func() {
+		defer close(done)
+		job.Run(context.Background())
+	}()
+
+	<-started
+
+	job.Run(context.Background())
+
+	close(finish)
+	<-done
+
+	assert.Equal(t, "1", job.Stats().Get(singleton.OverrunRuns).String(), "запуск во время цикла пропускается")
+	assert.Equal(t, "1", job.Stats().Get(singleton.OverrunCycles).String())
+	assert.Equal(t, "1", job.Stats().Get(singleton.StartedRuns).String())
+}
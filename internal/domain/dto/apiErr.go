@@ -2,8 +2,9 @@ package dto
 
 // HTTP codes.
 const (
-	httpStatusBadRequest = "400"
-	httpStatusNotFound   = "404"
+	httpStatusBadRequest  = "400"
+	httpStatusNotFound    = "404"
+	httpStatusUnavailable = "503"
 )
 
 // api err descriptions.
@@ -13,9 +14,15 @@ const (
 
 // exceptions name.
 const (
-	errID   = "id error"
-	errBody = "body error"
-	errLink = "link erroe"
+	errID       = "id error"
+	errBody     = "body error"
+	errLink     = "link erroe"
+	errDelivery = "delivery error"
+	errQuiet    = "quiet hours error"
+	errTimezone = "timezone error"
+	errLanguage = "language error"
+	errInterval = "interval error"
+	errSend     = "send error"
 )
 
 // exceptions message.
@@ -29,6 +36,12 @@ const (
 	cantTrackLink        = "переданная ссылка не поддерживается"
 	userAlreadyTrackLink = "пользователь уже отслеживает эту ссылку"
 	userNotTrackLink     = "пользователь не отслеживает эту ссылку"
+	badDelivery          = "способ доставки должен быть instant или digest со временем в формате HH:MM"
+	badQuietHours        = "начало и конец тихих часов должны быть в формате HH:MM и не совпадать"
+	badTimezone          = "часовой пояс должен быть именем из базы IANA, например Europe/Moscow"
+	badLanguage          = "язык должен быть ru или en"
+	badInterval          = "интервал проверки должен быть длительностью от 1m до 24h, например 30m"
+	notSent              = "обновление не удалось отправить части получателей, запрос нужно повторить"
 )
 
 // api errors chat handler.
@@ -42,6 +55,12 @@ var (
 	APIErrBadLink           = newAPIErrResponse(errLink, cantTrackLink, httpStatusBadRequest)
 	APIErrDuplicateLink     = newAPIErrResponse(errLink, userAlreadyTrackLink, httpStatusBadRequest)
 	APIErrNotTrackLink      = newAPIErrResponse(errLink, userNotTrackLink, httpStatusNotFound)
+	APIErrBadDelivery       = newAPIErrResponse(errDelivery, badDelivery, httpStatusBadRequest)
+	APIErrBadQuietHours     = newAPIErrResponse(errQuiet, badQuietHours, httpStatusBadRequest)
+	APIErrBadTimezone       = newAPIErrResponse(errTimezone, badTimezone, httpStatusBadRequest)
+	APIErrBadLanguage       = newAPIErrResponse(errLanguage, badLanguage, httpStatusBadRequest)
+	APIErrBadInterval       = newAPIErrResponse(errInterval, badInterval, httpStatusBadRequest)
+	APIErrNotSent           = newAPIErrResponse(errSend, notSent, httpStatusUnavailable)
 )
 
 type APIErrResponse struct {
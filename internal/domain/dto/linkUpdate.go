@@ -1,8 +1,33 @@
 package dto
 
+import "time"
+
+// LinkUpdate - обновление ссылки (Update) или дайджест (Digest), текст сообщения бот формирует сам.
 type LinkUpdate struct {
-	ID          int64   `json:"id"`
-	URL         string  `json:"url"`
-	Description string  `json:"description"`
-	TgChatIDs   []int64 `json:"tgChatIds"`
+	ID          int64         `json:"id"`
+	URL         string        `json:"url"`
+	EventID     string        `json:"eventId,omitempty"`
+	Description string        `json:"description,omitempty"` // готовый текст старых уведомлений
+	Update      *UpdateItem   `json:"update,omitempty"`
+	Digest      []*LinkDigest `json:"digest,omitempty"`
+	Language    string        `json:"language,omitempty"`
+	Timezone    string        `json:"timezone,omitempty"`
+	TgChatIDs   []int64       `json:"tgChatIds"`
+}
+
+// UpdateItem - новый элемент на ссылке: issue, pull request, ответ или комментарий. URL ведет прямо на элемент.
+type UpdateItem struct {
+	Site      string    `json:"site"`
+	Type      string    `json:"type"`
+	URL       string    `json:"url,omitempty"`
+	Author    string    `json:"author"`
+	CreatedAt time.Time `json:"createdAt"`
+	Title     string    `json:"title"`
+	Excerpt   string    `json:"excerpt,omitempty"`
+}
+
+// LinkDigest - обновления одной ссылки в дайджесте.
+type LinkDigest struct {
+	URL     string        `json:"url"`
+	Updates []*UpdateItem `json:"updates"`
 }
@@ -0,0 +1,48 @@
+package i18n
+
+import "strings"
+
+type Lang = string
+
+const (
+	Russian Lang = "ru"
+	English Lang = "en"
+
+	Default = Russian // язык пользователей, для которых язык неизвестен или не поддерживается
+)
+
+// Languages - поддерживаемые языки в порядке, в котором они показываются пользователю.
+var Languages = []Lang{Russian, English}
+
+func Supported(lang Lang) bool {
+	for _, supported := range Languages {
+		if lang == supported {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FromLanguageCode приводит language_code из Telegram (IETF тег, например en-US) к поддерживаемому языку.
+func FromLanguageCode(code string) Lang {
+	lang, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(code)), "-")
+
+	if Supported(lang) {
+		return lang
+	}
+
+	return Default
+}
+
+// Catalog - тексты сообщений по языкам и ключам.
+type Catalog map[Lang]map[string]string
+
+// Text возвращает сообщение на языке lang, а если перевода нет - на языке по умолчанию.
+func (c Catalog) Text(lang Lang, key string) string {
+	if msg, ok := c[lang][key]; ok {
+		return msg
+	}
+
+	return c[Default][key]
+}
@@ -0,0 +1,34 @@
+package i18n_test
+
+import (
+	"linkTraccer/internal/domain/i18n"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromLanguageCode(t *testing.T) {
+	tests := map[string]i18n.Lang{
+		"ru":    i18n.Russian,
+		"en":    i18n.English,
+		"en-US": i18n.English,
+		"EN-gb": i18n.English,
+		"de":    i18n.Default,
+		"":      i18n.Default,
+	}
+
+	for code, expected := range tests {
+		assert.Equal(t, expected, i18n.FromLanguageCode(code), code)
+	}
+}
+
+func TestCatalog_Text(t *testing.T) {
+	catalog := i18n.Catalog{
+		i18n.Russian: {"hello": "Привет", "bye": "Пока"},
+		i18n.English: {"hello": "Hello"},
+	}
+
+	assert.Equal(t, "Hello", catalog.Text(i18n.English, "hello"))
+	assert.Equal(t, "Пока", catalog.Text(i18n.English, "bye"), "нет перевода, берем язык по умолчанию")
+	assert.Equal(t, "Привет", catalog.Text("de", "hello"), "неизвестный язык, берем язык по умолчанию")
+}
@@ -0,0 +1,48 @@
+package scrapper
+
+import "time"
+
+// Cursor - последний уже обработанный элемент одного типа на ссылке.
+type Cursor struct {
+	ItemID   int64
+	ItemTime time.Time
+}
+
+// Behind сообщает, что элемент новее курсора. Элементы, созданные в одну секунду, различаются по идентификатору.
+func (c *Cursor) Behind(itemTime time.Time, itemID int64) bool {
+	return itemTime.After(c.ItemTime) || itemTime.Equal(c.ItemTime) && itemID > c.ItemID
+}
+
+// LinkCursors - курсоры по типам элементов, для типа без элементов курсором служит Since.
+type LinkCursors struct {
+	Since  time.Time
+	ByType map[UpdateType]*Cursor
+}
+
+func (c *LinkCursors) Cursor(updateType UpdateType) *Cursor {
+	if cursor, ok := c.ByType[updateType]; ok {
+		return cursor
+	}
+
+	return &Cursor{ItemTime: c.Since}
+}
+
+// Advance сдвигает курсоры на самые новые из обновлений и возвращает курсоры, которые изменились.
+func (c *LinkCursors) Advance(updates LinkUpdates) map[UpdateType]*Cursor {
+	advanced := make(map[UpdateType]*Cursor)
+
+	for _, update := range updates {
+		if !c.Cursor(update.Type).Behind(update.ItemTime, update.ItemID) {
+			continue
+		}
+
+		if c.ByType == nil {
+			c.ByType = make(map[UpdateType]*Cursor)
+		}
+
+		c.ByType[update.Type] = &Cursor{ItemID: update.ItemID, ItemTime: update.ItemTime}
+		advanced[update.Type] = c.ByType[update.Type]
+	}
+
+	return advanced
+}
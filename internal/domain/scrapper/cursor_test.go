@@ -0,0 +1,43 @@
+package scrapper_test
+
+import (
+	"linkTraccer/internal/domain/scrapper"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinkCursors_Advance(t *testing.T) {
+	since := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+	cursors := &scrapper.LinkCursors{
+		Since: since,
+		ByType: map[scrapper.UpdateType]*scrapper.Cursor{
+			scrapper.AnswerUpdate: {ItemID: 5, ItemTime: since.Add(time.Hour)},
+		},
+	}
+
+	assert.Equal(t, &scrapper.Cursor{ItemTime: since}, cursors.Cursor(scrapper.CommentUpdate),
+		"для типа без курсора курсором служит время Since")
+
+	answer := cursors.Cursor(scrapper.AnswerUpdate)
+
+	assert.True(t, answer.Behind(since.Add(2*time.Hour), 1))
+	assert.True(t, answer.Behind(since.Add(time.Hour), 6), "элемент той же секунды с большим идентификатором")
+	assert.False(t, answer.Behind(since.Add(time.Hour), 5), "элемент, на котором стоит курсор")
+	assert.False(t, answer.Behind(since, 10))
+
+	advanced := cursors.Advance(scrapper.LinkUpdates{
+		{Type: scrapper.AnswerUpdate, ItemID: 7, ItemTime: since.Add(2 * time.Hour)},
+		{Type: scrapper.AnswerUpdate, ItemID: 6, ItemTime: since.Add(time.Hour)},
+		{Type: scrapper.CommentUpdate, ItemID: 1, ItemTime: since.Add(time.Minute)},
+	})
+
+	assert.Equal(t, map[scrapper.UpdateType]*scrapper.Cursor{
+		scrapper.AnswerUpdate:  {ItemID: 7, ItemTime: since.Add(2 * time.Hour)},
+		scrapper.CommentUpdate: {ItemID: 1, ItemTime: since.Add(time.Minute)},
+	}, advanced)
+	assert.Equal(t, advanced[scrapper.AnswerUpdate], cursors.Cursor(scrapper.AnswerUpdate))
+	assert.Empty(t, cursors.Advance(scrapper.LinkUpdates{{Type: scrapper.CommentUpdate, ItemTime: since}}),
+		"старые элементы курсор не сдвигают")
+}
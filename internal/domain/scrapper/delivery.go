@@ -0,0 +1,29 @@
+package scrapper
+
+type DeliveryMode = string
+
+const (
+	InstantDelivery DeliveryMode = "instant" // обновления отправляются сразу после обнаружения
+	DigestDelivery  DeliveryMode = "digest"  // обновления копятся и отправляются раз в день одним сообщением
+)
+
+// DigestTimeLayout - формат времени отправки дайджеста (HH:MM).
+const DigestTimeLayout = "15:04"
+
+type DeliverySettings struct {
+	Mode DeliveryMode `json:"mode"`
+	Time string       `json:"time,omitempty"`
+}
+
+// PendingUpdate - обновление, отложенное до отправки дайджеста пользователю.
+type PendingUpdate struct {
+	ID     int64
+	LinkID LinkID
+	URL    Link
+	Update *LinkUpdate
+}
+
+type LinkDigest struct {
+	URL     Link        `json:"url"`
+	Updates LinkUpdates `json:"updates"`
+}
@@ -1,10 +1,69 @@
 package scrapper
 
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+type UpdateType = string
+
+const (
+	IssueUpdate   UpdateType = "issue"
+	PRUpdate      UpdateType = "pr"
+	AnswerUpdate  UpdateType = "answer"
+	CommentUpdate UpdateType = "comment"
+)
+
+// Site - сайт, на котором произошло обновление.
+type Site = string
+
+const (
+	GitHubSite        Site = "github"
+	StackOverflowSite Site = "stackoverflow"
+)
+
+// LinkUpdate - новый элемент на отслеживаемой ссылке: issue, pull request, ответ или комментарий.
 type LinkUpdate struct {
-	Header     string
-	UserName   string
-	CreateTime string
-	Preview    string
+	Site     Site       `json:"site"`
+	Type     UpdateType `json:"type"`
+	ItemID   int64      `json:"itemId"`
+	ItemURL  string     `json:"itemUrl"`
+	Author   string     `json:"author"`
+	ItemTime time.Time  `json:"createdAt"` // в UTC, по ItemTime и ItemID сдвигается курсор
+	Title    string     `json:"title"`
+	Excerpt  string     `json:"excerpt"`
 }
 
 type LinkUpdates = []*LinkUpdate
+
+// EventID не меняется при повторных проверках и правках элемента, по нему бот отбрасывает повторы.
+func EventID(link Link, update *LinkUpdate) string {
+	return hashFields(link, update.Type, strconv.FormatInt(update.ItemID, 10), update.Author,
+		update.ItemTime.UTC().Format(time.RFC3339))
+}
+
+// DigestEventID - идентификатор дайджеста пользователя, составленный из идентификаторов вошедших в него событий.
+func DigestEventID(user User, digest []*LinkDigest) string {
+	fields := []string{"digest", strconv.FormatInt(user, 10)}
+
+	for _, linkDigest := range digest {
+		for _, update := range linkDigest.Updates {
+			fields = append(fields, EventID(linkDigest.URL, update))
+		}
+	}
+
+	return hashFields(fields...)
+}
+
+func hashFields(fields ...string) string {
+	hash := sha256.New()
+
+	for _, field := range fields {
+		hash.Write([]byte(field))
+		hash.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
@@ -0,0 +1,21 @@
+package scrapper
+
+// OutboxUpdate сохраняется в одной транзакции с изменением ссылки, поэтому не теряется, если бот недоступен.
+type OutboxUpdate struct {
+	ID          int64
+	LinkID      LinkID // 0 у уведомлений не об одной ссылке, например дайджеста
+	URL         Link
+	EventID     string // по нему бот отбрасывает повторы
+	Description string // готовый текст уведомлений, сохраненных до перехода на Content
+	Content     *Notification
+	TgChatIDs   []User
+	Attempts    int // сколько раз бот не принял уведомление
+}
+
+// Notification - обновление ссылки или дайджест, сообщение из него формирует бот.
+type Notification struct {
+	Update   *LinkUpdate   `json:"update,omitempty"`
+	Digest   []*LinkDigest `json:"digest,omitempty"`
+	Language string        `json:"language"`
+	Timezone string        `json:"timezone"`
+}
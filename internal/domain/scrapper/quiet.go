@@ -0,0 +1,65 @@
+package scrapper
+
+import "time"
+
+// DefaultTimezone - часовой пояс пользователя, пока он не выбрал свой.
+const DefaultTimezone = "Europe/Moscow"
+
+type QuietHoursSettings struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+type TimezoneSettings struct {
+	Timezone string `json:"timezone"`
+}
+
+type LanguageSettings struct {
+	Language string `json:"language"`
+}
+
+// QuietHours - интервал [Start, End) в часовом поясе пользователя, может переходить через полночь.
+type QuietHours struct {
+	Start    string
+	End      string
+	Timezone string
+}
+
+// Active сообщает, попадает ли момент now в тихие часы. При некорректных настройках уведомления не задерживаются.
+func (q *QuietHours) Active(now time.Time) bool {
+	location, err := time.LoadLocation(q.Timezone)
+	if err != nil {
+		return false
+	}
+
+	start, err := time.Parse(DigestTimeLayout, q.Start)
+	if err != nil {
+		return false
+	}
+
+	end, err := time.Parse(DigestTimeLayout, q.End)
+	if err != nil {
+		return false
+	}
+
+	local := now.In(location)
+	current := local.Hour()*60 + local.Minute()
+	from := start.Hour()*60 + start.Minute()
+	to := end.Hour()*60 + end.Minute()
+
+	if from <= to {
+		return from <= current && current < to
+	}
+
+	return current >= from || current < to
+}
+
+// HeldUpdate - уведомление, задержанное до конца тихих часов.
+type HeldUpdate struct {
+	ID          int64
+	LinkID      LinkID
+	URL         Link
+	EventID     string
+	Description string
+	Content     *Notification
+}
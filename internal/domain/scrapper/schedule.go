@@ -0,0 +1,73 @@
+package scrapper
+
+import "time"
+
+// Границы интервала проверки ссылки, который пользователь задает командой /interval.
+
+const (
+	MinCheckInterval = time.Minute
+	MaxCheckInterval = 24 * time.Hour
+)
+
+// IntervalSettings - интервал проверки ссылки, заданный пользователем, например 30m или 2h.
+type IntervalSettings struct {
+	Link     Link   `json:"link"`
+	Interval string `json:"interval,omitempty"`
+}
+
+type LinkSchedule struct {
+	Interval  time.Duration // адаптивный интервал между проверками
+	Failures  int           // проверок подряд с ошибкой
+	Override  time.Duration // самый короткий интервал, заданный пользователями, или 0
+	NextCheck time.Time
+}
+
+// CheckPolicy проверяет ссылки с обновлениями чаще, а без обновлений и с ошибками - реже, в пределах интервалов.
+type CheckPolicy struct {
+	MinInterval time.Duration
+	MaxInterval time.Duration
+}
+
+// Checked возвращает расписание после успешной проверки, на которой нашлось updates обновлений.
+func (p *CheckPolicy) Checked(schedule LinkSchedule, now time.Time, updates int) LinkSchedule {
+	interval := schedule.Interval * 3 / 2
+	if updates > 0 {
+		interval = schedule.Interval / 2
+	}
+
+	schedule.Interval = min(max(interval, p.MinInterval), p.MaxInterval)
+	schedule.Failures = 0
+	schedule.NextCheck = now.Add(schedule.effectiveInterval())
+
+	return schedule
+}
+
+// Failed удваивает задержку с каждой ошибкой подряд, не меняя адаптивный интервал.
+func (p *CheckPolicy) Failed(schedule LinkSchedule, now time.Time) LinkSchedule {
+	schedule.Failures++
+
+	delay, limit := max(schedule.effectiveInterval(), p.MinInterval), max(p.MaxInterval, schedule.Override)
+
+	for attempt := 0; attempt < schedule.Failures && delay < limit; attempt++ {
+		delay *= 2
+	}
+
+	schedule.NextCheck = now.Add(min(delay, limit))
+
+	return schedule
+}
+
+// Deferred переносит проверку на retryAt, не считая это ошибкой сайта.
+func (p *CheckPolicy) Deferred(schedule LinkSchedule, retryAt time.Time) LinkSchedule {
+	schedule.NextCheck = retryAt
+
+	return schedule
+}
+
+func (s *LinkSchedule) effectiveInterval() time.Duration {
+	if s.Override > 0 {
+		return s.Override
+	}
+
+	return s.Interval
+}
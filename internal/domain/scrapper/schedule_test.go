@@ -0,0 +1,84 @@
+package scrapper_test
+
+import (
+	"linkTraccer/internal/domain/scrapper"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckPolicy_Checked(t *testing.T) {
+	policy := &scrapper.CheckPolicy{MinInterval: time.Minute, MaxInterval: 6 * time.Hour}
+	now := time.Date(2025, 5, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		schedule scrapper.LinkSchedule
+		updates  int
+		expected scrapper.LinkSchedule
+	}{
+		{
+			name:     "ссылка с обновлениями проверяется чаще",
+			schedule: scrapper.LinkSchedule{Interval: 10 * time.Minute, Failures: 2},
+			updates:  3,
+			expected: scrapper.LinkSchedule{Interval: 5 * time.Minute, NextCheck: now.Add(5 * time.Minute)},
+		},
+		{
+			name:     "но не чаще минимального интервала",
+			schedule: scrapper.LinkSchedule{Interval: time.Minute},
+			updates:  1,
+			expected: scrapper.LinkSchedule{Interval: time.Minute, NextCheck: now.Add(time.Minute)},
+		},
+		{
+			name:     "ссылка без обновлений проверяется реже",
+			schedule: scrapper.LinkSchedule{Interval: 10 * time.Minute},
+			expected: scrapper.LinkSchedule{Interval: 15 * time.Minute, NextCheck: now.Add(15 * time.Minute)},
+		},
+		{
+			name:     "но не реже максимального интервала",
+			schedule: scrapper.LinkSchedule{Interval: 5 * time.Hour},
+			expected: scrapper.LinkSchedule{Interval: 6 * time.Hour, NextCheck: now.Add(6 * time.Hour)},
+		},
+		{
+			name:     "интервал пользователя важнее адаптивного",
+			schedule: scrapper.LinkSchedule{Interval: 10 * time.Minute, Override: 2 * time.Minute},
+			expected: scrapper.LinkSchedule{Interval: 15 * time.Minute, Override: 2 * time.Minute,
+				NextCheck: now.Add(2 * time.Minute)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, policy.Checked(test.schedule, now, test.updates))
+		})
+	}
+}
+
+func TestCheckPolicy_Failed(t *testing.T) {
+	policy := &scrapper.CheckPolicy{MinInterval: time.Minute, MaxInterval: 6 * time.Hour}
+	now := time.Date(2025, 5, 10, 12, 0, 0, 0, time.UTC)
+	schedule := scrapper.LinkSchedule{Interval: 5 * time.Minute}
+
+	for _, delay := range []time.Duration{10 * time.Minute, 20 * time.Minute, 40 * time.Minute, 80 * time.Minute} {
+		schedule = policy.Failed(schedule, now)
+
+		assert.Equal(t, now.Add(delay), schedule.NextCheck)
+		assert.Equal(t, 5*time.Minute, schedule.Interval, "адаптивный интервал не меняется из-за ошибок")
+	}
+
+	schedule.Failures = 100
+
+	assert.Equal(t, now.Add(6*time.Hour), policy.Failed(schedule, now).NextCheck, "задержка ограничена сверху")
+	assert.Equal(t, 0, policy.Checked(schedule, now, 0).Failures, "успешная проверка сбрасывает ошибки")
+}
+
+func TestCheckPolicy_Deferred(t *testing.T) {
+	policy := &scrapper.CheckPolicy{MinInterval: time.Minute, MaxInterval: 6 * time.Hour}
+	retryAt := time.Date(2025, 5, 10, 13, 0, 0, 0, time.UTC)
+	schedule := scrapper.LinkSchedule{Interval: 5 * time.Minute, Failures: 2}
+
+	expected := scrapper.LinkSchedule{Interval: 5 * time.Minute, Failures: 2, NextCheck: retryAt}
+
+	assert.Equal(t, expected, policy.Deferred(schedule, retryAt), "ограничение сайта не считается ошибкой ссылки")
+}
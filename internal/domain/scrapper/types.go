@@ -7,12 +7,14 @@ type Link = string
 type LinkState = string
 type LinkID = int64
 type Tag = string
+type Filter = string
 
 type StackAnswers struct {
 	Items []StackAnswer `json:"items"`
 }
 
 type StackAnswer struct {
+	ID         int64  `json:"answer_id"`
 	UpdateTime int64  `json:"last_activity_date"`
 	Title      string `json:"title"`
 	Body       string `json:"body"`
@@ -24,6 +26,7 @@ type StackComments struct {
 }
 
 type StackComment struct {
+	ID         int64  `json:"comment_id"`
 	UpdateTime int64  `json:"creation_date"`
 	Title      string `json:"title"`
 	Body       string `json:"body"`
@@ -39,7 +42,7 @@ type LastGitUpdate struct {
 }
 
 type LinkResponse struct {
-	ID      int      `json:"id"`
+	ID      int64    `json:"id"`
 	URL     Link     `json:"url"`
 	Tags    []string `json:"tags"`
 	Filters []string `json:"filters"`
@@ -50,6 +53,15 @@ type ListLinksResponse struct {
 	Size  int            `json:"size"`
 }
 
+type TagedLink struct {
+	Tag   Tag    `json:"tag"`
+	Links []Link `json:"links"`
+}
+
+type ListTagedLinks struct {
+	TagedLinks []TagedLink `json:"tagedLinks"`
+}
+
 type AddLinkRequest struct {
 	Link    string   `json:"link"`
 	Tags    []string `json:"tags"`
@@ -66,8 +78,11 @@ type GitUpdates struct {
 }
 
 type GitUpdate struct {
+	ID          int64     `json:"id"`
+	HTMLURL     string    `json:"html_url"`
 	GitUser     GitUser   `json:"user"`
 	Title       string    `json:"title"`
+	Body        string    `json:"body"`
 	CreatedTime time.Time `json:"created_at"`
 	PullRequest PR        `json:"pull_request"`
 }
@@ -80,8 +95,17 @@ type PR struct {
 	URL string `json:"url"`
 }
 
+type UserLink struct {
+	ID      LinkID
+	URL     Link
+	Tags    []Tag
+	Filters []Filter
+}
+
 type LinkInfo struct {
-	ID         LinkID
-	URL        Link
-	LastUpdate time.Time
+	ID          LinkID
+	URL         Link
+	LastUpdate  time.Time
+	Schedule    LinkSchedule
+	LeasedUntil time.Time // до какого момента ссылку проверяет эта реплика
 }
@@ -7,6 +7,15 @@ import (
 
 var LinkNotExist = errors.New("ссылка не найдена")
 var LinkNotSupport = errors.New("отслеживание переданной ссылки не поддерживается")
+var BotBlocked = errors.New("пользователь заблокировал бота")
+var TooManyRequests = errors.New("превышен лимит запросов к telegram")
+var ChatNotFound = errors.New("чат не найден")
+var StateChanged = errors.New("состояние пользователя изменилось во время перехода")
+
+// ChatUnavailable сообщает, что сообщения в чат больше не доставить: пользователь заблокировал бота или чат удален.
+func ChatUnavailable(err error) bool {
+	return errors.Is(err, BotBlocked) || errors.Is(err, ChatNotFound)
+}
 
 type ErrBadRequestStatus struct {
 	msg  string
@@ -61,3 +70,23 @@ func NewErrTransitionFailed(id ID, event Event) *ErrTransitionFailed {
 func (err *ErrTransitionFailed) Error() string {
 	return fmt.Sprintf("Не получилось сделать переход по событию %s у user = %d", err.event, err.id)
 }
+
+type ErrStateStore struct {
+	id  ID
+	err error
+}
+
+func NewErrStateStore(id ID, err error) *ErrStateStore {
+	return &ErrStateStore{
+		id:  id,
+		err: err,
+	}
+}
+
+func (err *ErrStateStore) Error() string {
+	return fmt.Sprintf("Ошибка хранилища состояний у user = %d: %s", err.id, err.err)
+}
+
+func (err *ErrStateStore) Unwrap() error {
+	return err.err
+}
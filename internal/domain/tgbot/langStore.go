@@ -0,0 +1,50 @@
+package tgbot
+
+import (
+	"context"
+	"linkTraccer/internal/domain/i18n"
+	"sync"
+)
+
+// LangStore возвращает пустой язык, если язык пользователя еще не определен.
+type LangStore interface {
+	UserLang(ctx context.Context, id ID) (i18n.Lang, error)
+	SetUserLang(ctx context.Context, id ID, lang i18n.Lang) error
+	DeleteUserLang(ctx context.Context, id ID) error
+}
+
+type MemoryLangStore struct {
+	mu    sync.Mutex
+	langs map[ID]i18n.Lang
+}
+
+func NewMemoryLangStore() *MemoryLangStore {
+	return &MemoryLangStore{
+		langs: make(map[ID]i18n.Lang),
+	}
+}
+
+func (m *MemoryLangStore) UserLang(_ context.Context, id ID) (i18n.Lang, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.langs[id], nil
+}
+
+func (m *MemoryLangStore) SetUserLang(_ context.Context, id ID, lang i18n.Lang) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.langs[id] = lang
+
+	return nil
+}
+
+func (m *MemoryLangStore) DeleteUserLang(_ context.Context, id ID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.langs, id)
+
+	return nil
+}
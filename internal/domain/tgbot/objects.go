@@ -1,22 +1,53 @@
 package tgbot
 
 type Update struct {
-	UpdateID int     `json:"update_id"`
-	Msg      Message `json:"message"`
+	UpdateID      int            `json:"update_id"`
+	Msg           Message        `json:"message"`
+	CallbackQuery *CallbackQuery `json:"callback_query,omitempty"`
 }
 
 type Message struct {
-	From User   `json:"from"`
-	Text string `json:"text"`
+	MessageID int    `json:"message_id"`
+	From      User   `json:"from"`
+	Text      string `json:"text"`
+}
+
+// CallbackQuery приходит при нажатии на кнопку инлайн клавиатуры, Message - сообщение с этой клавиатурой.
+type CallbackQuery struct {
+	ID      string   `json:"id"`
+	From    User     `json:"from"`
+	Message *Message `json:"message,omitempty"`
+	Data    string   `json:"data"`
+}
+
+type InlineKeyboardButton struct {
+	Text         string `json:"text"`
+	CallbackData string `json:"callback_data"`
+}
+
+type InlineKeyboardMarkup struct {
+	InlineKeyboard [][]InlineKeyboardButton `json:"inline_keyboard"`
 }
 
 type User struct {
-	ID int64 `json:"id"`
+	ID           int64  `json:"id"`
+	LanguageCode string `json:"language_code,omitempty"`
 }
 
 type Updates = []Update
 
 type Link = string
+type Tag = string
+
+type SavedLink struct {
+	ID  int64
+	URL Link
+}
+
+type TagedLinks struct {
+	Tag   Tag
+	Links []Link
+}
 
 type BotCommand struct {
 	Command     string `json:"command"`
@@ -24,5 +55,6 @@ type BotCommand struct {
 }
 
 type SetCommands struct {
-	Commands []BotCommand `json:"commands"`
+	Commands     []BotCommand `json:"commands"`
+	LanguageCode string       `json:"language_code,omitempty"`
 }
@@ -1,11 +1,16 @@
 package tgbot
 
+import "context"
+
 // специальная константа, которая задает переход в следующее состояние, по любому сообщению
 
 const (
 	TextEvent Event = "text"
 )
 
+// сколько раз переход повторяется, если состояние пользователя одновременно изменила другая реплика
+const maxTransitionAttempts = 3
+
 type ID = int64
 type Event = string
 type State = string
@@ -15,7 +20,7 @@ type state struct {
 }
 
 type StateMachine struct {
-	current map[ID]State
+	store   StateStore
 	initial State
 	states  map[State]state
 }
@@ -34,7 +39,7 @@ type StateDesc struct {
 
 type States []StateDesc
 
-func NewStateMachine(initial State, states States) (*StateMachine, error) {
+func NewStateMachine(initial State, states States, store StateStore) (*StateMachine, error) {
 	mStates := make(map[State]state)
 
 	for _, s := range states {
@@ -54,7 +59,7 @@ func NewStateMachine(initial State, states States) (*StateMachine, error) {
 	}
 
 	machine := &StateMachine{
-		current: make(map[ID]State),
+		store:   store,
 		states:  mStates,
 		initial: initial,
 	}
@@ -62,16 +67,24 @@ func NewStateMachine(initial State, states States) (*StateMachine, error) {
 	return machine, nil
 }
 
-func (m *StateMachine) Current(id ID) State {
-	if m.current[id] == "" {
+func (m *StateMachine) Current(ctx context.Context, id ID) (State, error) {
+	stored, err := m.store.UserState(ctx, id)
+	if err != nil {
+		return "", NewErrStateStore(id, err)
+	}
+
+	return m.current(stored), nil
+}
+
+func (m *StateMachine) current(stored State) State {
+	if stored == "" {
 		return m.initial
 	}
 
-	return m.current[id]
+	return stored
 }
 
-func (m *StateMachine) getNextState(id ID, event Event) (State, error) {
-	current := m.Current(id)
+func (m *StateMachine) getNextState(current State, event Event) (State, error) {
 	next, ok := m.states[current].transitions[event]
 
 	if !ok {
@@ -87,14 +100,32 @@ func (m *StateMachine) getNextState(id ID, event Event) (State, error) {
 	return next, nil
 }
 
-func (m *StateMachine) Transition(id ID, event Event) (State, error) {
-	next, err := m.getNextState(id, event)
+// Transition меняет состояние, только если другая реплика не изменила его с момента чтения.
+func (m *StateMachine) Transition(ctx context.Context, id ID, event Event) (State, error) {
+	var current State
 
-	if err != nil {
-		return m.current[id], NewErrTransitionFailed(id, event)
-	}
+	for attempt := 0; attempt < maxTransitionAttempts; attempt++ {
+		stored, err := m.store.UserState(ctx, id)
+		if err != nil {
+			return "", NewErrStateStore(id, err)
+		}
 
-	m.current[id] = next
+		current = m.current(stored)
 
-	return next, nil
+		next, err := m.getNextState(current, event)
+		if err != nil {
+			return current, NewErrTransitionFailed(id, event)
+		}
+
+		swapped, err := m.store.CompareAndSwapUserState(ctx, id, stored, next)
+		if err != nil {
+			return current, NewErrStateStore(id, err)
+		}
+
+		if swapped {
+			return next, nil
+		}
+	}
+
+	return current, NewErrStateStore(id, StateChanged)
 }
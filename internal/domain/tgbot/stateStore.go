@@ -0,0 +1,63 @@
+package tgbot
+
+import (
+	"context"
+	"sync"
+)
+
+// StateStore меняет состояние в CompareAndSwapUserState, только если у пользователя состояние old.
+type StateStore interface {
+	UserState(ctx context.Context, id ID) (State, error)
+	SetUserState(ctx context.Context, id ID, state State) error
+	CompareAndSwapUserState(ctx context.Context, id ID, old, next State) (bool, error)
+	DeleteUserState(ctx context.Context, id ID) error
+}
+
+type MemoryStateStore struct {
+	mu      sync.Mutex
+	current map[ID]State
+}
+
+func NewMemoryStateStore() *MemoryStateStore {
+	return &MemoryStateStore{
+		current: make(map[ID]State),
+	}
+}
+
+func (m *MemoryStateStore) UserState(_ context.Context, id ID) (State, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.current[id], nil
+}
+
+func (m *MemoryStateStore) SetUserState(_ context.Context, id ID, state State) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.current[id] = state
+
+	return nil
+}
+
+func (m *MemoryStateStore) CompareAndSwapUserState(_ context.Context, id ID, old, next State) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.current[id] != old {
+		return false, nil
+	}
+
+	m.current[id] = next
+
+	return true, nil
+}
+
+func (m *MemoryStateStore) DeleteUserState(_ context.Context, id ID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.current, id)
+
+	return nil
+}
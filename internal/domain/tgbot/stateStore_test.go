@@ -0,0 +1,112 @@
+package tgbot_test
+
+import (
+	"context"
+	"linkTraccer/internal/domain/tgbot"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	userID     = 1
+	startState = "start"
+	waitState  = "wait"
+	doneState  = "done"
+	nextEvent  = "next"
+)
+
+var states = tgbot.States{
+	{Name: startState, Transitions: tgbot.Transitions{{Event: nextEvent, Dst: waitState}}},
+	{Name: waitState, Transitions: tgbot.Transitions{{Event: nextEvent, Dst: doneState}}},
+	{Name: doneState},
+}
+
+// racingStore имитирует реплику, которая меняет состояние пользователя между чтением и записью перехода.
+type racingStore struct {
+	*tgbot.MemoryStateStore
+	races int
+}
+
+func (s *racingStore) CompareAndSwapUserState(ctx context.Context, id tgbot.ID, old, next tgbot.State) (bool, error) {
+	if s.races > 0 {
+		s.races--
+
+		return false, s.SetUserState(ctx, id, waitState)
+	}
+
+	return s.MemoryStateStore.CompareAndSwapUserState(ctx, id, old, next)
+}
+
+func TestMemoryStateStore(t *testing.T) {
+	ctx := context.Background()
+	store := tgbot.NewMemoryStateStore()
+
+	state, err := store.UserState(ctx, userID)
+	assert.NoError(t, err)
+	assert.Empty(t, state, "у нового пользователя нет состояния")
+
+	swapped, err := store.CompareAndSwapUserState(ctx, userID, waitState, doneState)
+	assert.NoError(t, err)
+	assert.False(t, swapped, "состояние не меняется, если оно отличается от ожидаемого")
+
+	swapped, err = store.CompareAndSwapUserState(ctx, userID, "", waitState)
+	assert.NoError(t, err)
+	assert.True(t, swapped)
+
+	assert.NoError(t, store.SetUserState(ctx, userID, doneState))
+
+	state, err = store.UserState(ctx, userID)
+	assert.NoError(t, err)
+	assert.Equal(t, doneState, state)
+
+	assert.NoError(t, store.DeleteUserState(ctx, userID))
+
+	state, err = store.UserState(ctx, userID)
+	assert.NoError(t, err)
+	assert.Empty(t, state)
+}
+
+func TestStateMachine_TransitionRace(t *testing.T) {
+	tests := []struct {
+		name     string
+		races    int
+		expected tgbot.State
+		correct  bool
+	}{
+		{
+			name:     "переход без гонки",
+			expected: waitState,
+			correct:  true,
+		},
+		{
+			name:     "переход повторяется от состояния, записанного другой репликой",
+			races:    1,
+			expected: doneState,
+			correct:  true,
+		},
+		{
+			name:    "состояние постоянно меняют другие реплики",
+			races:   100,
+			correct: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			store := &racingStore{MemoryStateStore: tgbot.NewMemoryStateStore(), races: test.races}
+
+			machine, err := tgbot.NewStateMachine(startState, states, store)
+			assert.NoError(t, err)
+
+			next, err := machine.Transition(context.Background(), userID, nextEvent)
+
+			if test.correct {
+				assert.NoError(t, err)
+				assert.Equal(t, test.expected, next)
+			} else {
+				assert.ErrorIs(t, err, tgbot.StateChanged)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package botclient
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"linkTraccer/internal/domain/dto"
@@ -10,7 +11,8 @@ import (
 )
 
 const (
-	updatesPath = "/updates"
+	updatesPath       = "/updates"
+	idempotencyHeader = "Idempotency-Key"
 )
 
 type HTTPClient interface {
@@ -31,7 +33,7 @@ func New(host string, client HTTPClient) *BotClient {
 	}
 }
 
-func (bot *BotClient) SendLinkUpdates(update *dto.LinkUpdate) error {
+func (bot *BotClient) SendLinkUpdates(ctx context.Context, update *dto.LinkUpdate) error {
 	jsonData, err := json.Marshal(update)
 
 	if err != nil {
@@ -44,7 +46,7 @@ func (bot *BotClient) SendLinkUpdates(update *dto.LinkUpdate) error {
 		Path:   bot.basePath,
 	}
 
-	req, err := http.NewRequest(http.MethodPost, tgBotURL.String(), bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tgBotURL.String(), bytes.NewBuffer(jsonData))
 
 	if err != nil {
 		return fmt.Errorf("ошибка при составлении запроса для отправки обновлений: %w", err)
@@ -52,6 +54,10 @@ func (bot *BotClient) SendLinkUpdates(update *dto.LinkUpdate) error {
 
 	req.Header.Set("Content-Type", "application/json")
 
+	if update.EventID != "" {
+		req.Header.Set(idempotencyHeader, update.EventID)
+	}
+
 	resp, err := bot.client.Do(req)
 
 	if err != nil {
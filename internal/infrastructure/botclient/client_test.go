@@ -1,6 +1,7 @@
 package botclient_test
 
 import (
+	"context"
 	"errors"
 	"io"
 	"linkTraccer/internal/domain/dto"
@@ -65,7 +66,7 @@ func TestBotClient_SendLinkUpdates(t *testing.T) {
 
 	for _, test := range tests {
 		client := botclient.New(test.host, test.client)
-		err := client.SendLinkUpdates(test.update)
+		err := client.SendLinkUpdates(context.Background(), test.update)
 
 		if test.correct {
 			assert.NoError(t, err)
@@ -74,3 +75,33 @@ func TestBotClient_SendLinkUpdates(t *testing.T) {
 		}
 	}
 }
+
+func TestBotClient_SendLinkUpdatesIdempotencyKey(t *testing.T) {
+	httpClient := mocks.NewHTTPClient(t)
+
+	httpClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+		return req.Header.Get("Idempotency-Key") == "event"
+	})).Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(nil)}, nil).Once()
+
+	update := *linkUpdate
+	update.EventID = "event"
+
+	assert.NoError(t, botclient.New("localhost:8080", httpClient).SendLinkUpdates(context.Background(), &update))
+}
+
+func TestErrBadAnswerFromServer_Permanent(t *testing.T) {
+	tests := []struct {
+		code      int
+		permanent bool
+	}{
+		{code: http.StatusBadRequest, permanent: true},
+		{code: http.StatusUnprocessableEntity, permanent: true},
+		{code: http.StatusTooManyRequests, permanent: false},
+		{code: http.StatusRequestTimeout, permanent: false},
+		{code: http.StatusInternalServerError, permanent: false},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.permanent, botclient.NewErrBadAnswerFromServer(test.code).Permanent(), test.code)
+	}
+}
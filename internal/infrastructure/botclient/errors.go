@@ -1,6 +1,9 @@
 package botclient
 
-import "fmt"
+import (
+	"fmt"
+	"net/http"
+)
 
 type ErrBadAnswerFromServer struct {
 	code int
@@ -15,3 +18,9 @@ func NewErrBadAnswerFromServer(code int) *ErrBadAnswerFromServer {
 func (err *ErrBadAnswerFromServer) Error() string {
 	return fmt.Sprintf("сервер бота прислал ответ: %d ", err.code)
 }
+
+// Permanent сообщает, что бот отклонил сам запрос (4xx), и повторная отправка того же обновления ничего не изменит.
+func (err *ErrBadAnswerFromServer) Permanent() bool {
+	return err.code >= http.StatusBadRequest && err.code < http.StatusInternalServerError &&
+		err.code != http.StatusRequestTimeout && err.code != http.StatusTooManyRequests
+}
@@ -2,17 +2,30 @@ package botconf
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/caarlos0/env/v11"
 )
 
 type Config struct {
-	BotToken         string `env:"BOT_TOKEN"`
-	ScrapperPort     string `env:"SCRAPPER_PORT"`
-	ScrapperHost     string `env:"SCRAPPER_HOST"`
-	BotPort          string `env:"BOT_PORT"`
-	BotBatch         int    `env:"BOT_BATCH"`
-	UpdatesTransport string `env:"UPDATES_TRANSPORT"`
+	BotToken         string        `env:"BOT_TOKEN"`
+	ScrapperPort     string        `env:"SCRAPPER_PORT"`
+	ScrapperHost     string        `env:"SCRAPPER_HOST"`
+	BotPort          string        `env:"BOT_PORT"`
+	BotBatch         int           `env:"BOT_BATCH"`
+	UpdatesTransport string        `env:"UPDATES_TRANSPORT"`                   // KAFKA или HTTP, переменные те же, что у scrapper
+	UpdatesFallback  bool          `env:"UPDATES_FALLBACK" envDefault:"false"` // при включенном fallback бот слушает оба транспорта
+	DialogStorage    string        `env:"DIALOG_STORAGE" envDefault:"REDIS"`
+	TgUpdatesMode    string        `env:"TG_UPDATES_MODE" envDefault:"POLLING"`
+	WebhookURL       string        `env:"WEBHOOK_URL"`
+	WebhookPath      string        `env:"WEBHOOK_PATH" envDefault:"/webhook"`
+	WebhookPort      string        `env:"WEBHOOK_PORT"`
+	WebhookSecret    string        `env:"WEBHOOK_SECRET"`
+	WebhookQueueSize int           `env:"WEBHOOK_QUEUE_SIZE" envDefault:"100"`
+	TgGlobalRPS      float64       `env:"TG_GLOBAL_RPS" envDefault:"30"`
+	TgChatRPS        float64       `env:"TG_CHAT_RPS" envDefault:"1"`
+	TgMaxRetries     int           `env:"TG_MAX_RETRIES" envDefault:"3"`
+	ShutdownTimeout  time.Duration `env:"SHUTDOWN_TIMEOUT" envDefault:"10s"` // сколько ждать остановки при завершении
 }
 
 func New() (*Config, error) {
@@ -22,5 +35,10 @@ func New() (*Config, error) {
 		return nil, fmt.Errorf("ошибка при конфигурации: %w", err)
 	}
 
+	if config.TgGlobalRPS <= 0 || config.TgChatRPS <= 0 {
+		return nil, fmt.Errorf("лимиты TG_GLOBAL_RPS и TG_CHAT_RPS должны быть больше нуля, получено %v и %v",
+			config.TgGlobalRPS, config.TgChatRPS)
+	}
+
 	return config, nil
 }
@@ -1,31 +1,51 @@
 package bothandler
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"linkTraccer/internal/application/botservice"
 	"linkTraccer/internal/domain/dto"
+	"linkTraccer/internal/domain/tgbot"
 	"log/slog"
 	"net/http"
 )
 
 const (
-	contentType = "Content-Type"
-	jsonType    = "application/json"
+	contentType       = "Content-Type"
+	jsonType          = "application/json"
+	idempotencyHeader = "Idempotency-Key"
 )
 
+// ChatRemover отписывает чаты, в которые больше нельзя отправить сообщение.
+type ChatRemover interface {
+	RemoveChat(ctx context.Context, id tgbot.ID) error
+}
+
+// DeliveryLog резервирует доставку события в чат, чтобы повтор запроса не дублировал сообщение.
+type DeliveryLog interface {
+	Reserve(ctx context.Context, id tgbot.ID, eventID string) (bool, error)
+	Release(ctx context.Context, id tgbot.ID, eventID string) error
+}
+
 type UpdatesHandler struct {
-	tgClient botservice.TgClient
-	log      *slog.Logger
+	tgClient   botservice.TgClient
+	remover    ChatRemover
+	deliveries DeliveryLog
+	log        *slog.Logger
 }
 
-func New(client botservice.TgClient, log *slog.Logger) *UpdatesHandler {
+func New(client botservice.TgClient, remover ChatRemover, deliveries DeliveryLog, log *slog.Logger) *UpdatesHandler {
 	return &UpdatesHandler{
-		tgClient: client,
-		log:      log,
+		tgClient:   client,
+		remover:    remover,
+		deliveries: deliveries,
+		log:        log,
 	}
 }
 
+// HandleLinkUpdates определяет событие по Idempotency-Key, а без него - по eventId. Если части получателей
+// отправить не удалось, отвечает 503, чтобы скраппер повторил запрос.
 func (u *UpdatesHandler) HandleLinkUpdates(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		w.WriteHeader(http.StatusMethodNotAllowed)
@@ -51,15 +71,73 @@ func (u *UpdatesHandler) HandleLinkUpdates(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
+	eventID := r.Header.Get(idempotencyHeader)
+	if eventID == "" {
+		eventID = linkUpdate.EventID
+	}
+
+	// отправка не прерывается, если скраппер закрыл соединение, иначе резерв неотправленного события не снимется
+	ctx := context.WithoutCancel(r.Context())
+	msg := botservice.RenderLinkUpdate(linkUpdate)
+	sent := true
 
 	for _, userID := range linkUpdate.TgChatIDs { // переписать на горутины
-		err := u.tgClient.SendMessage(userID, linkUpdate.Description+linkUpdate.URL)
+		if !u.reserve(ctx, userID, eventID) {
+			continue
+		}
+
+		err := u.tgClient.SendMessage(ctx, userID, msg)
+
+		if tgbot.ChatUnavailable(err) {
+			if err = u.remover.RemoveChat(ctx, userID); err != nil {
+				u.log.Error("ошибка при отписке недоступного чата", "err", err.Error())
+			}
+
+			continue
+		}
 
 		if err != nil {
 			u.log.Error("ошибка при отправке обновлений по ссылке в телеграмм", "err", err.Error())
+			u.release(ctx, userID, eventID)
+
+			sent = false
 		}
 	}
+
+	// скраппер повторит запрос, а получатели, которым событие уже доставлено, его не получат второй раз
+	if !sent {
+		u.APIErrToResponse(w, dto.APIErrNotSent, http.StatusServiceUnavailable)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// reserve при ошибке хранилища разрешает отправку: лучше повторить сообщение, чем потерять его.
+func (u *UpdatesHandler) reserve(ctx context.Context, userID tgbot.ID, eventID string) bool {
+	if eventID == "" {
+		return true
+	}
+
+	reserved, err := u.deliveries.Reserve(ctx, userID, eventID)
+	if err != nil {
+		u.log.Error("ошибка при резервировании доставки события", "err", err.Error())
+
+		return true
+	}
+
+	return reserved
+}
+
+func (u *UpdatesHandler) release(ctx context.Context, userID tgbot.ID, eventID string) {
+	if eventID == "" {
+		return
+	}
+
+	if err := u.deliveries.Release(ctx, userID, eventID); err != nil {
+		u.log.Error("ошибка при снятии резерва доставки события", "err", err.Error())
+	}
 }
 
 func (u *UpdatesHandler) APIErrToResponse(w http.ResponseWriter, errAPI *dto.APIErrResponse, statusCode int) {
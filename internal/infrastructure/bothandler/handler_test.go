@@ -3,8 +3,11 @@ package bothandler_test
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"linkTraccer/internal/domain/dto"
+	"linkTraccer/internal/domain/tgbot"
 	"linkTraccer/internal/infrastructure/bothandler"
 	"linkTraccer/internal/infrastructure/bothandler/mocks"
 	"log/slog"
@@ -14,6 +17,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 var (
@@ -27,7 +31,7 @@ var (
 
 func TestUpdateServer_HandleLinkUpdates(t *testing.T) {
 	tgClient := mocks.NewTgClient(t)
-	botHandler := bothandler.New(tgClient, logger)
+	botHandler := bothandler.New(tgClient, mocks.NewChatRemover(t), mocks.NewDeliveryLog(t), logger)
 
 	type testCase struct {
 		name         string
@@ -76,3 +80,99 @@ func TestUpdateServer_HandleLinkUpdates(t *testing.T) {
 		}
 	}
 }
+
+func TestUpdateServer_HandleLinkUpdatesUnavailableChats(t *testing.T) {
+	tgClient := mocks.NewTgClient(t)
+	remover := mocks.NewChatRemover(t)
+
+	update, _ := json.Marshal(&dto.LinkUpdate{URL: "github.com", Description: "new ", TgChatIDs: []int64{1, 2, 3, 4}})
+
+	tgClient.On("SendMessage", mock.Anything, int64(1), "new github.com").Return(tgbot.BotBlocked)
+	tgClient.On("SendMessage", mock.Anything, int64(2), "new github.com").Return(fmt.Errorf("обертка: %w", tgbot.ChatNotFound))
+	tgClient.On("SendMessage", mock.Anything, int64(3), "new github.com").Return(tgbot.TooManyRequests)
+	tgClient.On("SendMessage", mock.Anything, int64(4), "new github.com").Return(nil)
+	remover.On("RemoveChat", mock.Anything, int64(1)).Return(nil).Once()
+	remover.On("RemoveChat", mock.Anything, int64(2)).Return(errors.New("ошибка в скраппере")).Once()
+
+	w := httptest.NewRecorder()
+	r := &http.Request{Method: http.MethodPost, Body: io.NopCloser(bytes.NewBuffer(update))}
+
+	bothandler.New(tgClient, remover, mocks.NewDeliveryLog(t), logger).HandleLinkUpdates(w, r)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code, "третьему чату отправить не удалось, запрос нужно повторить")
+	remover.AssertNotCalled(t, "RemoveChat", int64(3))
+	remover.AssertNotCalled(t, "RemoveChat", int64(4))
+}
+
+func TestUpdateServer_HandleLinkUpdatesIdempotency(t *testing.T) {
+	tgClient := mocks.NewTgClient(t)
+	deliveries := mocks.NewDeliveryLog(t)
+
+	update, _ := json.Marshal(&dto.LinkUpdate{URL: "github.com", EventID: "body-event", Description: "new ",
+		TgChatIDs: []int64{1, 2, 3, 4}})
+
+	// заголовок Idempotency-Key важнее eventId из тела, первому чату событие уже доставлено, для третьего
+	// зарезервировать доставку не удалось, поэтому ему событие отправляется еще раз, а четвертому
+	// отправить не удалось, и резерв снимается, чтобы повтор запроса доставил событие
+	deliveries.On("Reserve", mock.Anything, int64(1), "header-event").Return(false, nil).Once()
+	deliveries.On("Reserve", mock.Anything, int64(2), "header-event").Return(true, nil).Once()
+	deliveries.On("Reserve", mock.Anything, int64(3), "header-event").Return(false, errors.New("redis недоступен")).Once()
+	deliveries.On("Reserve", mock.Anything, int64(4), "header-event").Return(true, nil).Once()
+	tgClient.On("SendMessage", mock.Anything, int64(2), "new github.com").Return(nil).Once()
+	tgClient.On("SendMessage", mock.Anything, int64(3), "new github.com").Return(nil).Once()
+	tgClient.On("SendMessage", mock.Anything, int64(4), "new github.com").Return(errors.New("telegram недоступен")).Once()
+	deliveries.On("Release", mock.Anything, int64(4), "header-event").Return(nil).Once()
+
+	w := httptest.NewRecorder()
+	r := &http.Request{Method: http.MethodPost, Header: http.Header{}, Body: io.NopCloser(bytes.NewBuffer(update))}
+	r.Header.Set("Idempotency-Key", "header-event")
+
+	bothandler.New(tgClient, mocks.NewChatRemover(t), deliveries, logger).HandleLinkUpdates(w, r)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	tgClient.AssertNotCalled(t, "SendMessage", int64(1), "new github.com")
+}
+
+func TestUpdateServer_HandleLinkUpdatesStatus(t *testing.T) {
+	update, _ := json.Marshal(&dto.LinkUpdate{URL: "github.com", Description: "new ", TgChatIDs: []int64{1, 2}})
+
+	tests := []struct {
+		name       string
+		sendErr    error
+		httpStatus int
+	}{
+		{
+			name:       "обновление отправлено всем получателям",
+			sendErr:    nil,
+			httpStatus: http.StatusOK,
+		},
+		{
+			name:       "второй чат больше недоступен, повтор ничего не изменит",
+			sendErr:    tgbot.BotBlocked,
+			httpStatus: http.StatusOK,
+		},
+		{
+			name:       "второму чату не удалось отправить из-за временной ошибки",
+			sendErr:    errors.New("telegram недоступен"),
+			httpStatus: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tgClient := mocks.NewTgClient(t)
+			remover := mocks.NewChatRemover(t)
+
+			tgClient.On("SendMessage", mock.Anything, int64(1), "new github.com").Return(nil).Once()
+			tgClient.On("SendMessage", mock.Anything, int64(2), "new github.com").Return(test.sendErr).Once()
+			remover.On("RemoveChat", mock.Anything, int64(2)).Return(nil).Maybe()
+
+			w := httptest.NewRecorder()
+			r := &http.Request{Method: http.MethodPost, Body: io.NopCloser(bytes.NewBuffer(update))}
+
+			bothandler.New(tgClient, remover, mocks.NewDeliveryLog(t), logger).HandleLinkUpdates(w, r)
+
+			assert.Equal(t, test.httpStatus, w.Code)
+		})
+	}
+}
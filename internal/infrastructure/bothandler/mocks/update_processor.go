@@ -0,0 +1,71 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	tgbot "linkTraccer/internal/domain/tgbot"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// UpdateProcessor is an autogenerated mock type for the UpdateProcessor type
+type UpdateProcessor struct {
+	mock.Mock
+}
+
+type UpdateProcessor_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *UpdateProcessor) EXPECT() *UpdateProcessor_Expecter {
+	return &UpdateProcessor_Expecter{mock: &_m.Mock}
+}
+
+// ProcessUpdate provides a mock function with given fields: ctx, update
+func (_m *UpdateProcessor) ProcessUpdate(ctx context.Context, update tgbot.Update) {
+	_m.Called(ctx, update)
+}
+
+// UpdateProcessor_ProcessUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProcessUpdate'
+type UpdateProcessor_ProcessUpdate_Call struct {
+	*mock.Call
+}
+
+// ProcessUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - update tgbot.Update
+func (_e *UpdateProcessor_Expecter) ProcessUpdate(ctx interface{}, update interface{}) *UpdateProcessor_ProcessUpdate_Call {
+	return &UpdateProcessor_ProcessUpdate_Call{Call: _e.mock.On("ProcessUpdate", ctx, update)}
+}
+
+func (_c *UpdateProcessor_ProcessUpdate_Call) Run(run func(ctx context.Context, update tgbot.Update)) *UpdateProcessor_ProcessUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(tgbot.Update))
+	})
+	return _c
+}
+
+func (_c *UpdateProcessor_ProcessUpdate_Call) Return() *UpdateProcessor_ProcessUpdate_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *UpdateProcessor_ProcessUpdate_Call) RunAndReturn(run func(context.Context, tgbot.Update)) *UpdateProcessor_ProcessUpdate_Call {
+	_c.Run(run)
+	return _c
+}
+
+// NewUpdateProcessor creates a new instance of UpdateProcessor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewUpdateProcessor(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *UpdateProcessor {
+	mock := &UpdateProcessor{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
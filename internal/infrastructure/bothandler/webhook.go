@@ -0,0 +1,109 @@
+package bothandler
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"linkTraccer/internal/domain/dto"
+	"linkTraccer/internal/domain/tgbot"
+	"log/slog"
+	"net/http"
+)
+
+const secretTokenHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+type UpdateProcessor interface {
+	ProcessUpdate(ctx context.Context, update tgbot.Update)
+}
+
+// WebhookHandler отклоняет запросы без секретного токена и обрабатывает обновления в Run.
+type WebhookHandler struct {
+	bot         UpdateProcessor
+	secretToken string
+	updates     chan tgbot.Update
+	stop        chan struct{}
+	log         *slog.Logger
+}
+
+func NewWebhookHandler(bot UpdateProcessor, secretToken string, queueSize int, log *slog.Logger) *WebhookHandler {
+	return &WebhookHandler{
+		bot:         bot,
+		secretToken: secretToken,
+		updates:     make(chan tgbot.Update, queueSize),
+		stop:        make(chan struct{}),
+		log:         log,
+	}
+}
+
+// Run после Stop дообрабатывает уже принятые обновления.
+func (wh *WebhookHandler) Run(ctx context.Context) {
+	for {
+		select {
+		case update := <-wh.updates:
+			wh.bot.ProcessUpdate(ctx, update)
+		case <-wh.stop:
+			wh.drain(ctx)
+
+			return
+		}
+	}
+}
+
+func (wh *WebhookHandler) Stop() {
+	close(wh.stop)
+}
+
+func (wh *WebhookHandler) drain(ctx context.Context) {
+	for {
+		select {
+		case update := <-wh.updates:
+			wh.bot.ProcessUpdate(ctx, update)
+		default:
+			return
+		}
+	}
+}
+
+func (wh *WebhookHandler) HandleTgUpdate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	token := r.Header.Get(secretTokenHeader)
+
+	if subtle.ConstantTimeCompare([]byte(token), []byte(wh.secretToken)) != 1 {
+		wh.log.Error("получен запрос на вебхук с неверным секретным токеном")
+		w.WriteHeader(http.StatusUnauthorized)
+
+		return
+	}
+
+	defer r.Body.Close()
+
+	update := tgbot.Update{}
+
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		wh.APIErrToResponse(w, dto.APIErrBadJSON, http.StatusBadRequest)
+
+		return
+	}
+
+	select {
+	case wh.updates <- update:
+		w.WriteHeader(http.StatusOK)
+	default:
+		// очередь заполнена, telegram повторит доставку обновления позже
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+}
+
+func (wh *WebhookHandler) APIErrToResponse(w http.ResponseWriter, errAPI *dto.APIErrResponse, statusCode int) {
+	w.Header().Set(contentType, jsonType)
+	w.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(w).Encode(errAPI); err != nil {
+		wh.log.Error("ошибка при формировании JSON APIErrResponse", "err", err.Error())
+	}
+}
@@ -0,0 +1,118 @@
+package bothandler_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"linkTraccer/internal/domain/dto"
+	"linkTraccer/internal/domain/tgbot"
+	"linkTraccer/internal/infrastructure/bothandler"
+	"linkTraccer/internal/infrastructure/bothandler/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const secretToken = "secret"
+
+func TestWebhookHandler_HandleTgUpdate(t *testing.T) {
+	update := tgbot.Update{UpdateID: 1, Msg: tgbot.Message{From: tgbot.User{ID: 5}, Text: "/help"}}
+	updateJSON, _ := json.Marshal(update)
+
+	bot := mocks.NewUpdateProcessor(t)
+	bot.On("ProcessUpdate", mock.Anything, update).Return().Once()
+
+	webhookHandler := bothandler.NewWebhookHandler(bot, secretToken, 1, logger)
+
+	type testCase struct {
+		name         string
+		method       string
+		token        string
+		body         []byte
+		responseBody *dto.APIErrResponse
+		httpStatus   int
+	}
+
+	tests := []testCase{
+		{
+			name:       "отправляем не обрабатываемый запрос",
+			method:     http.MethodGet,
+			token:      secretToken,
+			body:       updateJSON,
+			httpStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:       "запрос без секретного токена",
+			method:     http.MethodPost,
+			body:       updateJSON,
+			httpStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "запрос с неверным секретным токеном",
+			method:     http.MethodPost,
+			token:      "wrong",
+			body:       updateJSON,
+			httpStatus: http.StatusUnauthorized,
+		},
+		{
+			name:         "некорректный json в запросе",
+			method:       http.MethodPost,
+			token:        secretToken,
+			body:         wrongJSON,
+			responseBody: dto.APIErrBadJSON,
+			httpStatus:   http.StatusBadRequest,
+		},
+		{
+			name:       "обновление принято в очередь",
+			method:     http.MethodPost,
+			token:      secretToken,
+			body:       updateJSON,
+			httpStatus: http.StatusOK,
+		},
+		{
+			name:       "очередь заполнена, telegram повторит доставку",
+			method:     http.MethodPost,
+			token:      secretToken,
+			body:       updateJSON,
+			httpStatus: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(test.method, "/webhook", bytes.NewBuffer(test.body))
+
+		if test.token != "" {
+			r.Header.Set("X-Telegram-Bot-Api-Secret-Token", test.token)
+		}
+
+		webhookHandler.HandleTgUpdate(w, r)
+
+		assert.Equal(t, test.httpStatus, w.Code, test.name)
+
+		if test.responseBody != nil {
+			respBody := &dto.APIErrResponse{}
+
+			err := json.NewDecoder(w.Body).Decode(respBody)
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.responseBody, respBody)
+		} else {
+			assert.Empty(t, w.Body)
+		}
+	}
+
+	processed := make(chan struct{})
+
+	go func() {
+		defer close(processed)
+
+		webhookHandler.Run(context.Background())
+	}()
+
+	webhookHandler.Stop()
+	<-processed
+}
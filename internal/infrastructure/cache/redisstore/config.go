@@ -3,10 +3,13 @@ package redisstore
 import (
 	"fmt"
 	"github.com/caarlos0/env/v11"
+	"time"
 )
 
 type Config struct {
-	RedisAddr string `env:"REDIS_ADDR"`
+	RedisAddr    string        `env:"REDIS_ADDR"`
+	DialogTTL    time.Duration `env:"REDIS_DIALOG_TTL" envDefault:"720h"`
+	DeliveredTTL time.Duration `env:"REDIS_DELIVERED_TTL" envDefault:"72h"` // сколько помним доставленные события
 }
 
 func NewConfig() (*Config, error) {
@@ -0,0 +1,58 @@
+package redisstore
+
+import (
+	"context"
+	"fmt"
+	"linkTraccer/internal/domain/tgbot"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+// reserveScript удаляет из множества устаревшие события и добавляет событие, только если его там еще нет.
+var reserveScript = redis.NewScript(`
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', '(' .. ARGV[2])
+local added = redis.call('ZADD', KEYS[1], 'NX', ARGV[1], ARGV[3])
+redis.call('PEXPIRE', KEYS[1], ARGV[4])
+return added
+`)
+
+// DeliveryStore хранит события чата в delivered:<id> с временем резервирования, записи старше ttl забываются.
+type DeliveryStore struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+func NewDeliveryStore(client *redis.Client, ttl time.Duration) *DeliveryStore {
+	return &DeliveryStore{
+		client: client,
+		ttl:    ttl,
+	}
+}
+
+// Reserve возвращает false, если событие уже доставлено или его доставляет другой обработчик.
+func (d *DeliveryStore) Reserve(ctx context.Context, id tgbot.ID, eventID string) (bool, error) {
+	now := time.Now()
+
+	added, err := reserveScript.Run(d.client.WithContext(ctx), []string{deliveredKey(id)},
+		now.Unix(), now.Add(-d.ttl).Unix(), eventID, d.ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, fmt.Errorf("ошибка при резервировании доставки события в чат %d: %w", id, err)
+	}
+
+	return added == 1, nil
+}
+
+// Release снимает резерв, если событие не удалось доставить, чтобы повтор отправил его снова.
+func (d *DeliveryStore) Release(ctx context.Context, id tgbot.ID, eventID string) error {
+	if err := d.client.WithContext(ctx).ZRem(deliveredKey(id), eventID).Err(); err != nil {
+		return fmt.Errorf("ошибка при снятии резерва доставки события в чат %d: %w", id, err)
+	}
+
+	return nil
+}
+
+func deliveredKey(id tgbot.ID) string {
+	return "delivered:" + strconv.FormatInt(id, 10)
+}
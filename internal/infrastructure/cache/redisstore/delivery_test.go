@@ -0,0 +1,63 @@
+package redisstore_test
+
+import (
+	"context"
+	"linkTraccer/internal/infrastructure/cache/redisstore"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/stretchr/testify/assert"
+)
+
+const deliveredTTL = time.Hour
+
+func TestDeliveryStore_Reserve(t *testing.T) {
+	ctx := context.Background()
+	_, client := newRedis(t)
+	store := redisstore.NewDeliveryStore(client, deliveredTTL)
+
+	reserved, err := store.Reserve(ctx, userID, "event")
+	assert.NoError(t, err)
+	assert.True(t, reserved)
+
+	reserved, err = store.Reserve(ctx, userID, "event")
+	assert.NoError(t, err)
+	assert.False(t, reserved, "событие доставляется в чат только один раз")
+
+	reserved, err = store.Reserve(ctx, userID+1, "event")
+	assert.NoError(t, err)
+	assert.True(t, reserved, "резерв одного чата не влияет на другие")
+
+	assert.NoError(t, store.Release(ctx, userID, "event"))
+
+	reserved, err = store.Reserve(ctx, userID, "event")
+	assert.NoError(t, err)
+	assert.True(t, reserved, "после снятия резерва событие доставляется снова")
+}
+
+func TestDeliveryStore_TTL(t *testing.T) {
+	ctx := context.Background()
+	server, client := newRedis(t)
+	store := redisstore.NewDeliveryStore(client, deliveredTTL)
+	key := "delivered:" + strconv.Itoa(userID)
+
+	reserved, err := store.Reserve(ctx, userID, "event")
+	assert.NoError(t, err)
+	assert.True(t, reserved)
+	assert.Equal(t, deliveredTTL, server.TTL(key))
+
+	server.FastForward(deliveredTTL)
+
+	reserved, err = store.Reserve(ctx, userID, "event")
+	assert.NoError(t, err)
+	assert.True(t, reserved, "ключ неактивного чата удаляется по истечении ttl")
+
+	stale := time.Now().Add(-2 * deliveredTTL).Unix()
+	assert.NoError(t, client.ZAdd(key, redis.Z{Score: float64(stale), Member: "stale"}).Err())
+
+	reserved, err = store.Reserve(ctx, userID, "stale")
+	assert.NoError(t, err)
+	assert.True(t, reserved, "событие старше ttl считается забытым, даже если ключ чата жив")
+}
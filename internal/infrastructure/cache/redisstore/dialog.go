@@ -0,0 +1,240 @@
+package redisstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"linkTraccer/internal/domain/i18n"
+	"linkTraccer/internal/domain/tgbot"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis"
+)
+
+const (
+	stateField   = "state"
+	urlField     = "url"
+	tagsField    = "tags"
+	filtersField = "filters"
+)
+
+// swapStateScript считает отсутствующее поле пустым состоянием.
+var swapStateScript = redis.NewScript(`
+local current = redis.call('HGET', KEYS[1], ARGV[1]) or ''
+if current ~= ARGV[2] then
+	return 0
+end
+redis.call('HSET', KEYS[1], ARGV[1], ARGV[3])
+redis.call('PEXPIRE', KEYS[1], ARGV[4])
+return 1
+`)
+
+// DialogStore хранит диалог в хеше dialog:<id>, каждая запись продлевает TTL ключа. Выбранный язык хранится
+// в lang:<id> без TTL, чтобы не потеряться, пока пользователь неактивен.
+type DialogStore struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+func NewDialogStore(client *redis.Client, ttl time.Duration) *DialogStore {
+	return &DialogStore{
+		client: client,
+		ttl:    ttl,
+	}
+}
+
+func (d *DialogStore) UserState(ctx context.Context, id tgbot.ID) (tgbot.State, error) {
+	state, err := d.client.WithContext(ctx).HGet(dialogKey(id), stateField).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("ошибка при получении состояния пользователя %d: %w", id, err)
+	}
+
+	return state, nil
+}
+
+func (d *DialogStore) SetUserState(ctx context.Context, id tgbot.ID, state tgbot.State) error {
+	if err := d.setFields(ctx, id, map[string]interface{}{stateField: state}); err != nil {
+		return fmt.Errorf("ошибка при сохранении состояния пользователя %d: %w", id, err)
+	}
+
+	return nil
+}
+
+func (d *DialogStore) CompareAndSwapUserState(ctx context.Context, id tgbot.ID, old, next tgbot.State) (bool, error) {
+	swapped, err := swapStateScript.Run(d.client.WithContext(ctx), []string{dialogKey(id)},
+		stateField, old, next, d.ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, fmt.Errorf("ошибка при смене состояния пользователя %d: %w", id, err)
+	}
+
+	return swapped == 1, nil
+}
+
+func (d *DialogStore) UserLang(ctx context.Context, id tgbot.ID) (i18n.Lang, error) {
+	lang, err := d.client.WithContext(ctx).Get(langKey(id)).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("ошибка при получении языка пользователя %d: %w", id, err)
+	}
+
+	return lang, nil
+}
+
+func (d *DialogStore) SetUserLang(ctx context.Context, id tgbot.ID, lang i18n.Lang) error {
+	if err := d.client.WithContext(ctx).Set(langKey(id), lang, 0).Err(); err != nil {
+		return fmt.Errorf("ошибка при сохранении языка пользователя %d: %w", id, err)
+	}
+
+	return nil
+}
+
+func (d *DialogStore) DeleteUserState(ctx context.Context, id tgbot.ID) error {
+	return d.deleteFields(ctx, id, stateField)
+}
+
+func (d *DialogStore) DeleteUserLang(ctx context.Context, id tgbot.ID) error {
+	if err := d.client.WithContext(ctx).Del(langKey(id)).Err(); err != nil {
+		return fmt.Errorf("ошибка при удалении языка пользователя %d: %w", id, err)
+	}
+
+	return nil
+}
+
+func (d *DialogStore) RegUser(ctx context.Context, id tgbot.ID) error {
+	if err := d.setFields(ctx, id, emptyContext()); err != nil {
+		return fmt.Errorf("ошибка при регистрации пользователя %d в хранилище контекста: %w", id, err)
+	}
+
+	return nil
+}
+
+func (d *DialogStore) AddURL(ctx context.Context, id tgbot.ID, url string) error {
+	return d.updateContext(ctx, id, urlField, url)
+}
+
+func (d *DialogStore) AddFilters(ctx context.Context, id tgbot.ID, filters []string) error {
+	data, err := json.Marshal(filters)
+	if err != nil {
+		return fmt.Errorf("ошибка при маршалинге фильтров пользователя %d: %w", id, err)
+	}
+
+	return d.updateContext(ctx, id, filtersField, string(data))
+}
+
+func (d *DialogStore) AddTags(ctx context.Context, id tgbot.ID, tags []string) error {
+	data, err := json.Marshal(tags)
+	if err != nil {
+		return fmt.Errorf("ошибка при маршалинге тегов пользователя %d: %w", id, err)
+	}
+
+	return d.updateContext(ctx, id, tagsField, string(data))
+}
+
+func (d *DialogStore) ResetCtx(ctx context.Context, id tgbot.ID) error {
+	if err := d.checkUser(ctx, id); err != nil {
+		return err
+	}
+
+	if err := d.setFields(ctx, id, emptyContext()); err != nil {
+		return fmt.Errorf("ошибка при сбросе контекста пользователя %d: %w", id, err)
+	}
+
+	return nil
+}
+
+func (d *DialogStore) DeleteUser(ctx context.Context, id tgbot.ID) error {
+	return d.deleteFields(ctx, id, urlField, tagsField, filtersField)
+}
+
+func (d *DialogStore) UserContext(ctx context.Context, id tgbot.ID) (*tgbot.ContextData, error) {
+	fields, err := d.client.WithContext(ctx).HGetAll(dialogKey(id)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении контекста пользователя %d: %w", id, err)
+	}
+
+	if _, ok := fields[urlField]; !ok {
+		return nil, NewErrUserNotReg(id)
+	}
+
+	userCtx := &tgbot.ContextData{URL: fields[urlField]}
+
+	if err := json.Unmarshal([]byte(fields[tagsField]), &userCtx.Tags); err != nil {
+		return nil, fmt.Errorf("ошибка при десериализации тегов пользователя %d: %w", id, err)
+	}
+
+	if err := json.Unmarshal([]byte(fields[filtersField]), &userCtx.Filters); err != nil {
+		return nil, fmt.Errorf("ошибка при десериализации фильтров пользователя %d: %w", id, err)
+	}
+
+	return userCtx, nil
+}
+
+func (d *DialogStore) updateContext(ctx context.Context, id tgbot.ID, field, value string) error {
+	if err := d.checkUser(ctx, id); err != nil {
+		return err
+	}
+
+	if err := d.setFields(ctx, id, map[string]interface{}{field: value}); err != nil {
+		return fmt.Errorf("ошибка при обновлении контекста пользователя %d: %w", id, err)
+	}
+
+	return nil
+}
+
+func (d *DialogStore) checkUser(ctx context.Context, id tgbot.ID) error {
+	registered, err := d.client.WithContext(ctx).HExists(dialogKey(id), urlField).Result()
+	if err != nil {
+		return fmt.Errorf("ошибка при проверке контекста пользователя %d: %w", id, err)
+	}
+
+	if !registered {
+		return NewErrUserNotReg(id)
+	}
+
+	return nil
+}
+
+func (d *DialogStore) setFields(ctx context.Context, id tgbot.ID, fields map[string]interface{}) error {
+	key := dialogKey(id)
+
+	pipe := d.client.WithContext(ctx).TxPipeline()
+	pipe.HMSet(key, fields)
+	pipe.Expire(key, d.ttl)
+
+	_, err := pipe.Exec()
+
+	return err
+}
+
+func (d *DialogStore) deleteFields(ctx context.Context, id tgbot.ID, fields ...string) error {
+	if err := d.client.WithContext(ctx).HDel(dialogKey(id), fields...).Err(); err != nil {
+		return fmt.Errorf("ошибка при удалении данных диалога пользователя %d: %w", id, err)
+	}
+
+	return nil
+}
+
+func emptyContext() map[string]interface{} {
+	return map[string]interface{}{
+		urlField:     "",
+		tagsField:    "[]",
+		filtersField: "[]",
+	}
+}
+
+func dialogKey(id tgbot.ID) string {
+	return "dialog:" + strconv.FormatInt(id, 10)
+}
+
+func langKey(id tgbot.ID) string {
+	return "lang:" + strconv.FormatInt(id, 10)
+}
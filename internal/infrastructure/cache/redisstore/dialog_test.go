@@ -0,0 +1,129 @@
+package redisstore_test
+
+import (
+	"context"
+	"linkTraccer/internal/domain/tgbot"
+	"linkTraccer/internal/infrastructure/cache/redisstore"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	userID    = 1
+	dialogTTL = time.Hour
+)
+
+func newRedis(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+
+	t.Cleanup(func() { _ = client.Close() })
+
+	return server, client
+}
+
+func TestDialogStore_UserState(t *testing.T) {
+	ctx := context.Background()
+	_, client := newRedis(t)
+	store := redisstore.NewDialogStore(client, dialogTTL)
+
+	state, err := store.UserState(ctx, userID)
+	assert.NoError(t, err)
+	assert.Empty(t, state, "у нового пользователя нет состояния")
+
+	swapped, err := store.CompareAndSwapUserState(ctx, userID, "wait", "done")
+	assert.NoError(t, err)
+	assert.False(t, swapped, "состояние не меняется, если оно отличается от ожидаемого")
+
+	swapped, err = store.CompareAndSwapUserState(ctx, userID, "", "wait")
+	assert.NoError(t, err)
+	assert.True(t, swapped)
+
+	state, err = store.UserState(ctx, userID)
+	assert.NoError(t, err)
+	assert.Equal(t, "wait", state)
+
+	assert.NoError(t, store.SetUserState(ctx, userID, "done"))
+
+	swapped, err = store.CompareAndSwapUserState(ctx, userID, "wait", "start")
+	assert.NoError(t, err)
+	assert.False(t, swapped, "переход от устаревшего состояния отклоняется")
+
+	assert.NoError(t, store.DeleteUserState(ctx, userID))
+
+	state, err = store.UserState(ctx, userID)
+	assert.NoError(t, err)
+	assert.Empty(t, state)
+}
+
+func TestDialogStore_UserContext(t *testing.T) {
+	ctx := context.Background()
+	_, client := newRedis(t)
+	store := redisstore.NewDialogStore(client, dialogTTL)
+
+	var notReg *redisstore.ErrUserNotReg
+
+	_, err := store.UserContext(ctx, userID)
+	assert.ErrorAs(t, err, &notReg)
+	assert.ErrorAs(t, store.AddURL(ctx, userID, "https://github.com/a/b"), &notReg)
+
+	assert.NoError(t, store.RegUser(ctx, userID))
+	assert.NoError(t, store.AddURL(ctx, userID, "https://github.com/a/b"))
+	assert.NoError(t, store.AddTags(ctx, userID, []string{"work"}))
+	assert.NoError(t, store.AddFilters(ctx, userID, []string{"type:issue"}))
+
+	userCtx, err := store.UserContext(ctx, userID)
+	assert.NoError(t, err)
+	assert.Equal(t, &tgbot.ContextData{URL: "https://github.com/a/b", Tags: []string{"work"}, Filters: []string{"type:issue"}}, userCtx)
+
+	assert.NoError(t, store.ResetCtx(ctx, userID))
+
+	userCtx, err = store.UserContext(ctx, userID)
+	assert.NoError(t, err)
+	assert.Equal(t, &tgbot.ContextData{Tags: []string{}, Filters: []string{}}, userCtx)
+
+	assert.NoError(t, store.DeleteUser(ctx, userID))
+
+	_, err = store.UserContext(ctx, userID)
+	assert.ErrorAs(t, err, &notReg)
+}
+
+func TestDialogStore_TTL(t *testing.T) {
+	ctx := context.Background()
+	server, client := newRedis(t)
+	store := redisstore.NewDialogStore(client, dialogTTL)
+
+	assert.NoError(t, store.SetUserLang(ctx, userID, "en"))
+	assert.NoError(t, store.SetUserState(ctx, userID, "wait"))
+	server.FastForward(dialogTTL / 2)
+
+	swapped, err := store.CompareAndSwapUserState(ctx, userID, "wait", "track")
+	assert.NoError(t, err)
+	assert.True(t, swapped)
+
+	server.FastForward(dialogTTL / 2)
+
+	state, err := store.UserState(ctx, userID)
+	assert.NoError(t, err)
+	assert.Equal(t, "track", state, "смена состояния продлевает TTL диалога")
+
+	server.FastForward(dialogTTL)
+
+	state, err = store.UserState(ctx, userID)
+	assert.NoError(t, err)
+	assert.Empty(t, state, "диалог неактивного пользователя удаляется")
+
+	lang, err := store.UserLang(ctx, userID)
+	assert.NoError(t, err)
+	assert.Equal(t, "en", lang, "выбранный язык не удаляется вместе с диалогом")
+
+	assert.NoError(t, store.DeleteUserLang(ctx, userID))
+
+	lang, err = store.UserLang(ctx, userID)
+	assert.NoError(t, err)
+	assert.Empty(t, lang)
+}
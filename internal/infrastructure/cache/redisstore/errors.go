@@ -0,0 +1,20 @@
+package redisstore
+
+import (
+	"fmt"
+	"linkTraccer/internal/domain/tgbot"
+)
+
+type ErrUserNotReg struct {
+	msg tgbot.ID
+}
+
+func (err *ErrUserNotReg) Error() string {
+	return fmt.Sprintf("пользователь с id = %d не регистрировался", err.msg)
+}
+
+func NewErrUserNotReg(id tgbot.ID) *ErrUserNotReg {
+	return &ErrUserNotReg{
+		msg: id,
+	}
+}
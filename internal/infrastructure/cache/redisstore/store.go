@@ -1,6 +1,7 @@
 package redisstore
 
 import (
+	"context"
 	"fmt"
 	"github.com/go-redis/redis"
 	"linkTraccer/internal/domain/tgbot"
@@ -12,38 +13,52 @@ type Store struct {
 	client *redis.Client
 }
 
-func NewStore(config *Config) (*Store, error) {
+func NewClient(config *Config) (*redis.Client, error) {
 	redisClient := redis.NewClient(&redis.Options{Addr: config.RedisAddr})
 
 	if err := redisClient.Ping().Err(); err != nil {
 		return nil, fmt.Errorf("ошибка при создании redis хранилища, не удалось установить соединение: %w", err)
 	}
 
-	return &Store{client: redisClient}, nil
+	return redisClient, nil
 }
 
-func (s *Store) SetUserLinks(id tgbot.ID, links string) error {
-	if err := s.client.Set(strconv.FormatInt(id, 10), links, time.Minute*3).Err(); err != nil {
+func NewStore(client *redis.Client) *Store {
+	return &Store{client: client}
+}
+
+// SetUserLinks хранит выдачи в хеше links:<id> по тегам, чтобы сбросить их одним удалением.
+func (s *Store) SetUserLinks(ctx context.Context, id tgbot.ID, tag tgbot.Tag, links string) error {
+	key := userLinksKey(id)
+
+	pipe := s.client.WithContext(ctx).TxPipeline()
+	pipe.HSet(key, tag, links)
+	pipe.Expire(key, time.Minute*3)
+
+	if _, err := pipe.Exec(); err != nil {
 		return fmt.Errorf("ошибка при обновлении кеша пользователя c id %d: %w", id, err)
 	}
 
 	return nil
 }
 
-func (s *Store) GetUserLinks(id tgbot.ID) (string, error) {
-	links, err := s.client.Get(strconv.FormatInt(id, 10)).Result()
+func (s *Store) GetUserLinks(ctx context.Context, id tgbot.ID, tag tgbot.Tag) (string, error) {
+	links, err := s.client.WithContext(ctx).HGet(userLinksKey(id), tag).Result()
 	if err != nil {
 		return links, fmt.Errorf("ошибка при получение ссылок пользователя из кеша: %w", err)
 	}
 
 	return links, err
-
 }
 
-func (s *Store) InvalidateUserCache(id tgbot.ID) error {
-	if err := s.client.Del(strconv.FormatInt(id, 10)).Err(); err != nil {
-		return fmt.Errorf("ошибка при инвалидации кеша, пользователя с id %d: %w", err)
+func (s *Store) InvalidateUserCache(ctx context.Context, id tgbot.ID) error {
+	if err := s.client.WithContext(ctx).Del(userLinksKey(id)).Err(); err != nil {
+		return fmt.Errorf("ошибка при инвалидации кеша, пользователя с id %d: %w", id, err)
 	}
 
 	return nil
 }
+
+func userLinksKey(id tgbot.ID) string {
+	return "links:" + strconv.FormatInt(id, 10)
+}
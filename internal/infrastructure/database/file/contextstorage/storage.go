@@ -1,6 +1,7 @@
 package contextstorage
 
 import (
+	"context"
 	"linkTraccer/internal/domain/tgbot"
 	"sync"
 )
@@ -19,7 +20,7 @@ func New() *ContextStorage {
 	}
 }
 
-func (c *ContextStorage) RegUser(id ID) error {
+func (c *ContextStorage) RegUser(_ context.Context, id ID) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -32,7 +33,7 @@ func (c *ContextStorage) RegUser(id ID) error {
 	return nil
 }
 
-func (c *ContextStorage) AddURL(id ID, url string) error {
+func (c *ContextStorage) AddURL(_ context.Context, id ID, url string) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -45,7 +46,7 @@ func (c *ContextStorage) AddURL(id ID, url string) error {
 	return nil
 }
 
-func (c *ContextStorage) AddFilters(id ID, filters []string) error {
+func (c *ContextStorage) AddFilters(_ context.Context, id ID, filters []string) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -58,7 +59,7 @@ func (c *ContextStorage) AddFilters(id ID, filters []string) error {
 	return nil
 }
 
-func (c *ContextStorage) AddTags(id ID, tags []string) error {
+func (c *ContextStorage) AddTags(_ context.Context, id ID, tags []string) error {
 	if _, ok := c.context[id]; !ok {
 		return NewErrUserNotReg(id)
 	}
@@ -68,7 +69,7 @@ func (c *ContextStorage) AddTags(id ID, tags []string) error {
 	return nil
 }
 
-func (c *ContextStorage) ResetCtx(id ID) error {
+func (c *ContextStorage) ResetCtx(_ context.Context, id ID) error {
 	if _, ok := c.context[id]; !ok {
 		return NewErrUserNotReg(id)
 	}
@@ -78,10 +79,19 @@ func (c *ContextStorage) ResetCtx(id ID) error {
 	return nil
 }
 
-func (c *ContextStorage) UserContext(id ID) (*ContextData, error) {
+func (c *ContextStorage) UserContext(_ context.Context, id ID) (*ContextData, error) {
 	if _, ok := c.context[id]; !ok {
 		return nil, NewErrUserNotReg(id)
 	}
 
 	return c.context[id], nil
 }
+
+func (c *ContextStorage) DeleteUser(_ context.Context, id ID) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.context, id)
+
+	return nil
+}
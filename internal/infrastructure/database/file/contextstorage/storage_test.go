@@ -1,6 +1,7 @@
 package contextstorage_test
 
 import (
+	"context"
 	"linkTraccer/internal/domain/tgbot"
 	"linkTraccer/internal/infrastructure/database/file/contextstorage"
 	"testing"
@@ -34,7 +35,7 @@ func TestContextStorage_RegUser(t *testing.T) {
 	contextStore := contextstorage.New()
 
 	for _, test := range tests {
-		err := contextStore.RegUser(test.id)
+		err := contextStore.RegUser(context.Background(), test.id)
 
 		if test.correct {
 			assert.NoError(t, err)
@@ -56,7 +57,7 @@ func TestContextStorage_AddUrl(t *testing.T) {
 	}
 
 	for _, user := range data {
-		_ = contextStore.RegUser(user.id)
+		_ = contextStore.RegUser(context.Background(), user.id)
 	}
 
 	type testCase struct {
@@ -82,7 +83,7 @@ func TestContextStorage_AddUrl(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		err := contextStore.AddURL(test.id, test.URL)
+		err := contextStore.AddURL(context.Background(), test.id, test.URL)
 
 		if test.correct {
 			assert.NoError(t, err)
@@ -104,7 +105,7 @@ func TestContextStorage_AddFilters(t *testing.T) {
 	}
 
 	for _, user := range data {
-		_ = contextStore.RegUser(user.id)
+		_ = contextStore.RegUser(context.Background(), user.id)
 	}
 
 	type testCase struct {
@@ -130,7 +131,7 @@ func TestContextStorage_AddFilters(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		err := contextStore.AddFilters(test.id, test.filters)
+		err := contextStore.AddFilters(context.Background(), test.id, test.filters)
 
 		if test.correct {
 			assert.NoError(t, err)
@@ -152,7 +153,7 @@ func TestContextStorage_AddTags(t *testing.T) {
 	}
 
 	for _, user := range data {
-		_ = contextStore.RegUser(user.id)
+		_ = contextStore.RegUser(context.Background(), user.id)
 	}
 
 	type testCase struct {
@@ -178,7 +179,7 @@ func TestContextStorage_AddTags(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		err := contextStore.AddTags(test.id, test.tags)
+		err := contextStore.AddTags(context.Background(), test.id, test.tags)
 
 		if test.correct {
 			assert.NoError(t, err)
@@ -200,7 +201,7 @@ func TestContextStorage_ResetCtx(t *testing.T) {
 	}
 
 	for _, user := range data {
-		_ = contextStore.RegUser(user.id)
+		_ = contextStore.RegUser(context.Background(), user.id)
 	}
 
 	type testCase struct {
@@ -223,7 +224,7 @@ func TestContextStorage_ResetCtx(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		err := contextStore.ResetCtx(test.id)
+		err := contextStore.ResetCtx(context.Background(), test.id)
 
 		if test.correct {
 			assert.NoError(t, err)
@@ -253,10 +254,10 @@ func TestContextStorage_UserContext(t *testing.T) {
 	}
 
 	for _, user := range data {
-		_ = contextStore.RegUser(user.id)
-		_ = contextStore.AddURL(user.id, user.URL)
-		_ = contextStore.AddTags(user.id, user.Tags)
-		_ = contextStore.AddFilters(user.id, user.Filters)
+		_ = contextStore.RegUser(context.Background(), user.id)
+		_ = contextStore.AddURL(context.Background(), user.id, user.URL)
+		_ = contextStore.AddTags(context.Background(), user.id, user.Tags)
+		_ = contextStore.AddFilters(context.Background(), user.id, user.Filters)
 	}
 
 	type testCase struct {
@@ -286,7 +287,7 @@ func TestContextStorage_UserContext(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		context, err := contextStore.UserContext(test.id)
+		context, err := contextStore.UserContext(context.Background(), test.id)
 
 		if test.correct {
 			assert.NoError(t, err)
@@ -0,0 +1,56 @@
+package advisorylock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Locker держит соединение с advisory lock вне пула, упавшую реплику постгрес разблокирует сам.
+type Locker struct {
+	pool *pgxpool.Pool
+}
+
+func New(pgxPool *pgxpool.Pool) *Locker {
+	return &Locker{
+		pool: pgxPool,
+	}
+}
+
+func (l *Locker) TryLock(ctx context.Context, key string) (func() error, bool, error) {
+	conn, err := l.pool.Acquire(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("ошибка при получении соединения для блокировки: %w", err)
+	}
+
+	var acquired bool
+
+	if err = conn.QueryRow(ctx, `SELECT pg_try_advisory_lock(hashtext($1))`, key).Scan(&acquired); err != nil {
+		conn.Release()
+
+		return nil, false, fmt.Errorf("ошибка при захвате блокировки: %w", err)
+	}
+
+	if !acquired {
+		conn.Release()
+
+		return nil, false, nil
+	}
+
+	// блокировка освобождается и после отмены ctx, иначе соединение с ней вернется в пул
+	release := func() error {
+		defer conn.Release()
+
+		if _, err := conn.Exec(context.Background(), `SELECT pg_advisory_unlock(hashtext($1))`, key); err != nil {
+			// закрытие соединения снимает блокировку, даже если unlock не прошел
+			return errors.Join(fmt.Errorf("ошибка при освобождении блокировки: %w", err),
+				conn.Conn().Close(context.Background()))
+		}
+
+		return nil
+	}
+
+	return release, true, nil
+}
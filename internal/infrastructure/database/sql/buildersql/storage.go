@@ -1,17 +1,21 @@
 package buildersql
 
 import (
+	"cmp"
 	"context"
-	"errors"
+	"encoding/json"
 	"fmt"
 	"linkTraccer/internal/application/scrapper/scrapservice"
+	"linkTraccer/internal/domain/i18n"
 	"linkTraccer/internal/domain/scrapper"
 	"linkTraccer/internal/infrastructure/database/sql"
 	"linkTraccer/internal/infrastructure/database/sql/transactor"
+	"slices"
 	"time"
 
 	"github.com/doug-martin/goqu/v9"
 	_ "github.com/doug-martin/goqu/v9/dialect/postgres" // диалект для постгреса
+	"github.com/doug-martin/goqu/v9/exp"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -23,21 +27,27 @@ const (
 
 type LinkInfo = scrapper.LinkInfo
 type LinkID = scrapper.LinkID
+type Tag = scrapper.Tag
+type Filter = scrapper.Filter
+type UserLink = scrapper.UserLink
 
 type UserStorage struct {
 	batchSize uint
+	linkLease time.Duration
 	db        *pgxpool.Pool
 }
 
+// linkPaginator арендует батчи наступивших ссылок на время lease, чтобы каждую ссылку проверяла одна реплика.
 type linkPaginator struct {
-	db         *pgxpool.Pool
-	lastLinkID int64
-	limit      uint
-	hasLinks   bool
+	db       *pgxpool.Pool
+	dueTime  time.Time
+	lease    time.Duration
+	limit    uint
+	hasLinks bool
 }
 
 func NewStore(dbConfig *sql.DBConfig, pgxPool *pgxpool.Pool) *UserStorage {
-	return &UserStorage{db: pgxPool, batchSize: dbConfig.BatchSize}
+	return &UserStorage{db: pgxPool, batchSize: dbConfig.BatchSize, linkLease: dbConfig.LinkLease}
 }
 
 func (u *UserStorage) TrackLink(ctx context.Context, userID scrapper.User, link scrapper.Link, addTime time.Time) error {
@@ -55,7 +65,7 @@ func (u *UserStorage) TrackLink(ctx context.Context, userID scrapper.User, link
 		UnionAll(goqu.From("links").Select("link_id").Where(goqu.Ex{"link_url": goqu.L("$1")})).
 		ToSQL()
 
-	if err := conn.QueryRow(context.Background(), sqlCmd, link, addTime).
+	if err := conn.QueryRow(ctx, sqlCmd, link, addTime).
 		Scan(&linkID); err != nil {
 		return fmt.Errorf("ошибка при добавлении в таблицу links: %w", err)
 	}
@@ -65,7 +75,7 @@ func (u *UserStorage) TrackLink(ctx context.Context, userID scrapper.User, link
 		Vals(goqu.Vals{goqu.L("$1")}).
 		OnConflict(goqu.DoNothing()).ToSQL()
 
-	if _, err := conn.Exec(context.Background(), sqlCmd, userID); err != nil {
+	if _, err := conn.Exec(ctx, sqlCmd, userID); err != nil {
 		return fmt.Errorf("ошибка при добавлении в таблицу users")
 	}
 
@@ -74,27 +84,136 @@ func (u *UserStorage) TrackLink(ctx context.Context, userID scrapper.User, link
 		Vals(goqu.Vals{goqu.L("$1"), goqu.L("$2")}).
 		ToSQL()
 
-	if _, err := conn.Exec(context.Background(), sqlCmd, userID, linkID); err != nil {
+	if _, err := conn.Exec(ctx, sqlCmd, userID, linkID); err != nil {
 		return fmt.Errorf("ошибка при добавлении в таблицу userlinks")
 	}
 
 	return nil
 }
 
-func (u *UserStorage) ChangeLastCheckTime(link scrapper.Link, checkTime time.Time) error {
+func (u *UserStorage) ChangeLastCheckTime(ctx context.Context, link scrapper.Link, checkTime time.Time) error {
+	conn := transactor.GetQuerier(ctx, u.db)
+
 	sqlCmd, _, _ := goqu.Update("links").
 		Set(goqu.Record{"last_update_check": goqu.L("$2")}).
 		Where(goqu.Ex{"link_url": goqu.L("$1")}).
 		ToSQL()
 
-	if _, err := u.db.Exec(context.Background(), sqlCmd, link, checkTime); err != nil {
+	if _, err := conn.Exec(ctx, sqlCmd, link, checkTime); err != nil {
 		return fmt.Errorf("ошибка при изменении времени: %w", err)
 	}
 
 	return nil
 }
 
-func (u *UserStorage) UsersWhoTrackLink(linkID LinkID) ([]scrapper.User, error) {
+// ScheduleNextCheck сохраняет расписание проверок ссылки и снимает с нее аренду.
+func (u *UserStorage) ScheduleNextCheck(ctx context.Context, linkID LinkID, schedule *scrapper.LinkSchedule) error {
+	conn := transactor.GetQuerier(ctx, u.db)
+
+	sqlCmd, _, _ := goqu.Update("links").
+		Set(goqu.Record{
+			"check_interval": goqu.L("$2"),
+			"check_failures": goqu.L("$3"),
+			"next_check_at":  goqu.L("$4"),
+			"leased_until":   nil,
+		}).
+		Where(goqu.Ex{"link_id": goqu.L("$1")}).
+		ToSQL()
+
+	if _, err := conn.Exec(ctx, sqlCmd, linkID, schedule.Interval, schedule.Failures, schedule.NextCheck); err != nil {
+		return fmt.Errorf("ошибка при изменении расписания проверок ссылки: %w", err)
+	}
+
+	return nil
+}
+
+// ExtendLinkLease продлевает аренду, только если ссылку с тех пор не арендовала другая реплика.
+func (u *UserStorage) ExtendLinkLease(ctx context.Context, linkInfo *LinkInfo) (bool, error) {
+	var leasedUntil time.Time
+
+	sqlCmd, _, _ := goqu.Dialect("postgres").Update("links").
+		Set(goqu.Record{"leased_until": goqu.L("CURRENT_TIMESTAMP + ($3)::interval")}).
+		Where(goqu.Ex{"link_id": goqu.L("$1"), "leased_until": goqu.L("$2")}).
+		Returning("leased_until").
+		ToSQL()
+
+	err := u.db.QueryRow(ctx, sqlCmd, linkInfo.ID, linkInfo.LeasedUntil, u.linkLease).Scan(&leasedUntil)
+
+	if err == pgx.ErrNoRows {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("ошибка при продлении аренды ссылки: %w", err)
+	}
+
+	linkInfo.LeasedUntil = leasedUntil
+
+	return true, nil
+}
+
+// SetCheckInterval задает интервал проверки ссылки пользователя, нулевой интервал возвращает адаптивный.
+func (u *UserStorage) SetCheckInterval(ctx context.Context, userID scrapper.User, link scrapper.Link, interval time.Duration) error {
+	sqlCmd, _, _ := goqu.Update("links").
+		With("updated", goqu.Update("userlinks").
+			Set(goqu.Record{"check_interval": goqu.L("NULLIF(($3)::interval, INTERVAL '0')")}).
+			From("links").
+			Where(goqu.Ex{
+				"userlinks.link_id": goqu.I("links.link_id"),
+				"userlinks.user_id": goqu.L("$1"),
+				"links.link_url":    goqu.L("$2"),
+			}).
+			Returning("userlinks.link_id")).
+		Set(goqu.Record{"next_check_at": goqu.L("LEAST(next_check_at, CURRENT_TIMESTAMP + ($3)::interval)")}).
+		Where(goqu.C("link_id").In(goqu.From("updated").Select("link_id"))).
+		ToSQL()
+
+	if _, err := u.db.Exec(ctx, sqlCmd, userID, link, interval); err != nil {
+		return fmt.Errorf("ошибка при изменении интервала проверки ссылки: %w", err)
+	}
+
+	return nil
+}
+
+// LinkCursors возвращает курсоры ссылки по типам элементов.
+func (u *UserStorage) LinkCursors(ctx context.Context, linkID LinkID) (map[scrapper.UpdateType]*scrapper.Cursor, error) {
+	sqlCmd, _, _ := goqu.From("link_cursors").
+		Select("item_type", "item_id", "item_time").
+		Where(goqu.Ex{"link_id": goqu.L("$1")}).
+		ToSQL()
+
+	rows, err := u.db.Query(ctx, sqlCmd, linkID)
+
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении курсоров ссылки: %w", err)
+	}
+
+	return scanCursors(rows)
+}
+
+// SaveLinkCursors сохраняет курсоры ссылки, остальные курсоры ссылки не меняются.
+func (u *UserStorage) SaveLinkCursors(ctx context.Context, linkID LinkID, cursors map[scrapper.UpdateType]*scrapper.Cursor) error {
+	conn := transactor.GetQuerier(ctx, u.db)
+	types, ids, times := cursorColumns(cursors)
+
+	sqlCmd, _, _ := goqu.Insert("link_cursors").
+		Cols("link_id", "item_type", "item_id", "item_time").
+		FromQuery(goqu.Select(goqu.L("$1"), goqu.L("unnest(($2)::text[])"), goqu.L("unnest(($3)::bigint[])"),
+			goqu.L("unnest(($4)::timestamptz[])"))).
+		OnConflict(goqu.DoUpdate("link_id, item_type", goqu.Record{
+			"item_id":   goqu.L("EXCLUDED.item_id"),
+			"item_time": goqu.L("EXCLUDED.item_time"),
+		})).
+		ToSQL()
+
+	if _, err := conn.Exec(ctx, sqlCmd, linkID, types, ids, times); err != nil {
+		return fmt.Errorf("ошибка при сохранении курсоров ссылки: %w", err)
+	}
+
+	return nil
+}
+
+func (u *UserStorage) UsersWhoTrackLink(ctx context.Context, linkID LinkID) ([]scrapper.User, error) {
 	var user scrapper.User
 
 	sqlCms, _, _ := goqu.From("userlinks").
@@ -102,7 +221,7 @@ func (u *UserStorage) UsersWhoTrackLink(linkID LinkID) ([]scrapper.User, error)
 		Where(goqu.Ex{"link_id": goqu.L("$1")}).
 		ToSQL()
 
-	rows, err := u.db.Query(context.Background(), sqlCms, linkID)
+	rows, err := u.db.Query(ctx, sqlCms, linkID)
 
 	if err != nil {
 		return nil, fmt.Errorf("ошибка при получении отслеживающих ссылку пользователей: %w", err)
@@ -125,25 +244,155 @@ func (u *UserStorage) UsersWhoTrackLink(linkID LinkID) ([]scrapper.User, error)
 	return users, nil
 }
 
-func (u *UserStorage) AllUserLinks(userID scrapper.User) ([]scrapper.Link, error) {
-	var link scrapper.Link
+func (u *UserStorage) AddLinkTags(ctx context.Context, userID scrapper.User, link scrapper.Link, tags []Tag) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	conn := transactor.GetQuerier(ctx, u.db)
+
+	sqlCmd, _, _ := goqu.Insert("tags").
+		Cols("tag_name").
+		FromQuery(goqu.Select(goqu.L("unnest(($1)::text[])"))).
+		OnConflict(goqu.DoNothing()).
+		ToSQL()
+
+	if _, err := conn.Exec(ctx, sqlCmd, tags); err != nil {
+		return fmt.Errorf("ошибка при добавлении в таблицу tags: %w", err)
+	}
+
+	sqlCmd, _, _ = goqu.Insert("userlinktags").
+		Cols("user_id", "link_id", "tag_id").
+		FromQuery(goqu.From("links").
+			Select(goqu.L("$1"), "links.link_id", "tags.tag_id").
+			Join(goqu.T("tags"), goqu.On(goqu.L("tags.tag_name = ANY(($3)::text[])"))).
+			Where(goqu.Ex{"links.link_url": goqu.L("$2")})).
+		OnConflict(goqu.DoNothing()).
+		ToSQL()
+
+	if _, err := conn.Exec(ctx, sqlCmd, userID, link, tags); err != nil {
+		return fmt.Errorf("ошибка при добавлении в таблицу userlinktags: %w", err)
+	}
+
+	return nil
+}
+
+func (u *UserStorage) AddLinkFilters(ctx context.Context, userID scrapper.User, link scrapper.Link, filters []Filter) error {
+	if len(filters) == 0 {
+		return nil
+	}
+
+	conn := transactor.GetQuerier(ctx, u.db)
+
+	sqlCmd, _, _ := goqu.Insert("filters").
+		Cols("filter_value").
+		FromQuery(goqu.Select(goqu.L("unnest(($1)::text[])"))).
+		OnConflict(goqu.DoNothing()).
+		ToSQL()
+
+	if _, err := conn.Exec(ctx, sqlCmd, filters); err != nil {
+		return fmt.Errorf("ошибка при добавлении в таблицу filters: %w", err)
+	}
+
+	sqlCmd, _, _ = goqu.Insert("userlinkfilters").
+		Cols("user_id", "link_id", "filter_id").
+		FromQuery(goqu.From("links").
+			Select(goqu.L("$1"), "links.link_id", "filters.filter_id").
+			Join(goqu.T("filters"), goqu.On(goqu.L("filters.filter_value = ANY(($3)::text[])"))).
+			Where(goqu.Ex{"links.link_url": goqu.L("$2")})).
+		OnConflict(goqu.DoNothing()).
+		ToSQL()
+
+	if _, err := conn.Exec(ctx, sqlCmd, userID, link, filters); err != nil {
+		return fmt.Errorf("ошибка при добавлении в таблицу userlinkfilters: %w", err)
+	}
+
+	return nil
+}
+
+func (u *UserStorage) UsersFilters(ctx context.Context, linkID LinkID) (map[scrapper.User][]Filter, error) {
+	userFilters := goqu.From("userlinkfilters").
+		Select("filters.filter_value").
+		Join(goqu.T("filters"), goqu.On(goqu.Ex{"filters.filter_id": goqu.I("userlinkfilters.filter_id")})).
+		Where(goqu.Ex{
+			"userlinkfilters.user_id": goqu.I("userlinks.user_id"),
+			"userlinkfilters.link_id": goqu.I("userlinks.link_id"),
+		})
+
+	sqlCmd, _, _ := goqu.From("userlinks").
+		Select("userlinks.user_id", goqu.L("ARRAY(?)", userFilters)).
+		Where(goqu.Ex{"userlinks.link_id": goqu.L("$1")}).
+		ToSQL()
+
+	rows, err := u.db.Query(ctx, sqlCmd, linkID)
+
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении фильтров пользователей, отслеживающих ссылку: %w", err)
+	}
+
+	defer rows.Close()
+
+	usersFilters := make(map[scrapper.User][]Filter, usersCap)
+
+	for rows.Next() {
+		var user scrapper.User
+
+		var filters []Filter
+
+		if err = rows.Scan(&user, &filters); err != nil {
+			return nil, fmt.Errorf("ошибка при чтении строки: %w", err)
+		}
+
+		usersFilters[user] = filters
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при получении фильтров пользователей: %w", err)
+	}
+
+	return usersFilters, nil
+}
+
+func (u *UserStorage) AllUserLinks(ctx context.Context, userID scrapper.User) ([]*UserLink, error) {
+	linkTags := goqu.From("userlinktags").
+		Select("tags.tag_name").
+		Join(goqu.T("tags"), goqu.On(goqu.Ex{"tags.tag_id": goqu.I("userlinktags.tag_id")})).
+		Where(goqu.Ex{
+			"userlinktags.user_id": goqu.I("userlinks.user_id"),
+			"userlinktags.link_id": goqu.I("userlinks.link_id"),
+		}).
+		Order(goqu.I("tags.tag_name").Asc())
+
+	linkFilters := goqu.From("userlinkfilters").
+		Select("filters.filter_value").
+		Join(goqu.T("filters"), goqu.On(goqu.Ex{"filters.filter_id": goqu.I("userlinkfilters.filter_id")})).
+		Where(goqu.Ex{
+			"userlinkfilters.user_id": goqu.I("userlinks.user_id"),
+			"userlinkfilters.link_id": goqu.I("userlinks.link_id"),
+		}).
+		Order(goqu.I("filters.filter_value").Asc())
 
 	sqlCmd, _, _ := goqu.From("links").
-		Select("link_url").
+		Select("links.link_id", "links.link_url", goqu.L("ARRAY(?)", linkTags), goqu.L("ARRAY(?)", linkFilters)).
 		Join(goqu.T("userlinks"), goqu.On(goqu.Ex{"links.link_id": goqu.I("userlinks.link_id")})).
 		Where(goqu.Ex{"userlinks.user_id": goqu.L("$1")}).
+		Order(goqu.I("links.link_id").Asc()).
 		ToSQL()
 
-	rows, err := u.db.Query(context.Background(), sqlCmd, userID)
+	rows, err := u.db.Query(ctx, sqlCmd, userID)
 
 	if err != nil {
 		return nil, fmt.Errorf("ошибка при выполнении запроса на получение всех ссылок пользователя: %w", err)
 	}
 
-	links := make([]scrapper.Link, 0, linkCap)
+	defer rows.Close()
+
+	links := make([]*UserLink, 0, linkCap)
 
 	for rows.Next() {
-		if err = rows.Scan(&link); err != nil {
+		link := &UserLink{}
+
+		if err = rows.Scan(&link.ID, &link.URL, &link.Tags, &link.Filters); err != nil {
 			return nil, fmt.Errorf("ошибка при чтении строки: %w", err)
 		}
 
@@ -157,7 +406,7 @@ func (u *UserStorage) AllUserLinks(userID scrapper.User) ([]scrapper.Link, error
 	return links, nil
 }
 
-func (u *UserStorage) UserTrackLink(userID scrapper.User, url scrapper.Link) (bool, error) { // переписать на Query Row
+func (u *UserStorage) UserTrackLink(ctx context.Context, userID scrapper.User, url scrapper.Link) (bool, error) { // переписать на Query Row
 	var link scrapper.Link
 
 	sqlCmd, _, _ := goqu.From("links").
@@ -166,7 +415,7 @@ func (u *UserStorage) UserTrackLink(userID scrapper.User, url scrapper.Link) (bo
 		Where(goqu.Ex{"userlinks.user_id": goqu.L("$1")}, goqu.Ex{"links.link_url": goqu.L("$2")}).
 		ToSQL()
 
-	rows, err := u.db.Query(context.Background(), sqlCmd, userID, url)
+	rows, err := u.db.Query(ctx, sqlCmd, userID, url)
 
 	if err != nil {
 		return false, fmt.Errorf("ошибка при выполнении запроса на получение всех ссылок пользователя: %w", err)
@@ -185,7 +434,7 @@ func (u *UserStorage) UserTrackLink(userID scrapper.User, url scrapper.Link) (bo
 	return link != "", nil
 }
 
-func (u *UserStorage) UserExist(userID scrapper.User) (bool, error) {
+func (u *UserStorage) UserExist(ctx context.Context, userID scrapper.User) (bool, error) {
 	var user scrapper.User
 
 	sqlCmd, _, _ := goqu.From("users").
@@ -193,7 +442,7 @@ func (u *UserStorage) UserExist(userID scrapper.User) (bool, error) {
 		Where(goqu.Ex{"user_id": goqu.L("$1")}).
 		ToSQL()
 
-	rows, err := u.db.Query(context.Background(), sqlCmd, userID)
+	rows, err := u.db.Query(ctx, sqlCmd, userID)
 
 	if err != nil {
 		return false, fmt.Errorf("ошибка при создании соединения:%w", err)
@@ -214,14 +463,14 @@ func (u *UserStorage) UserExist(userID scrapper.User) (bool, error) {
 	return user == userID, nil
 }
 
-func (u *UserStorage) RegUser(userID scrapper.User) error {
+func (u *UserStorage) RegUser(ctx context.Context, userID scrapper.User) error {
 	sqlCmd, _, _ := goqu.Insert("users").
 		Cols("user_id").
 		Vals(goqu.Vals{goqu.L("$1")}).
 		OnConflict(goqu.DoNothing()).
 		ToSQL()
 
-	if _, err := u.db.Exec(context.Background(), sqlCmd, userID); err != nil {
+	if _, err := u.db.Exec(ctx, sqlCmd, userID); err != nil {
 		return fmt.Errorf("ошибка при добавлении нового пользователя: %w", err)
 	}
 
@@ -233,13 +482,13 @@ func (u *UserStorage) DeleteUser(ctx context.Context, user scrapper.User) error
 
 	sqlCmd, _, _ := goqu.Delete("userlinks").Where(goqu.Ex{"user_id": goqu.L("$1")}).ToSQL()
 
-	if _, err := conn.Exec(context.Background(), sqlCmd, user); err != nil {
+	if _, err := conn.Exec(ctx, sqlCmd, user); err != nil {
 		return fmt.Errorf("ошибка при удалении пользователя: %w", err)
 	}
 
 	sqlCmd, _, _ = goqu.Delete("users").Where(goqu.Ex{"user_id": goqu.L("$1")}).ToSQL()
 
-	if _, err := conn.Exec(context.Background(), sqlCmd, user); err != nil {
+	if _, err := conn.Exec(ctx, sqlCmd, user); err != nil {
 		return fmt.Errorf("ошибка при удалении пользователя: %w", err)
 	}
 
@@ -248,8 +497,8 @@ func (u *UserStorage) DeleteUser(ctx context.Context, user scrapper.User) error
 
 // using нету в библиотеке, по этому оставил запрос на чистом sql
 
-func (u *UserStorage) UntrackLink(user scrapper.User, link scrapper.Link) error {
-	_, err := u.db.Exec(context.Background(),
+func (u *UserStorage) UntrackLink(ctx context.Context, user scrapper.User, link scrapper.Link) error {
+	_, err := u.db.Exec(ctx,
 		`DELETE FROM userlinks USING links 
        WHERE userlinks.link_id = links.link_id AND links.link_url = ($1) AND userlinks.user_id = ($2)`,
 		link, user)
@@ -261,8 +510,11 @@ func (u *UserStorage) UntrackLink(user scrapper.User, link scrapper.Link) error
 	return nil
 }
 
-func (u *UserStorage) DeleteUntrackedLinks() error {
-	_, err := u.db.Exec(context.Background(), `DELETE FROM links 
+// DeleteUntrackedLinks удаляет ссылки, которые больше никто не отслеживает.
+func (u *UserStorage) DeleteUntrackedLinks(ctx context.Context) error {
+	conn := transactor.GetQuerier(ctx, u.db)
+
+	_, err := conn.Exec(ctx, `DELETE FROM links 
        									 WHERE link_id NOT IN (SELECT  link_id FROM userlinks)`)
 
 	if err != nil {
@@ -272,31 +524,639 @@ func (u *UserStorage) DeleteUntrackedLinks() error {
 	return nil
 }
 
+// SetDigestTime сохраняет время дайджеста, если сегодня оно уже прошло, следующий дайджест уйдет завтра.
+func (u *UserStorage) SetDigestTime(ctx context.Context, user scrapper.User, sendTime string, now time.Time) error {
+	localNow := goqu.L("(($3)::timestamptz AT TIME ZONE users.timezone)")
+
+	sqlCmd, _, _ := goqu.Insert("update_time").
+		Cols("user_id", "send_time", "last_sent_date").
+		FromQuery(goqu.From("users").
+			Select(
+				goqu.I("users.user_id"),
+				goqu.L("($2)::text::time"),
+				goqu.Case().When(goqu.L("($2)::text::time <= ?::time", localNow), goqu.L("?::date", localNow)),
+			).
+			Where(goqu.Ex{"users.user_id": goqu.L("$1")})).
+		OnConflict(goqu.DoUpdate("user_id", goqu.Record{
+			"send_time":      goqu.L("EXCLUDED.send_time"),
+			"last_sent_date": goqu.L("EXCLUDED.last_sent_date"),
+		})).
+		ToSQL()
+
+	if _, err := u.db.Exec(ctx, sqlCmd, user, sendTime, now); err != nil {
+		return fmt.Errorf("ошибка при сохранении времени отправки дайджеста: %w", err)
+	}
+
+	return nil
+}
+
+func (u *UserStorage) SetInstantDelivery(ctx context.Context, user scrapper.User) error {
+	sqlCmd, _, _ := goqu.Delete("update_time").Where(goqu.Ex{"user_id": goqu.L("$1")}).ToSQL()
+
+	if _, err := u.db.Exec(ctx, sqlCmd, user); err != nil {
+		return fmt.Errorf("ошибка при удалении времени отправки дайджеста: %w", err)
+	}
+
+	return nil
+}
+
+func (u *UserStorage) DigestUsers(ctx context.Context, users []scrapper.User) ([]scrapper.User, error) {
+	sqlCmd, _, _ := goqu.From("update_time").
+		Select("user_id").
+		Where(goqu.L("user_id = ANY(($1)::bigint[])")).
+		ToSQL()
+
+	rows, err := u.db.Query(ctx, sqlCmd, users)
+
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении пользователей с дайджестом: %w", err)
+	}
+
+	return scanUsers(rows)
+}
+
+// DueDigestUsers возвращает пользователей, которым пора отправить дайджест или отложенные обновления.
+func (u *UserStorage) DueDigestUsers(ctx context.Context, now time.Time) ([]scrapper.User, error) {
+	sqlCmd, _, _ := goqu.From("update_time").
+		Select("update_time.user_id").
+		Join(goqu.T("users"), goqu.On(goqu.Ex{"users.user_id": goqu.I("update_time.user_id")})).
+		Where(
+			goqu.L("send_time <= (($1)::timestamptz AT TIME ZONE users.timezone)::time"),
+			goqu.Or(goqu.C("last_sent_date").IsNull(),
+				goqu.L("last_sent_date < (($1)::timestamptz AT TIME ZONE users.timezone)::date")),
+		).
+		Union(goqu.From("pending_updates").
+			Select("user_id").
+			Distinct().
+			Where(goqu.C("user_id").NotIn(goqu.From("update_time").Select("user_id")))).
+		ToSQL()
+
+	rows, err := u.db.Query(ctx, sqlCmd, now)
+
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении пользователей для отправки дайджеста: %w", err)
+	}
+
+	return scanUsers(rows)
+}
+
+func (u *UserStorage) SavePendingUpdate(ctx context.Context, linkID LinkID, update *scrapper.LinkUpdate,
+	users []scrapper.User) error {
+	conn := transactor.GetQuerier(ctx, u.db)
+
+	sqlCmd, _, _ := goqu.Insert("pending_updates").
+		Cols("user_id", "link_id", "site", "update_type", "item_id", "item_url", "author", "item_time", "title", "excerpt").
+		FromQuery(goqu.Select(goqu.L("unnest(($1)::bigint[])"), goqu.L("$2"), goqu.L("$3"), goqu.L("$4"),
+			goqu.L("$5"), goqu.L("$6"), goqu.L("$7"), goqu.L("$8"), goqu.L("$9"), goqu.L("$10"))).
+		ToSQL()
+
+	_, err := conn.Exec(ctx, sqlCmd,
+		users, linkID, update.Site, update.Type, update.ItemID, update.ItemURL, update.Author, update.ItemTime,
+		update.Title, update.Excerpt)
+
+	if err != nil {
+		return fmt.Errorf("ошибка при добавлении в таблицу pending_updates: %w", err)
+	}
+
+	return nil
+}
+
+func (u *UserStorage) PendingUpdates(ctx context.Context, user scrapper.User) ([]*scrapper.PendingUpdate, error) {
+	conn := transactor.GetQuerier(ctx, u.db)
+
+	sqlCmd, _, _ := goqu.From("pending_updates").
+		Select("pending_updates.update_id", "pending_updates.link_id", "links.link_url", "pending_updates.site",
+			"pending_updates.update_type", "pending_updates.item_id", "pending_updates.item_url", "pending_updates.author",
+			"pending_updates.item_time", "pending_updates.title", "pending_updates.excerpt").
+		Join(goqu.T("links"), goqu.On(goqu.Ex{"links.link_id": goqu.I("pending_updates.link_id")})).
+		Where(goqu.Ex{"pending_updates.user_id": goqu.L("$1")}).
+		Order(goqu.I("pending_updates.update_id").Asc()).
+		ToSQL()
+
+	rows, err := conn.Query(ctx, sqlCmd, user)
+
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении отложенных обновлений: %w", err)
+	}
+
+	defer rows.Close()
+
+	pendingUpdates := make([]*scrapper.PendingUpdate, 0, linkCap)
+
+	for rows.Next() {
+		pending := &scrapper.PendingUpdate{Update: &scrapper.LinkUpdate{}}
+
+		if err = rows.Scan(&pending.ID, &pending.LinkID, &pending.URL, &pending.Update.Site, &pending.Update.Type,
+			&pending.Update.ItemID, &pending.Update.ItemURL, &pending.Update.Author, &pending.Update.ItemTime,
+			&pending.Update.Title, &pending.Update.Excerpt); err != nil {
+			return nil, fmt.Errorf("ошибка при чтении строки: %w", err)
+		}
+
+		pending.Update.ItemTime = pending.Update.ItemTime.UTC()
+
+		pendingUpdates = append(pendingUpdates, pending)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при получении отложенных обновлений: %w", err)
+	}
+
+	return pendingUpdates, nil
+}
+
+func (u *UserStorage) DeletePendingUpdates(ctx context.Context, user scrapper.User, lastUpdateID int64) error {
+	conn := transactor.GetQuerier(ctx, u.db)
+
+	sqlCmd, _, _ := goqu.Delete("pending_updates").
+		Where(goqu.Ex{"user_id": goqu.L("$1")}, goqu.C("update_id").Lte(goqu.L("$2"))).
+		ToSQL()
+
+	if _, err := conn.Exec(ctx, sqlCmd, user, lastUpdateID); err != nil {
+		return fmt.Errorf("ошибка при удалении отправленных обновлений: %w", err)
+	}
+
+	return nil
+}
+
+func (u *UserStorage) MarkDigestSent(ctx context.Context, user scrapper.User, sentAt time.Time) error {
+	conn := transactor.GetQuerier(ctx, u.db)
+
+	sqlCmd, _, _ := goqu.Update("update_time").
+		Set(goqu.Record{"last_sent_date": goqu.L("(($2)::timestamptz AT TIME ZONE users.timezone)::date")}).
+		From("users").
+		Where(goqu.Ex{"update_time.user_id": goqu.L("$1"), "users.user_id": goqu.I("update_time.user_id")}).
+		ToSQL()
+
+	if _, err := conn.Exec(ctx, sqlCmd, user, sentAt); err != nil {
+		return fmt.Errorf("ошибка при сохранении даты отправки дайджеста: %w", err)
+	}
+
+	return nil
+}
+
+func (u *UserStorage) SetQuietHours(ctx context.Context, user scrapper.User, start, end string) error {
+	sqlCmd, _, _ := goqu.Insert("quiet_hours").
+		Cols("user_id", "quiet_start", "quiet_end").
+		Vals(goqu.Vals{goqu.L("$1"), goqu.L("($2)::text::time"), goqu.L("($3)::text::time")}).
+		OnConflict(goqu.DoUpdate("user_id", goqu.Record{
+			"quiet_start": goqu.L("EXCLUDED.quiet_start"),
+			"quiet_end":   goqu.L("EXCLUDED.quiet_end"),
+		})).
+		ToSQL()
+
+	if _, err := u.db.Exec(ctx, sqlCmd, user, start, end); err != nil {
+		return fmt.Errorf("ошибка при сохранении тихих часов: %w", err)
+	}
+
+	return nil
+}
+
+func (u *UserStorage) DisableQuietHours(ctx context.Context, user scrapper.User) error {
+	sqlCmd, _, _ := goqu.Delete("quiet_hours").Where(goqu.Ex{"user_id": goqu.L("$1")}).ToSQL()
+
+	if _, err := u.db.Exec(ctx, sqlCmd, user); err != nil {
+		return fmt.Errorf("ошибка при удалении тихих часов: %w", err)
+	}
+
+	return nil
+}
+
+func (u *UserStorage) SetTimezone(ctx context.Context, user scrapper.User, timezone string) error {
+	sqlCmd, _, _ := goqu.Update("users").
+		Set(goqu.Record{"timezone": goqu.L("$2")}).
+		Where(goqu.Ex{"user_id": goqu.L("$1")}).
+		ToSQL()
+
+	if _, err := u.db.Exec(ctx, sqlCmd, user, timezone); err != nil {
+		return fmt.Errorf("ошибка при сохранении часового пояса: %w", err)
+	}
+
+	return nil
+}
+
+func (u *UserStorage) SetLanguage(ctx context.Context, user scrapper.User, lang i18n.Lang) error {
+	sqlCmd, _, _ := goqu.Update("users").
+		Set(goqu.Record{"language": goqu.L("$2")}).
+		Where(goqu.Ex{"user_id": goqu.L("$1")}).
+		ToSQL()
+
+	if _, err := u.db.Exec(ctx, sqlCmd, user, lang); err != nil {
+		return fmt.Errorf("ошибка при сохранении языка: %w", err)
+	}
+
+	return nil
+}
+
+// UsersLanguages возвращает язык уведомлений каждого пользователя из users.
+func (u *UserStorage) UsersLanguages(ctx context.Context, users []scrapper.User) (map[scrapper.User]i18n.Lang, error) {
+	sqlCmd, _, _ := goqu.From("users").
+		Select("user_id", "language").
+		Where(goqu.L("user_id = ANY(($1)::bigint[])")).
+		ToSQL()
+
+	rows, err := u.db.Query(ctx, sqlCmd, users)
+
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении языков пользователей: %w", err)
+	}
+
+	defer rows.Close()
+
+	languages := make(map[scrapper.User]i18n.Lang, len(users))
+
+	for rows.Next() {
+		var (
+			user scrapper.User
+			lang i18n.Lang
+		)
+
+		if err = rows.Scan(&user, &lang); err != nil {
+			return nil, fmt.Errorf("ошибка при чтении строки: %w", err)
+		}
+
+		languages[user] = lang
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при получении языков пользователей: %w", err)
+	}
+
+	return languages, nil
+}
+
+// UsersTimezones возвращает часовой пояс каждого пользователя из users.
+func (u *UserStorage) UsersTimezones(ctx context.Context, users []scrapper.User) (map[scrapper.User]string, error) {
+	sqlCmd, _, _ := goqu.From("users").
+		Select("user_id", "timezone").
+		Where(goqu.L("user_id = ANY(($1)::bigint[])")).
+		ToSQL()
+
+	rows, err := u.db.Query(ctx, sqlCmd, users)
+
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении часовых поясов пользователей: %w", err)
+	}
+
+	defer rows.Close()
+
+	timezones := make(map[scrapper.User]string, len(users))
+
+	for rows.Next() {
+		var (
+			user     scrapper.User
+			timezone string
+		)
+
+		if err = rows.Scan(&user, &timezone); err != nil {
+			return nil, fmt.Errorf("ошибка при чтении строки: %w", err)
+		}
+
+		timezones[user] = timezone
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при получении часовых поясов пользователей: %w", err)
+	}
+
+	return timezones, nil
+}
+
+// QuietHours возвращает тихие часы только тех пользователей из users, у которых они включены.
+func (u *UserStorage) QuietHours(ctx context.Context, users []scrapper.User) (map[scrapper.User]*scrapper.QuietHours, error) {
+	sqlCmd, _, _ := goqu.From("quiet_hours").
+		Select("quiet_hours.user_id", goqu.L("to_char(quiet_hours.quiet_start, 'HH24:MI')"),
+			goqu.L("to_char(quiet_hours.quiet_end, 'HH24:MI')"), "users.timezone").
+		Join(goqu.T("users"), goqu.On(goqu.Ex{"users.user_id": goqu.I("quiet_hours.user_id")})).
+		Where(goqu.L("quiet_hours.user_id = ANY(($1)::bigint[])")).
+		ToSQL()
+
+	rows, err := u.db.Query(ctx, sqlCmd, users)
+
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении тихих часов: %w", err)
+	}
+
+	defer rows.Close()
+
+	quietHours := make(map[scrapper.User]*scrapper.QuietHours, len(users))
+
+	for rows.Next() {
+		var user scrapper.User
+
+		hours := &scrapper.QuietHours{}
+
+		if err = rows.Scan(&user, &hours.Start, &hours.End, &hours.Timezone); err != nil {
+			return nil, fmt.Errorf("ошибка при чтении строки: %w", err)
+		}
+
+		quietHours[user] = hours
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при получении тихих часов: %w", err)
+	}
+
+	return quietHours, nil
+}
+
+// HoldUpdate откладывает уведомление до конца тихих часов, у дайджеста linkID равен 0.
+func (u *UserStorage) HoldUpdate(ctx context.Context, linkID LinkID, eventID string, content *scrapper.Notification,
+	users []scrapper.User) error {
+	conn := transactor.GetQuerier(ctx, u.db)
+
+	contentJSON, err := marshalContent(content)
+	if err != nil {
+		return err
+	}
+
+	sqlCmd, _, _ := goqu.Insert("held_updates").
+		Cols("user_id", "link_id", "event_id", "description", "content").
+		FromQuery(goqu.Select(goqu.L("unnest(($1)::bigint[])"), goqu.L("NULLIF(($2)::bigint, 0)"), goqu.L("$3"),
+			goqu.L("''"), goqu.L("($4)::jsonb"))).
+		ToSQL()
+
+	if _, err = conn.Exec(ctx, sqlCmd, users, linkID, eventID, contentJSON); err != nil {
+		return fmt.Errorf("ошибка при добавлении в таблицу held_updates: %w", err)
+	}
+
+	return nil
+}
+
+func (u *UserStorage) HeldUsers(ctx context.Context) ([]scrapper.User, error) {
+	sqlCmd, _, _ := goqu.From("held_updates").Select("user_id").Distinct().ToSQL()
+
+	rows, err := u.db.Query(ctx, sqlCmd)
+
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении пользователей с задержанными уведомлениями: %w", err)
+	}
+
+	return scanUsers(rows)
+}
+
+// HeldUpdates блокирует возвращенные уведомления до конца транзакции из ctx, уже заблокированные пропускает.
+func (u *UserStorage) HeldUpdates(ctx context.Context, user scrapper.User) ([]*scrapper.HeldUpdate, error) {
+	conn := transactor.GetQuerier(ctx, u.db)
+
+	sqlCmd, _, _ := goqu.From("held_updates").
+		Select("held_updates.update_id", goqu.COALESCE(goqu.I("held_updates.link_id"), 0),
+			goqu.COALESCE(goqu.I("links.link_url"), ""), "held_updates.event_id", "held_updates.description",
+			"held_updates.content").
+		LeftJoin(goqu.T("links"), goqu.On(goqu.Ex{"links.link_id": goqu.I("held_updates.link_id")})).
+		Where(goqu.Ex{"held_updates.user_id": goqu.L("$1")}).
+		Order(goqu.I("held_updates.update_id").Asc()).
+		ForUpdate(exp.SkipLocked, goqu.T("held_updates")).
+		ToSQL()
+
+	rows, err := conn.Query(ctx, sqlCmd, user)
+
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении задержанных уведомлений: %w", err)
+	}
+
+	defer rows.Close()
+
+	heldUpdates := make([]*scrapper.HeldUpdate, 0, linkCap)
+
+	for rows.Next() {
+		var contentJSON []byte
+
+		held := &scrapper.HeldUpdate{}
+
+		if err = rows.Scan(&held.ID, &held.LinkID, &held.URL, &held.EventID, &held.Description, &contentJSON); err != nil {
+			return nil, fmt.Errorf("ошибка при чтении строки: %w", err)
+		}
+
+		if held.Content, err = unmarshalContent(contentJSON); err != nil {
+			return nil, err
+		}
+
+		heldUpdates = append(heldUpdates, held)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при получении задержанных уведомлений: %w", err)
+	}
+
+	return heldUpdates, nil
+}
+
+func (u *UserStorage) DeleteHeldUpdates(ctx context.Context, ids []int64) error {
+	conn := transactor.GetQuerier(ctx, u.db)
+
+	sqlCmd, _, _ := goqu.Delete("held_updates").
+		Where(goqu.L("update_id = ANY(($1)::bigint[])")).
+		ToSQL()
+
+	if _, err := conn.Exec(ctx, sqlCmd, ids); err != nil {
+		return fmt.Errorf("ошибка при удалении отправленных уведомлений: %w", err)
+	}
+
+	return nil
+}
+
+func (u *UserStorage) SaveOutboxUpdate(ctx context.Context, update *scrapper.OutboxUpdate) error {
+	conn := transactor.GetQuerier(ctx, u.db)
+
+	contentJSON, err := marshalContent(update.Content)
+	if err != nil {
+		return err
+	}
+
+	sqlCmd, _, _ := goqu.Insert("outbox").
+		Cols("link_id", "link_url", "event_id", "description", "content", "chat_ids").
+		Vals(goqu.Vals{goqu.L("$1"), goqu.L("$2"), goqu.L("$3"), goqu.L("$4"), goqu.L("($5)::jsonb"), goqu.L("$6")}).
+		ToSQL()
+
+	_, err = conn.Exec(ctx, sqlCmd, update.LinkID, update.URL, update.EventID, update.Description, contentJSON,
+		update.TgChatIDs)
+	if err != nil {
+		return fmt.Errorf("ошибка при добавлении в таблицу outbox: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimOutboxUpdates захватывает на время lease до limit неотправленных уведомлений, не блокируя строки.
+func (u *UserStorage) ClaimOutboxUpdates(ctx context.Context, limit uint, lease time.Duration) ([]*scrapper.OutboxUpdate, error) {
+	conn := transactor.GetQuerier(ctx, u.db)
+
+	batch := goqu.From("outbox").
+		Select("update_id").
+		Where(goqu.C("sent_at").IsNull(), goqu.C("failed_at").IsNull(),
+			goqu.Or(goqu.C("leased_until").IsNull(), goqu.C("leased_until").Lte(goqu.L("CURRENT_TIMESTAMP")))).
+		Order(goqu.I("update_id").Asc()).
+		Limit(limit).
+		ForUpdate(exp.SkipLocked)
+
+	claim := goqu.Update("outbox").
+		Set(goqu.Record{"leased_until": goqu.L("CURRENT_TIMESTAMP + ($1)::bigint * INTERVAL '1 millisecond'")}).
+		Where(goqu.C("update_id").In(batch)).
+		Returning("update_id", "link_id", "link_url", "event_id", "description", "content", "chat_ids", "attempts")
+
+	sqlCmd, _, _ := goqu.Dialect("postgres").From("claimed").
+		With("claimed", claim).
+		Order(goqu.I("update_id").Asc()).
+		ToSQL()
+
+	rows, err := conn.Query(ctx, sqlCmd, lease.Milliseconds())
+
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении неотправленных уведомлений: %w", err)
+	}
+
+	defer rows.Close()
+
+	updates := make([]*scrapper.OutboxUpdate, 0, limit)
+
+	for rows.Next() {
+		var contentJSON []byte
+
+		update := &scrapper.OutboxUpdate{}
+
+		if err = rows.Scan(&update.ID, &update.LinkID, &update.URL, &update.EventID, &update.Description,
+			&contentJSON, &update.TgChatIDs, &update.Attempts); err != nil {
+			return nil, fmt.Errorf("ошибка при чтении строки: %w", err)
+		}
+
+		if update.Content, err = unmarshalContent(contentJSON); err != nil {
+			return nil, err
+		}
+
+		updates = append(updates, update)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при получении неотправленных уведомлений: %w", err)
+	}
+
+	return updates, nil
+}
+
+func (u *UserStorage) MarkOutboxSent(ctx context.Context, ids []int64) error {
+	conn := transactor.GetQuerier(ctx, u.db)
+
+	sqlCmd, _, _ := goqu.Update("outbox").
+		Set(goqu.Record{"sent_at": goqu.L("CURRENT_TIMESTAMP")}).
+		Where(goqu.L("update_id = ANY(($1)::bigint[])")).
+		ToSQL()
+
+	if _, err := conn.Exec(ctx, sqlCmd, ids); err != nil {
+		return fmt.Errorf("ошибка при отметке отправленных уведомлений: %w", err)
+	}
+
+	return nil
+}
+
+// MarkOutboxFailed записывает неудачную попытку отправки, отложенное (park) уведомление больше не отправляется.
+func (u *UserStorage) MarkOutboxFailed(ctx context.Context, id int64, lastErr string, park bool) error {
+	conn := transactor.GetQuerier(ctx, u.db)
+
+	sqlCmd, _, _ := goqu.Update("outbox").
+		Set(goqu.Record{
+			"attempts":     goqu.L("attempts + 1"),
+			"last_error":   goqu.L("$2"),
+			"leased_until": nil,
+			"failed_at":    goqu.L("CASE WHEN ($3)::boolean THEN CURRENT_TIMESTAMP END"),
+		}).
+		Where(goqu.C("update_id").Eq(goqu.L("$1"))).
+		ToSQL()
+
+	if _, err := conn.Exec(ctx, sqlCmd, id, lastErr, park); err != nil {
+		return fmt.Errorf("ошибка при отметке неотправленного уведомления: %w", err)
+	}
+
+	return nil
+}
+
+func (u *UserStorage) ReleaseOutbox(ctx context.Context, ids []int64) error {
+	conn := transactor.GetQuerier(ctx, u.db)
+
+	sqlCmd, _, _ := goqu.Update("outbox").
+		Set(goqu.Record{"leased_until": nil}).
+		Where(goqu.L("update_id = ANY(($1)::bigint[])")).
+		ToSQL()
+
+	if _, err := conn.Exec(ctx, sqlCmd, ids); err != nil {
+		return fmt.Errorf("ошибка при снятии захвата уведомлений: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteSentOutbox удаляет уведомления, отправленные больше чем retention назад.
+func (u *UserStorage) DeleteSentOutbox(ctx context.Context, retention time.Duration) error {
+	conn := transactor.GetQuerier(ctx, u.db)
+
+	sqlCmd, _, _ := goqu.Delete("outbox").
+		Where(goqu.C("sent_at").Lt(goqu.L("CURRENT_TIMESTAMP - ($1)::bigint * INTERVAL '1 second'"))).
+		ToSQL()
+
+	if _, err := conn.Exec(ctx, sqlCmd, int64(retention.Seconds())); err != nil {
+		return fmt.Errorf("ошибка при удалении отправленных уведомлений из outbox: %w", err)
+	}
+
+	return nil
+}
+
+func scanUsers(rows pgx.Rows) ([]scrapper.User, error) {
+	defer rows.Close()
+
+	users := make([]scrapper.User, 0, usersCap)
+
+	for rows.Next() {
+		var user scrapper.User
+
+		if err := rows.Scan(&user); err != nil {
+			return nil, fmt.Errorf("ошибка при чтении строки: %w", err)
+		}
+
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при получении пользователей: %w", err)
+	}
+
+	return users, nil
+}
+
 func (u *UserStorage) NewLinksPaginator() scrapservice.LinkPaginator {
-	return &linkPaginator{db: u.db, limit: u.batchSize, hasLinks: true}
+	return &linkPaginator{db: u.db, dueTime: time.Now().UTC(), lease: u.linkLease, limit: u.batchSize, hasLinks: true}
 }
 
 func (l *linkPaginator) HasLinks() bool {
 	return l.hasLinks
 }
 
-func (l *linkPaginator) LinksBatch() ([]*LinkInfo, error) {
+func (l *linkPaginator) LinksBatch(ctx context.Context) ([]*LinkInfo, error) {
 	var id int64
 
 	var link string
 
-	var lastCheck time.Time
-
-	rows, err := l.db.Query(context.Background(),
-		`SELECT link_id, link_url, last_update_check FROM links 
-             WHERE link_id > ($1) AND CURRENT_TIMESTAMP - last_update_check > '5 minutes' 
-             ORDER BY link_id ASC LIMIT ($2);`, l.lastLinkID, l.limit)
-
-	if errors.Is(err, pgx.ErrNoRows) {
-		l.hasLinks = false
-		return []*LinkInfo{}, nil
-	}
+	var lastCheck, nextCheck time.Time
+
+	batch := goqu.From("links").
+		Select("link_id").
+		Where(goqu.I("next_check_at").Lte(goqu.L("$1")),
+			goqu.Or(goqu.I("leased_until").IsNull(), goqu.I("leased_until").Lte(goqu.L("CURRENT_TIMESTAMP")))).
+		Order(goqu.I("next_check_at").Asc(), goqu.I("link_id").Asc()).
+		Limit(l.limit).
+		ForUpdate(exp.SkipLocked)
+
+	override := goqu.From("userlinks").
+		Select(goqu.COALESCE(goqu.MIN("userlinks.check_interval"), goqu.L("INTERVAL '0'"))).
+		Where(goqu.Ex{"userlinks.link_id": goqu.I("links.link_id")})
+
+	sqlCmd, _, _ := goqu.Dialect("postgres").Update("links").
+		Set(goqu.Record{"leased_until": goqu.L("CURRENT_TIMESTAMP + ($2)::interval")}).
+		From(batch.As("batch")).
+		Where(goqu.Ex{"links.link_id": goqu.I("batch.link_id")}).
+		Returning("links.link_id", "links.link_url", "links.last_update_check", "links.next_check_at",
+			"links.check_interval", "links.check_failures", override, "links.leased_until").
+		ToSQL()
 
+	rows, err := l.db.Query(ctx, sqlCmd, l.dueTime, l.lease)
 	if err != nil {
 		return nil, fmt.Errorf("ошика при выполнении запроса на получение пачки ссылок: %w", err)
 	}
@@ -308,13 +1168,17 @@ func (l *linkPaginator) LinksBatch() ([]*LinkInfo, error) {
 	for rows.Next() {
 		linkInfo := &LinkInfo{}
 
-		if err = rows.Scan(&id, &link, &lastCheck); err != nil {
+		schedule := &linkInfo.Schedule
+
+		if err = rows.Scan(&id, &link, &lastCheck, &nextCheck, &schedule.Interval, &schedule.Failures,
+			&schedule.Override, &linkInfo.LeasedUntil); err != nil {
 			return nil, fmt.Errorf("ошика при сканировании ссылок: %w", err)
 		}
 
 		linkInfo.ID = id
 		linkInfo.URL = link
-		linkInfo.LastUpdate = lastCheck
+		linkInfo.LastUpdate = lastCheck.UTC()
+		schedule.NextCheck = nextCheck.UTC()
 
 		links = append(links, linkInfo)
 	}
@@ -323,7 +1187,90 @@ func (l *linkPaginator) LinksBatch() ([]*LinkInfo, error) {
 		return nil, fmt.Errorf("ошика при сканировании ссылок: %w", err)
 	}
 
-	l.lastLinkID = id
+	if len(links) == 0 {
+		l.hasLinks = false
+	}
+
+	return sortByNextCheck(links), nil
+}
+
+func cursorColumns(cursors map[scrapper.UpdateType]*scrapper.Cursor) ([]scrapper.UpdateType, []int64, []time.Time) {
+	types := make([]scrapper.UpdateType, 0, len(cursors))
+	ids := make([]int64, 0, len(cursors))
+	times := make([]time.Time, 0, len(cursors))
 
-	return links, nil
+	for updateType, cursor := range cursors {
+		types = append(types, updateType)
+		ids = append(ids, cursor.ItemID)
+		times = append(times, cursor.ItemTime)
+	}
+
+	return types, ids, times
+}
+
+func scanCursors(rows pgx.Rows) (map[scrapper.UpdateType]*scrapper.Cursor, error) {
+	defer rows.Close()
+
+	cursors := make(map[scrapper.UpdateType]*scrapper.Cursor)
+
+	for rows.Next() {
+		var updateType scrapper.UpdateType
+
+		cursor := &scrapper.Cursor{}
+
+		if err := rows.Scan(&updateType, &cursor.ItemID, &cursor.ItemTime); err != nil {
+			return nil, fmt.Errorf("ошибка при чтении строки: %w", err)
+		}
+
+		cursor.ItemTime = cursor.ItemTime.UTC()
+
+		cursors[updateType] = cursor
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при получении курсоров ссылки: %w", err)
+	}
+
+	return cursors, nil
+}
+
+// marshalContent кодирует содержимое уведомления, у старых уведомлений без него content равен NULL.
+func marshalContent(content *scrapper.Notification) ([]byte, error) {
+	if content == nil {
+		return nil, nil
+	}
+
+	contentJSON, err := json.Marshal(content)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при кодировании содержимого уведомления: %w", err)
+	}
+
+	return contentJSON, nil
+}
+
+func unmarshalContent(contentJSON []byte) (*scrapper.Notification, error) {
+	if contentJSON == nil {
+		return nil, nil
+	}
+
+	content := &scrapper.Notification{}
+
+	if err := json.Unmarshal(contentJSON, content); err != nil {
+		return nil, fmt.Errorf("ошибка при чтении содержимого уведомления: %w", err)
+	}
+
+	return content, nil
+}
+
+// sortByNextCheck восстанавливает порядок батча: RETURNING не сохраняет порядок подзапроса.
+func sortByNextCheck(links []*LinkInfo) []*LinkInfo {
+	slices.SortFunc(links, func(a, b *LinkInfo) int {
+		if byTime := a.Schedule.NextCheck.Compare(b.Schedule.NextCheck); byTime != 0 {
+			return byTime
+		}
+
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return links
 }
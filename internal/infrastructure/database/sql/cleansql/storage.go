@@ -1,12 +1,16 @@
 package cleansql
 
 import (
+	"cmp"
 	"context"
+	"encoding/json"
 	"fmt"
 	"linkTraccer/internal/application/scrapper/scrapservice"
+	"linkTraccer/internal/domain/i18n"
 	"linkTraccer/internal/domain/scrapper"
 	"linkTraccer/internal/infrastructure/database/sql"
 	"linkTraccer/internal/infrastructure/database/sql/transactor"
+	"slices"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -21,14 +25,17 @@ const (
 type LinkInfo = scrapper.LinkInfo
 type LinkID = scrapper.LinkID
 type Tag = scrapper.Tag
+type Filter = scrapper.Filter
+type UserLink = scrapper.UserLink
 
 type UserStorage struct {
 	batchSize uint
+	linkLease time.Duration
 	db        *pgxpool.Pool
 }
 
 func NewStore(dbConfig *sql.DBConfig, pgxPool *pgxpool.Pool) *UserStorage {
-	return &UserStorage{db: pgxPool, batchSize: dbConfig.BatchSize}
+	return &UserStorage{db: pgxPool, batchSize: dbConfig.BatchSize, linkLease: dbConfig.LinkLease}
 }
 
 // возможно в этой части кода нужно выделение отдельного соединения, для того что бы метод всегда выполнялся транзакционно
@@ -38,7 +45,7 @@ func (u *UserStorage) TrackLink(ctx context.Context, userID scrapper.User, link
 
 	conn := transactor.GetQuerier(ctx, u.db)
 
-	err := conn.QueryRow(context.Background(), `WITH id AS (INSERT INTO links(link_url,last_update_check) values 
+	err := conn.QueryRow(ctx, `WITH id AS (INSERT INTO links(link_url,last_update_check) values 
  														($1,$2) ON CONFLICT (link_url) DO NOTHING RETURNING link_id)
 														SELECT id.link_id FROM id UNION ALL SELECT link_id FROM links
 														WHERE link_url = ($1);`, link, addTime).Scan(&linkID)
@@ -47,14 +54,14 @@ func (u *UserStorage) TrackLink(ctx context.Context, userID scrapper.User, link
 		return fmt.Errorf("ошибка при добавлении в таблицу links: %w", err)
 	}
 
-	_, err = conn.Exec(context.Background(),
+	_, err = conn.Exec(ctx,
 		`INSERT INTO users(user_id) values ($1) ON CONFLICT (user_id) DO NOTHING`, userID)
 
 	if err != nil {
 		return fmt.Errorf("ошибка при добавлении в таблицу users")
 	}
 
-	if _, err := conn.Exec(context.Background(), `INSERT INTO userlinks(user_id,link_id) values ($1,$2)`,
+	if _, err := conn.Exec(ctx, `INSERT INTO userlinks(user_id,link_id) values ($1,$2)`,
 		userID, linkID); err != nil {
 		return fmt.Errorf("ошибка при добавлении в таблицу userlinks")
 	}
@@ -62,9 +69,10 @@ func (u *UserStorage) TrackLink(ctx context.Context, userID scrapper.User, link
 	return nil
 }
 
-func (u *UserStorage) ChangeLastCheckTime(link scrapper.Link, checkTime time.Time) error {
-	_, err := u.db.Exec(context.Background(), "UPDATE links SET last_update_check=($2) WHERE link_url = ($1)",
-		link, checkTime)
+func (u *UserStorage) ChangeLastCheckTime(ctx context.Context, link scrapper.Link, checkTime time.Time) error {
+	conn := transactor.GetQuerier(ctx, u.db)
+
+	_, err := conn.Exec(ctx, "UPDATE links SET last_update_check=($2) WHERE link_url = ($1)", link, checkTime)
 
 	if err != nil {
 		return fmt.Errorf("ошибка при изменении времени обновлениия ссылки: %w", err)
@@ -73,10 +81,93 @@ func (u *UserStorage) ChangeLastCheckTime(link scrapper.Link, checkTime time.Tim
 	return nil
 }
 
-func (u *UserStorage) UsersWhoTrackLink(linkID LinkID) ([]scrapper.User, error) {
+// ScheduleNextCheck сохраняет расписание проверок ссылки и снимает с нее аренду.
+func (u *UserStorage) ScheduleNextCheck(ctx context.Context, linkID LinkID, schedule *scrapper.LinkSchedule) error {
+	conn := transactor.GetQuerier(ctx, u.db)
+
+	_, err := conn.Exec(ctx, `UPDATE links SET check_interval = ($2), check_failures = ($3), next_check_at = ($4),
+									leased_until = NULL WHERE link_id = ($1)`,
+		linkID, schedule.Interval, schedule.Failures, schedule.NextCheck)
+
+	if err != nil {
+		return fmt.Errorf("ошибка при изменении расписания проверок ссылки: %w", err)
+	}
+
+	return nil
+}
+
+// ExtendLinkLease продлевает аренду, только если ссылку с тех пор не арендовала другая реплика.
+func (u *UserStorage) ExtendLinkLease(ctx context.Context, linkInfo *LinkInfo) (bool, error) {
+	var leasedUntil time.Time
+
+	err := u.db.QueryRow(ctx,
+		`UPDATE links SET leased_until = CURRENT_TIMESTAMP + ($3)::interval
+			WHERE link_id = ($1) AND leased_until = ($2) RETURNING leased_until`,
+		linkInfo.ID, linkInfo.LeasedUntil, u.linkLease).Scan(&leasedUntil)
+
+	if err == pgx.ErrNoRows {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("ошибка при продлении аренды ссылки: %w", err)
+	}
+
+	linkInfo.LeasedUntil = leasedUntil
+
+	return true, nil
+}
+
+// SetCheckInterval задает интервал проверки ссылки пользователя, нулевой интервал возвращает адаптивный.
+func (u *UserStorage) SetCheckInterval(ctx context.Context, userID scrapper.User, link scrapper.Link, interval time.Duration) error {
+	_, err := u.db.Exec(ctx,
+		`WITH updated AS (UPDATE userLinks SET check_interval = NULLIF(($3)::interval, INTERVAL '0') FROM links
+							WHERE userLinks.link_id = links.link_id AND userLinks.user_id = ($1) AND links.link_url = ($2)
+							RETURNING userLinks.link_id)
+			UPDATE links SET next_check_at = LEAST(next_check_at, CURRENT_TIMESTAMP + ($3)::interval)
+				WHERE link_id IN (SELECT link_id FROM updated)`, userID, link, interval)
+
+	if err != nil {
+		return fmt.Errorf("ошибка при изменении интервала проверки ссылки: %w", err)
+	}
+
+	return nil
+}
+
+// LinkCursors возвращает курсоры ссылки по типам элементов.
+func (u *UserStorage) LinkCursors(ctx context.Context, linkID LinkID) (map[scrapper.UpdateType]*scrapper.Cursor, error) {
+	rows, err := u.db.Query(ctx,
+		"SELECT item_type, item_id, item_time FROM link_cursors WHERE link_id = ($1)", linkID)
+
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении курсоров ссылки: %w", err)
+	}
+
+	return scanCursors(rows)
+}
+
+// SaveLinkCursors сохраняет курсоры ссылки, остальные курсоры ссылки не меняются.
+func (u *UserStorage) SaveLinkCursors(ctx context.Context, linkID LinkID, cursors map[scrapper.UpdateType]*scrapper.Cursor) error {
+	conn := transactor.GetQuerier(ctx, u.db)
+	types, ids, times := cursorColumns(cursors)
+
+	_, err := conn.Exec(ctx,
+		`INSERT INTO link_cursors(link_id, item_type, item_id, item_time)
+             SELECT ($1), unnest(($2)::text[]), unnest(($3)::bigint[]), unnest(($4)::timestamptz[])
+             ON CONFLICT (link_id, item_type) DO UPDATE SET item_id = EXCLUDED.item_id, item_time = EXCLUDED.item_time`,
+		linkID, types, ids, times)
+
+	if err != nil {
+		return fmt.Errorf("ошибка при сохранении курсоров ссылки: %w", err)
+	}
+
+	return nil
+}
+
+func (u *UserStorage) UsersWhoTrackLink(ctx context.Context, linkID LinkID) ([]scrapper.User, error) {
 	var user scrapper.User
 
-	rows, err := u.db.Query(context.Background(), "SELECT user_id FROM userlinks WHERE link_id = ($1) ", linkID)
+	rows, err := u.db.Query(ctx, "SELECT user_id FROM userlinks WHERE link_id = ($1) ", linkID)
 
 	if err != nil {
 		return nil, fmt.Errorf("ошибка при получении отслеживающих ссылку пользователей: %w", err)
@@ -99,22 +190,121 @@ func (u *UserStorage) UsersWhoTrackLink(linkID LinkID) ([]scrapper.User, error)
 	return users, nil
 }
 
-func (u *UserStorage) AllUserLinks(userID scrapper.User) ([]scrapper.Link, error) {
-	var link scrapper.Link
+func (u *UserStorage) AddLinkTags(ctx context.Context, userID scrapper.User, link scrapper.Link, tags []Tag) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	conn := transactor.GetQuerier(ctx, u.db)
+
+	_, err := conn.Exec(ctx, `INSERT INTO tags(tag_name) SELECT unnest(($1)::text[]) ON CONFLICT (tag_name) DO NOTHING`,
+		tags)
 
-	rows, err := u.db.Query(context.Background(),
-		`SELECT link_url FROM links 
+	if err != nil {
+		return fmt.Errorf("ошибка при добавлении в таблицу tags: %w", err)
+	}
+
+	_, err = conn.Exec(ctx, `INSERT INTO userlinktags(user_id, link_id, tag_id)
+								 SELECT ($1), links.link_id, tags.tag_id FROM links
+								 JOIN tags ON tags.tag_name = ANY(($3)::text[])
+								 WHERE links.link_url = ($2) ON CONFLICT DO NOTHING`, userID, link, tags)
+
+	if err != nil {
+		return fmt.Errorf("ошибка при добавлении в таблицу userlinktags: %w", err)
+	}
+
+	return nil
+}
+
+func (u *UserStorage) AddLinkFilters(ctx context.Context, userID scrapper.User, link scrapper.Link, filters []Filter) error {
+	if len(filters) == 0 {
+		return nil
+	}
+
+	conn := transactor.GetQuerier(ctx, u.db)
+
+	_, err := conn.Exec(ctx,
+		`INSERT INTO filters(filter_value) SELECT unnest(($1)::text[]) ON CONFLICT (filter_value) DO NOTHING`, filters)
+
+	if err != nil {
+		return fmt.Errorf("ошибка при добавлении в таблицу filters: %w", err)
+	}
+
+	_, err = conn.Exec(ctx, `INSERT INTO userlinkfilters(user_id, link_id, filter_id)
+								 SELECT ($1), links.link_id, filters.filter_id FROM links
+								 JOIN filters ON filters.filter_value = ANY(($3)::text[])
+								 WHERE links.link_url = ($2) ON CONFLICT DO NOTHING`, userID, link, filters)
+
+	if err != nil {
+		return fmt.Errorf("ошибка при добавлении в таблицу userlinkfilters: %w", err)
+	}
+
+	return nil
+}
+
+func (u *UserStorage) UsersFilters(ctx context.Context, linkID LinkID) (map[scrapper.User][]Filter, error) {
+	rows, err := u.db.Query(ctx,
+		`SELECT userlinks.user_id,
+    			ARRAY(SELECT filters.filter_value FROM userlinkfilters
+    			      JOIN filters ON filters.filter_id = userlinkfilters.filter_id
+    			      WHERE userlinkfilters.user_id = userlinks.user_id AND userlinkfilters.link_id = userlinks.link_id)
+    		 FROM userlinks WHERE userlinks.link_id = ($1)`, linkID)
+
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении фильтров пользователей, отслеживающих ссылку: %w", err)
+	}
+
+	defer rows.Close()
+
+	usersFilters := make(map[scrapper.User][]Filter, usersCap)
+
+	for rows.Next() {
+		var user scrapper.User
+
+		var filters []Filter
+
+		if err = rows.Scan(&user, &filters); err != nil {
+			return nil, fmt.Errorf("ошибка при чтении строки: %w", err)
+		}
+
+		usersFilters[user] = filters
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при получении фильтров пользователей: %w", err)
+	}
+
+	return usersFilters, nil
+}
+
+func (u *UserStorage) AllUserLinks(ctx context.Context, userID scrapper.User) ([]*UserLink, error) {
+	rows, err := u.db.Query(ctx,
+		`SELECT links.link_id, links.link_url,
+    			ARRAY(SELECT tags.tag_name FROM userlinktags
+    			      JOIN tags ON tags.tag_id = userlinktags.tag_id
+    			      WHERE userlinktags.user_id = userlinks.user_id AND userlinktags.link_id = userlinks.link_id
+    			      ORDER BY tags.tag_name),
+    			ARRAY(SELECT filters.filter_value FROM userlinkfilters
+    			      JOIN filters ON filters.filter_id = userlinkfilters.filter_id
+    			      WHERE userlinkfilters.user_id = userlinks.user_id AND userlinkfilters.link_id = userlinks.link_id
+    			      ORDER BY filters.filter_value)
+    		 FROM links
     		 JOIN userlinks ON links.link_id = userlinks.link_id 
-             WHERE userlinks.user_id = ($1)`, userID)
+             WHERE userlinks.user_id = ($1)
+             ORDER BY links.link_id`, userID)
 
 	if err != nil {
 		return nil, fmt.Errorf("ошибка при выполнении запроса на получение всех ссылок пользователя: %w", err)
 	}
 
-	links := make([]scrapper.Link, 0, linkCap)
+	defer rows.Close()
+
+	links := make([]*UserLink, 0, linkCap)
 
 	for rows.Next() {
-		if err = rows.Scan(&link); err != nil {
+		link := &UserLink{}
+
+		if err = rows.Scan(&link.ID, &link.URL, &link.Tags, &link.Filters); err != nil {
 			return nil, fmt.Errorf("ошибка при чтении строки: %w", err)
 		}
 
@@ -128,10 +318,10 @@ func (u *UserStorage) AllUserLinks(userID scrapper.User) ([]scrapper.Link, error
 	return links, nil
 }
 
-func (u *UserStorage) UserTrackLink(userID scrapper.User, url scrapper.Link) (bool, error) {
+func (u *UserStorage) UserTrackLink(ctx context.Context, userID scrapper.User, url scrapper.Link) (bool, error) {
 	var link scrapper.Link
 
-	err := u.db.QueryRow(context.Background(),
+	err := u.db.QueryRow(ctx,
 		`SELECT link_url FROM links JOIN userLinks ON links.link_id = userLinks.link_id
              WHERE userLinks.user_id = ($1) AND links.link_url = ($2)`, userID, url).Scan(&link)
 
@@ -146,10 +336,10 @@ func (u *UserStorage) UserTrackLink(userID scrapper.User, url scrapper.Link) (bo
 	return true, nil
 }
 
-func (u *UserStorage) UserExist(userID scrapper.User) (bool, error) {
+func (u *UserStorage) UserExist(ctx context.Context, userID scrapper.User) (bool, error) {
 	var user scrapper.User
 
-	err := u.db.QueryRow(context.Background(),
+	err := u.db.QueryRow(ctx,
 		"SELECT user_id FROM users WHERE user_id = ($1)", userID).Scan(&user)
 
 	if err == pgx.ErrNoRows {
@@ -163,8 +353,8 @@ func (u *UserStorage) UserExist(userID scrapper.User) (bool, error) {
 	return user == userID, nil
 }
 
-func (u *UserStorage) RegUser(userID scrapper.User) error {
-	_, err := u.db.Exec(context.Background(),
+func (u *UserStorage) RegUser(ctx context.Context, userID scrapper.User) error {
+	_, err := u.db.Exec(ctx,
 		"INSERT INTO users(user_id) VALUES ($1) ON CONFLICT (user_id) DO NOTHING", userID)
 
 	if err != nil {
@@ -177,19 +367,19 @@ func (u *UserStorage) RegUser(userID scrapper.User) error {
 func (u *UserStorage) DeleteUser(ctx context.Context, user scrapper.User) (err error) {
 	conn := transactor.GetQuerier(ctx, u.db)
 
-	if _, err = conn.Exec(context.Background(), "DELETE FROM userlinks WHERE user_id = ($1);", user); err != nil {
+	if _, err = conn.Exec(ctx, "DELETE FROM userlinks WHERE user_id = ($1);", user); err != nil {
 		return fmt.Errorf("ошибка при удалении пользователя из таблицы userlinks: %w", err)
 	}
 
-	if _, err = conn.Exec(context.Background(), "DELETE FROM users WHERE user_id = ($1);", user); err != nil {
+	if _, err = conn.Exec(ctx, "DELETE FROM users WHERE user_id = ($1);", user); err != nil {
 		return fmt.Errorf("ошибка при удалении пользователя из таблицы users: %w", err)
 	}
 
 	return nil
 }
 
-func (u *UserStorage) UntrackLink(user scrapper.User, link scrapper.Link) error {
-	_, err := u.db.Exec(context.Background(),
+func (u *UserStorage) UntrackLink(ctx context.Context, user scrapper.User, link scrapper.Link) error {
+	_, err := u.db.Exec(ctx,
 		`DELETE FROM userlinks USING links 
              WHERE userlinks.link_id = links.link_id AND links.link_url = ($1) AND userlinks.user_id = ($2)`,
 		link, user)
@@ -201,8 +391,11 @@ func (u *UserStorage) UntrackLink(user scrapper.User, link scrapper.Link) error
 	return nil
 }
 
-func (u *UserStorage) DeleteUntrackedLinks() error {
-	_, err := u.db.Exec(context.Background(), `DELETE FROM links 
+// DeleteUntrackedLinks удаляет ссылки, которые больше никто не отслеживает.
+func (u *UserStorage) DeleteUntrackedLinks(ctx context.Context) error {
+	conn := transactor.GetQuerier(ctx, u.db)
+
+	_, err := conn.Exec(ctx, `DELETE FROM links 
        									 WHERE link_id NOT IN (SELECT  link_id FROM userlinks)`)
 
 	if err != nil {
@@ -212,32 +405,556 @@ func (u *UserStorage) DeleteUntrackedLinks() error {
 	return nil
 }
 
+// SetDigestTime сохраняет время дайджеста, если сегодня оно уже прошло, следующий дайджест уйдет завтра.
+func (u *UserStorage) SetDigestTime(ctx context.Context, user scrapper.User, sendTime string, now time.Time) error {
+	_, err := u.db.Exec(ctx,
+		`INSERT INTO update_time(user_id, send_time, last_sent_date)
+             SELECT users.user_id, ($2)::text::time,
+                    CASE WHEN ($2)::text::time <= (($3)::timestamptz AT TIME ZONE users.timezone)::time
+                         THEN (($3)::timestamptz AT TIME ZONE users.timezone)::date END
+             FROM users WHERE users.user_id = ($1)
+             ON CONFLICT (user_id) DO UPDATE SET send_time = EXCLUDED.send_time, last_sent_date = EXCLUDED.last_sent_date`,
+		user, sendTime, now)
+
+	if err != nil {
+		return fmt.Errorf("ошибка при сохранении времени отправки дайджеста: %w", err)
+	}
+
+	return nil
+}
+
+func (u *UserStorage) SetInstantDelivery(ctx context.Context, user scrapper.User) error {
+	if _, err := u.db.Exec(ctx, "DELETE FROM update_time WHERE user_id = ($1)", user); err != nil {
+		return fmt.Errorf("ошибка при удалении времени отправки дайджеста: %w", err)
+	}
+
+	return nil
+}
+
+func (u *UserStorage) DigestUsers(ctx context.Context, users []scrapper.User) ([]scrapper.User, error) {
+	rows, err := u.db.Query(ctx,
+		"SELECT user_id FROM update_time WHERE user_id = ANY(($1)::bigint[])", users)
+
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении пользователей с дайджестом: %w", err)
+	}
+
+	return scanUsers(rows)
+}
+
+// DueDigestUsers возвращает пользователей, которым пора отправить дайджест или отложенные обновления.
+func (u *UserStorage) DueDigestUsers(ctx context.Context, now time.Time) ([]scrapper.User, error) {
+	rows, err := u.db.Query(ctx,
+		`SELECT update_time.user_id FROM update_time
+             JOIN users ON users.user_id = update_time.user_id
+             WHERE send_time <= (($1)::timestamptz AT TIME ZONE users.timezone)::time
+                 AND (last_sent_date IS NULL OR last_sent_date < (($1)::timestamptz AT TIME ZONE users.timezone)::date)
+         UNION
+         SELECT DISTINCT user_id FROM pending_updates
+             WHERE user_id NOT IN (SELECT user_id FROM update_time)`, now)
+
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении пользователей для отправки дайджеста: %w", err)
+	}
+
+	return scanUsers(rows)
+}
+
+func (u *UserStorage) SavePendingUpdate(ctx context.Context, linkID LinkID, update *scrapper.LinkUpdate,
+	users []scrapper.User) error {
+	conn := transactor.GetQuerier(ctx, u.db)
+
+	_, err := conn.Exec(ctx,
+		`INSERT INTO pending_updates(user_id, link_id, site, update_type, item_id, item_url, author, item_time,
+                                     title, excerpt)
+             SELECT unnest(($1)::bigint[]), ($2), ($3), ($4), ($5), ($6), ($7), ($8), ($9), ($10)`,
+		users, linkID, update.Site, update.Type, update.ItemID, update.ItemURL, update.Author, update.ItemTime,
+		update.Title, update.Excerpt)
+
+	if err != nil {
+		return fmt.Errorf("ошибка при добавлении в таблицу pending_updates: %w", err)
+	}
+
+	return nil
+}
+
+func (u *UserStorage) PendingUpdates(ctx context.Context, user scrapper.User) ([]*scrapper.PendingUpdate, error) {
+	conn := transactor.GetQuerier(ctx, u.db)
+
+	rows, err := conn.Query(ctx,
+		`SELECT pending_updates.update_id, pending_updates.link_id, links.link_url, pending_updates.site,
+    			pending_updates.update_type, pending_updates.item_id, pending_updates.item_url, pending_updates.author,
+    			pending_updates.item_time, pending_updates.title, pending_updates.excerpt
+    		 FROM pending_updates
+    		 JOIN links ON links.link_id = pending_updates.link_id
+    		 WHERE pending_updates.user_id = ($1)
+    		 ORDER BY pending_updates.update_id`, user)
+
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении отложенных обновлений: %w", err)
+	}
+
+	defer rows.Close()
+
+	pendingUpdates := make([]*scrapper.PendingUpdate, 0, linkCap)
+
+	for rows.Next() {
+		pending := &scrapper.PendingUpdate{Update: &scrapper.LinkUpdate{}}
+
+		if err = rows.Scan(&pending.ID, &pending.LinkID, &pending.URL, &pending.Update.Site, &pending.Update.Type,
+			&pending.Update.ItemID, &pending.Update.ItemURL, &pending.Update.Author, &pending.Update.ItemTime,
+			&pending.Update.Title, &pending.Update.Excerpt); err != nil {
+			return nil, fmt.Errorf("ошибка при чтении строки: %w", err)
+		}
+
+		pending.Update.ItemTime = pending.Update.ItemTime.UTC()
+
+		pendingUpdates = append(pendingUpdates, pending)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при получении отложенных обновлений: %w", err)
+	}
+
+	return pendingUpdates, nil
+}
+
+func (u *UserStorage) DeletePendingUpdates(ctx context.Context, user scrapper.User, lastUpdateID int64) error {
+	conn := transactor.GetQuerier(ctx, u.db)
+
+	_, err := conn.Exec(ctx, "DELETE FROM pending_updates WHERE user_id = ($1) AND update_id <= ($2)",
+		user, lastUpdateID)
+
+	if err != nil {
+		return fmt.Errorf("ошибка при удалении отправленных обновлений: %w", err)
+	}
+
+	return nil
+}
+
+func (u *UserStorage) MarkDigestSent(ctx context.Context, user scrapper.User, sentAt time.Time) error {
+	conn := transactor.GetQuerier(ctx, u.db)
+
+	_, err := conn.Exec(ctx,
+		`UPDATE update_time SET last_sent_date = (($2)::timestamptz AT TIME ZONE users.timezone)::date
+             FROM users
+             WHERE update_time.user_id = ($1) AND users.user_id = update_time.user_id`, user, sentAt)
+
+	if err != nil {
+		return fmt.Errorf("ошибка при сохранении даты отправки дайджеста: %w", err)
+	}
+
+	return nil
+}
+
+func (u *UserStorage) SetQuietHours(ctx context.Context, user scrapper.User, start, end string) error {
+	_, err := u.db.Exec(ctx,
+		`INSERT INTO quiet_hours(user_id, quiet_start, quiet_end) VALUES ($1, ($2)::text::time, ($3)::text::time)
+             ON CONFLICT (user_id) DO UPDATE SET quiet_start = EXCLUDED.quiet_start, quiet_end = EXCLUDED.quiet_end`,
+		user, start, end)
+
+	if err != nil {
+		return fmt.Errorf("ошибка при сохранении тихих часов: %w", err)
+	}
+
+	return nil
+}
+
+func (u *UserStorage) DisableQuietHours(ctx context.Context, user scrapper.User) error {
+	if _, err := u.db.Exec(ctx, "DELETE FROM quiet_hours WHERE user_id = ($1)", user); err != nil {
+		return fmt.Errorf("ошибка при удалении тихих часов: %w", err)
+	}
+
+	return nil
+}
+
+func (u *UserStorage) SetTimezone(ctx context.Context, user scrapper.User, timezone string) error {
+	_, err := u.db.Exec(ctx, "UPDATE users SET timezone = ($2) WHERE user_id = ($1)",
+		user, timezone)
+
+	if err != nil {
+		return fmt.Errorf("ошибка при сохранении часового пояса: %w", err)
+	}
+
+	return nil
+}
+
+func (u *UserStorage) SetLanguage(ctx context.Context, user scrapper.User, lang i18n.Lang) error {
+	_, err := u.db.Exec(ctx, "UPDATE users SET language = ($2) WHERE user_id = ($1)",
+		user, lang)
+
+	if err != nil {
+		return fmt.Errorf("ошибка при сохранении языка: %w", err)
+	}
+
+	return nil
+}
+
+// UsersLanguages возвращает язык уведомлений каждого пользователя из users.
+func (u *UserStorage) UsersLanguages(ctx context.Context, users []scrapper.User) (map[scrapper.User]i18n.Lang, error) {
+	rows, err := u.db.Query(ctx,
+		"SELECT user_id, language FROM users WHERE user_id = ANY(($1)::bigint[])", users)
+
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении языков пользователей: %w", err)
+	}
+
+	defer rows.Close()
+
+	languages := make(map[scrapper.User]i18n.Lang, len(users))
+
+	for rows.Next() {
+		var (
+			user scrapper.User
+			lang i18n.Lang
+		)
+
+		if err = rows.Scan(&user, &lang); err != nil {
+			return nil, fmt.Errorf("ошибка при чтении строки: %w", err)
+		}
+
+		languages[user] = lang
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при получении языков пользователей: %w", err)
+	}
+
+	return languages, nil
+}
+
+// UsersTimezones возвращает часовой пояс каждого пользователя из users.
+func (u *UserStorage) UsersTimezones(ctx context.Context, users []scrapper.User) (map[scrapper.User]string, error) {
+	rows, err := u.db.Query(ctx,
+		"SELECT user_id, timezone FROM users WHERE user_id = ANY(($1)::bigint[])", users)
+
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении часовых поясов пользователей: %w", err)
+	}
+
+	defer rows.Close()
+
+	timezones := make(map[scrapper.User]string, len(users))
+
+	for rows.Next() {
+		var (
+			user     scrapper.User
+			timezone string
+		)
+
+		if err = rows.Scan(&user, &timezone); err != nil {
+			return nil, fmt.Errorf("ошибка при чтении строки: %w", err)
+		}
+
+		timezones[user] = timezone
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при получении часовых поясов пользователей: %w", err)
+	}
+
+	return timezones, nil
+}
+
+// QuietHours возвращает тихие часы только тех пользователей из users, у которых они включены.
+func (u *UserStorage) QuietHours(ctx context.Context, users []scrapper.User) (map[scrapper.User]*scrapper.QuietHours, error) {
+	rows, err := u.db.Query(ctx,
+		`SELECT quiet_hours.user_id, to_char(quiet_hours.quiet_start, 'HH24:MI'),
+    			to_char(quiet_hours.quiet_end, 'HH24:MI'), users.timezone
+    		 FROM quiet_hours
+    		 JOIN users ON users.user_id = quiet_hours.user_id
+    		 WHERE quiet_hours.user_id = ANY(($1)::bigint[])`, users)
+
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении тихих часов: %w", err)
+	}
+
+	defer rows.Close()
+
+	quietHours := make(map[scrapper.User]*scrapper.QuietHours, len(users))
+
+	for rows.Next() {
+		var user scrapper.User
+
+		hours := &scrapper.QuietHours{}
+
+		if err = rows.Scan(&user, &hours.Start, &hours.End, &hours.Timezone); err != nil {
+			return nil, fmt.Errorf("ошибка при чтении строки: %w", err)
+		}
+
+		quietHours[user] = hours
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при получении тихих часов: %w", err)
+	}
+
+	return quietHours, nil
+}
+
+// HoldUpdate откладывает уведомление до конца тихих часов, у дайджеста linkID равен 0.
+func (u *UserStorage) HoldUpdate(ctx context.Context, linkID LinkID, eventID string, content *scrapper.Notification,
+	users []scrapper.User) error {
+	conn := transactor.GetQuerier(ctx, u.db)
+
+	contentJSON, err := marshalContent(content)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.Exec(ctx,
+		`INSERT INTO held_updates(user_id, link_id, event_id, description, content)
+             SELECT unnest(($1)::bigint[]), NULLIF(($2)::bigint, 0), ($3), '', ($4)::jsonb`,
+		users, linkID, eventID, contentJSON)
+
+	if err != nil {
+		return fmt.Errorf("ошибка при добавлении в таблицу held_updates: %w", err)
+	}
+
+	return nil
+}
+
+func (u *UserStorage) HeldUsers(ctx context.Context) ([]scrapper.User, error) {
+	rows, err := u.db.Query(ctx, "SELECT DISTINCT user_id FROM held_updates")
+
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении пользователей с задержанными уведомлениями: %w", err)
+	}
+
+	return scanUsers(rows)
+}
+
+// HeldUpdates блокирует возвращенные уведомления до конца транзакции из ctx, уже заблокированные пропускает.
+func (u *UserStorage) HeldUpdates(ctx context.Context, user scrapper.User) ([]*scrapper.HeldUpdate, error) {
+	conn := transactor.GetQuerier(ctx, u.db)
+
+	rows, err := conn.Query(ctx,
+		`SELECT held_updates.update_id, COALESCE(held_updates.link_id, 0), COALESCE(links.link_url, ''),
+    			held_updates.event_id, held_updates.description, held_updates.content
+    		 FROM held_updates
+    		 LEFT JOIN links ON links.link_id = held_updates.link_id
+    		 WHERE held_updates.user_id = ($1)
+    		 ORDER BY held_updates.update_id
+    		 FOR UPDATE OF held_updates SKIP LOCKED`, user)
+
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении задержанных уведомлений: %w", err)
+	}
+
+	defer rows.Close()
+
+	heldUpdates := make([]*scrapper.HeldUpdate, 0, linkCap)
+
+	for rows.Next() {
+		var contentJSON []byte
+
+		held := &scrapper.HeldUpdate{}
+
+		if err = rows.Scan(&held.ID, &held.LinkID, &held.URL, &held.EventID, &held.Description, &contentJSON); err != nil {
+			return nil, fmt.Errorf("ошибка при чтении строки: %w", err)
+		}
+
+		if held.Content, err = unmarshalContent(contentJSON); err != nil {
+			return nil, err
+		}
+
+		heldUpdates = append(heldUpdates, held)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при получении задержанных уведомлений: %w", err)
+	}
+
+	return heldUpdates, nil
+}
+
+func (u *UserStorage) DeleteHeldUpdates(ctx context.Context, ids []int64) error {
+	conn := transactor.GetQuerier(ctx, u.db)
+
+	_, err := conn.Exec(ctx, "DELETE FROM held_updates WHERE update_id = ANY(($1)::bigint[])", ids)
+
+	if err != nil {
+		return fmt.Errorf("ошибка при удалении отправленных уведомлений: %w", err)
+	}
+
+	return nil
+}
+
+func (u *UserStorage) SaveOutboxUpdate(ctx context.Context, update *scrapper.OutboxUpdate) error {
+	conn := transactor.GetQuerier(ctx, u.db)
+
+	contentJSON, err := marshalContent(update.Content)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.Exec(ctx,
+		`INSERT INTO outbox(link_id, link_url, event_id, description, content, chat_ids)
+             VALUES ($1, $2, $3, $4, ($5)::jsonb, $6)`,
+		update.LinkID, update.URL, update.EventID, update.Description, contentJSON, update.TgChatIDs)
+
+	if err != nil {
+		return fmt.Errorf("ошибка при добавлении в таблицу outbox: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimOutboxUpdates захватывает на время lease до limit неотправленных уведомлений, не блокируя строки.
+func (u *UserStorage) ClaimOutboxUpdates(ctx context.Context, limit uint, lease time.Duration) ([]*scrapper.OutboxUpdate, error) {
+	conn := transactor.GetQuerier(ctx, u.db)
+
+	rows, err := conn.Query(ctx,
+		`WITH claimed AS (
+             UPDATE outbox SET leased_until = CURRENT_TIMESTAMP + ($2)::bigint * INTERVAL '1 millisecond'
+             WHERE update_id IN (SELECT update_id FROM outbox
+                                 WHERE sent_at IS NULL AND failed_at IS NULL
+                                   AND (leased_until IS NULL OR leased_until <= CURRENT_TIMESTAMP)
+                                 ORDER BY update_id LIMIT ($1) FOR UPDATE SKIP LOCKED)
+             RETURNING update_id, link_id, link_url, event_id, description, content, chat_ids, attempts)
+         SELECT * FROM claimed ORDER BY update_id`, limit, lease.Milliseconds())
+
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при получении неотправленных уведомлений: %w", err)
+	}
+
+	defer rows.Close()
+
+	updates := make([]*scrapper.OutboxUpdate, 0, limit)
+
+	for rows.Next() {
+		var contentJSON []byte
+
+		update := &scrapper.OutboxUpdate{}
+
+		if err = rows.Scan(&update.ID, &update.LinkID, &update.URL, &update.EventID, &update.Description,
+			&contentJSON, &update.TgChatIDs, &update.Attempts); err != nil {
+			return nil, fmt.Errorf("ошибка при чтении строки: %w", err)
+		}
+
+		if update.Content, err = unmarshalContent(contentJSON); err != nil {
+			return nil, err
+		}
+
+		updates = append(updates, update)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при получении неотправленных уведомлений: %w", err)
+	}
+
+	return updates, nil
+}
+
+func (u *UserStorage) MarkOutboxSent(ctx context.Context, ids []int64) error {
+	conn := transactor.GetQuerier(ctx, u.db)
+
+	_, err := conn.Exec(ctx, "UPDATE outbox SET sent_at = CURRENT_TIMESTAMP WHERE update_id = ANY(($1)::bigint[])", ids)
+
+	if err != nil {
+		return fmt.Errorf("ошибка при отметке отправленных уведомлений: %w", err)
+	}
+
+	return nil
+}
+
+// MarkOutboxFailed записывает неудачную попытку отправки, отложенное (park) уведомление больше не отправляется.
+func (u *UserStorage) MarkOutboxFailed(ctx context.Context, id int64, lastErr string, park bool) error {
+	conn := transactor.GetQuerier(ctx, u.db)
+
+	_, err := conn.Exec(ctx,
+		`UPDATE outbox SET attempts = attempts + 1, last_error = ($2), leased_until = NULL,
+                           failed_at = CASE WHEN ($3)::boolean THEN CURRENT_TIMESTAMP END
+         WHERE update_id = ($1)`, id, lastErr, park)
+
+	if err != nil {
+		return fmt.Errorf("ошибка при отметке неотправленного уведомления: %w", err)
+	}
+
+	return nil
+}
+
+func (u *UserStorage) ReleaseOutbox(ctx context.Context, ids []int64) error {
+	conn := transactor.GetQuerier(ctx, u.db)
+
+	_, err := conn.Exec(ctx, "UPDATE outbox SET leased_until = NULL WHERE update_id = ANY(($1)::bigint[])", ids)
+
+	if err != nil {
+		return fmt.Errorf("ошибка при снятии захвата уведомлений: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteSentOutbox удаляет уведомления, отправленные больше чем retention назад.
+func (u *UserStorage) DeleteSentOutbox(ctx context.Context, retention time.Duration) error {
+	conn := transactor.GetQuerier(ctx, u.db)
+
+	_, err := conn.Exec(ctx, "DELETE FROM outbox WHERE sent_at < CURRENT_TIMESTAMP - ($1)::bigint * INTERVAL '1 second'",
+		int64(retention.Seconds()))
+
+	if err != nil {
+		return fmt.Errorf("ошибка при удалении отправленных уведомлений из outbox: %w", err)
+	}
+
+	return nil
+}
+
+func scanUsers(rows pgx.Rows) ([]scrapper.User, error) {
+	defer rows.Close()
+
+	users := make([]scrapper.User, 0, usersCap)
+
+	for rows.Next() {
+		var user scrapper.User
+
+		if err := rows.Scan(&user); err != nil {
+			return nil, fmt.Errorf("ошибка при чтении строки: %w", err)
+		}
+
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при получении пользователей: %w", err)
+	}
+
+	return users, nil
+}
+
+// linkPaginator арендует батчи наступивших ссылок на время lease, чтобы каждую ссылку проверяла одна реплика.
 type linkPaginator struct {
-	db         *pgxpool.Pool
-	lastLinkID int64
-	limit      uint
-	hasLinks   bool
+	db       *pgxpool.Pool
+	dueTime  time.Time
+	lease    time.Duration
+	limit    uint
+	hasLinks bool
 }
 
 func (u *UserStorage) NewLinksPaginator() scrapservice.LinkPaginator {
-	return &linkPaginator{db: u.db, limit: u.batchSize, hasLinks: true}
+	return &linkPaginator{db: u.db, dueTime: time.Now().UTC(), lease: u.linkLease, limit: u.batchSize, hasLinks: true}
 }
 
 func (l *linkPaginator) HasLinks() bool {
 	return l.hasLinks
 }
 
-func (l *linkPaginator) LinksBatch() ([]*LinkInfo, error) {
+func (l *linkPaginator) LinksBatch(ctx context.Context) ([]*LinkInfo, error) {
 	var id int64
 
 	var link string
 
-	var lastCheck time.Time
+	var lastCheck, nextCheck time.Time
 
-	rows, err := l.db.Query(context.Background(),
-		`SELECT link_id, link_url, last_update_check FROM links 
-             WHERE link_id > ($1) AND CURRENT_TIMESTAMP - last_update_check > '5 minutes' 
-             ORDER BY link_id ASC LIMIT ($2);`, l.lastLinkID, l.limit)
+	rows, err := l.db.Query(ctx,
+		`UPDATE links SET leased_until = CURRENT_TIMESTAMP + ($3)::interval
+			FROM (SELECT link_id FROM links
+					WHERE next_check_at <= ($1) AND (leased_until IS NULL OR leased_until <= CURRENT_TIMESTAMP)
+					ORDER BY next_check_at, link_id LIMIT ($2) FOR UPDATE SKIP LOCKED) AS batch
+			WHERE links.link_id = batch.link_id
+			RETURNING links.link_id, links.link_url, links.last_update_check, links.next_check_at, links.check_interval,
+				links.check_failures, (SELECT COALESCE(MIN(userLinks.check_interval), INTERVAL '0') FROM userLinks
+											WHERE userLinks.link_id = links.link_id), links.leased_until;`, l.dueTime, l.limit, l.lease)
 
 	if err != nil {
 		return nil, fmt.Errorf("ошика при выполнении запроса на получение пачки ссылок: %w", err)
@@ -250,19 +967,21 @@ func (l *linkPaginator) LinksBatch() ([]*LinkInfo, error) {
 	for rows.Next() {
 		linkInfo := &LinkInfo{}
 
-		if err = rows.Scan(&id, &link, &lastCheck); err != nil {
+		schedule := &linkInfo.Schedule
+
+		if err = rows.Scan(&id, &link, &lastCheck, &nextCheck, &schedule.Interval, &schedule.Failures,
+			&schedule.Override, &linkInfo.LeasedUntil); err != nil {
 			return nil, fmt.Errorf("ошика при сканировании ссылок: %w", err)
 		}
 
 		linkInfo.ID = id
 		linkInfo.URL = link
-		linkInfo.LastUpdate = lastCheck
+		linkInfo.LastUpdate = lastCheck.UTC()
+		schedule.NextCheck = nextCheck.UTC()
 
 		links = append(links, linkInfo)
 	}
 
-	l.lastLinkID = id
-
 	if err = rows.Err(); err != nil {
 		return nil, fmt.Errorf("ошика при сканировании ссылок: %w", err)
 	}
@@ -271,5 +990,86 @@ func (l *linkPaginator) LinksBatch() ([]*LinkInfo, error) {
 		l.hasLinks = false
 	}
 
-	return links, nil
+	return sortByNextCheck(links), nil
+}
+
+func cursorColumns(cursors map[scrapper.UpdateType]*scrapper.Cursor) ([]scrapper.UpdateType, []int64, []time.Time) {
+	types := make([]scrapper.UpdateType, 0, len(cursors))
+	ids := make([]int64, 0, len(cursors))
+	times := make([]time.Time, 0, len(cursors))
+
+	for updateType, cursor := range cursors {
+		types = append(types, updateType)
+		ids = append(ids, cursor.ItemID)
+		times = append(times, cursor.ItemTime)
+	}
+
+	return types, ids, times
+}
+
+func scanCursors(rows pgx.Rows) (map[scrapper.UpdateType]*scrapper.Cursor, error) {
+	defer rows.Close()
+
+	cursors := make(map[scrapper.UpdateType]*scrapper.Cursor)
+
+	for rows.Next() {
+		var updateType scrapper.UpdateType
+
+		cursor := &scrapper.Cursor{}
+
+		if err := rows.Scan(&updateType, &cursor.ItemID, &cursor.ItemTime); err != nil {
+			return nil, fmt.Errorf("ошибка при чтении строки: %w", err)
+		}
+
+		cursor.ItemTime = cursor.ItemTime.UTC()
+
+		cursors[updateType] = cursor
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка при получении курсоров ссылки: %w", err)
+	}
+
+	return cursors, nil
+}
+
+// marshalContent кодирует содержимое уведомления, у старых уведомлений без него content равен NULL.
+func marshalContent(content *scrapper.Notification) ([]byte, error) {
+	if content == nil {
+		return nil, nil
+	}
+
+	contentJSON, err := json.Marshal(content)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка при кодировании содержимого уведомления: %w", err)
+	}
+
+	return contentJSON, nil
+}
+
+func unmarshalContent(contentJSON []byte) (*scrapper.Notification, error) {
+	if contentJSON == nil {
+		return nil, nil
+	}
+
+	content := &scrapper.Notification{}
+
+	if err := json.Unmarshal(contentJSON, content); err != nil {
+		return nil, fmt.Errorf("ошибка при чтении содержимого уведомления: %w", err)
+	}
+
+	return content, nil
+}
+
+// sortByNextCheck восстанавливает порядок батча: RETURNING не сохраняет порядок подзапроса.
+func sortByNextCheck(links []*LinkInfo) []*LinkInfo {
+	slices.SortFunc(links, func(a, b *LinkInfo) int {
+		if byTime := a.Schedule.NextCheck.Compare(b.Schedule.NextCheck); byTime != 0 {
+			return byTime
+		}
+
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return links
 }
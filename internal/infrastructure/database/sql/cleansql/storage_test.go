@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"linkTraccer/internal/domain/i18n"
 	"linkTraccer/internal/domain/scrapper"
 	"linkTraccer/internal/infrastructure/database/sql"
 	"linkTraccer/internal/infrastructure/database/sql/cleansql"
@@ -252,7 +253,7 @@ func TestUserStorage_ChangeLastCheckTime(t *testing.T) {
 	expectedRows := 1
 
 	for _, test := range tests {
-		err := userRepo.ChangeLastCheckTime(test.link, test.newTime)
+		err := userRepo.ChangeLastCheckTime(context.Background(), test.link, test.newTime)
 
 		assert.NoError(t, err)
 
@@ -276,10 +277,98 @@ func TestUserStorage_ChangeLastCheckTime(t *testing.T) {
 
 		assert.NoError(t, rows.Err())
 		assert.Equal(t, expectedRows, countRows)
-		assert.Equal(t, test.newTime, updateTime)
+		assert.Equal(t, test.newTime, updateTime.UTC())
 	}
 }
 
+func TestUserStorage_LinksSchedule(t *testing.T) {
+	pgxPool := ConfigureDatabase(t)
+	userRepo := cleansql.NewStore(&sql.DBConfig{BatchSize: 1, LinkLease: time.Minute}, pgxPool)
+
+	assert.NoError(t, userRepo.TrackLink(context.Background(), firstID, githubLink, time.Now()))
+	assert.NoError(t, userRepo.TrackLink(context.Background(), secondID, stackoverflowLink, time.Now()))
+
+	linkIDs := make(map[scrapper.Link]scrapper.LinkID)
+
+	for _, link := range []scrapper.Link{githubLink, stackoverflowLink} {
+		var linkID int64
+
+		err := pgxPool.QueryRow(context.Background(),
+			`SELECT link_id FROM links WHERE link_url = ($1)`, link).Scan(&linkID)
+		assert.NoError(t, err, "ошибка при подготовке тестовых данных")
+
+		linkIDs[link] = linkID
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	githubSchedule := &scrapper.LinkSchedule{Interval: 10 * time.Minute, Failures: 1, NextCheck: now.Add(-time.Hour)}
+	stackSchedule := &scrapper.LinkSchedule{Interval: 5 * time.Minute, NextCheck: now.Add(-2 * time.Hour)}
+
+	assert.NoError(t, userRepo.ScheduleNextCheck(context.Background(), linkIDs[githubLink], githubSchedule))
+	assert.NoError(t, userRepo.ScheduleNextCheck(context.Background(), linkIDs[stackoverflowLink], stackSchedule))
+	assert.NoError(t, userRepo.SetCheckInterval(context.Background(), firstID, githubLink, 2*time.Minute))
+
+	paginator := userRepo.NewLinksPaginator()
+
+	links, err := paginator.LinksBatch(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, links, 1)
+	assert.Equal(t, stackoverflowLink, links[0].URL, "первой проверяется ссылка, которая ждет дольше")
+	assert.Equal(t, *stackSchedule, links[0].Schedule)
+
+	staleLease := links[0]
+
+	links, err = userRepo.NewLinksPaginator().LinksBatch(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, links, 1)
+	assert.Equal(t, githubLink, links[0].URL, "арендованную ссылку другая реплика пропускает")
+	assert.Equal(t, scrapper.LinkSchedule{Interval: 10 * time.Minute, Failures: 1, Override: 2 * time.Minute,
+		NextCheck: now.Add(-time.Hour)}, links[0].Schedule, "проверка уже просрочена, интервал ее не переносит")
+
+	links, err = paginator.LinksBatch(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, links)
+	assert.False(t, paginator.HasLinks())
+
+	githubSchedule.NextCheck = now.Add(time.Hour)
+
+	assert.NoError(t, userRepo.ScheduleNextCheck(context.Background(), linkIDs[githubLink], githubSchedule))
+
+	links, err = userRepo.NewLinksPaginator().LinksBatch(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, links, "вторая ссылка еще арендована, а время проверки первой не наступило")
+
+	_, err = pgxPool.Exec(context.Background(),
+		`UPDATE links SET leased_until = CURRENT_TIMESTAMP - INTERVAL '1 second' WHERE link_id = ($1)`,
+		linkIDs[stackoverflowLink])
+	assert.NoError(t, err, "ошибка при подготовке тестовых данных")
+
+	links, err = userRepo.NewLinksPaginator().LinksBatch(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, links, 1)
+	assert.Equal(t, stackoverflowLink, links[0].URL, "истекшая аренда упавшей реплики освобождает ссылку")
+
+	extended, err := userRepo.ExtendLinkLease(context.Background(), staleLease)
+	assert.NoError(t, err)
+	assert.False(t, extended, "аренду, которую забрала другая реплика, продлить нельзя")
+
+	leasedUntil := links[0].LeasedUntil
+
+	extended, err = userRepo.ExtendLinkLease(context.Background(), links[0])
+	assert.NoError(t, err)
+	assert.True(t, extended)
+	assert.False(t, links[0].LeasedUntil.Before(leasedUntil))
+
+	assert.NoError(t, userRepo.SetCheckInterval(context.Background(), firstID, githubLink, 0))
+
+	var nextCheck time.Time
+
+	err = pgxPool.QueryRow(context.Background(),
+		`SELECT next_check_at FROM links WHERE link_id = ($1)`, linkIDs[githubLink]).Scan(&nextCheck)
+	assert.NoError(t, err)
+	assert.False(t, nextCheck.After(time.Now()), "после сброса интервала ссылка проверяется сразу")
+}
+
 func TestUserStorage_UsersWhoTrackLink(t *testing.T) {
 	pgxPool := ConfigureDatabase(t)
 	userRepo := cleansql.NewStore(&sql.DBConfig{}, pgxPool)
@@ -335,7 +424,7 @@ func TestUserStorage_UsersWhoTrackLink(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		users, err := userRepo.UsersWhoTrackLink(test.linkID)
+		users, err := userRepo.UsersWhoTrackLink(context.Background(), test.linkID)
 
 		assert.NoError(t, err)
 		assert.ElementsMatch(t, test.expectedUsers, users)
@@ -347,18 +436,23 @@ func TestUserStorage_AllUserLinks(t *testing.T) {
 	userRepo := cleansql.NewStore(&sql.DBConfig{}, pgxPool)
 
 	type TestData struct {
-		userID int64
-		link   string
+		userID  int64
+		link    string
+		tags    []string
+		filters []string
 	}
 
 	dataToDB := []TestData{
 		{
-			userID: firstID,
-			link:   githubLink,
+			userID:  firstID,
+			link:    githubLink,
+			tags:    []string{"work", "go"},
+			filters: []string{"user:dummy"},
 		},
 		{
 			userID: secondID,
 			link:   stackoverflowLink,
+			tags:   []string{"work"},
 		},
 		{
 			userID: secondID,
@@ -370,29 +464,42 @@ func TestUserStorage_AllUserLinks(t *testing.T) {
 		err := userRepo.TrackLink(context.Background(), data.userID, data.link, time.Now())
 
 		assert.NoError(t, err, "ошибка при подготовке тестовых данных")
+
+		err = userRepo.AddLinkTags(context.Background(), data.userID, data.link, data.tags)
+
+		assert.NoError(t, err, "ошибка при подготовке тестовых данных")
+
+		err = userRepo.AddLinkFilters(context.Background(), data.userID, data.link, data.filters)
+
+		assert.NoError(t, err, "ошибка при подготовке тестовых данных")
 	}
 
 	type TestCase struct {
 		userID        int64
-		expectedLinks []scrapper.Link
+		expectedLinks []*scrapper.UserLink
 	}
 
 	tests := []TestCase{
 		{
-			userID:        secondID,
-			expectedLinks: []scrapper.Link{stackoverflowLink, githubLink},
+			userID: secondID,
+			expectedLinks: []*scrapper.UserLink{
+				{ID: 1, URL: githubLink, Tags: []string{}, Filters: []string{}},
+				{ID: 2, URL: stackoverflowLink, Tags: []string{"work"}, Filters: []string{}},
+			},
 		},
 		{
-			userID:        firstID,
-			expectedLinks: []scrapper.Link{githubLink},
+			userID: firstID,
+			expectedLinks: []*scrapper.UserLink{
+				{ID: 1, URL: githubLink, Tags: []string{"go", "work"}, Filters: []string{"user:dummy"}},
+			},
 		},
 	}
 
 	for _, test := range tests {
-		links, err := userRepo.AllUserLinks(test.userID)
+		links, err := userRepo.AllUserLinks(context.Background(), test.userID)
 
 		assert.NoError(t, err)
-		assert.ElementsMatch(t, test.expectedLinks, links)
+		assert.Equal(t, test.expectedLinks, links)
 	}
 }
 
@@ -447,7 +554,7 @@ func TestUserStorage_UserTrackLink(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		track, err := userRepo.UserTrackLink(test.userID, test.link)
+		track, err := userRepo.UserTrackLink(context.Background(), test.userID, test.link)
 
 		assert.NoError(t, err)
 		assert.Equal(t, test.trackLink, track)
@@ -501,7 +608,7 @@ func TestUserStorage_UserExist(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		userExist, err := userRepo.UserExist(test.userID)
+		userExist, err := userRepo.UserExist(context.Background(), test.userID)
 
 		assert.NoError(t, err)
 		assert.Equal(t, test.userExist, userExist)
@@ -526,11 +633,11 @@ func TestUserStorage_RegUser(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		err := userRepo.RegUser(test.userID)
+		err := userRepo.RegUser(context.Background(), test.userID)
 
 		assert.NoError(t, err)
 
-		userExist, err := userRepo.UserExist(test.userID)
+		userExist, err := userRepo.UserExist(context.Background(), test.userID)
 
 		assert.NoError(t, err)
 		assert.Equal(t, true, userExist)
@@ -657,7 +764,7 @@ func TestUserStorage_UntrackLink(t *testing.T) {
 	currentCount := 0
 
 	for _, test := range tests {
-		err := userRepo.UntrackLink(test.userID, test.deletedLink)
+		err := userRepo.UntrackLink(context.Background(), test.userID, test.deletedLink)
 
 		assert.NoError(t, err)
 
@@ -670,6 +777,28 @@ func TestUserStorage_UntrackLink(t *testing.T) {
 	}
 }
 
+func TestUserStorage_DeleteUserWithUntrackedLinks(t *testing.T) {
+	pgxPool := ConfigureDatabase(t)
+	userRepo := cleansql.NewStore(&sql.DBConfig{}, pgxPool)
+
+	assert.NoError(t, userRepo.TrackLink(context.Background(), firstID, githubLink, time.Now()))
+	assert.NoError(t, userRepo.TrackLink(context.Background(), firstID, stackoverflowLink, time.Now()))
+	assert.NoError(t, userRepo.TrackLink(context.Background(), secondID, stackoverflowLink, time.Now()))
+
+	assert.NoError(t, userRepo.DeleteUser(context.Background(), firstID))
+	assert.NoError(t, userRepo.DeleteUntrackedLinks(context.Background()))
+
+	var link string
+
+	err := pgxPool.QueryRow(context.Background(),
+		`SELECT link_url FROM links WHERE link_url = ($1)`, githubLink).Scan(&link)
+	assert.ErrorIs(t, err, pgx.ErrNoRows, "ссылку удаленного пользователя больше никто не отслеживает")
+
+	err = pgxPool.QueryRow(context.Background(),
+		`SELECT link_url FROM links WHERE link_url = ($1)`, stackoverflowLink).Scan(&link)
+	assert.NoError(t, err, "ссылку еще отслеживает другой пользователь")
+}
+
 func TestUserStorage_DeleteUntrackedLinks(t *testing.T) {
 	pgxPool := ConfigureDatabase(t)
 	userRepo := cleansql.NewStore(&sql.DBConfig{}, pgxPool)
@@ -731,11 +860,11 @@ func TestUserStorage_DeleteUntrackedLinks(t *testing.T) {
 	var link string
 
 	for _, test := range tests {
-		err := userRepo.UntrackLink(test.userID, test.deletedLink)
+		err := userRepo.UntrackLink(context.Background(), test.userID, test.deletedLink)
 
 		assert.NoError(t, err)
 
-		err = userRepo.DeleteUntrackedLinks()
+		err = userRepo.DeleteUntrackedLinks(context.Background())
 
 		assert.NoError(t, err)
 
@@ -750,3 +879,258 @@ func TestUserStorage_DeleteUntrackedLinks(t *testing.T) {
 		}
 	}
 }
+
+func TestUserStorage_Digest(t *testing.T) {
+	pgxPool := ConfigureDatabase(t)
+	userRepo := cleansql.NewStore(&sql.DBConfig{}, pgxPool)
+
+	for _, userID := range []int64{firstID, secondID, thirdID} {
+		err := userRepo.TrackLink(context.Background(), userID, githubLink, time.Now())
+
+		assert.NoError(t, err, "ошибка при подготовке тестовых данных")
+	}
+
+	var linkID int64
+
+	err := pgxPool.QueryRow(context.Background(),
+		`SELECT link_id FROM links WHERE link_url = ($1)`, githubLink).Scan(&linkID)
+	assert.NoError(t, err, "ошибка при подготовке тестовых данных")
+
+	// 09:30 в часовом поясе пользователей по умолчанию (Europe/Moscow)
+	now := time.Date(2025, 4, 1, 6, 30, 0, 0, time.UTC)
+
+	assert.NoError(t, userRepo.SetDigestTime(context.Background(), firstID, "09:00", now.Add(-time.Hour)))
+	assert.NoError(t, userRepo.SetDigestTime(context.Background(), secondID, "21:00", now.Add(-time.Hour)))
+	assert.NoError(t, userRepo.SetDigestTime(context.Background(), secondID, "10:00", now.Add(-time.Hour)),
+		"повторная установка времени обновляет его")
+
+	digestUsers, err := userRepo.DigestUsers(context.Background(), []scrapper.User{firstID, secondID, thirdID})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []scrapper.User{firstID, secondID}, digestUsers)
+
+	update := &scrapper.LinkUpdate{Site: scrapper.GitHubSite, Type: scrapper.IssueUpdate, ItemID: 1,
+		ItemURL: githubLink + "/issues/1", Author: "orlov4919", ItemTime: time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC),
+		Title: "Issue", Excerpt: "new feature"}
+
+	err = userRepo.SavePendingUpdate(context.Background(), linkID, update, []scrapper.User{firstID, secondID})
+	assert.NoError(t, err)
+
+	pending, err := userRepo.PendingUpdates(context.Background(), firstID)
+	assert.NoError(t, err)
+	assert.Len(t, pending, 1)
+	assert.Equal(t, githubLink, pending[0].URL)
+	assert.Equal(t, update, pending[0].Update)
+
+	assert.NoError(t, userRepo.SetDigestTime(context.Background(), thirdID, "09:00", now),
+		"время дайджеста, которое сегодня уже прошло")
+
+	dueUsers, err := userRepo.DueDigestUsers(context.Background(), now)
+	assert.NoError(t, err)
+	assert.Equal(t, []scrapper.User{firstID}, dueUsers, "у второго пользователя время дайджеста еще не наступило")
+
+	assert.NoError(t, userRepo.DeletePendingUpdates(context.Background(), firstID, pending[0].ID))
+	assert.NoError(t, userRepo.MarkDigestSent(context.Background(), firstID, now))
+
+	pending, err = userRepo.PendingUpdates(context.Background(), firstID)
+	assert.NoError(t, err)
+	assert.Empty(t, pending)
+
+	dueUsers, err = userRepo.DueDigestUsers(context.Background(), now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, []scrapper.User{secondID}, dueUsers, "первый пользователь уже получил дайджест сегодня")
+
+	assert.NoError(t, userRepo.SetInstantDelivery(context.Background(), secondID))
+
+	digestUsers, err = userRepo.DigestUsers(context.Background(), []scrapper.User{secondID})
+	assert.NoError(t, err)
+	assert.Empty(t, digestUsers)
+
+	dueUsers, err = userRepo.DueDigestUsers(context.Background(), now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, []scrapper.User{secondID}, dueUsers, "отложенные обновления отправляются после отключения дайджеста")
+
+	dueUsers, err = userRepo.DueDigestUsers(context.Background(), now.Add(24*time.Hour))
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []scrapper.User{firstID, secondID, thirdID}, dueUsers,
+		"дайджест, время которого прошло при настройке, уходит на следующий день")
+}
+
+func TestUserStorage_QuietHours(t *testing.T) {
+	pgxPool := ConfigureDatabase(t)
+	userRepo := cleansql.NewStore(&sql.DBConfig{}, pgxPool)
+
+	for _, userID := range []int64{firstID, secondID} {
+		err := userRepo.TrackLink(context.Background(), userID, githubLink, time.Now())
+
+		assert.NoError(t, err, "ошибка при подготовке тестовых данных")
+	}
+
+	var linkID int64
+
+	err := pgxPool.QueryRow(context.Background(),
+		`SELECT link_id FROM links WHERE link_url = ($1)`, githubLink).Scan(&linkID)
+	assert.NoError(t, err, "ошибка при подготовке тестовых данных")
+
+	assert.NoError(t, userRepo.SetQuietHours(context.Background(), firstID, "23:00", "08:00"))
+	assert.NoError(t, userRepo.SetQuietHours(context.Background(), secondID, "13:00", "14:00"))
+	assert.NoError(t, userRepo.SetTimezone(context.Background(), secondID, "Asia/Yekaterinburg"))
+	assert.NoError(t, userRepo.DisableQuietHours(context.Background(), secondID))
+
+	quietHours, err := userRepo.QuietHours(context.Background(), []scrapper.User{firstID, secondID, thirdID})
+	assert.NoError(t, err)
+	assert.Equal(t, map[scrapper.User]*scrapper.QuietHours{
+		firstID: {Start: "23:00", End: "08:00", Timezone: scrapper.DefaultTimezone},
+	}, quietHours)
+
+	first := &scrapper.Notification{Update: &scrapper.LinkUpdate{Site: scrapper.GitHubSite, Type: scrapper.IssueUpdate,
+		Title: "first"}, Language: i18n.Default, Timezone: scrapper.DefaultTimezone}
+	digest := &scrapper.Notification{Digest: []*scrapper.LinkDigest{{URL: githubLink}}, Language: i18n.Default,
+		Timezone: scrapper.DefaultTimezone}
+
+	assert.NoError(t, userRepo.HoldUpdate(context.Background(), linkID, "event", first, []scrapper.User{firstID, secondID}))
+	assert.NoError(t, userRepo.HoldUpdate(context.Background(), 0, "", digest, []scrapper.User{firstID}))
+
+	heldUsers, err := userRepo.HeldUsers(context.Background())
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []scrapper.User{firstID, secondID}, heldUsers)
+
+	heldUpdates, err := userRepo.HeldUpdates(context.Background(), firstID)
+	assert.NoError(t, err)
+	assert.Len(t, heldUpdates, 2)
+	assert.Equal(t, githubLink, heldUpdates[0].URL)
+	assert.Equal(t, first, heldUpdates[0].Content)
+	assert.Equal(t, "event", heldUpdates[0].EventID)
+	assert.Equal(t, scrapper.LinkID(0), heldUpdates[1].LinkID, "дайджест не привязан к ссылке")
+	assert.Equal(t, digest, heldUpdates[1].Content)
+
+	assert.NoError(t, userRepo.DeleteHeldUpdates(context.Background(), []int64{heldUpdates[0].ID, heldUpdates[1].ID}))
+
+	heldUsers, err = userRepo.HeldUsers(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []scrapper.User{secondID}, heldUsers)
+}
+
+func TestUserStorage_Languages(t *testing.T) {
+	pgxPool := ConfigureDatabase(t)
+	userRepo := cleansql.NewStore(&sql.DBConfig{}, pgxPool)
+
+	for _, userID := range []int64{firstID, secondID} {
+		assert.NoError(t, userRepo.RegUser(context.Background(), userID), "ошибка при подготовке тестовых данных")
+	}
+
+	assert.NoError(t, userRepo.SetLanguage(context.Background(), secondID, i18n.English))
+
+	languages, err := userRepo.UsersLanguages(context.Background(), []scrapper.User{firstID, secondID, thirdID})
+	assert.NoError(t, err)
+	assert.Equal(t, map[scrapper.User]i18n.Lang{
+		firstID:  i18n.Default,
+		secondID: i18n.English,
+	}, languages)
+
+	assert.NoError(t, userRepo.SetTimezone(context.Background(), secondID, "Asia/Yekaterinburg"))
+
+	timezones, err := userRepo.UsersTimezones(context.Background(), []scrapper.User{firstID, secondID, thirdID})
+	assert.NoError(t, err)
+	assert.Equal(t, map[scrapper.User]string{
+		firstID:  scrapper.DefaultTimezone,
+		secondID: "Asia/Yekaterinburg",
+	}, timezones)
+}
+
+func TestUserStorage_Outbox(t *testing.T) {
+	pgxPool := ConfigureDatabase(t)
+	userRepo := cleansql.NewStore(&sql.DBConfig{}, pgxPool)
+
+	first := &scrapper.OutboxUpdate{LinkID: 1, URL: githubLink, EventID: "event",
+		Content: &scrapper.Notification{Update: &scrapper.LinkUpdate{Site: scrapper.GitHubSite, Title: "first"},
+			Language: i18n.Default}, TgChatIDs: []scrapper.User{firstID, secondID}}
+	digest := &scrapper.OutboxUpdate{Description: "digest", TgChatIDs: []scrapper.User{thirdID}}
+
+	assert.NoError(t, userRepo.SaveOutboxUpdate(context.Background(), first))
+	assert.NoError(t, userRepo.SaveOutboxUpdate(context.Background(), digest))
+
+	claimed, err := userRepo.ClaimOutboxUpdates(context.Background(), 1, time.Hour)
+	assert.NoError(t, err)
+	assert.Len(t, claimed, 1)
+	assert.Equal(t, first.URL, claimed[0].URL)
+	assert.Equal(t, first.TgChatIDs, claimed[0].TgChatIDs)
+	assert.Equal(t, first.EventID, claimed[0].EventID)
+	assert.Equal(t, first.Content, claimed[0].Content)
+
+	other, err := userRepo.ClaimOutboxUpdates(context.Background(), 10, time.Hour)
+	assert.NoError(t, err)
+	assert.Len(t, other, 1, "захваченное уведомление другая реплика не получает")
+	assert.Equal(t, digest.Description, other[0].Description)
+	assert.Nil(t, other[0].Content, "уведомления без содержимого отправляются по описанию")
+	assert.Equal(t, scrapper.LinkID(0), other[0].LinkID)
+
+	assert.NoError(t, userRepo.MarkOutboxSent(context.Background(), []int64{claimed[0].ID}))
+	assert.NoError(t, userRepo.MarkOutboxFailed(context.Background(), other[0].ID, "бот ответил 500", false))
+
+	unsent, err := userRepo.ClaimOutboxUpdates(context.Background(), 10, time.Hour)
+	assert.NoError(t, err)
+
+	if assert.Len(t, unsent, 1, "после неудачной попытки захват снимается") {
+		assert.Equal(t, digest.Description, unsent[0].Description)
+		assert.Equal(t, 1, unsent[0].Attempts)
+	}
+
+	assert.NoError(t, userRepo.ReleaseOutbox(context.Background(), []int64{other[0].ID}))
+	assert.NoError(t, userRepo.MarkOutboxFailed(context.Background(), other[0].ID, "бот ответил 400", true))
+
+	parked, err := userRepo.ClaimOutboxUpdates(context.Background(), 10, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, parked, "отложенное уведомление больше не отправляется")
+
+	var lastErr string
+
+	assert.NoError(t, pgxPool.QueryRow(context.Background(), "SELECT last_error FROM outbox WHERE update_id = ($1)",
+		other[0].ID).Scan(&lastErr))
+	assert.Equal(t, "бот ответил 400", lastErr)
+
+	var count int
+
+	assert.NoError(t, userRepo.DeleteSentOutbox(context.Background(), time.Hour))
+	assert.NoError(t, pgxPool.QueryRow(context.Background(), "SELECT count(*) FROM outbox").Scan(&count))
+	assert.Equal(t, 2, count, "отправленное уведомление еще не устарело")
+
+	assert.NoError(t, userRepo.DeleteSentOutbox(context.Background(), 0))
+	assert.NoError(t, pgxPool.QueryRow(context.Background(), "SELECT count(*) FROM outbox").Scan(&count))
+	assert.Equal(t, 1, count, "отложенное уведомление не удаляется")
+}
+
+func TestUserStorage_LinkCursors(t *testing.T) {
+	pgxPool := ConfigureDatabase(t)
+	userRepo := cleansql.NewStore(&sql.DBConfig{}, pgxPool)
+
+	assert.NoError(t, userRepo.TrackLink(context.Background(), firstID, githubLink, time.Now()),
+		"ошибка при подготовке тестовых данных")
+
+	var linkID int64
+
+	err := pgxPool.QueryRow(context.Background(),
+		`SELECT link_id FROM links WHERE link_url = ($1)`, githubLink).Scan(&linkID)
+	assert.NoError(t, err, "ошибка при подготовке тестовых данных")
+
+	cursors, err := userRepo.LinkCursors(context.Background(), linkID)
+	assert.NoError(t, err)
+	assert.Empty(t, cursors)
+
+	issueTime := time.Date(2025, 4, 1, 10, 0, 0, 0, time.UTC)
+	prTime := issueTime.Add(time.Hour)
+
+	assert.NoError(t, userRepo.SaveLinkCursors(context.Background(), linkID, map[scrapper.UpdateType]*scrapper.Cursor{
+		scrapper.IssueUpdate: {ItemID: 1, ItemTime: issueTime},
+		scrapper.PRUpdate:    {ItemID: 2, ItemTime: prTime},
+	}))
+	assert.NoError(t, userRepo.SaveLinkCursors(context.Background(), linkID, map[scrapper.UpdateType]*scrapper.Cursor{
+		scrapper.IssueUpdate: {ItemID: 3, ItemTime: prTime},
+	}))
+
+	cursors, err = userRepo.LinkCursors(context.Background(), linkID)
+	assert.NoError(t, err)
+	assert.Len(t, cursors, 2)
+	assert.Equal(t, int64(3), cursors[scrapper.IssueUpdate].ItemID)
+	assert.True(t, prTime.Equal(cursors[scrapper.IssueUpdate].ItemTime), "время курсора не зависит от часового пояса БД")
+	assert.Equal(t, int64(2), cursors[scrapper.PRUpdate].ItemID, "курсор другого типа не меняется")
+}
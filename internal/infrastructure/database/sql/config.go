@@ -2,18 +2,20 @@ package sql
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/caarlos0/env/v11"
 )
 
 type DBConfig struct {
-	DBUser     string `env:"DB_USER"`
-	DBPass     string `env:"DB_PASS"`
-	DBName     string `env:"DB_NAME"`
-	DBHost     string `env:"DB_HOST"`
-	DBPort     string `env:"DB_PORT"`
-	BatchSize  uint   `env:"BATCH_SIZE"`
-	AccessType string `env:"ACCESS_TYPE"`
+	DBUser     string        `env:"DB_USER"`
+	DBPass     string        `env:"DB_PASS"`
+	DBName     string        `env:"DB_NAME"`
+	DBHost     string        `env:"DB_HOST"`
+	DBPort     string        `env:"DB_PORT"`
+	BatchSize  uint          `env:"BATCH_SIZE"`
+	AccessType string        `env:"ACCESS_TYPE"`
+	LinkLease  time.Duration `env:"LINK_LEASE" envDefault:"10m"` // на сколько реплика захватывает батч ссылок
 }
 
 func NewConfig() (*DBConfig, error) {
@@ -0,0 +1,127 @@
+package fallbackclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"linkTraccer/internal/domain/dto"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Sender - транспорт, которым обновления доставляются боту (kafka продюсер или http клиент).
+type Sender interface {
+	SendLinkUpdates(ctx context.Context, update *dto.LinkUpdate) error
+}
+
+// FallbackClient после failureThreshold ошибок подряд переходит на запасной транспорт
+// и раз в probeInterval пробует вернуться на основной.
+type FallbackClient struct {
+	primary          Sender
+	secondary        Sender
+	failureThreshold int
+	probeInterval    time.Duration
+	log              *slog.Logger
+
+	mu          sync.Mutex
+	failures    int
+	open        bool
+	probing     bool
+	nextProbeAt time.Time
+}
+
+func New(primary, secondary Sender, failureThreshold int, probeInterval time.Duration, log *slog.Logger) *FallbackClient {
+	return &FallbackClient{
+		primary:          primary,
+		secondary:        secondary,
+		failureThreshold: max(1, failureThreshold),
+		probeInterval:    probeInterval,
+		log:              log,
+	}
+}
+
+func (c *FallbackClient) SendLinkUpdates(ctx context.Context, update *dto.LinkUpdate) error {
+	if !c.usePrimary() {
+		return c.sendSecondary(ctx, update, nil)
+	}
+
+	err := c.primary.SendLinkUpdates(ctx, update)
+
+	c.report(err)
+
+	if err == nil {
+		return nil
+	}
+
+	return c.sendSecondary(ctx, update, fmt.Errorf("ошибка при отправке обновления через основной транспорт: %w", err))
+}
+
+func (c *FallbackClient) sendSecondary(ctx context.Context, update *dto.LinkUpdate, primaryErr error) error {
+	if err := c.secondary.SendLinkUpdates(ctx, update); err != nil {
+		return errors.Join(primaryErr, fmt.Errorf("ошибка при отправке обновления через запасной транспорт: %w", err))
+	}
+
+	return nil
+}
+
+// Close закрывает транспорты, которые держат соединения (kafka продюсер дописывает накопленный батч).
+func (c *FallbackClient) Close() error {
+	var errs []error
+
+	for _, sender := range []Sender{c.primary, c.secondary} {
+		if closer, ok := sender.(io.Closer); ok {
+			errs = append(errs, closer.Close())
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// usePrimary решает, идет ли обновление через основной транспорт. Пока размыкатель открыт,
+// через основной транспорт проходит только одна пробная отправка раз в probeInterval.
+func (c *FallbackClient) usePrimary() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.open {
+		return true
+	}
+
+	if c.probing || time.Now().Before(c.nextProbeAt) {
+		return false
+	}
+
+	c.probing = true
+
+	return true
+}
+
+func (c *FallbackClient) report(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.probing = false
+
+	if err == nil {
+		if c.open {
+			c.log.Info("основной транспорт обновлений снова доступен")
+		}
+
+		c.failures, c.open = 0, false
+
+		return
+	}
+
+	c.failures++
+
+	if c.open || c.failures >= c.failureThreshold {
+		if !c.open {
+			c.log.Warn("основной транспорт обновлений недоступен, переключаемся на запасной", "err", err.Error(),
+				"failures", c.failures)
+		}
+
+		c.open, c.nextProbeAt = true, time.Now().Add(c.probeInterval)
+	}
+}
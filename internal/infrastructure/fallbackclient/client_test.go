@@ -0,0 +1,119 @@
+package fallbackclient_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"linkTraccer/internal/domain/dto"
+	"linkTraccer/internal/infrastructure/fallbackclient"
+	"linkTraccer/internal/infrastructure/fallbackclient/mocks"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var errTest = errors.New("брокер недоступен")
+
+var linkUpdate = &dto.LinkUpdate{
+	ID:          1,
+	URL:         "stackoverflow.com",
+	Description: "Тема с вопросом обновилась",
+	TgChatIDs:   []int64{2, 8},
+}
+
+func TestFallbackClient_SendLinkUpdates(t *testing.T) {
+	type TestCase struct {
+		name          string
+		threshold     int
+		probeInterval time.Duration
+		sends         int
+		prepare       func(primary, secondary *mocks.Sender)
+		correct       bool
+	}
+
+	tests := []TestCase{
+		{
+			name:          "Основной транспорт работает, запасной не используется",
+			threshold:     2,
+			probeInterval: time.Hour,
+			sends:         3,
+			prepare: func(primary, _ *mocks.Sender) {
+				primary.On("SendLinkUpdates", mock.Anything, linkUpdate).Return(nil).Times(3)
+			},
+			correct: true,
+		},
+		{
+			name:          "Единичная ошибка основного транспорта, обновление уходит через запасной",
+			threshold:     2,
+			probeInterval: time.Hour,
+			sends:         2,
+			prepare: func(primary, secondary *mocks.Sender) {
+				primary.On("SendLinkUpdates", mock.Anything, linkUpdate).Return(errTest).Once()
+				primary.On("SendLinkUpdates", mock.Anything, linkUpdate).Return(nil).Once()
+				secondary.On("SendLinkUpdates", mock.Anything, linkUpdate).Return(nil).Once()
+			},
+			correct: true,
+		},
+		{
+			name:          "Основной транспорт недоступен, после открытия размыкателя он больше не вызывается",
+			threshold:     2,
+			probeInterval: time.Hour,
+			sends:         5,
+			prepare: func(primary, secondary *mocks.Sender) {
+				primary.On("SendLinkUpdates", mock.Anything, linkUpdate).Return(errTest).Twice()
+				secondary.On("SendLinkUpdates", mock.Anything, linkUpdate).Return(nil).Times(5)
+			},
+			correct: true,
+		},
+		{
+			name:          "Пробная отправка прошла, клиент возвращается на основной транспорт",
+			threshold:     1,
+			probeInterval: 0,
+			sends:         3,
+			prepare: func(primary, secondary *mocks.Sender) {
+				primary.On("SendLinkUpdates", mock.Anything, linkUpdate).Return(errTest).Once()
+				primary.On("SendLinkUpdates", mock.Anything, linkUpdate).Return(nil).Twice()
+				secondary.On("SendLinkUpdates", mock.Anything, linkUpdate).Return(nil).Once()
+			},
+			correct: true,
+		},
+		{
+			name:          "Оба транспорта недоступны, ожидаем ошибку",
+			threshold:     1,
+			probeInterval: time.Hour,
+			sends:         2,
+			prepare: func(primary, secondary *mocks.Sender) {
+				primary.On("SendLinkUpdates", mock.Anything, linkUpdate).Return(errTest).Once()
+				secondary.On("SendLinkUpdates", mock.Anything, linkUpdate).Return(errTest).Twice()
+			},
+			correct: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			primary, secondary := mocks.NewSender(t), mocks.NewSender(t)
+
+			test.prepare(primary, secondary)
+
+			client := fallbackclient.New(primary, secondary, test.threshold, test.probeInterval,
+				slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			for range test.sends {
+				err := client.SendLinkUpdates(context.Background(), linkUpdate)
+
+				if test.correct {
+					assert.NoError(t, err)
+				} else {
+					assert.Error(t, err)
+				}
+			}
+
+			primary.AssertExpectations(t)
+			secondary.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	dto "linkTraccer/internal/domain/dto"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Sender is an autogenerated mock type for the Sender type
+type Sender struct {
+	mock.Mock
+}
+
+type Sender_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *Sender) EXPECT() *Sender_Expecter {
+	return &Sender_Expecter{mock: &_m.Mock}
+}
+
+// SendLinkUpdates provides a mock function with given fields: ctx, update
+func (_m *Sender) SendLinkUpdates(ctx context.Context, update *dto.LinkUpdate) error {
+	ret := _m.Called(ctx, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendLinkUpdates")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.LinkUpdate) error); ok {
+		r0 = rf(ctx, update)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Sender_SendLinkUpdates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendLinkUpdates'
+type Sender_SendLinkUpdates_Call struct {
+	*mock.Call
+}
+
+// SendLinkUpdates is a helper method to define mock.On call
+//   - ctx context.Context
+//   - update *dto.LinkUpdate
+func (_e *Sender_Expecter) SendLinkUpdates(ctx interface{}, update interface{}) *Sender_SendLinkUpdates_Call {
+	return &Sender_SendLinkUpdates_Call{Call: _e.mock.On("SendLinkUpdates", ctx, update)}
+}
+
+func (_c *Sender_SendLinkUpdates_Call) Run(run func(ctx context.Context, update *dto.LinkUpdate)) *Sender_SendLinkUpdates_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*dto.LinkUpdate))
+	})
+	return _c
+}
+
+func (_c *Sender_SendLinkUpdates_Call) Return(_a0 error) *Sender_SendLinkUpdates_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Sender_SendLinkUpdates_Call) RunAndReturn(run func(context.Context, *dto.LinkUpdate) error) *Sender_SendLinkUpdates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewSender creates a new instance of Sender. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSender(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Sender {
+	mock := &Sender{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
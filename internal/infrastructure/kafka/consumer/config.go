@@ -3,12 +3,21 @@ package consumer
 import (
 	"fmt"
 	"github.com/caarlos0/env/v11"
+	"time"
 )
 
 type Config struct {
-	Topic   string `env:"UPDATE_TOPIC"`
-	Brokers string `env:"BROKERS_ADDR"`
-	Batch   int    `env:"KAFKA_BATCH_SIZE"`
+	Topic            string        `env:"UPDATE_TOPIC"`
+	RetryTopic       string        `env:"UPDATE_RETRY_TOPIC" envDefault:"updates-retry"`
+	DLQTopic         string        `env:"UPDATE_DLQ_TOPIC" envDefault:"updates-dlq"`
+	Brokers          string        `env:"BROKERS_ADDR"`
+	Batch            int           `env:"KAFKA_BATCH_SIZE"`
+	GroupID          string        `env:"KAFKA_GROUP_ID" envDefault:"bot"`
+	Workers          int           `env:"KAFKA_WORKERS" envDefault:"1"`           // партиций обрабатывается параллельно
+	DeliveryAttempts int           `env:"KAFKA_DELIVERY_ATTEMPTS" envDefault:"5"` // после стольких попыток сообщение уходит в DLQ
+	RetryDelay       time.Duration `env:"KAFKA_RETRY_DELAY" envDefault:"1s"`      // пауза перед первым повтором, дальше удваивается
+	MaxRetryDelay    time.Duration `env:"KAFKA_MAX_RETRY_DELAY" envDefault:"5m"`
+	DrainTimeout     time.Duration `env:"KAFKA_DRAIN_TIMEOUT" envDefault:"10s"` // сколько при остановке дообрабатываем полученные сообщения
 }
 
 func NewConfig() (*Config, error) {
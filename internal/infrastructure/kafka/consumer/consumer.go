@@ -8,76 +8,354 @@ import (
 	"github.com/segmentio/kafka-go"
 	"linkTraccer/internal/application/botservice"
 	"linkTraccer/internal/domain/dto"
+	"linkTraccer/internal/domain/tgbot"
 	"log/slog"
 	"strings"
+	"sync"
+	"time"
 )
 
-func New(tg botservice.TgClient, cfg *Config, log *slog.Logger) *KafkaConsumer {
+// у топика повторов своя группа, чтобы его ребалансировка не останавливала основной топик.
+const retryGroupSuffix = "-retry"
+
+// ChatRemover отписывает чаты, в которые больше нельзя отправить сообщение.
+type ChatRemover interface {
+	RemoveChat(ctx context.Context, id tgbot.ID) error
+}
+
+// DeliveryLog помнит, в какие чаты событие уже доставлено.
+type DeliveryLog interface {
+	Reserve(ctx context.Context, id tgbot.ID, eventID string) (bool, error)
+	Release(ctx context.Context, id tgbot.ID, eventID string) error
+}
+
+// MessageReader - часть kafka.Reader, которой пользуется консьюмер.
+type MessageReader interface {
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// MessageWriter пишет сообщения в топик, указанный в самом сообщении.
+type MessageWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+func New(tg botservice.TgClient, remover ChatRemover, deliveries DeliveryLog, cfg *Config, log *slog.Logger) *KafkaConsumer {
+	brokers := strings.Split(cfg.Brokers, ",")
+
+	reader := kafka.NewReader(
+		kafka.ReaderConfig{
+			Brokers:  brokers,
+			Topic:    cfg.Topic,
+			GroupID:  cfg.GroupID,
+			MaxBytes: 10e6,
+		},
+	)
+
+	retryReader := kafka.NewReader(
+		kafka.ReaderConfig{
+			Brokers:  brokers,
+			Topic:    cfg.RetryTopic,
+			GroupID:  cfg.GroupID + retryGroupSuffix,
+			MaxBytes: 10e6,
+		},
+	)
+
+	writer := &kafka.Writer{
+		Addr:     kafka.TCP(brokers...),
+		Balancer: &kafka.Hash{},
+	}
+
+	return NewWithKafka(reader, retryReader, writer, tg, remover, deliveries, cfg, log)
+}
+
+func NewWithKafka(reader, retryReader MessageReader, writer MessageWriter, tg botservice.TgClient, remover ChatRemover,
+	deliveries DeliveryLog, cfg *Config, log *slog.Logger) *KafkaConsumer {
 	return &KafkaConsumer{
-		reader: kafka.NewReader(
-			kafka.ReaderConfig{
-				Brokers:  strings.Split(cfg.Brokers, ","),
-				Topic:    cfg.Topic,
-				MaxBytes: 10e6,
-			},
-		),
-		tg:  tg,
-		log: log,
+		reader:           reader,
+		retryReader:      retryReader,
+		writer:           writer,
+		tg:               tg,
+		remover:          remover,
+		deliveries:       deliveries,
+		log:              log,
+		retryTopic:       cfg.RetryTopic,
+		dlqTopic:         cfg.DLQTopic,
+		workers:          max(1, cfg.Workers),
+		deliveryAttempts: max(1, cfg.DeliveryAttempts),
+		retryDelay:       cfg.RetryDelay,
+		maxRetryDelay:    max(cfg.RetryDelay, cfg.MaxRetryDelay),
+		drainTimeout:     cfg.DrainTimeout,
 	}
 }
 
+// KafkaConsumer коммитит оффсет только после доставки обновления или его переноса в топик повторов или DLQ.
 type KafkaConsumer struct {
-	tg     botservice.TgClient
-	log    *slog.Logger
-	reader *kafka.Reader
+	tg               botservice.TgClient
+	remover          ChatRemover
+	deliveries       DeliveryLog
+	log              *slog.Logger
+	reader           MessageReader
+	retryReader      MessageReader
+	writer           MessageWriter
+	retryTopic       string
+	dlqTopic         string
+	workers          int
+	deliveryAttempts int
+	retryDelay       time.Duration
+	maxRetryDelay    time.Duration
+	drainTimeout     time.Duration
 }
 
+// ReadUserUpdates обрабатывает партиции параллельно, а сообщения одной партиции - по порядку.
+// После отмены ctx полученные сообщения дообрабатываются не дольше drainTimeout.
 func (c *KafkaConsumer) ReadUserUpdates(ctx context.Context) error {
-	for {
-		select {
-		case <-ctx.Done():
-			c.log.Info("consumer завершил свою работу")
-			return nil
+	workCtx, cancelWork := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelWork()
 
-		default:
-			msg, err := c.reader.ReadMessage(context.Background())
+	workers := &sync.WaitGroup{}
+	fetchers := &sync.WaitGroup{}
 
-			if err != nil && errors.Is(err, ctx.Err()) {
-				c.log.Info("consumer завершил свою работу")
-				return nil
-			}
+	for _, reader := range []MessageReader{c.reader, c.retryReader} {
+		queues := c.startWorkers(workCtx, reader, workers)
+
+		fetchers.Add(1)
 
-			if err != nil {
-				c.log.Error("ошибка при получении обновлений пользователей из топика", "err", err)
-				continue
+		go func() {
+			defer fetchers.Done()
+
+			c.fetchMessages(ctx, reader, queues)
+
+			for _, queue := range queues {
+				close(queue)
 			}
+		}()
+	}
+
+	fetchers.Wait()
 
-			updates := &dto.LinkUpdate{}
+	drained := make(chan struct{})
+
+	go func() {
+		workers.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+	case <-time.After(c.drainTimeout):
+		c.log.Warn("не успели дообработать сообщения до остановки, они будут прочитаны заново")
+		cancelWork()
+		<-drained
+	}
 
-			if err := json.Unmarshal(msg.Value, updates); err != nil {
-				c.log.Error("ошибка при анмаршалинге сообщений из топика", "err", err)
-				continue
+	c.log.Info("consumer завершил свою работу")
+
+	return nil
+}
+
+func (c *KafkaConsumer) startWorkers(ctx context.Context, reader MessageReader, wg *sync.WaitGroup) []chan kafka.Message {
+	queues := make([]chan kafka.Message, c.workers)
+
+	for i := range queues {
+		queues[i] = make(chan kafka.Message)
+
+		wg.Add(1)
+
+		go func(queue <-chan kafka.Message) {
+			defer wg.Done()
+
+			for msg := range queue {
+				if !c.handleMessage(ctx, reader, msg) {
+					// коммит следующего сообщения партиции сдвинул бы оффсет за необработанное сообщение
+					for range queue {
+					}
+
+					return
+				}
 			}
+		}(queues[i])
+	}
 
-			if err := c.processUpdate(updates); err != nil {
-				c.log.Error("ошибка при обработке обновлений", "err", err.Error())
+	return queues
+}
+
+// fetchMessages раздает сообщения обработчикам партиций, повторяя чтение после ошибки с растущей паузой.
+func (c *KafkaConsumer) fetchMessages(ctx context.Context, reader MessageReader, queues []chan kafka.Message) {
+	for failures := 0; ; {
+		msg, err := reader.FetchMessage(ctx)
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err != nil {
+			failures++
+
+			c.log.Error("ошибка при получении обновлений пользователей из топика", "err", err.Error(),
+				"failures", failures)
+
+			if !sleep(ctx, backoff(failures, c.retryDelay, c.maxRetryDelay)) {
+				return
 			}
+
+			continue
+		}
+
+		failures = 0
+
+		select {
+		case queues[msg.Partition%len(queues)] <- msg:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// handleMessage возвращает false, если обработку прервала остановка и сообщение не закоммичено.
+func (c *KafkaConsumer) handleMessage(ctx context.Context, reader MessageReader, msg kafka.Message) bool {
+	if !sleep(ctx, time.Until(retryAtOf(msg))) {
+		return false
+	}
+
+	updates := &dto.LinkUpdate{}
+
+	if err := json.Unmarshal(msg.Value, updates); err != nil {
+		c.log.Error("ошибка при анмаршалинге сообщений из топика", "err", err)
+
+		reason := fmt.Sprintf("ошибка при анмаршалинге сообщения: %s", err)
+
+		if err = c.writeFailed(ctx, failedMessage(c.dlqTopic, msg, msg.Value, attemptOf(msg), reason,
+			time.Time{})); err != nil {
+			c.log.Error("ошибка при отправке сообщения в DLQ", "err", err.Error())
+
+			return false
+		}
+	} else if err = c.retryFailed(ctx, msg, updates, c.processUpdate(ctx, updates)); err != nil {
+		c.log.Error("ошибка при отправке сообщения на повтор", "err", err.Error())
+
+		return false
+	}
+
+	// оффсет, не закоммиченный из-за ошибки, закоммитится вместе со следующим сообщением партиции
+	if err := reader.CommitMessages(ctx, msg); err != nil {
+		c.log.Error("ошибка при коммите сообщения", "err", err.Error(), "partition", msg.Partition,
+			"offset", msg.Offset)
+	}
+
+	return true
+}
+
+// writeFailed повторяет запись, пока она не удастся или не отменят ctx.
+func (c *KafkaConsumer) writeFailed(ctx context.Context, msg kafka.Message) error {
+	for attempt := 1; ; attempt++ {
+		err := c.writer.WriteMessages(ctx, msg)
+		if err == nil {
+			return nil
+		}
+
+		c.log.Error("ошибка при записи сообщения в топик", "err", err.Error(), "topic", msg.Topic, "attempt", attempt)
+
+		if !sleep(ctx, backoff(attempt, c.retryDelay, c.maxRetryDelay)) {
+			return fmt.Errorf("запись в топик %s прервана: %w", msg.Topic, err)
 		}
 	}
 }
 
-func (c *KafkaConsumer) processUpdate(updates *dto.LinkUpdate) error {
-	msg := updates.Description + updates.URL
+// retryFailed перекладывает неотправленное обновление в топик повторов или, когда попытки кончились, в DLQ.
+func (c *KafkaConsumer) retryFailed(ctx context.Context, msg kafka.Message, updates *dto.LinkUpdate, failed map[tgbot.ID]error) error {
+	if len(failed) == 0 {
+		return nil
+	}
+
+	var sendErr error
+
+	recipients := make([]tgbot.ID, 0, len(failed))
 
 	for _, userID := range updates.TgChatIDs {
-		if err := c.tg.SendMessage(userID, msg); err != nil {
-			return fmt.Errorf("ошибка при отправке обновлений в телеграмм: %w", err)
+		if err, ok := failed[userID]; ok {
+			recipients = append(recipients, userID)
+			sendErr = errors.Join(sendErr, err)
 		}
 	}
 
-	return nil
+	retry := *updates
+	retry.TgChatIDs = recipients
+
+	value, err := json.Marshal(retry)
+	if err != nil {
+		return fmt.Errorf("ошибка при маршалинге обновления для повтора: %w", err)
+	}
+
+	attempt := attemptOf(msg) + 1
+	topic, retryAt := c.retryTopic, time.Now().Add(backoff(attempt, c.retryDelay, c.maxRetryDelay))
+
+	if attempt >= c.deliveryAttempts {
+		topic, retryAt = c.dlqTopic, time.Time{}
+	}
+
+	c.log.Warn("не удалось доставить обновление", "err", sendErr.Error(), "attempt", attempt, "topic", topic)
+
+	return c.writeFailed(ctx, failedMessage(topic, msg, value, attempt, sendErr.Error(), retryAt))
+}
+
+// processUpdate возвращает ошибки отправки по получателям, пропуская тех, кому событие уже доставлено.
+func (c *KafkaConsumer) processUpdate(ctx context.Context, updates *dto.LinkUpdate) map[tgbot.ID]error {
+	msg := botservice.RenderLinkUpdate(updates)
+	failed := make(map[tgbot.ID]error)
+
+	for _, userID := range updates.TgChatIDs {
+		if !c.reserve(ctx, userID, updates.EventID) {
+			continue
+		}
+
+		err := c.tg.SendMessage(ctx, userID, msg)
+
+		if tgbot.ChatUnavailable(err) {
+			if err = c.remover.RemoveChat(ctx, userID); err != nil {
+				c.log.Error("ошибка при отписке недоступного чата", "err", err.Error())
+			}
+
+			continue
+		}
+
+		if err != nil {
+			failed[userID] = fmt.Errorf("ошибка при отправке обновлений в телеграмм: %w", err)
+			c.release(ctx, userID, updates.EventID)
+		}
+	}
+
+	return failed
+}
+
+// reserve при ошибке хранилища разрешает отправку: лучше повторить сообщение, чем потерять его.
+func (c *KafkaConsumer) reserve(ctx context.Context, userID tgbot.ID, eventID string) bool {
+	if eventID == "" {
+		return true
+	}
+
+	reserved, err := c.deliveries.Reserve(ctx, userID, eventID)
+	if err != nil {
+		c.log.Error("ошибка при резервировании доставки события", "err", err.Error())
+
+		return true
+	}
+
+	return reserved
+}
+
+func (c *KafkaConsumer) release(ctx context.Context, userID tgbot.ID, eventID string) {
+	if eventID == "" {
+		return
+	}
+
+	if err := c.deliveries.Release(ctx, userID, eventID); err != nil {
+		c.log.Error("ошибка при снятии резерва доставки события", "err", err.Error())
+	}
 }
 
 func (c *KafkaConsumer) Close() error {
-	return c.reader.Close()
+	return errors.Join(c.reader.Close(), c.retryReader.Close(), c.writer.Close())
 }
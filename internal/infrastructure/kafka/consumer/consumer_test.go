@@ -2,11 +2,16 @@ package consumer_test
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/segmentio/kafka-go"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/testcontainers/testcontainers-go"
 	kafkatest "github.com/testcontainers/testcontainers-go/modules/kafka"
 	"linkTraccer/internal/domain/dto"
+	"linkTraccer/internal/domain/tgbot"
 	consumer2 "linkTraccer/internal/infrastructure/kafka/consumer"
 	"linkTraccer/internal/infrastructure/kafka/consumer/mocks"
 	producer2 "linkTraccer/internal/infrastructure/kafka/producer"
@@ -22,6 +27,11 @@ const (
 	bathSize = 1
 	firstID  = int64(1)
 	secondID = int64(2)
+	thirdID  = int64(3)
+	groupID  = "bot"
+
+	retryTopic = "updates-retry"
+	dlqTopic   = "updates-dlq"
 )
 
 var (
@@ -32,7 +42,7 @@ var (
 		ID:          2,
 		URL:         "http://localhost:8080",
 		Description: "Новое обновление",
-		TgChatIDs:   []int64{firstID, secondID},
+		TgChatIDs:   []int64{firstID, secondID, thirdID},
 	}
 
 	msg = usersUpdate.Description + usersUpdate.URL
@@ -56,18 +66,22 @@ func SetupContainer(ctx context.Context, t *testing.T) *kafkatest.KafkaContainer
 }
 
 func CreateTopic(ctx context.Context, container *kafkatest.KafkaContainer) error {
-	cmd := []string{
-		"/bin/bash",
-		"-c",
-		fmt.Sprintf(
-			"/usr/bin/kafka-topics --bootstrap-server localhost:9092 --create --topic %s --partitions 1 --replication-factor 1",
-			topic,
-		),
-	}
+	for _, name := range []string{topic, retryTopic, dlqTopic} {
+		cmd := []string{
+			"/bin/bash",
+			"-c",
+			fmt.Sprintf(
+				"/usr/bin/kafka-topics --bootstrap-server localhost:9092 --create --topic %s --partitions 1 --replication-factor 1",
+				name,
+			),
+		}
 
-	_, _, err := container.Exec(ctx, cmd)
+		if _, _, err := container.Exec(ctx, cmd); err != nil {
+			return err
+		}
+	}
 
-	return err
+	return nil
 }
 
 func TestKafkaConsumer_ReadUserUpdates(t *testing.T) {
@@ -80,11 +94,26 @@ func TestKafkaConsumer_ReadUserUpdates(t *testing.T) {
 
 	tgClient := mocks.NewTgClient(t)
 
-	tgClient.On("SendMessage", firstID, msg).Return(nil).Once()
-	tgClient.On("SendMessage", secondID, msg).Return(nil).Once()
+	tgClient.On("SendMessage", mock.Anything, firstID, msg).Return(nil).Once()
+	tgClient.On("SendMessage", mock.Anything, secondID, msg).Return(nil).Once()
+	// третий пользователь заблокировал бота, его чат отписывается
+	tgClient.On("SendMessage", mock.Anything, thirdID, msg).Return(tgbot.BotBlocked).Once()
+
+	remover := mocks.NewChatRemover(t)
+	remover.On("RemoveChat", mock.Anything, thirdID).Return(nil).Once()
 
 	producer := producer2.New(&producer2.Config{Brokers: brokers, Bath: bathSize, Topic: topic})
-	consumer := consumer2.New(tgClient, &consumer2.Config{Brokers: brokers, Topic: topic, Batch: bathSize}, logger)
+	consumer := consumer2.New(tgClient, remover, mocks.NewDeliveryLog(t), &consumer2.Config{
+		Brokers:          brokers,
+		Topic:            topic,
+		Batch:            bathSize,
+		RetryTopic:       retryTopic,
+		DLQTopic:         dlqTopic,
+		GroupID:          groupID,
+		Workers:          1,
+		DeliveryAttempts: 1,
+		DrainTimeout:     time.Second,
+	}, logger)
 	ctx, cancel := context.WithCancel(context.Background())
 
 	go consumer.ReadUserUpdates(ctx)
@@ -103,12 +132,376 @@ func TestKafkaConsumer_ReadUserUpdates(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		err := producer.SendLinkUpdates(test.update)
+		err := producer.SendLinkUpdates(context.Background(), test.update)
 
 		assert.NoError(t, err)
 	}
-	// ожидаем окончания выполнения горутин
-	time.Sleep(time.Second * 2)
+	// ожидаем вступления в группу и окончания выполнения горутин
+	time.Sleep(time.Second * 10)
 	cancel()
 	tgClient.AssertExpectations(t)
 }
+
+func blockingFetch(ctx context.Context) (kafka.Message, error) {
+	<-ctx.Done()
+
+	return kafka.Message{}, ctx.Err()
+}
+
+func failedTo(topic string, attempt string, chatIDs []int64, withRetryAt bool) func(msg kafka.Message) bool {
+	return func(msg kafka.Message) bool {
+		update := &dto.LinkUpdate{}
+
+		if err := json.Unmarshal(msg.Value, update); err != nil {
+			return false
+		}
+
+		headers := make(map[string]string)
+
+		for _, h := range msg.Headers {
+			headers[h.Key] = string(h.Value)
+		}
+
+		_, hasRetryAt := headers[consumer2.HeaderRetryAt]
+
+		return msg.Topic == topic && headers[consumer2.HeaderAttempt] == attempt && headers[consumer2.HeaderReason] != "" &&
+			hasRetryAt == withRetryAt && assert.ObjectsAreEqual(chatIDs, update.TgChatIDs)
+	}
+}
+
+func TestKafkaConsumer_RetryAndDLQ(t *testing.T) {
+	value, err := json.Marshal(usersUpdate)
+
+	assert.NoError(t, err)
+
+	retryValue, err := json.Marshal(&dto.LinkUpdate{
+		ID:          usersUpdate.ID,
+		URL:         usersUpdate.URL,
+		Description: usersUpdate.Description,
+		TgChatIDs:   []int64{firstID},
+	})
+
+	assert.NoError(t, err)
+
+	message := kafka.Message{Partition: 1, Offset: 10, Value: value}
+	retryMessage := kafka.Message{Partition: 0, Offset: 4, Value: retryValue, Headers: []kafka.Header{
+		{Key: consumer2.HeaderAttempt, Value: []byte("2")},
+		{Key: consumer2.HeaderReason, Value: []byte("telegram недоступен")},
+		{Key: consumer2.HeaderRetryAt, Value: []byte(time.Now().Add(-time.Second).Format(time.RFC3339Nano))},
+	}}
+	errSend := errors.New("telegram недоступен")
+	cfg := &consumer2.Config{
+		RetryTopic:       "updates-retry",
+		DLQTopic:         "updates-dlq",
+		Workers:          2,
+		DeliveryAttempts: 3,
+		RetryDelay:       time.Second,
+		MaxRetryDelay:    time.Minute,
+		DrainTimeout:     time.Second,
+	}
+
+	type TestCase struct {
+		name      string
+		message   kafka.Message
+		fromRetry bool
+		setup     func(tg *mocks.TgClient, remover *mocks.ChatRemover, writer *mocks.MessageWriter, cancel func())
+		commited  bool
+	}
+
+	tests := []TestCase{
+		{
+			name:    "обновление доставлено всем получателям, сообщение коммитится",
+			message: message,
+			setup: func(tg *mocks.TgClient, remover *mocks.ChatRemover, _ *mocks.MessageWriter, _ func()) {
+				tg.On("SendMessage", mock.Anything, firstID, msg).Return(nil).Once()
+				tg.On("SendMessage", mock.Anything, secondID, msg).Return(nil).Once()
+				tg.On("SendMessage", mock.Anything, thirdID, msg).Return(tgbot.BotBlocked).Once()
+				remover.On("RemoveChat", mock.Anything, thirdID).Return(nil).Once()
+			},
+			commited: true,
+		},
+		{
+			name:    "в топик повторов уходят только получатели с ошибкой",
+			message: message,
+			setup: func(tg *mocks.TgClient, _ *mocks.ChatRemover, writer *mocks.MessageWriter, _ func()) {
+				tg.On("SendMessage", mock.Anything, firstID, msg).Return(nil).Once()
+				tg.On("SendMessage", mock.Anything, secondID, msg).Return(errSend).Once()
+				tg.On("SendMessage", mock.Anything, thirdID, msg).Return(errSend).Once()
+				writer.On("WriteMessages", mock.Anything,
+					mock.MatchedBy(failedTo(cfg.RetryTopic, "1", []int64{secondID, thirdID}, true))).Return(nil).Once()
+			},
+			commited: true,
+		},
+		{
+			name:      "попытки доставки закончились, обновление уходит в DLQ",
+			message:   retryMessage,
+			fromRetry: true,
+			setup: func(tg *mocks.TgClient, _ *mocks.ChatRemover, writer *mocks.MessageWriter, _ func()) {
+				tg.On("SendMessage", mock.Anything, firstID, msg).Return(errSend).Once()
+				writer.On("WriteMessages", mock.Anything,
+					mock.MatchedBy(failedTo(cfg.DLQTopic, "3", []int64{firstID}, false))).Return(nil).Once()
+			},
+			commited: true,
+		},
+		{
+			name:    "некорректное сообщение уходит в DLQ без отправки",
+			message: kafka.Message{Partition: 0, Offset: 3, Value: []byte("{id:")},
+			setup: func(_ *mocks.TgClient, _ *mocks.ChatRemover, writer *mocks.MessageWriter, _ func()) {
+				writer.On("WriteMessages", mock.Anything, mock.MatchedBy(func(msg kafka.Message) bool {
+					return msg.Topic == cfg.DLQTopic && string(msg.Value) == "{id:"
+				})).Return(nil).Once()
+			},
+			commited: true,
+		},
+		{
+			name:    "переложить сообщение в топик повторов не удалось до остановки, сообщение не коммитится",
+			message: message,
+			setup: func(tg *mocks.TgClient, _ *mocks.ChatRemover, writer *mocks.MessageWriter, cancel func()) {
+				tg.On("SendMessage", mock.Anything, firstID, msg).Return(errSend).Once()
+				tg.On("SendMessage", mock.Anything, secondID, msg).Return(nil).Once()
+				tg.On("SendMessage", mock.Anything, thirdID, msg).Return(nil).Once()
+				writer.On("WriteMessages", mock.Anything, mock.Anything).Return(errors.New("kafka недоступна")).Run(
+					func(mock.Arguments) {
+						cancel()
+					})
+			},
+		},
+	}
+
+	for _, test := range tests {
+		tg := mocks.NewTgClient(t)
+		remover := mocks.NewChatRemover(t)
+		reader := mocks.NewMessageReader(t)
+		retryReader := mocks.NewMessageReader(t)
+		writer := mocks.NewMessageWriter(t)
+		ctx, cancel := context.WithCancel(context.Background())
+
+		test.setup(tg, remover, writer, cancel)
+
+		source, other := reader, retryReader
+		if test.fromRetry {
+			source, other = retryReader, reader
+		}
+
+		source.On("FetchMessage", mock.Anything).Return(test.message, nil).Once()
+		source.On("FetchMessage", mock.Anything).Return(blockingFetch).Maybe()
+		other.On("FetchMessage", mock.Anything).Return(blockingFetch)
+
+		if test.commited {
+			source.On("CommitMessages", mock.Anything, test.message).Return(nil).Run(func(mock.Arguments) {
+				cancel()
+			}).Once()
+		}
+
+		consumer := consumer2.NewWithKafka(reader, retryReader, writer, tg, remover, mocks.NewDeliveryLog(t), cfg, logger)
+
+		assert.NoError(t, consumer.ReadUserUpdates(ctx), test.name)
+		cancel()
+	}
+}
+
+func TestKafkaConsumer_NoCommitOnShutdown(t *testing.T) {
+	value, err := json.Marshal(usersUpdate)
+
+	assert.NoError(t, err)
+
+	// повтор запланирован через час, бот останавливается раньше
+	message := kafka.Message{Partition: 0, Offset: 5, Value: value, Headers: []kafka.Header{
+		{Key: consumer2.HeaderAttempt, Value: []byte("1")},
+		{Key: consumer2.HeaderRetryAt, Value: []byte(time.Now().Add(time.Hour).Format(time.RFC3339Nano))},
+	}}
+
+	tg := mocks.NewTgClient(t)
+	remover := mocks.NewChatRemover(t)
+	reader := mocks.NewMessageReader(t)
+	retryReader := mocks.NewMessageReader(t)
+	writer := mocks.NewMessageWriter(t)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	reader.On("FetchMessage", mock.Anything).Return(blockingFetch)
+	retryReader.On("FetchMessage", mock.Anything).Return(message, nil).Run(func(mock.Arguments) {
+		cancel()
+	}).Once()
+	retryReader.On("FetchMessage", mock.Anything).Return(blockingFetch).Maybe()
+
+	cfg := &consumer2.Config{Workers: 1, DeliveryAttempts: 5, RetryDelay: time.Hour, DrainTimeout: 50 * time.Millisecond}
+	consumer := consumer2.NewWithKafka(reader, retryReader, writer, tg, remover, mocks.NewDeliveryLog(t), cfg, logger)
+
+	assert.NoError(t, consumer.ReadUserUpdates(ctx))
+	retryReader.AssertNotCalled(t, "CommitMessages", mock.Anything, message)
+}
+
+func TestKafkaConsumer_SkipDeliveredEvents(t *testing.T) {
+	update := *usersUpdate
+	update.EventID = "event"
+
+	value, err := json.Marshal(update)
+
+	assert.NoError(t, err)
+
+	message := kafka.Message{Partition: 0, Offset: 7, Value: value}
+
+	tg := mocks.NewTgClient(t)
+	deliveries := mocks.NewDeliveryLog(t)
+	reader := mocks.NewMessageReader(t)
+	retryReader := mocks.NewMessageReader(t)
+	writer := mocks.NewMessageWriter(t)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// сообщение прочитано повторно: первому чату событие уже доставлено, второму доставляется сейчас,
+	// а третьему отправить не удалось, поэтому резерв снимается и в топик повторов уходит только он
+	deliveries.On("Reserve", mock.Anything, firstID, "event").Return(false, nil).Once()
+	deliveries.On("Reserve", mock.Anything, secondID, "event").Return(true, nil).Once()
+	deliveries.On("Reserve", mock.Anything, thirdID, "event").Return(true, nil).Once()
+	tg.On("SendMessage", mock.Anything, secondID, msg).Return(nil).Once()
+	tg.On("SendMessage", mock.Anything, thirdID, msg).Return(errors.New("telegram недоступен")).Once()
+	deliveries.On("Release", mock.Anything, thirdID, "event").Return(nil).Once()
+	writer.On("WriteMessages", mock.Anything,
+		mock.MatchedBy(failedTo("updates-retry", "1", []int64{thirdID}, true))).Return(nil).Once()
+
+	reader.On("FetchMessage", mock.Anything).Return(message, nil).Once()
+	reader.On("FetchMessage", mock.Anything).Return(blockingFetch).Maybe()
+	retryReader.On("FetchMessage", mock.Anything).Return(blockingFetch)
+	reader.On("CommitMessages", mock.Anything, message).Return(nil).Run(func(mock.Arguments) {
+		cancel()
+	}).Once()
+
+	cfg := &consumer2.Config{RetryTopic: "updates-retry", DLQTopic: "updates-dlq", Workers: 1, DeliveryAttempts: 3,
+		RetryDelay: time.Second, MaxRetryDelay: time.Minute, DrainTimeout: time.Second}
+	consumer := consumer2.NewWithKafka(reader, retryReader, writer, tg, mocks.NewChatRemover(t), deliveries, cfg, logger)
+
+	assert.NoError(t, consumer.ReadUserUpdates(ctx))
+	tg.AssertNotCalled(t, "SendMessage", firstID, msg)
+}
+
+func TestRedrive(t *testing.T) {
+	messages := []kafka.Message{
+		{Partition: 0, Offset: 1, Key: []byte("1"), Value: []byte("first"), Headers: []kafka.Header{
+			{Key: consumer2.HeaderAttempt, Value: []byte("5")},
+			{Key: consumer2.HeaderReason, Value: []byte("telegram недоступен")},
+		}},
+		{Partition: 0, Offset: 2, Value: []byte("{id:"), Headers: []kafka.Header{
+			{Key: consumer2.HeaderReason, Value: []byte("ошибка при анмаршалинге сообщения")},
+		}},
+	}
+
+	reader := mocks.NewMessageReader(t)
+	writer := mocks.NewMessageWriter(t)
+
+	for _, message := range messages {
+		reader.On("FetchMessage", mock.Anything).Return(message, nil).Once()
+		writer.On("WriteMessages", mock.Anything, kafka.Message{Topic: topic, Key: message.Key, Value: message.Value}).
+			Return(nil).Once()
+		reader.On("CommitMessages", mock.Anything, message).Return(nil).Once()
+	}
+
+	reader.On("FetchMessage", mock.Anything).Return(blockingFetch).Once()
+
+	redriven, err := consumer2.Redrive(context.Background(), reader, writer, topic, 50*time.Millisecond, logger)
+
+	assert.NoError(t, err)
+	assert.Equal(t, len(messages), redriven)
+}
+
+func TestKafkaConsumer_FetchErrors(t *testing.T) {
+	value, err := json.Marshal(&dto.LinkUpdate{URL: usersUpdate.URL, Description: usersUpdate.Description,
+		TgChatIDs: []int64{firstID}})
+
+	assert.NoError(t, err)
+
+	message := kafka.Message{Partition: 0, Offset: 1, Value: value}
+
+	tg := mocks.NewTgClient(t)
+	reader := mocks.NewMessageReader(t)
+	retryReader := mocks.NewMessageReader(t)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// брокер временно недоступен: после ошибок чтения консьюмер продолжает читать топик
+	reader.On("FetchMessage", mock.Anything).Return(kafka.Message{}, errors.New("брокер недоступен")).Twice()
+	reader.On("FetchMessage", mock.Anything).Return(message, nil).Once()
+	reader.On("FetchMessage", mock.Anything).Return(blockingFetch).Maybe()
+	retryReader.On("FetchMessage", mock.Anything).Return(blockingFetch)
+	tg.On("SendMessage", mock.Anything, firstID, msg).Return(nil).Once()
+	reader.On("CommitMessages", mock.Anything, message).Return(nil).Run(func(mock.Arguments) {
+		cancel()
+	}).Once()
+
+	cfg := &consumer2.Config{Workers: 1, DeliveryAttempts: 3, RetryDelay: time.Millisecond,
+		MaxRetryDelay: 10 * time.Millisecond, DrainTimeout: time.Second}
+	consumer := consumer2.NewWithKafka(reader, retryReader, mocks.NewMessageWriter(t), tg, mocks.NewChatRemover(t),
+		mocks.NewDeliveryLog(t), cfg, logger)
+
+	assert.NoError(t, consumer.ReadUserUpdates(ctx))
+}
+
+func TestKafkaConsumer_RetryWriteFailure(t *testing.T) {
+	first, err := json.Marshal(&dto.LinkUpdate{URL: usersUpdate.URL, Description: usersUpdate.Description,
+		TgChatIDs: []int64{firstID}})
+	assert.NoError(t, err)
+
+	second, err := json.Marshal(&dto.LinkUpdate{URL: usersUpdate.URL, Description: usersUpdate.Description,
+		TgChatIDs: []int64{secondID}})
+	assert.NoError(t, err)
+
+	firstMessage := kafka.Message{Partition: 0, Offset: 1, Value: first}
+	secondMessage := kafka.Message{Partition: 0, Offset: 2, Value: second}
+	errWrite := errors.New("kafka недоступна")
+	cfg := &consumer2.Config{RetryTopic: "updates-retry", DLQTopic: "updates-dlq", Workers: 1, DeliveryAttempts: 3,
+		RetryDelay: time.Millisecond, MaxRetryDelay: 10 * time.Millisecond, DrainTimeout: 50 * time.Millisecond}
+
+	t.Run("запись повторяется, и сообщения партиции коммитятся по порядку", func(t *testing.T) {
+		tg := mocks.NewTgClient(t)
+		reader := mocks.NewMessageReader(t)
+		retryReader := mocks.NewMessageReader(t)
+		writer := mocks.NewMessageWriter(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		commits := make([]int64, 0, 2)
+
+		reader.On("FetchMessage", mock.Anything).Return(firstMessage, nil).Once()
+		reader.On("FetchMessage", mock.Anything).Return(secondMessage, nil).Once()
+		reader.On("FetchMessage", mock.Anything).Return(blockingFetch).Maybe()
+		retryReader.On("FetchMessage", mock.Anything).Return(blockingFetch)
+		tg.On("SendMessage", mock.Anything, firstID, msg).Return(errors.New("telegram недоступен")).Once()
+		tg.On("SendMessage", mock.Anything, secondID, msg).Return(nil).Once()
+		writer.On("WriteMessages", mock.Anything, mock.Anything).Return(errWrite).Twice()
+		writer.On("WriteMessages", mock.Anything,
+			mock.MatchedBy(failedTo(cfg.RetryTopic, "1", []int64{firstID}, true))).Return(nil).Once()
+		reader.On("CommitMessages", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+			commits = append(commits, args.Get(1).(kafka.Message).Offset)
+
+			if len(commits) == 2 {
+				cancel()
+			}
+		}).Twice()
+
+		consumer := consumer2.NewWithKafka(reader, retryReader, writer, tg, mocks.NewChatRemover(t),
+			mocks.NewDeliveryLog(t), cfg, logger)
+
+		assert.NoError(t, consumer.ReadUserUpdates(ctx))
+		assert.Equal(t, []int64{firstMessage.Offset, secondMessage.Offset}, commits)
+	})
+
+	t.Run("запись не удалась до остановки, следующие сообщения партиции не коммитятся", func(t *testing.T) {
+		tg := mocks.NewTgClient(t)
+		reader := mocks.NewMessageReader(t)
+		retryReader := mocks.NewMessageReader(t)
+		writer := mocks.NewMessageWriter(t)
+		ctx, cancel := context.WithCancel(context.Background())
+
+		reader.On("FetchMessage", mock.Anything).Return(firstMessage, nil).Once()
+		reader.On("FetchMessage", mock.Anything).Return(secondMessage, nil).Maybe()
+		reader.On("FetchMessage", mock.Anything).Return(blockingFetch).Maybe()
+		retryReader.On("FetchMessage", mock.Anything).Return(blockingFetch)
+		tg.On("SendMessage", mock.Anything, firstID, msg).Return(errors.New("telegram недоступен")).Once()
+		writer.On("WriteMessages", mock.Anything, mock.Anything).Return(errWrite).Run(func(mock.Arguments) {
+			cancel()
+		})
+
+		consumer := consumer2.NewWithKafka(reader, retryReader, writer, tg, mocks.NewChatRemover(t),
+			mocks.NewDeliveryLog(t), cfg, logger)
+
+		assert.NoError(t, consumer.ReadUserUpdates(ctx))
+		reader.AssertNotCalled(t, "CommitMessages", mock.Anything, mock.Anything)
+		tg.AssertNotCalled(t, "SendMessage", mock.Anything, secondID, msg)
+	})
+}
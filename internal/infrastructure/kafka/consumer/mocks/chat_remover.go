@@ -0,0 +1,83 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ChatRemover is an autogenerated mock type for the ChatRemover type
+type ChatRemover struct {
+	mock.Mock
+}
+
+type ChatRemover_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *ChatRemover) EXPECT() *ChatRemover_Expecter {
+	return &ChatRemover_Expecter{mock: &_m.Mock}
+}
+
+// RemoveChat provides a mock function with given fields: ctx, id
+func (_m *ChatRemover) RemoveChat(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveChat")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ChatRemover_RemoveChat_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveChat'
+type ChatRemover_RemoveChat_Call struct {
+	*mock.Call
+}
+
+// RemoveChat is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+func (_e *ChatRemover_Expecter) RemoveChat(ctx interface{}, id interface{}) *ChatRemover_RemoveChat_Call {
+	return &ChatRemover_RemoveChat_Call{Call: _e.mock.On("RemoveChat", ctx, id)}
+}
+
+func (_c *ChatRemover_RemoveChat_Call) Run(run func(ctx context.Context, id int64)) *ChatRemover_RemoveChat_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *ChatRemover_RemoveChat_Call) Return(_a0 error) *ChatRemover_RemoveChat_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ChatRemover_RemoveChat_Call) RunAndReturn(run func(context.Context, int64) error) *ChatRemover_RemoveChat_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewChatRemover creates a new instance of ChatRemover. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewChatRemover(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ChatRemover {
+	mock := &ChatRemover{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,142 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// DeliveryLog is an autogenerated mock type for the DeliveryLog type
+type DeliveryLog struct {
+	mock.Mock
+}
+
+type DeliveryLog_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *DeliveryLog) EXPECT() *DeliveryLog_Expecter {
+	return &DeliveryLog_Expecter{mock: &_m.Mock}
+}
+
+// Release provides a mock function with given fields: ctx, id, eventID
+func (_m *DeliveryLog) Release(ctx context.Context, id int64, eventID string) error {
+	ret := _m.Called(ctx, id, eventID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Release")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, id, eventID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeliveryLog_Release_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Release'
+type DeliveryLog_Release_Call struct {
+	*mock.Call
+}
+
+// Release is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - eventID string
+func (_e *DeliveryLog_Expecter) Release(ctx interface{}, id interface{}, eventID interface{}) *DeliveryLog_Release_Call {
+	return &DeliveryLog_Release_Call{Call: _e.mock.On("Release", ctx, id, eventID)}
+}
+
+func (_c *DeliveryLog_Release_Call) Run(run func(ctx context.Context, id int64, eventID string)) *DeliveryLog_Release_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *DeliveryLog_Release_Call) Return(_a0 error) *DeliveryLog_Release_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DeliveryLog_Release_Call) RunAndReturn(run func(context.Context, int64, string) error) *DeliveryLog_Release_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reserve provides a mock function with given fields: ctx, id, eventID
+func (_m *DeliveryLog) Reserve(ctx context.Context, id int64, eventID string) (bool, error) {
+	ret := _m.Called(ctx, id, eventID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reserve")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) (bool, error)); ok {
+		return rf(ctx, id, eventID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) bool); ok {
+		r0 = rf(ctx, id, eventID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, id, eventID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeliveryLog_Reserve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reserve'
+type DeliveryLog_Reserve_Call struct {
+	*mock.Call
+}
+
+// Reserve is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int64
+//   - eventID string
+func (_e *DeliveryLog_Expecter) Reserve(ctx interface{}, id interface{}, eventID interface{}) *DeliveryLog_Reserve_Call {
+	return &DeliveryLog_Reserve_Call{Call: _e.mock.On("Reserve", ctx, id, eventID)}
+}
+
+func (_c *DeliveryLog_Reserve_Call) Run(run func(ctx context.Context, id int64, eventID string)) *DeliveryLog_Reserve_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *DeliveryLog_Reserve_Call) Return(_a0 bool, _a1 error) *DeliveryLog_Reserve_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DeliveryLog_Reserve_Call) RunAndReturn(run func(context.Context, int64, string) (bool, error)) *DeliveryLog_Reserve_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewDeliveryLog creates a new instance of DeliveryLog. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDeliveryLog(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DeliveryLog {
+	mock := &DeliveryLog{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -3,6 +3,7 @@
 package mocks
 
 import (
+	context "context"
 	tgbot "linkTraccer/internal/domain/tgbot"
 
 	mock "github.com/stretchr/testify/mock"
@@ -21,9 +22,106 @@ func (_m *TgClient) EXPECT() *TgClient_Expecter {
 	return &TgClient_Expecter{mock: &_m.Mock}
 }
 
-// HandleUsersUpdates provides a mock function with given fields: offset, limit
-func (_m *TgClient) HandleUsersUpdates(offset int, limit int) ([]tgbot.Update, error) {
-	ret := _m.Called(offset, limit)
+// AnswerCallbackQuery provides a mock function with given fields: ctx, callbackID, text
+func (_m *TgClient) AnswerCallbackQuery(ctx context.Context, callbackID string, text string) error {
+	ret := _m.Called(ctx, callbackID, text)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AnswerCallbackQuery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, callbackID, text)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TgClient_AnswerCallbackQuery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AnswerCallbackQuery'
+type TgClient_AnswerCallbackQuery_Call struct {
+	*mock.Call
+}
+
+// AnswerCallbackQuery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - callbackID string
+//   - text string
+func (_e *TgClient_Expecter) AnswerCallbackQuery(ctx interface{}, callbackID interface{}, text interface{}) *TgClient_AnswerCallbackQuery_Call {
+	return &TgClient_AnswerCallbackQuery_Call{Call: _e.mock.On("AnswerCallbackQuery", ctx, callbackID, text)}
+}
+
+func (_c *TgClient_AnswerCallbackQuery_Call) Run(run func(ctx context.Context, callbackID string, text string)) *TgClient_AnswerCallbackQuery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *TgClient_AnswerCallbackQuery_Call) Return(_a0 error) *TgClient_AnswerCallbackQuery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *TgClient_AnswerCallbackQuery_Call) RunAndReturn(run func(context.Context, string, string) error) *TgClient_AnswerCallbackQuery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EditMessageText provides a mock function with given fields: ctx, userID, messageID, text
+func (_m *TgClient) EditMessageText(ctx context.Context, userID int64, messageID int, text string) error {
+	ret := _m.Called(ctx, userID, messageID, text)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EditMessageText")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int, string) error); ok {
+		r0 = rf(ctx, userID, messageID, text)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TgClient_EditMessageText_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EditMessageText'
+type TgClient_EditMessageText_Call struct {
+	*mock.Call
+}
+
+// EditMessageText is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - messageID int
+//   - text string
+func (_e *TgClient_Expecter) EditMessageText(ctx interface{}, userID interface{}, messageID interface{}, text interface{}) *TgClient_EditMessageText_Call {
+	return &TgClient_EditMessageText_Call{Call: _e.mock.On("EditMessageText", ctx, userID, messageID, text)}
+}
+
+func (_c *TgClient_EditMessageText_Call) Run(run func(ctx context.Context, userID int64, messageID int, text string)) *TgClient_EditMessageText_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(int), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *TgClient_EditMessageText_Call) Return(_a0 error) *TgClient_EditMessageText_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *TgClient_EditMessageText_Call) RunAndReturn(run func(context.Context, int64, int, string) error) *TgClient_EditMessageText_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HandleUsersUpdates provides a mock function with given fields: ctx, offset, limit
+func (_m *TgClient) HandleUsersUpdates(ctx context.Context, offset int, limit int) ([]tgbot.Update, error) {
+	ret := _m.Called(ctx, offset, limit)
 
 	if len(ret) == 0 {
 		panic("no return value specified for HandleUsersUpdates")
@@ -31,19 +129,19 @@ func (_m *TgClient) HandleUsersUpdates(offset int, limit int) ([]tgbot.Update, e
 
 	var r0 []tgbot.Update
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int, int) ([]tgbot.Update, error)); ok {
-		return rf(offset, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]tgbot.Update, error)); ok {
+		return rf(ctx, offset, limit)
 	}
-	if rf, ok := ret.Get(0).(func(int, int) []tgbot.Update); ok {
-		r0 = rf(offset, limit)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []tgbot.Update); ok {
+		r0 = rf(ctx, offset, limit)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]tgbot.Update)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int, int) error); ok {
-		r1 = rf(offset, limit)
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, offset, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -57,15 +155,16 @@ type TgClient_HandleUsersUpdates_Call struct {
 }
 
 // HandleUsersUpdates is a helper method to define mock.On call
+//   - ctx context.Context
 //   - offset int
 //   - limit int
-func (_e *TgClient_Expecter) HandleUsersUpdates(offset interface{}, limit interface{}) *TgClient_HandleUsersUpdates_Call {
-	return &TgClient_HandleUsersUpdates_Call{Call: _e.mock.On("HandleUsersUpdates", offset, limit)}
+func (_e *TgClient_Expecter) HandleUsersUpdates(ctx interface{}, offset interface{}, limit interface{}) *TgClient_HandleUsersUpdates_Call {
+	return &TgClient_HandleUsersUpdates_Call{Call: _e.mock.On("HandleUsersUpdates", ctx, offset, limit)}
 }
 
-func (_c *TgClient_HandleUsersUpdates_Call) Run(run func(offset int, limit int)) *TgClient_HandleUsersUpdates_Call {
+func (_c *TgClient_HandleUsersUpdates_Call) Run(run func(ctx context.Context, offset int, limit int)) *TgClient_HandleUsersUpdates_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int), args[1].(int))
+		run(args[0].(context.Context), args[1].(int), args[2].(int))
 	})
 	return _c
 }
@@ -75,22 +174,71 @@ func (_c *TgClient_HandleUsersUpdates_Call) Return(_a0 []tgbot.Update, _a1 error
 	return _c
 }
 
-func (_c *TgClient_HandleUsersUpdates_Call) RunAndReturn(run func(int, int) ([]tgbot.Update, error)) *TgClient_HandleUsersUpdates_Call {
+func (_c *TgClient_HandleUsersUpdates_Call) RunAndReturn(run func(context.Context, int, int) ([]tgbot.Update, error)) *TgClient_HandleUsersUpdates_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendKeyboard provides a mock function with given fields: ctx, userID, text, keyboard
+func (_m *TgClient) SendKeyboard(ctx context.Context, userID int64, text string, keyboard *tgbot.InlineKeyboardMarkup) error {
+	ret := _m.Called(ctx, userID, text, keyboard)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendKeyboard")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, *tgbot.InlineKeyboardMarkup) error); ok {
+		r0 = rf(ctx, userID, text, keyboard)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TgClient_SendKeyboard_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendKeyboard'
+type TgClient_SendKeyboard_Call struct {
+	*mock.Call
+}
+
+// SendKeyboard is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - text string
+//   - keyboard *tgbot.InlineKeyboardMarkup
+func (_e *TgClient_Expecter) SendKeyboard(ctx interface{}, userID interface{}, text interface{}, keyboard interface{}) *TgClient_SendKeyboard_Call {
+	return &TgClient_SendKeyboard_Call{Call: _e.mock.On("SendKeyboard", ctx, userID, text, keyboard)}
+}
+
+func (_c *TgClient_SendKeyboard_Call) Run(run func(ctx context.Context, userID int64, text string, keyboard *tgbot.InlineKeyboardMarkup)) *TgClient_SendKeyboard_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string), args[3].(*tgbot.InlineKeyboardMarkup))
+	})
+	return _c
+}
+
+func (_c *TgClient_SendKeyboard_Call) Return(_a0 error) *TgClient_SendKeyboard_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *TgClient_SendKeyboard_Call) RunAndReturn(run func(context.Context, int64, string, *tgbot.InlineKeyboardMarkup) error) *TgClient_SendKeyboard_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SendMessage provides a mock function with given fields: userID, text
-func (_m *TgClient) SendMessage(userID int64, text string) error {
-	ret := _m.Called(userID, text)
+// SendMessage provides a mock function with given fields: ctx, userID, text
+func (_m *TgClient) SendMessage(ctx context.Context, userID int64, text string) error {
+	ret := _m.Called(ctx, userID, text)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SendMessage")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64, string) error); ok {
-		r0 = rf(userID, text)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, userID, text)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -104,15 +252,16 @@ type TgClient_SendMessage_Call struct {
 }
 
 // SendMessage is a helper method to define mock.On call
+//   - ctx context.Context
 //   - userID int64
 //   - text string
-func (_e *TgClient_Expecter) SendMessage(userID interface{}, text interface{}) *TgClient_SendMessage_Call {
-	return &TgClient_SendMessage_Call{Call: _e.mock.On("SendMessage", userID, text)}
+func (_e *TgClient_Expecter) SendMessage(ctx interface{}, userID interface{}, text interface{}) *TgClient_SendMessage_Call {
+	return &TgClient_SendMessage_Call{Call: _e.mock.On("SendMessage", ctx, userID, text)}
 }
 
-func (_c *TgClient_SendMessage_Call) Run(run func(userID int64, text string)) *TgClient_SendMessage_Call {
+func (_c *TgClient_SendMessage_Call) Run(run func(ctx context.Context, userID int64, text string)) *TgClient_SendMessage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int64), args[1].(string))
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
 	})
 	return _c
 }
@@ -122,22 +271,22 @@ func (_c *TgClient_SendMessage_Call) Return(_a0 error) *TgClient_SendMessage_Cal
 	return _c
 }
 
-func (_c *TgClient_SendMessage_Call) RunAndReturn(run func(int64, string) error) *TgClient_SendMessage_Call {
+func (_c *TgClient_SendMessage_Call) RunAndReturn(run func(context.Context, int64, string) error) *TgClient_SendMessage_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SetBotCommands provides a mock function with given fields: data
-func (_m *TgClient) SetBotCommands(data *tgbot.SetCommands) error {
-	ret := _m.Called(data)
+// SetBotCommands provides a mock function with given fields: ctx, data
+func (_m *TgClient) SetBotCommands(ctx context.Context, data *tgbot.SetCommands) error {
+	ret := _m.Called(ctx, data)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SetBotCommands")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*tgbot.SetCommands) error); ok {
-		r0 = rf(data)
+	if rf, ok := ret.Get(0).(func(context.Context, *tgbot.SetCommands) error); ok {
+		r0 = rf(ctx, data)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -151,14 +300,15 @@ type TgClient_SetBotCommands_Call struct {
 }
 
 // SetBotCommands is a helper method to define mock.On call
+//   - ctx context.Context
 //   - data *tgbot.SetCommands
-func (_e *TgClient_Expecter) SetBotCommands(data interface{}) *TgClient_SetBotCommands_Call {
-	return &TgClient_SetBotCommands_Call{Call: _e.mock.On("SetBotCommands", data)}
+func (_e *TgClient_Expecter) SetBotCommands(ctx interface{}, data interface{}) *TgClient_SetBotCommands_Call {
+	return &TgClient_SetBotCommands_Call{Call: _e.mock.On("SetBotCommands", ctx, data)}
 }
 
-func (_c *TgClient_SetBotCommands_Call) Run(run func(data *tgbot.SetCommands)) *TgClient_SetBotCommands_Call {
+func (_c *TgClient_SetBotCommands_Call) Run(run func(ctx context.Context, data *tgbot.SetCommands)) *TgClient_SetBotCommands_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*tgbot.SetCommands))
+		run(args[0].(context.Context), args[1].(*tgbot.SetCommands))
 	})
 	return _c
 }
@@ -168,7 +318,7 @@ func (_c *TgClient_SetBotCommands_Call) Return(_a0 error) *TgClient_SetBotComman
 	return _c
 }
 
-func (_c *TgClient_SetBotCommands_Call) RunAndReturn(run func(*tgbot.SetCommands) error) *TgClient_SetBotCommands_Call {
+func (_c *TgClient_SetBotCommands_Call) RunAndReturn(run func(context.Context, *tgbot.SetCommands) error) *TgClient_SetBotCommands_Call {
 	_c.Call.Return(run)
 	return _c
 }
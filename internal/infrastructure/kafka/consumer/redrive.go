@@ -0,0 +1,44 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/segmentio/kafka-go"
+	"log/slog"
+	"time"
+)
+
+// Redrive возвращает сообщения из DLQ в topic без заголовков попыток и заканчивает работу после idle без сообщений.
+func Redrive(ctx context.Context, reader MessageReader, writer MessageWriter, topic string, idle time.Duration,
+	log *slog.Logger) (int, error) {
+	redriven := 0
+
+	for {
+		fetchCtx, cancel := context.WithTimeout(ctx, idle)
+		msg, err := reader.FetchMessage(fetchCtx)
+
+		cancel()
+
+		if err != nil {
+			if ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded) {
+				return redriven, nil
+			}
+
+			return redriven, fmt.Errorf("ошибка при чтении сообщения из DLQ: %w", err)
+		}
+
+		log.Info("возвращаем сообщение из DLQ", "reason", header(msg, HeaderReason),
+			"attempt", header(msg, HeaderAttempt), "partition", msg.Partition, "offset", msg.Offset)
+
+		if err = writer.WriteMessages(ctx, kafka.Message{Topic: topic, Key: msg.Key, Value: msg.Value}); err != nil {
+			return redriven, fmt.Errorf("ошибка при отправке сообщения в топик %s: %w", topic, err)
+		}
+
+		if err = reader.CommitMessages(ctx, msg); err != nil {
+			return redriven, fmt.Errorf("ошибка при коммите сообщения из DLQ: %w", err)
+		}
+
+		redriven++
+	}
+}
@@ -0,0 +1,93 @@
+package consumer
+
+import (
+	"context"
+	"github.com/segmentio/kafka-go"
+	"strconv"
+	"time"
+)
+
+// Заголовки сообщений в топиках повторов и DLQ.
+
+const (
+	HeaderAttempt = "x-attempt"
+	HeaderReason  = "x-failure-reason"
+	HeaderRetryAt = "x-retry-at"
+)
+
+// attemptOf возвращает число уже сделанных попыток доставки сообщения.
+func attemptOf(msg kafka.Message) int {
+	attempt, err := strconv.Atoi(header(msg, HeaderAttempt))
+	if err != nil {
+		return 0
+	}
+
+	return attempt
+}
+
+// retryAtOf возвращает время, раньше которого повтор обрабатывать не нужно.
+func retryAtOf(msg kafka.Message) time.Time {
+	retryAt, err := time.Parse(time.RFC3339Nano, header(msg, HeaderRetryAt))
+	if err != nil {
+		return time.Time{}
+	}
+
+	return retryAt
+}
+
+func header(msg kafka.Message, key string) string {
+	for _, h := range msg.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+
+	return ""
+}
+
+// failedMessage - сообщение для топика повторов или DLQ с причиной ошибки и числом попыток в заголовках.
+func failedMessage(topic string, src kafka.Message, value []byte, attempt int, reason string, retryAt time.Time) kafka.Message {
+	headers := []kafka.Header{
+		{Key: HeaderAttempt, Value: []byte(strconv.Itoa(attempt))},
+		{Key: HeaderReason, Value: []byte(reason)},
+	}
+
+	if !retryAt.IsZero() {
+		headers = append(headers, kafka.Header{Key: HeaderRetryAt, Value: []byte(retryAt.Format(time.RFC3339Nano))})
+	}
+
+	return kafka.Message{
+		Topic:   topic,
+		Key:     src.Key,
+		Value:   value,
+		Headers: headers,
+	}
+}
+
+// sleep возвращает false, если ожидание прервала отмена ctx.
+func sleep(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return true
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// backoff - пауза перед попыткой attempt, удваивается с каждой попыткой.
+func backoff(attempt int, base, maxDelay time.Duration) time.Duration {
+	delay := base
+
+	for i := 1; i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxDelay)
+}
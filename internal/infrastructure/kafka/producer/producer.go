@@ -22,13 +22,13 @@ func New(config *Config) *KafkaProducer {
 	}}
 }
 
-func (k *KafkaProducer) SendLinkUpdates(update *dto.LinkUpdate) error {
+func (k *KafkaProducer) SendLinkUpdates(ctx context.Context, update *dto.LinkUpdate) error {
 	updatesJSON, err := json.Marshal(update)
 	if err != nil {
 		return fmt.Errorf("ошибка при маршилинге апдейта в кафка продюсере: %w", err)
 	}
 
-	err = k.writer.WriteMessages(context.Background(), kafka.Message{Value: updatesJSON})
+	err = k.writer.WriteMessages(ctx, kafka.Message{Value: updatesJSON})
 	if err != nil {
 		return fmt.Errorf("ошибка при отправке обновлений в топик: %w", err)
 	}
@@ -36,6 +36,7 @@ func (k *KafkaProducer) SendLinkUpdates(update *dto.LinkUpdate) error {
 	return nil
 }
 
+// Close дожидается отправки сообщений, накопленных в батче, и закрывает соединения с брокерами.
 func (k *KafkaProducer) Close() error {
 	return k.writer.Close()
 }
@@ -88,7 +88,7 @@ func TestKafkaProducer_SendLinkUpdates(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		err := producer.SendLinkUpdates(test.update)
+		err := producer.SendLinkUpdates(context.Background(), test.update)
 
 		assert.NoError(t, err)
 
@@ -2,15 +2,26 @@ package scrapclient
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"linkTraccer/internal/domain/i18n"
 	"linkTraccer/internal/domain/scrapper"
 	"linkTraccer/internal/domain/tgbot"
 	"net/http"
 	"net/url"
 	"path"
 	"strconv"
+	"time"
+)
+
+// Настройки пользователя, которые меняются запросом на /tg-chat/{id}/{настройка}.
+const (
+	deliverySetting = "delivery"
+	quietSetting    = "quiet"
+	timezoneSetting = "timezone"
+	languageSetting = "language"
 )
 
 type HTTPClient interface {
@@ -18,24 +29,28 @@ type HTTPClient interface {
 }
 
 type ScrapperClient struct {
-	scheme         string
-	host           string
-	baseLinkPath   string
-	baseTgChatPath string
-	client         HTTPClient
+	scheme            string
+	host              string
+	baseLinkPath      string
+	baseTgChatPath    string
+	baseTagedLinkPath string
+	intervalPath      string
+	client            HTTPClient
 }
 
 func New(client HTTPClient, host, port string) *ScrapperClient {
 	return &ScrapperClient{
-		scheme:         "http",
-		host:           host + port,
-		baseLinkPath:   "/links",
-		baseTgChatPath: "/tg-chat",
-		client:         client,
+		scheme:            "http",
+		host:              host + port,
+		baseLinkPath:      "/links",
+		baseTgChatPath:    "/tg-chat",
+		baseTagedLinkPath: "/tagedlinks",
+		intervalPath:      "/links/interval",
+		client:            client,
 	}
 }
 
-func (s *ScrapperClient) RegUser(id tgbot.ID) error {
+func (s *ScrapperClient) RegUser(ctx context.Context, id tgbot.ID) error {
 	url := &url.URL{
 		Scheme: s.scheme,
 		Host:   s.host,
@@ -47,7 +62,7 @@ func (s *ScrapperClient) RegUser(id tgbot.ID) error {
 		URL:    url,
 	}
 
-	resp, err := s.client.Do(req)
+	resp, err := s.client.Do(req.WithContext(ctx))
 
 	if err != nil {
 		return fmt.Errorf("запрос регистрации пользователя закончился ошибкой: %w", err)
@@ -62,7 +77,35 @@ func (s *ScrapperClient) RegUser(id tgbot.ID) error {
 	return nil
 }
 
-func (s *ScrapperClient) UserLinks(id tgbot.ID) ([]tgbot.Link, error) {
+// DeleteUser удаляет пользователя в скраппере. Если пользователь уже удален, ошибки нет.
+func (s *ScrapperClient) DeleteUser(ctx context.Context, id tgbot.ID) error {
+	url := &url.URL{
+		Scheme: s.scheme,
+		Host:   s.host,
+		Path:   path.Join(s.baseTgChatPath, strconv.FormatInt(id, 10)),
+	}
+
+	req := &http.Request{
+		Method: http.MethodDelete,
+		URL:    url,
+	}
+
+	resp, err := s.client.Do(req.WithContext(ctx))
+
+	if err != nil {
+		return fmt.Errorf("запрос удаления пользователя закончился ошибкой: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return tgbot.NewErrBadRequestStatus("не получилось удалить юзера", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (s *ScrapperClient) UserLinks(ctx context.Context, id tgbot.ID) ([]tgbot.SavedLink, error) {
 	url := &url.URL{
 		Scheme: s.scheme,
 		Host:   s.host,
@@ -77,7 +120,7 @@ func (s *ScrapperClient) UserLinks(id tgbot.ID) ([]tgbot.Link, error) {
 		},
 	}
 
-	resp, err := s.client.Do(req)
+	resp, err := s.client.Do(req.WithContext(ctx))
 
 	if err != nil {
 		return nil, fmt.Errorf("во время выполнения запроса на получение ссылок возникла ошибка: %w", err)
@@ -95,16 +138,66 @@ func (s *ScrapperClient) UserLinks(id tgbot.ID) ([]tgbot.Link, error) {
 		return nil, fmt.Errorf("не смогли десериализовать ссылки пользователя: %w ", err)
 	}
 
-	links := make([]tgbot.Link, 0, listLinks.Size)
+	links := make([]tgbot.SavedLink, 0, listLinks.Size)
 
 	for _, link := range listLinks.Links {
-		links = append(links, link.URL)
+		links = append(links, tgbot.SavedLink{ID: link.ID, URL: link.URL})
 	}
 
 	return links, nil
 }
 
-func (s *ScrapperClient) RemoveLink(id tgbot.ID, link tgbot.Link) error {
+// TagedLinks с непустым tag возвращает только группу с этим тегом.
+func (s *ScrapperClient) TagedLinks(ctx context.Context, id tgbot.ID, tag tgbot.Tag) ([]tgbot.TagedLinks, error) {
+	query := url.Values{}
+
+	if tag != "" {
+		query.Set("tag", tag)
+	}
+
+	url := &url.URL{
+		Scheme:   s.scheme,
+		Host:     s.host,
+		Path:     s.baseTagedLinkPath,
+		RawQuery: query.Encode(),
+	}
+
+	req := &http.Request{
+		Method: http.MethodGet,
+		URL:    url,
+		Header: map[string][]string{
+			"Tg-Chat-Id": {strconv.FormatInt(id, 10)},
+		},
+	}
+
+	resp, err := s.client.Do(req.WithContext(ctx))
+
+	if err != nil {
+		return nil, fmt.Errorf("во время выполнения запроса на получение ссылок по тегам возникла ошибка: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, tgbot.NewErrBadRequestStatus("не смогли получить ссылки пользователя по тегам", resp.StatusCode)
+	}
+
+	listTagedLinks := &scrapper.ListTagedLinks{}
+
+	if err = json.NewDecoder(resp.Body).Decode(listTagedLinks); err != nil {
+		return nil, fmt.Errorf("не смогли десериализовать ссылки пользователя по тегам: %w ", err)
+	}
+
+	tagedLinks := make([]tgbot.TagedLinks, 0, len(listTagedLinks.TagedLinks))
+
+	for _, tagedLink := range listTagedLinks.TagedLinks {
+		tagedLinks = append(tagedLinks, tgbot.TagedLinks{Tag: tagedLink.Tag, Links: tagedLink.Links})
+	}
+
+	return tagedLinks, nil
+}
+
+func (s *ScrapperClient) RemoveLink(ctx context.Context, id tgbot.ID, link tgbot.Link) error {
 	removeLink, err := json.Marshal(&scrapper.RemoveLinkRequest{Link: link})
 
 	if err != nil {
@@ -127,7 +220,7 @@ func (s *ScrapperClient) RemoveLink(id tgbot.ID, link tgbot.Link) error {
 		Body: io.NopCloser(bytes.NewBuffer(removeLink)),
 	}
 
-	resp, err := s.client.Do(req)
+	resp, err := s.client.Do(req.WithContext(ctx))
 
 	if err != nil {
 		return fmt.Errorf("запрос на удаление ссылки пользователя, закончился ошибкой :%w", err)
@@ -146,7 +239,7 @@ func (s *ScrapperClient) RemoveLink(id tgbot.ID, link tgbot.Link) error {
 	return nil
 }
 
-func (s *ScrapperClient) AddLink(id tgbot.ID, userCtx *tgbot.ContextData) error {
+func (s *ScrapperClient) AddLink(ctx context.Context, id tgbot.ID, userCtx *tgbot.ContextData) error {
 	addLink, err := json.Marshal(&scrapper.AddLinkRequest{
 		Link:    userCtx.URL,
 		Tags:    userCtx.Tags,
@@ -173,7 +266,7 @@ func (s *ScrapperClient) AddLink(id tgbot.ID, userCtx *tgbot.ContextData) error
 		Body: io.NopCloser(bytes.NewBuffer(addLink)),
 	}
 
-	resp, err := s.client.Do(req)
+	resp, err := s.client.Do(req.WithContext(ctx))
 
 	if err != nil {
 		return fmt.Errorf("запрос на добавление ссылки пользователя, закончился ошибкой :%w", err)
@@ -191,3 +284,122 @@ func (s *ScrapperClient) AddLink(id tgbot.ID, userCtx *tgbot.ContextData) error
 
 	return nil
 }
+
+// SetDigestTime включает для пользователя ежедневный дайджест, отправляемый в sendTime (HH:MM).
+func (s *ScrapperClient) SetDigestTime(ctx context.Context, id tgbot.ID, sendTime string) error {
+	return s.changeSettings(ctx, id, http.MethodPut, deliverySetting,
+		&scrapper.DeliverySettings{Mode: scrapper.DigestDelivery, Time: sendTime})
+}
+
+func (s *ScrapperClient) SetInstantDelivery(ctx context.Context, id tgbot.ID) error {
+	return s.changeSettings(ctx, id, http.MethodPut, deliverySetting,
+		&scrapper.DeliverySettings{Mode: scrapper.InstantDelivery})
+}
+
+// SetQuietHours включает тихие часы с start до end (HH:MM) в часовом поясе пользователя.
+func (s *ScrapperClient) SetQuietHours(ctx context.Context, id tgbot.ID, start, end string) error {
+	return s.changeSettings(ctx, id, http.MethodPut, quietSetting, &scrapper.QuietHoursSettings{Start: start, End: end})
+}
+
+func (s *ScrapperClient) DisableQuietHours(ctx context.Context, id tgbot.ID) error {
+	return s.changeSettings(ctx, id, http.MethodDelete, quietSetting, nil)
+}
+
+func (s *ScrapperClient) SetTimezone(ctx context.Context, id tgbot.ID, timezone string) error {
+	return s.changeSettings(ctx, id, http.MethodPut, timezoneSetting, &scrapper.TimezoneSettings{Timezone: timezone})
+}
+
+// SetLanguage сохраняет язык, на котором скраппер формирует уведомления для пользователя.
+func (s *ScrapperClient) SetLanguage(ctx context.Context, id tgbot.ID, lang i18n.Lang) error {
+	return s.changeSettings(ctx, id, http.MethodPut, languageSetting, &scrapper.LanguageSettings{Language: lang})
+}
+
+// SetCheckInterval задает интервал, с которым скраппер проверяет ссылку пользователя.
+func (s *ScrapperClient) SetCheckInterval(ctx context.Context, id tgbot.ID, link tgbot.Link, interval time.Duration) error {
+	return s.changeInterval(ctx, id, http.MethodPut, &scrapper.IntervalSettings{Link: link, Interval: interval.String()})
+}
+
+// ResetCheckInterval возвращает ссылке пользователя адаптивное расписание проверок.
+func (s *ScrapperClient) ResetCheckInterval(ctx context.Context, id tgbot.ID, link tgbot.Link) error {
+	return s.changeInterval(ctx, id, http.MethodDelete, &scrapper.IntervalSettings{Link: link})
+}
+
+func (s *ScrapperClient) changeInterval(ctx context.Context, id tgbot.ID, method string, settings *scrapper.IntervalSettings) error {
+	body, err := json.Marshal(settings)
+	if err != nil {
+		return fmt.Errorf("ошибка при маршалинге интервала проверки ссылки: %w", err)
+	}
+
+	req := &http.Request{
+		Method: method,
+		URL: &url.URL{
+			Scheme: s.scheme,
+			Host:   s.host,
+			Path:   s.intervalPath,
+		},
+		Header: map[string][]string{
+			"Tg-Chat-Id":   {strconv.FormatInt(id, 10)},
+			"Content-Type": {"application/json"},
+		},
+		Body: io.NopCloser(bytes.NewBuffer(body)),
+	}
+
+	resp, err := s.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("запрос на изменение интервала проверки ссылки закончился ошибкой: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return tgbot.LinkNotExist
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return tgbot.NewErrBadRequestStatus("не смогли изменить интервал проверки ссылки", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// changeSettings отправляет запрос на /tg-chat/{id}/{setting}. Если settings равен nil, запрос уходит без тела.
+func (s *ScrapperClient) changeSettings(ctx context.Context, id tgbot.ID, method, setting string, settings any) error {
+	body := []byte{}
+
+	if settings != nil {
+		var err error
+
+		if body, err = json.Marshal(settings); err != nil {
+			return fmt.Errorf("ошибка при маршалинге настроек %s: %w", setting, err)
+		}
+	}
+
+	url := &url.URL{
+		Scheme: s.scheme,
+		Host:   s.host,
+		Path:   path.Join(s.baseTgChatPath, strconv.FormatInt(id, 10), setting),
+	}
+
+	req := &http.Request{
+		Method: method,
+		URL:    url,
+		Header: map[string][]string{
+			"Content-Type": {"application/json"},
+		},
+		Body: io.NopCloser(bytes.NewBuffer(body)),
+	}
+
+	resp, err := s.client.Do(req.WithContext(ctx))
+
+	if err != nil {
+		return fmt.Errorf("запрос на изменение настроек %s закончился ошибкой: %w", setting, err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return tgbot.NewErrBadRequestStatus("не смогли изменить настройки "+setting, resp.StatusCode)
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package scrapclient_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
@@ -11,6 +12,7 @@ import (
 	"linkTraccer/internal/infrastructure/scrapclient/mocks"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -28,6 +30,7 @@ var (
 		Size: 1,
 		Links: []scrapper.LinkResponse{
 			{
+				ID:      1,
 				URL:     savedLink,
 				Tags:    []string{},
 				Filters: []string{},
@@ -88,7 +91,7 @@ func TestScrapperClient_RegUser(t *testing.T) {
 
 	for _, test := range tests {
 		client := scrapclient.New(test.client, host, port)
-		err := client.RegUser(test.id)
+		err := client.RegUser(context.Background(), test.id)
 
 		if test.correct {
 			assert.NoError(t, err)
@@ -98,6 +101,61 @@ func TestScrapperClient_RegUser(t *testing.T) {
 	}
 }
 
+func TestScrapperClient_DeleteUser(t *testing.T) {
+	badClient := mocks.NewHTTPClient(t)
+	badRequestClient := mocks.NewHTTPClient(t)
+	notFoundClient := mocks.NewHTTPClient(t)
+	goodClient := mocks.NewHTTPClient(t)
+
+	badClient.On("Do", mock.Anything).Return(nil, errTest)
+	badRequestClient.On("Do", mock.Anything).Return(badResponse, nil)
+	notFoundClient.On("Do", mock.Anything).Return(&http.Response{StatusCode: http.StatusNotFound,
+		Body: io.NopCloser(bytes.NewBuffer([]byte{}))}, nil)
+	goodClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+		return req.Method == http.MethodDelete && req.URL.Path == "/tg-chat/1"
+	})).Return(goodResponse, nil)
+
+	type testCase struct {
+		name    string
+		client  scrapclient.HTTPClient
+		correct bool
+	}
+
+	tests := []testCase{
+		{
+			name:    "ошибка во время выполнения запроса",
+			client:  badClient,
+			correct: false,
+		},
+		{
+			name:    "ошибка неправильного запроса",
+			client:  badRequestClient,
+			correct: false,
+		},
+		{
+			name:    "пользователь уже удален",
+			client:  notFoundClient,
+			correct: true,
+		},
+		{
+			name:    "правильный запрос",
+			client:  goodClient,
+			correct: true,
+		},
+	}
+
+	for _, test := range tests {
+		client := scrapclient.New(test.client, host, port)
+		err := client.DeleteUser(context.Background(), 1)
+
+		if test.correct {
+			assert.NoError(t, err, test.name)
+		} else {
+			assert.Error(t, err, test.name)
+		}
+	}
+}
+
 func TestScrapperClient_UserLinks(t *testing.T) {
 	badClient := mocks.NewHTTPClient(t)
 	badRequestClient := mocks.NewHTTPClient(t)
@@ -113,7 +171,7 @@ func TestScrapperClient_UserLinks(t *testing.T) {
 		name    string
 		client  scrapclient.HTTPClient
 		id      tgbot.ID
-		links   []tgbot.Link
+		links   []tgbot.SavedLink
 		correct bool
 	}
 
@@ -143,14 +201,14 @@ func TestScrapperClient_UserLinks(t *testing.T) {
 			name:    "тест без ошибок",
 			client:  goodClient,
 			id:      10,
-			links:   []tgbot.Link{savedLink},
+			links:   []tgbot.SavedLink{{ID: 1, URL: savedLink}},
 			correct: true,
 		},
 	}
 
 	for _, test := range tests {
 		client := scrapclient.New(test.client, host, port)
-		links, err := client.UserLinks(test.id)
+		links, err := client.UserLinks(context.Background(), test.id)
 
 		if test.correct {
 			assert.ElementsMatch(t, test.links, links)
@@ -161,6 +219,72 @@ func TestScrapperClient_UserLinks(t *testing.T) {
 	}
 }
 
+func TestScrapperClient_TagedLinks(t *testing.T) {
+	tagedLinksJSON, _ := json.Marshal(&scrapper.ListTagedLinks{
+		TagedLinks: []scrapper.TagedLink{{Tag: "work", Links: []scrapper.Link{savedLink}}},
+	})
+
+	badClient := mocks.NewHTTPClient(t)
+	badRequestClient := mocks.NewHTTPClient(t)
+	badBodyClient := mocks.NewHTTPClient(t)
+	goodClient := mocks.NewHTTPClient(t)
+
+	badClient.On("Do", mock.Anything).Return(nil, errTest)
+	badRequestClient.On("Do", mock.Anything).Return(&http.Response{StatusCode: http.StatusBadRequest,
+		Body: io.NopCloser(bytes.NewBuffer([]byte{}))}, nil)
+	badBodyClient.On("Do", mock.Anything).Return(&http.Response{StatusCode: http.StatusOK,
+		Body: io.NopCloser(bytes.NewBuffer([]byte(randomStr)))}, nil)
+	goodClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+		return req.URL.Path == "/tagedlinks" && req.URL.Query().Get("tag") == "work"
+	})).Return(&http.Response{StatusCode: http.StatusOK,
+		Body: io.NopCloser(bytes.NewBuffer(tagedLinksJSON))}, nil)
+
+	type testCase struct {
+		name       string
+		client     scrapclient.HTTPClient
+		tag        tgbot.Tag
+		tagedLinks []tgbot.TagedLinks
+		correct    bool
+	}
+
+	tests := []testCase{
+		{
+			name:    "ошибка во время выполнения запроса",
+			client:  badClient,
+			correct: false,
+		},
+		{
+			name:    "пришла ошибка от сервера",
+			client:  badRequestClient,
+			correct: false,
+		},
+		{
+			name:    "в качестве ответа в теле пришел не json",
+			client:  badBodyClient,
+			correct: false,
+		},
+		{
+			name:       "тест без ошибок",
+			client:     goodClient,
+			tag:        "work",
+			tagedLinks: []tgbot.TagedLinks{{Tag: "work", Links: []tgbot.Link{savedLink}}},
+			correct:    true,
+		},
+	}
+
+	for _, test := range tests {
+		client := scrapclient.New(test.client, host, port)
+		tagedLinks, err := client.TagedLinks(context.Background(), 10, test.tag)
+
+		if test.correct {
+			assert.NoError(t, err)
+			assert.Equal(t, test.tagedLinks, tagedLinks)
+		} else {
+			assert.Error(t, err)
+		}
+	}
+}
+
 func TestScrapperClient_RemoveLink(t *testing.T) {
 	badClient := mocks.NewHTTPClient(t)
 	badRequestClient := mocks.NewHTTPClient(t)
@@ -203,7 +327,7 @@ func TestScrapperClient_RemoveLink(t *testing.T) {
 
 	for _, test := range tests {
 		client := scrapclient.New(test.client, host, port)
-		err := client.RemoveLink(test.id, test.link)
+		err := client.RemoveLink(context.Background(), test.id, test.link)
 
 		if test.correct {
 			assert.NoError(t, err)
@@ -256,7 +380,60 @@ func TestScrapperClient_AddLink(t *testing.T) {
 
 	for _, test := range tests {
 		client := scrapclient.New(test.client, host, port)
-		err := client.AddLink(test.id, test.data)
+		err := client.AddLink(context.Background(), test.id, test.data)
+
+		if test.correct {
+			assert.NoError(t, err)
+		} else {
+			assert.Error(t, err)
+		}
+	}
+}
+
+func TestScrapperClient_SetDelivery(t *testing.T) {
+	badClient := mocks.NewHTTPClient(t)
+	badRequestClient := mocks.NewHTTPClient(t)
+	goodClient := mocks.NewHTTPClient(t)
+
+	badClient.On("Do", mock.Anything).Return(nil, errTest)
+	badRequestClient.On("Do", mock.Anything).Return(&http.Response{StatusCode: http.StatusBadRequest,
+		Body: io.NopCloser(bytes.NewBuffer([]byte{}))}, nil)
+	goodClient.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+		settings := &scrapper.DeliverySettings{}
+
+		return req.Method == http.MethodPut && req.URL.Path == "/tg-chat/10/delivery" &&
+			json.NewDecoder(req.Body).Decode(settings) == nil &&
+			settings.Mode == scrapper.DigestDelivery && settings.Time == "09:30"
+	})).Return(&http.Response{StatusCode: http.StatusOK,
+		Body: io.NopCloser(bytes.NewBuffer([]byte{}))}, nil)
+
+	type testCase struct {
+		name    string
+		client  scrapclient.HTTPClient
+		correct bool
+	}
+
+	tests := []testCase{
+		{
+			name:    "ошибка во время выполнения запроса",
+			client:  badClient,
+			correct: false,
+		},
+		{
+			name:    "пришла ошибка от сервера",
+			client:  badRequestClient,
+			correct: false,
+		},
+		{
+			name:    "тест без ошибок",
+			client:  goodClient,
+			correct: true,
+		},
+	}
+
+	for _, test := range tests {
+		client := scrapclient.New(test.client, host, port)
+		err := client.SetDigestTime(context.Background(), 10, "09:30")
 
 		if test.correct {
 			assert.NoError(t, err)
@@ -265,3 +442,50 @@ func TestScrapperClient_AddLink(t *testing.T) {
 		}
 	}
 }
+
+func TestScrapperClient_QuietHoursAndTimezone(t *testing.T) {
+	client := mocks.NewHTTPClient(t)
+
+	client.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+		settings := &scrapper.QuietHoursSettings{}
+
+		return req.Method == http.MethodPut && req.URL.Path == "/tg-chat/10/quiet" &&
+			json.NewDecoder(req.Body).Decode(settings) == nil &&
+			settings.Start == "23:00" && settings.End == "08:00"
+	})).Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBuffer([]byte{}))}, nil)
+	client.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+		return req.Method == http.MethodDelete && req.URL.Path == "/tg-chat/10/quiet"
+	})).Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBuffer([]byte{}))}, nil)
+	client.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+		settings := &scrapper.TimezoneSettings{}
+
+		return req.Method == http.MethodPut && req.URL.Path == "/tg-chat/10/timezone" &&
+			json.NewDecoder(req.Body).Decode(settings) == nil && settings.Timezone == "Europe/Moscow"
+	})).Return(&http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(bytes.NewBuffer([]byte{}))}, nil)
+
+	scrapClient := scrapclient.New(client, host, port)
+
+	assert.NoError(t, scrapClient.SetQuietHours(context.Background(), 10, "23:00", "08:00"))
+	assert.NoError(t, scrapClient.DisableQuietHours(context.Background(), 10))
+	assert.Error(t, scrapClient.SetTimezone(context.Background(), 10, "Europe/Moscow"), "пользователь не зарегистрирован в скраппере")
+}
+
+func TestScrapperClient_CheckInterval(t *testing.T) {
+	client := mocks.NewHTTPClient(t)
+
+	client.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+		settings := &scrapper.IntervalSettings{}
+
+		return req.Method == http.MethodPut && req.URL.Path == "/links/interval" &&
+			req.Header.Get("Tg-Chat-Id") == "10" && json.NewDecoder(req.Body).Decode(settings) == nil &&
+			settings.Link == savedLink && settings.Interval == "30m0s"
+	})).Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBuffer([]byte{}))}, nil)
+	client.On("Do", mock.MatchedBy(func(req *http.Request) bool {
+		return req.Method == http.MethodDelete && req.URL.Path == "/links/interval"
+	})).Return(&http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(bytes.NewBuffer([]byte{}))}, nil)
+
+	scrapClient := scrapclient.New(client, host, port)
+
+	assert.NoError(t, scrapClient.SetCheckInterval(context.Background(), 10, savedLink, 30*time.Minute))
+	assert.ErrorIs(t, scrapClient.ResetCheckInterval(context.Background(), 10, savedLink), tgbot.LinkNotExist)
+}
@@ -2,14 +2,37 @@ package scrapconfig
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/caarlos0/env/v11"
 )
 
 type Config struct {
-	UpdatesTransport string `env:"UPDATES_TRANSPORT"`
-	ScrapperPort     string `env:"SCRAPPER_PORT"`
-	GitHubAPIKey     string `env:"GIT_KEY"`
+	UpdatesTransport string        `env:"UPDATES_TRANSPORT"`
+	ScrapperPort     string        `env:"SCRAPPER_PORT"`
+	GitHubAPIKey     string        `env:"GIT_KEY"`
+	OutboxInterval   time.Duration `env:"OUTBOX_INTERVAL" envDefault:"10s"`         // как часто релей отправляет уведомления из outbox
+	OutboxBatch      uint          `env:"OUTBOX_BATCH" envDefault:"100"`            // сколько уведомлений захватывается за раз
+	OutboxRetention  time.Duration `env:"OUTBOX_RETENTION" envDefault:"24h"`        // сколько хранятся отправленные уведомления
+	OutboxLease      time.Duration `env:"OUTBOX_LEASE" envDefault:"1m"`             // на сколько захватываются уведомления
+	OutboxAttempts   int           `env:"OUTBOX_ATTEMPTS" envDefault:"5"`           // после стольких отказов бота уведомление откладывается
+	UpdatesFallback  bool          `env:"UPDATES_FALLBACK" envDefault:"false"`      // переключаться ли на второй транспорт
+	FallbackFailures int           `env:"FALLBACK_FAILURES" envDefault:"3"`         // ошибок подряд до отключения основного транспорта
+	FallbackProbe    time.Duration `env:"FALLBACK_PROBE_INTERVAL" envDefault:"30s"` // как часто проверяем отключенный транспорт
+	CheckMinInterval time.Duration `env:"CHECK_MIN_INTERVAL" envDefault:"1m"`       // границы адаптивного интервала проверки
+	CheckMaxInterval time.Duration `env:"CHECK_MAX_INTERVAL" envDefault:"6h"`
+	LinksCron        string        `env:"LINKS_CRON" envDefault:"* * * * *"` // цикл проверки ссылок идет на одной реплике
+	CheckQueueSize   int           `env:"CHECK_QUEUE_SIZE" envDefault:"64"`  // ссылок одного хоста в очереди на проверку
+	GitHubWorkers    int           `env:"GITHUB_CONCURRENCY" envDefault:"4"` // одновременных проверок ссылок GitHub
+	GitHubRPS        float64       `env:"GITHUB_RPS" envDefault:"1"`
+	StackWorkers     int           `env:"STACKOVERFLOW_CONCURRENCY" envDefault:"4"` // одновременных проверок ссылок StackOverflow
+	StackRPS         float64       `env:"STACKOVERFLOW_RPS" envDefault:"10"`
+	SiteMaxRetries   int           `env:"SITE_MAX_RETRIES" envDefault:"2"` // повторов после сетевой ошибки или 5xx
+	SiteRetryDelay   time.Duration `env:"SITE_RETRY_DELAY" envDefault:"500ms"`
+	SiteMaxDelay     time.Duration `env:"SITE_MAX_RETRY_DELAY" envDefault:"5s"`
+	BreakerFailures  int           `env:"SITE_BREAKER_FAILURES" envDefault:"5"` // отказов хоста подряд до паузы на BreakerCooldown
+	BreakerCooldown  time.Duration `env:"SITE_BREAKER_COOLDOWN" envDefault:"1m"`
+	ShutdownTimeout  time.Duration `env:"SHUTDOWN_TIMEOUT" envDefault:"10s"` // сколько ждать остановки при завершении
 }
 
 func New() (*Config, error) {
@@ -0,0 +1,45 @@
+package scrapconfig_test
+
+import (
+	"linkTraccer/internal/infrastructure/scrapconfig"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew_UpdatesFallback(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		set      bool
+		fallback bool
+	}{
+		{
+			name:     "по умолчанию запасной транспорт выключен",
+			set:      false,
+			fallback: false,
+		},
+		{
+			name:     "запасной транспорт включается явно",
+			value:    "true",
+			set:      true,
+			fallback: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("UPDATES_FALLBACK", test.value)
+
+			if !test.set {
+				os.Unsetenv("UPDATES_FALLBACK")
+			}
+
+			config, err := scrapconfig.New()
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.fallback, config.UpdatesFallback)
+		})
+	}
+}
@@ -48,7 +48,7 @@ func (c *ChatHandler) HandleChatChanges(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	userExist, err := c.userRepo.UserExist(userID)
+	userExist, err := c.userRepo.UserExist(r.Context(), userID)
 
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -62,24 +62,24 @@ func (c *ChatHandler) HandleChatChanges(w http.ResponseWriter, r *http.Request)
 
 	switch r.Method {
 	case http.MethodPost:
-		c.PostHandler(w, userID, userExist)
+		c.PostHandler(r.Context(), w, userID, userExist)
 
 	case http.MethodDelete:
-		c.DeleteHandler(w, userID, userExist)
+		c.DeleteHandler(r.Context(), w, userID, userExist)
 
 	default:
 		w.WriteHeader(http.StatusMethodNotAllowed)
 	}
 }
 
-func (c *ChatHandler) PostHandler(w http.ResponseWriter, userID int64, userExist bool) {
+func (c *ChatHandler) PostHandler(ctx context.Context, w http.ResponseWriter, userID int64, userExist bool) {
 	if userExist {
 		c.apiErrToResponse(w, dto.APIErrUserRegistered, http.StatusBadRequest)
 
 		return
 	}
 
-	if err := c.userRepo.RegUser(userID); err != nil {
+	if err := c.userRepo.RegUser(ctx, userID); err != nil {
 		w.Header().Set(contentType, jsonType)
 		w.WriteHeader(http.StatusInternalServerError)
 
@@ -91,19 +91,19 @@ func (c *ChatHandler) PostHandler(w http.ResponseWriter, userID int64, userExist
 	w.WriteHeader(http.StatusOK)
 }
 
-func (c *ChatHandler) DeleteHandler(w http.ResponseWriter, userID int64, userExist bool) {
+func (c *ChatHandler) DeleteHandler(ctx context.Context, w http.ResponseWriter, userID int64, userExist bool) {
 	if !userExist {
 		c.apiErrToResponse(w, dto.APIErrUserNotRegistered, http.StatusNotFound)
 
 		return
 	}
 
-	err := c.transactor.WithTransaction(context.Background(), func(ctx context.Context) error {
+	err := c.transactor.WithTransaction(ctx, func(ctx context.Context) error {
 		if err := c.userRepo.DeleteUser(ctx, userID); err != nil {
 			return err
 		}
 
-		return nil
+		return c.userRepo.DeleteUntrackedLinks(ctx)
 	})
 
 	if err != nil {
@@ -2,6 +2,7 @@ package scraphandlers_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
@@ -33,8 +34,8 @@ func TestChatHandler_PostHandler(t *testing.T) {
 	repoWithErr := mocks.NewUserRepo(t)
 	repoWithoutErr := mocks.NewUserRepo(t)
 
-	repoWithErr.On("RegUser", mock.Anything).Return(errRepo)
-	repoWithoutErr.On("RegUser", mock.Anything).Return(nil)
+	repoWithErr.On("RegUser", mock.Anything, mock.Anything).Return(errRepo)
+	repoWithoutErr.On("RegUser", mock.Anything, mock.Anything).Return(nil)
 
 	type TestCase struct {
 		name           string
@@ -77,7 +78,7 @@ func TestChatHandler_PostHandler(t *testing.T) {
 
 		chatHandler := scraphandlers.NewChatHandler(test.repo, transactor, logger)
 
-		chatHandler.PostHandler(w, test.userID, test.userExist)
+		chatHandler.PostHandler(context.Background(), w, test.userID, test.userExist)
 
 		assert.Equal(t, test.expectedStatus, w.Code)
 
@@ -147,7 +148,7 @@ func TestChatHandler_DeleteHandler(t *testing.T) {
 
 		chatHandler := scraphandlers.NewChatHandler(test.repo, test.transactor, logger)
 
-		chatHandler.DeleteHandler(w, test.userID, test.userExist)
+		chatHandler.DeleteHandler(context.Background(), w, test.userID, test.userExist)
 
 		assert.Equal(t, test.expectedStatus, w.Code)
 
@@ -173,10 +174,10 @@ func TestChatHandler_HandleChatChanges(t *testing.T) {
 	repoWithoutUsers := mocks.NewUserRepo(t)
 	repoWithUsers := mocks.NewUserRepo(t)
 
-	repoWithErr.On("UserExist", mock.Anything).Return(false, errRepo)
-	repoWithoutUsers.On("UserExist", mock.Anything).Return(false, nil)
-	repoWithoutUsers.On("RegUser", mock.Anything).Return(nil)
-	repoWithUsers.On("UserExist", mock.Anything).Return(true, nil)
+	repoWithErr.On("UserExist", mock.Anything, mock.Anything).Return(false, errRepo)
+	repoWithoutUsers.On("UserExist", mock.Anything, mock.Anything).Return(false, nil)
+	repoWithoutUsers.On("RegUser", mock.Anything, mock.Anything).Return(nil)
+	repoWithUsers.On("UserExist", mock.Anything, mock.Anything).Return(true, nil)
 
 	type TestCase struct {
 		name           string
@@ -0,0 +1,126 @@
+package scraphandlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"linkTraccer/internal/domain/dto"
+	"linkTraccer/internal/domain/scrapper"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+type DeliveryRepo interface {
+	UserExist(ctx context.Context, userID scrapper.User) (bool, error)
+	SetDigestTime(ctx context.Context, user scrapper.User, sendTime string, now time.Time) error
+	SetInstantDelivery(ctx context.Context, user scrapper.User) error
+}
+
+type DeliveryHandler struct {
+	repo DeliveryRepo
+	log  *slog.Logger
+}
+
+func NewDeliveryHandler(repo DeliveryRepo, log *slog.Logger) *DeliveryHandler {
+	return &DeliveryHandler{
+		repo: repo,
+		log:  log,
+	}
+}
+
+func (d *DeliveryHandler) HandleDeliveryChanges(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	if r.Method != http.MethodPut {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	userID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+
+	if err != nil {
+		d.apiErrToResponse(w, dto.APIErrIDNotNum, http.StatusBadRequest)
+
+		return
+	}
+
+	if userID < 0 {
+		d.apiErrToResponse(w, dto.APIErrNegativeID, http.StatusBadRequest)
+
+		return
+	}
+
+	settings := &scrapper.DeliverySettings{}
+
+	if err = json.NewDecoder(r.Body).Decode(settings); err != nil {
+		d.apiErrToResponse(w, dto.APIErrBadJSON, http.StatusBadRequest)
+
+		return
+	}
+
+	if !validDelivery(settings) {
+		d.apiErrToResponse(w, dto.APIErrBadDelivery, http.StatusBadRequest)
+
+		return
+	}
+
+	userExist, err := d.repo.UserExist(r.Context(), userID)
+
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+
+		d.log.Error("ошибка в БД при проверке пользователя", "err", err.Error())
+
+		return
+	}
+
+	if !userExist {
+		d.apiErrToResponse(w, dto.APIErrUserNotRegistered, http.StatusNotFound)
+
+		return
+	}
+
+	if settings.Mode == scrapper.DigestDelivery {
+		err = d.repo.SetDigestTime(r.Context(), userID, settings.Time, time.Now())
+	} else {
+		err = d.repo.SetInstantDelivery(r.Context(), userID)
+	}
+
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+
+		d.log.Error(fmt.Sprintf("ошибка в БД при изменении способа доставки пользователя %d", userID),
+			"err", err.Error())
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func validDelivery(settings *scrapper.DeliverySettings) bool {
+	switch settings.Mode {
+	case scrapper.InstantDelivery:
+		return true
+	case scrapper.DigestDelivery:
+		_, err := time.Parse(scrapper.DigestTimeLayout, settings.Time)
+
+		return err == nil
+	default:
+		return false
+	}
+}
+
+func (d *DeliveryHandler) apiErrToResponse(w http.ResponseWriter, errAPI *dto.APIErrResponse, statusCode int) {
+	w.Header().Set(contentType, jsonType)
+	w.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(w).Encode(errAPI); err != nil {
+		d.log.Error("ошибка при формировании JSON APIErrResponse", "err", err.Error())
+	}
+}
@@ -0,0 +1,146 @@
+package scraphandlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"linkTraccer/internal/domain/dto"
+	"linkTraccer/internal/infrastructure/scraphandlers"
+	"linkTraccer/internal/infrastructure/scraphandlers/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDeliveryHandler_HandleDeliveryChanges(t *testing.T) {
+	repoWithErr := mocks.NewDeliveryRepo(t)
+	repoWithoutUsers := mocks.NewDeliveryRepo(t)
+	repoWithUsers := mocks.NewDeliveryRepo(t)
+	repoWithSetErr := mocks.NewDeliveryRepo(t)
+
+	repoWithErr.On("UserExist", mock.Anything, mock.Anything).Return(false, errRepo)
+	repoWithoutUsers.On("UserExist", mock.Anything, mock.Anything).Return(false, nil)
+	repoWithUsers.On("UserExist", mock.Anything, mock.Anything).Return(true, nil)
+	repoWithUsers.On("SetDigestTime", mock.Anything, int64(1), "09:30", mock.Anything).Return(nil)
+	repoWithUsers.On("SetInstantDelivery", mock.Anything, int64(1)).Return(nil)
+	repoWithSetErr.On("UserExist", mock.Anything, mock.Anything).Return(true, nil)
+	repoWithSetErr.On("SetDigestTime", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errRepo)
+
+	type TestCase struct {
+		name           string
+		userID         string
+		body           string
+		repo           scraphandlers.DeliveryRepo
+		httpMethod     string
+		expectedStatus int
+		expectedBody   *dto.APIErrResponse
+	}
+
+	tests := []TestCase{
+		{
+			name:           "обрабатываем метод, который не поддерживается",
+			userID:         "1",
+			httpMethod:     http.MethodPost,
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:           "пришло не числовое id",
+			userID:         "Hello Word",
+			httpMethod:     http.MethodPut,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   dto.APIErrIDNotNum,
+		},
+		{
+			name:           "пришел некорректный JSON",
+			userID:         "1",
+			body:           "{mode:",
+			httpMethod:     http.MethodPut,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   dto.APIErrBadJSON,
+		},
+		{
+			name:           "неизвестный способ доставки",
+			userID:         "1",
+			body:           `{"mode":"weekly"}`,
+			httpMethod:     http.MethodPut,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   dto.APIErrBadDelivery,
+		},
+		{
+			name:           "некорректное время дайджеста",
+			userID:         "1",
+			body:           `{"mode":"digest","time":"25:00"}`,
+			httpMethod:     http.MethodPut,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   dto.APIErrBadDelivery,
+		},
+		{
+			name:           "ошибка в БД при проверке пользователя",
+			userID:         "1",
+			body:           `{"mode":"instant"}`,
+			repo:           repoWithErr,
+			httpMethod:     http.MethodPut,
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "пользователь не зарегистрирован",
+			userID:         "1",
+			body:           `{"mode":"instant"}`,
+			repo:           repoWithoutUsers,
+			httpMethod:     http.MethodPut,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   dto.APIErrUserNotRegistered,
+		},
+		{
+			name:           "ошибка в БД при сохранении времени дайджеста",
+			userID:         "1",
+			body:           `{"mode":"digest","time":"09:30"}`,
+			repo:           repoWithSetErr,
+			httpMethod:     http.MethodPut,
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "включаем дайджест",
+			userID:         "1",
+			body:           `{"mode":"digest","time":"09:30"}`,
+			repo:           repoWithUsers,
+			httpMethod:     http.MethodPut,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "возвращаем мгновенную доставку",
+			userID:         "1",
+			body:           `{"mode":"instant"}`,
+			repo:           repoWithUsers,
+			httpMethod:     http.MethodPut,
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(test.httpMethod, "", bytes.NewBufferString(test.body))
+
+		r = mux.SetURLVars(r, map[string]string{"id": test.userID})
+
+		deliveryHandler := scraphandlers.NewDeliveryHandler(test.repo, logger)
+
+		deliveryHandler.HandleDeliveryChanges(w, r)
+
+		assert.Equal(t, test.expectedStatus, w.Code, test.name)
+
+		if test.expectedBody != nil {
+			unmarshalBody := &dto.APIErrResponse{}
+
+			err := json.Unmarshal(w.Body.Bytes(), unmarshalBody)
+
+			assert.NoError(t, err, "ошибка при анмаршалинге тела ответа")
+			assert.Equal(t, test.expectedBody, unmarshalBody)
+		} else {
+			assert.Empty(t, w.Body.String())
+		}
+	}
+}
@@ -10,21 +10,26 @@ import (
 	"linkTraccer/internal/domain/scrapper"
 	"log/slog"
 	"net/http"
+	"slices"
 	"strconv"
 	"time"
 )
 
-var (
-	MoskowTime = time.FixedZone("UTC+3", 3*60*60)
-)
-
 type LinkResponse = scrapper.LinkResponse
 type ListLinksResponse = scrapper.ListLinksResponse
 type UserRepo = scrapservice.UserRepo
 type AddLinkRequest = scrapper.AddLinkRequest
-type SiteClient = scrapservice.SiteClient
 type RemoveLink = scrapper.RemoveLinkRequest
 type Transactor = scrapservice.Transactor
+type Link = scrapper.Link
+type Tag = scrapper.Tag
+type TagedLink = scrapper.TagedLink
+type ListTagedLinks = scrapper.ListTagedLinks
+
+// SiteClient проверяет запросом к API сайта, что ссылку можно отслеживать.
+type SiteClient interface {
+	CanTrack(ctx context.Context, link scrapper.Link) bool
+}
 
 type LinkHandler struct {
 	userRepo    UserRepo
@@ -53,92 +58,132 @@ func (l *LinkHandler) HandleLinksChanges(w http.ResponseWriter, r *http.Request)
 
 	defer r.Body.Close()
 
-	userID, err := strconv.ParseInt(r.Header.Get("Tg-Chat-Id"), 10, 64)
+	userID, ok := l.registeredUser(w, r)
 
-	if err != nil {
-		l.apiErrToResponse(w, dto.APIErrIDNotNum, http.StatusBadRequest)
+	if !ok {
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		l.GetMethodHandler(r.Context(), w, userID, requestTag(r))
+
+	case http.MethodPost:
+		l.PostMethodHandler(r.Context(), w, userID, reqData)
+
+	case http.MethodDelete:
+		l.DeleteMethodHandler(r.Context(), w, userID, reqData)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (l *LinkHandler) HandleTagedLinks(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
 
 		return
 	}
 
-	if userID < 0 {
-		l.apiErrToResponse(w, dto.APIErrNegativeID, http.StatusBadRequest)
+	userID, ok := l.registeredUser(w, r)
 
+	if !ok {
 		return
 	}
 
-	userExist, err := l.userRepo.UserExist(userID)
+	l.TagedLinksHandler(r.Context(), w, userID, requestTag(r))
+}
+
+func (l *LinkHandler) GetMethodHandler(ctx context.Context, w http.ResponseWriter, userID int64, tag Tag) {
+	listLinksResponse := &ListLinksResponse{}
+	userLinks, err := l.userRepo.AllUserLinks(ctx, userID)
 
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 
-		if r.URL != nil {
-			l.log.Error(
-				fmt.Sprintf("обработка запроса %s закончилась ошибкой, при проверке пользователя в БД", r.URL.Path),
-				"err", err.Error())
-		}
+		l.log.Error(
+			fmt.Sprintf("ошибка в БД при получении всех ссылок пользователя %d", userID),
+			"err", err.Error())
 
 		return
 	}
 
-	if !userExist {
-		l.apiErrToResponse(w, dto.APIErrUserNotRegistered, http.StatusBadRequest)
+	w.WriteHeader(http.StatusOK)
 
-		return
-	}
+	listLinksResponse.Links = make([]LinkResponse, 0, len(userLinks))
 
-	switch r.Method {
-	case http.MethodGet:
-		l.GetMethodHandler(w, userID)
+	for _, link := range userLinks {
+		if tag != "" && !slices.Contains(link.Tags, tag) {
+			continue
+		}
 
-	case http.MethodPost:
-		l.PostMethodHandler(w, userID, reqData)
+		linkResponse := LinkResponse{
+			ID:      link.ID,
+			URL:     link.URL,
+			Tags:    link.Tags,
+			Filters: link.Filters}
 
-	case http.MethodDelete:
-		l.DeleteMethodHandler(w, userID, reqData)
-	default:
-		w.WriteHeader(http.StatusMethodNotAllowed)
+		listLinksResponse.Links = append(listLinksResponse.Links, linkResponse)
+	}
+
+	listLinksResponse.Size = len(listLinksResponse.Links)
+
+	if err = json.NewEncoder(w).Encode(listLinksResponse); err != nil {
+		l.log.Error(fmt.Sprintf("ошибка при формировании JSON всех ссылок пользователя %d", userID),
+			"err", err)
 	}
 }
 
-func (l *LinkHandler) GetMethodHandler(w http.ResponseWriter, userID int64) {
-	listLinksResponse := &ListLinksResponse{}
-	userLinks, err := l.userRepo.AllUserLinks(userID)
+// TagedLinksHandler кладет ссылки без тегов в группу с пустым тегом.
+func (l *LinkHandler) TagedLinksHandler(ctx context.Context, w http.ResponseWriter, userID int64, tag Tag) {
+	userLinks, err := l.userRepo.AllUserLinks(ctx, userID)
 
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 
-		if err != nil {
-			l.log.Error(
-				fmt.Sprintf("ошибка в БД при получении всех ссылок пользователя %d", userID),
-				"err", err.Error())
-		}
+		l.log.Error(
+			fmt.Sprintf("ошибка в БД при получении ссылок по тегам пользователя %d", userID),
+			"err", err.Error())
 
 		return
 	}
 
 	w.WriteHeader(http.StatusOK)
 
-	listLinksResponse.Size = len(userLinks)
-	listLinksResponse.Links = make([]LinkResponse, 0, listLinksResponse.Size)
+	tagedLinks := &ListTagedLinks{TagedLinks: make([]TagedLink, 0)}
+	tagInd := make(map[Tag]int)
 
-	for ind, link := range userLinks {
-		linkResponse := LinkResponse{
-			ID:      ind,
-			URL:     link,
-			Tags:    []string{},
-			Filters: []string{}}
+	for _, link := range userLinks {
+		linkTags := link.Tags
 
-		listLinksResponse.Links = append(listLinksResponse.Links, linkResponse)
+		if len(linkTags) == 0 {
+			linkTags = []Tag{""}
+		}
+
+		for _, linkTag := range linkTags {
+			if tag != "" && linkTag != tag {
+				continue
+			}
+
+			ind, ok := tagInd[linkTag]
+
+			if !ok {
+				ind = len(tagedLinks.TagedLinks)
+				tagInd[linkTag] = ind
+				tagedLinks.TagedLinks = append(tagedLinks.TagedLinks, TagedLink{Tag: linkTag, Links: []Link{}})
+			}
+
+			tagedLinks.TagedLinks[ind].Links = append(tagedLinks.TagedLinks[ind].Links, link.URL)
+		}
 	}
 
-	if err = json.NewEncoder(w).Encode(listLinksResponse); err != nil {
-		l.log.Error(fmt.Sprintf("ошибка при формировании JSON всех ссылок пользователя %d", userID),
+	if err = json.NewEncoder(w).Encode(tagedLinks); err != nil {
+		l.log.Error(fmt.Sprintf("ошибка при формировании JSON ссылок по тегам пользователя %d", userID),
 			"err", err)
 	}
 }
 
-func (l *LinkHandler) PostMethodHandler(w http.ResponseWriter, userID int64, reqData []byte) {
+func (l *LinkHandler) PostMethodHandler(ctx context.Context, w http.ResponseWriter, userID int64, reqData []byte) {
 	addLinkRequest := &AddLinkRequest{}
 
 	if err := json.Unmarshal(reqData, addLinkRequest); err != nil {
@@ -149,7 +194,7 @@ func (l *LinkHandler) PostMethodHandler(w http.ResponseWriter, userID int64, req
 	canTrackLink := false
 
 	for _, client := range l.siteClients {
-		if client.CanTrack(addLinkRequest.Link) {
+		if client.CanTrack(ctx, addLinkRequest.Link) {
 			canTrackLink = true
 			break
 		}
@@ -160,7 +205,7 @@ func (l *LinkHandler) PostMethodHandler(w http.ResponseWriter, userID int64, req
 		return
 	}
 
-	userTrackLink, err := l.userRepo.UserTrackLink(userID, addLinkRequest.Link)
+	userTrackLink, err := l.userRepo.UserTrackLink(ctx, userID, addLinkRequest.Link)
 
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -176,14 +221,18 @@ func (l *LinkHandler) PostMethodHandler(w http.ResponseWriter, userID int64, req
 		return
 	}
 
-	err = l.transactor.WithTransaction(context.Background(), func(ctx context.Context) error {
-		err = l.userRepo.TrackLink(ctx, userID, addLinkRequest.Link, time.Now().In(MoskowTime).Truncate(time.Second))
+	err = l.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		err = l.userRepo.TrackLink(ctx, userID, addLinkRequest.Link, time.Now().UTC().Truncate(time.Second))
 
 		if err != nil {
 			return err
 		}
 
-		return nil
+		if err = l.userRepo.AddLinkTags(ctx, userID, addLinkRequest.Link, addLinkRequest.Tags); err != nil {
+			return err
+		}
+
+		return l.userRepo.AddLinkFilters(ctx, userID, addLinkRequest.Link, addLinkRequest.Filters)
 	})
 
 	if err != nil {
@@ -210,7 +259,7 @@ func (l *LinkHandler) PostMethodHandler(w http.ResponseWriter, userID int64, req
 	}
 }
 
-func (l *LinkHandler) DeleteMethodHandler(w http.ResponseWriter, userID int64, reqData []byte) {
+func (l *LinkHandler) DeleteMethodHandler(ctx context.Context, w http.ResponseWriter, userID int64, reqData []byte) {
 	removeLink := &RemoveLink{}
 
 	if err := json.Unmarshal(reqData, removeLink); err != nil {
@@ -219,7 +268,7 @@ func (l *LinkHandler) DeleteMethodHandler(w http.ResponseWriter, userID int64, r
 		return
 	}
 
-	userTrackLink, err := l.userRepo.UserTrackLink(userID, removeLink.Link)
+	userTrackLink, err := l.userRepo.UserTrackLink(ctx, userID, removeLink.Link)
 
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -237,7 +286,7 @@ func (l *LinkHandler) DeleteMethodHandler(w http.ResponseWriter, userID int64, r
 		return
 	}
 
-	err = l.userRepo.UntrackLink(userID, removeLink.Link)
+	err = l.userRepo.UntrackLink(ctx, userID, removeLink.Link)
 
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -259,6 +308,124 @@ func (l *LinkHandler) DeleteMethodHandler(w http.ResponseWriter, userID int64, r
 	}
 }
 
+// HandleIntervalChanges задает интервал (PUT) или возвращает адаптивное расписание (DELETE).
+func (l *LinkHandler) HandleIntervalChanges(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	if r.Method != http.MethodPut && r.Method != http.MethodDelete {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	settings := &scrapper.IntervalSettings{}
+
+	if err := json.NewDecoder(r.Body).Decode(settings); err != nil {
+		l.apiErrToResponse(w, dto.APIErrBadJSON, http.StatusBadRequest)
+
+		return
+	}
+
+	var interval time.Duration
+
+	if r.Method == http.MethodPut {
+		var ok bool
+
+		if interval, ok = validInterval(settings.Interval); !ok {
+			l.apiErrToResponse(w, dto.APIErrBadInterval, http.StatusBadRequest)
+
+			return
+		}
+	}
+
+	userID, ok := l.registeredUser(w, r)
+	if !ok {
+		return
+	}
+
+	userTrackLink, err := l.userRepo.UserTrackLink(r.Context(), userID, settings.Link)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+
+		l.log.Error(fmt.Sprintf("ошибка в БД при проверке отслеживает пользователь %d ссылку %s",
+			userID, settings.Link), "err", err.Error())
+
+		return
+	}
+
+	if !userTrackLink {
+		l.apiErrToResponse(w, dto.APIErrNotTrackLink, http.StatusNotFound)
+
+		return
+	}
+
+	if err = l.userRepo.SetCheckInterval(r.Context(), userID, settings.Link, interval); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+
+		l.log.Error(fmt.Sprintf("ошибка в БД при изменении интервала проверки ссылки %s", settings.Link),
+			"err", err.Error())
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (l *LinkHandler) registeredUser(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	userID, err := strconv.ParseInt(r.Header.Get("Tg-Chat-Id"), 10, 64)
+
+	if err != nil {
+		l.apiErrToResponse(w, dto.APIErrIDNotNum, http.StatusBadRequest)
+
+		return 0, false
+	}
+
+	if userID < 0 {
+		l.apiErrToResponse(w, dto.APIErrNegativeID, http.StatusBadRequest)
+
+		return 0, false
+	}
+
+	userExist, err := l.userRepo.UserExist(r.Context(), userID)
+
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+
+		if r.URL != nil {
+			l.log.Error(
+				fmt.Sprintf("обработка запроса %s закончилась ошибкой, при проверке пользователя в БД", r.URL.Path),
+				"err", err.Error())
+		}
+
+		return 0, false
+	}
+
+	if !userExist {
+		l.apiErrToResponse(w, dto.APIErrUserNotRegistered, http.StatusBadRequest)
+
+		return 0, false
+	}
+
+	return userID, true
+}
+
+func validInterval(value string) (time.Duration, bool) {
+	interval, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, false
+	}
+
+	return interval, interval >= scrapper.MinCheckInterval && interval <= scrapper.MaxCheckInterval
+}
+
+func requestTag(r *http.Request) Tag {
+	if r.URL == nil {
+		return ""
+	}
+
+	return r.URL.Query().Get("tag")
+}
+
 func (l *LinkHandler) apiErrToResponse(w http.ResponseWriter, errAPI *dto.APIErrResponse, statusCode int) {
 	w.Header().Set(contentType, jsonType)
 	w.WriteHeader(statusCode)
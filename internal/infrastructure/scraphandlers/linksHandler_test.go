@@ -2,6 +2,7 @@ package scraphandlers_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"linkTraccer/internal/application/scrapper/scrapservice"
@@ -12,6 +13,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -31,10 +33,10 @@ var (
 
 	userLinks = &listLinksResponse{
 		Links: []LinkResponse{{
-			ID:      0,
+			ID:      7,
 			URL:     expectedLink,
-			Tags:    []string{},
-			Filters: []string{},
+			Tags:    []string{"work"},
+			Filters: []string{"user:dummy"},
 		}},
 		Size: 1,
 	}
@@ -63,14 +65,20 @@ func TestLinkHandler_GetMethodHandler(t *testing.T) {
 
 	stackClient, gitClient := mocks.NewSiteClient(t), mocks.NewSiteClient(t)
 
-	repoWithErr.On("AllUserLinks", mock.Anything).Return(nil, errRepo)
-	repoWithLinks.On("AllUserLinks", mock.Anything).Return([]Link{expectedLink}, nil)
-	repoWithoutLinks.On("AllUserLinks", mock.Anything).Return([]Link{}, nil)
+	repoWithErr.On("AllUserLinks", mock.Anything, mock.Anything).Return(nil, errRepo)
+	repoWithLinks.On("AllUserLinks", mock.Anything, mock.Anything).Return([]*scrapper.UserLink{{
+		ID:      7,
+		URL:     expectedLink,
+		Tags:    []string{"work"},
+		Filters: []string{"user:dummy"},
+	}}, nil)
+	repoWithoutLinks.On("AllUserLinks", mock.Anything, mock.Anything).Return([]*scrapper.UserLink{}, nil)
 
 	type testCase struct {
 		name         string
 		repo         scrapservice.UserRepo
 		userID       int64
+		tag          string
 		httpStatus   int
 		expectedBody *listLinksResponse
 	}
@@ -97,13 +105,29 @@ func TestLinkHandler_GetMethodHandler(t *testing.T) {
 			httpStatus:   http.StatusOK,
 			expectedBody: &listLinksResponse{Links: make([]LinkResponse, 0)},
 		},
+		{
+			name:         "получение ссылок по тегу",
+			repo:         repoWithLinks,
+			userID:       1,
+			tag:          "work",
+			httpStatus:   http.StatusOK,
+			expectedBody: userLinks,
+		},
+		{
+			name:         "ссылок с таким тегом нет",
+			repo:         repoWithLinks,
+			userID:       1,
+			tag:          "home",
+			httpStatus:   http.StatusOK,
+			expectedBody: &listLinksResponse{Links: make([]LinkResponse, 0)},
+		},
 	}
 
 	for _, test := range tests {
 		w := httptest.NewRecorder()
 
 		linkHandler := scraphandlers.NewLinkHandler(test.repo, transactor, logger, stackClient, gitClient)
-		linkHandler.GetMethodHandler(w, test.userID)
+		linkHandler.GetMethodHandler(context.Background(), w, test.userID, test.tag)
 
 		assert.Equal(t, test.httpStatus, w.Code)
 
@@ -120,6 +144,84 @@ func TestLinkHandler_GetMethodHandler(t *testing.T) {
 	}
 }
 
+func TestLinkHandler_TagedLinksHandler(t *testing.T) {
+	transactor := mocks.NewTransactor(t)
+
+	repoWithErr := mocks.NewUserRepo(t)
+	repoWithLinks := mocks.NewUserRepo(t)
+
+	stackClient, gitClient := mocks.NewSiteClient(t), mocks.NewSiteClient(t)
+
+	repoWithErr.On("AllUserLinks", mock.Anything, mock.Anything).Return(nil, errRepo)
+	repoWithLinks.On("AllUserLinks", mock.Anything, mock.Anything).Return([]*scrapper.UserLink{
+		{ID: 1, URL: "tbank.ru", Tags: []string{"work", "go"}},
+		{ID: 2, URL: "github.com", Tags: []string{"go"}},
+		{ID: 3, URL: "stackoverflow.com"},
+	}, nil)
+
+	type testCase struct {
+		name         string
+		repo         scrapservice.UserRepo
+		tag          string
+		httpStatus   int
+		expectedBody *scrapper.ListTagedLinks
+	}
+
+	tests := []testCase{
+		{
+			name:       "ошибка при попытке получить все ссылки пользователя",
+			repo:       repoWithErr,
+			httpStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "группировка всех ссылок по тегам",
+			repo:       repoWithLinks,
+			httpStatus: http.StatusOK,
+			expectedBody: &scrapper.ListTagedLinks{TagedLinks: []scrapper.TagedLink{
+				{Tag: "work", Links: []Link{"tbank.ru"}},
+				{Tag: "go", Links: []Link{"tbank.ru", "github.com"}},
+				{Tag: "", Links: []Link{"stackoverflow.com"}},
+			}},
+		},
+		{
+			name:       "получение ссылок с одним тегом",
+			repo:       repoWithLinks,
+			tag:        "go",
+			httpStatus: http.StatusOK,
+			expectedBody: &scrapper.ListTagedLinks{TagedLinks: []scrapper.TagedLink{
+				{Tag: "go", Links: []Link{"tbank.ru", "github.com"}},
+			}},
+		},
+		{
+			name:         "ссылок с таким тегом нет",
+			repo:         repoWithLinks,
+			tag:          "home",
+			httpStatus:   http.StatusOK,
+			expectedBody: &scrapper.ListTagedLinks{TagedLinks: []scrapper.TagedLink{}},
+		},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+
+		linkHandler := scraphandlers.NewLinkHandler(test.repo, transactor, logger, stackClient, gitClient)
+		linkHandler.TagedLinksHandler(context.Background(), w, 1, test.tag)
+
+		assert.Equal(t, test.httpStatus, w.Code, test.name)
+
+		if test.expectedBody != nil {
+			tagedLinks := &scrapper.ListTagedLinks{}
+
+			err := json.NewDecoder(w.Body).Decode(tagedLinks)
+
+			assert.NoError(t, err, "ошибка при декодинге тела ответа")
+			assert.Equal(t, test.expectedBody, tagedLinks, test.name)
+		} else {
+			assert.Empty(t, w.Body)
+		}
+	}
+}
+
 func TestLinkHandler_PostMethodHandler(t *testing.T) {
 	transactorWithErr := mocks.NewTransactor(t)
 	transactorWithoutErr := mocks.NewTransactor(t)
@@ -131,19 +233,19 @@ func TestLinkHandler_PostMethodHandler(t *testing.T) {
 	repoUserAlwaysTrackLink := mocks.NewUserRepo(t)
 	repoUserAlwaysNotTrackLink := mocks.NewUserRepo(t)
 
-	repoWithErrUserTrackLink.On("UserTrackLink", mock.Anything, mock.Anything).
+	repoWithErrUserTrackLink.On("UserTrackLink", mock.Anything, mock.Anything, mock.Anything).
 		Return(false, errRepo)
 
-	repoUserAlwaysTrackLink.On("UserTrackLink", mock.Anything, mock.Anything).
+	repoUserAlwaysTrackLink.On("UserTrackLink", mock.Anything, mock.Anything, mock.Anything).
 		Return(true, nil)
 
-	repoUserAlwaysNotTrackLink.On("UserTrackLink", mock.Anything, mock.Anything).
+	repoUserAlwaysNotTrackLink.On("UserTrackLink", mock.Anything, mock.Anything, mock.Anything).
 		Return(false, nil)
 
 	stackClient := mocks.NewSiteClient(t)
 
-	stackClient.On("CanTrack", wrongLink).Return(false)
-	stackClient.On("CanTrack", goodLink).Return(true)
+	stackClient.On("CanTrack", mock.Anything, wrongLink).Return(false)
+	stackClient.On("CanTrack", mock.Anything, goodLink).Return(true)
 
 	type testCase struct {
 		name           string
@@ -226,7 +328,7 @@ func TestLinkHandler_PostMethodHandler(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		linkHandler := scraphandlers.NewLinkHandler(test.userRepo, test.transactor, logger, stackClient)
-		linkHandler.PostMethodHandler(w, test.userID, test.reqData)
+		linkHandler.PostMethodHandler(context.Background(), w, test.userID, test.reqData)
 
 		assert.Equal(t, test.httpStatus, w.Code)
 
@@ -259,22 +361,22 @@ func TestLinkHandler_DeleteMethodHandler(t *testing.T) {
 	repoUntrackLinkWithErr := mocks.NewUserRepo(t)
 	repoUntrackLink := mocks.NewUserRepo(t)
 
-	repoWithErrUserTrackLink.On("UserTrackLink", mock.Anything, mock.Anything).
+	repoWithErrUserTrackLink.On("UserTrackLink", mock.Anything, mock.Anything, mock.Anything).
 		Return(false, errRepo)
 
-	repoUserAlwaysNotTrackLink.On("UserTrackLink", mock.Anything, mock.Anything).
+	repoUserAlwaysNotTrackLink.On("UserTrackLink", mock.Anything, mock.Anything, mock.Anything).
 		Return(false, nil)
 
-	repoUntrackLinkWithErr.On("UserTrackLink", mock.Anything, mock.Anything).
+	repoUntrackLinkWithErr.On("UserTrackLink", mock.Anything, mock.Anything, mock.Anything).
 		Return(true, nil)
 
-	repoUntrackLinkWithErr.On("UntrackLink", mock.Anything, mock.Anything).
+	repoUntrackLinkWithErr.On("UntrackLink", mock.Anything, mock.Anything, mock.Anything).
 		Return(errRepo)
 
-	repoUntrackLink.On("UserTrackLink", mock.Anything, mock.Anything).
+	repoUntrackLink.On("UserTrackLink", mock.Anything, mock.Anything, mock.Anything).
 		Return(true, nil)
 
-	repoUntrackLink.On("UntrackLink", mock.Anything, mock.Anything).
+	repoUntrackLink.On("UntrackLink", mock.Anything, mock.Anything, mock.Anything).
 		Return(nil)
 
 	stackClient := mocks.NewSiteClient(t)
@@ -347,7 +449,7 @@ func TestLinkHandler_DeleteMethodHandler(t *testing.T) {
 		w := httptest.NewRecorder()
 
 		linkHandler := scraphandlers.NewLinkHandler(test.userRepo, transactor, logger, stackClient)
-		linkHandler.DeleteMethodHandler(w, test.userID, test.reqData)
+		linkHandler.DeleteMethodHandler(context.Background(), w, test.userID, test.reqData)
 
 		assert.Equal(t, test.httpStatus, w.Code)
 
@@ -380,10 +482,10 @@ func TestLinkHandler_HandleLinksChanges(t *testing.T) {
 	userRepoWithoutUsers := mocks.NewUserRepo(t)
 	userRepoWithUsers := mocks.NewUserRepo(t)
 
-	userRepoWithErr.On("UserExist", mock.Anything).Return(false, errRepo)
-	userRepoWithoutUsers.On("UserExist", mock.Anything).Return(false, nil)
-	userRepoWithUsers.On("UserExist", mock.Anything).Return(true, nil)
-	userRepoWithUsers.On("AllUserLinks", mock.Anything).Return(nil, errRepo)
+	userRepoWithErr.On("UserExist", mock.Anything, mock.Anything).Return(false, errRepo)
+	userRepoWithoutUsers.On("UserExist", mock.Anything, mock.Anything).Return(false, nil)
+	userRepoWithUsers.On("UserExist", mock.Anything, mock.Anything).Return(true, nil)
+	userRepoWithUsers.On("AllUserLinks", mock.Anything, mock.Anything).Return(nil, errRepo)
 
 	stackClient := mocks.NewSiteClient(t)
 
@@ -503,3 +605,107 @@ func TestLinkHandler_HandleLinksChanges(t *testing.T) {
 		}
 	}
 }
+
+func TestLinkHandler_HandleIntervalChanges(t *testing.T) {
+	transactor := mocks.NewTransactor(t)
+
+	repoNotTrackLink := mocks.NewUserRepo(t)
+	repoWithSetErr := mocks.NewUserRepo(t)
+	repoWithLinks := mocks.NewUserRepo(t)
+
+	repoNotTrackLink.On("UserExist", mock.Anything, mock.Anything).Return(true, nil)
+	repoNotTrackLink.On("UserTrackLink", mock.Anything, mock.Anything, mock.Anything).Return(false, nil)
+	repoWithSetErr.On("UserExist", mock.Anything, mock.Anything).Return(true, nil)
+	repoWithSetErr.On("UserTrackLink", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	repoWithSetErr.On("SetCheckInterval", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errRepo)
+	repoWithLinks.On("UserExist", mock.Anything, mock.Anything).Return(true, nil)
+	repoWithLinks.On("UserTrackLink", mock.Anything, int64(5), goodLink).Return(true, nil)
+	repoWithLinks.On("SetCheckInterval", mock.Anything, int64(5), goodLink, 30*time.Minute).Return(nil)
+	repoWithLinks.On("SetCheckInterval", mock.Anything, int64(5), goodLink, time.Duration(0)).Return(nil)
+
+	tests := []struct {
+		name           string
+		userRepo       scrapservice.UserRepo
+		httpMethod     string
+		body           string
+		expectedStatus int
+		expectedBody   *dto.APIErrResponse
+	}{
+		{
+			name:           "обрабатываем метод, который не поддерживается",
+			httpMethod:     http.MethodPost,
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:           "пришел некорректный JSON",
+			httpMethod:     http.MethodPut,
+			body:           wrongStr,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   dto.APIErrBadJSON,
+		},
+		{
+			name:           "интервал меньше минимального",
+			httpMethod:     http.MethodPut,
+			body:           `{"link":"tbank.com","interval":"10s"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   dto.APIErrBadInterval,
+		},
+		{
+			name:           "интервал не является длительностью",
+			httpMethod:     http.MethodPut,
+			body:           `{"link":"tbank.com","interval":"often"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   dto.APIErrBadInterval,
+		},
+		{
+			name:           "пользователь не отслеживает ссылку",
+			userRepo:       repoNotTrackLink,
+			httpMethod:     http.MethodPut,
+			body:           `{"link":"tbank.com","interval":"30m"}`,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   dto.APIErrNotTrackLink,
+		},
+		{
+			name:           "ошибка в БД при изменении интервала",
+			userRepo:       repoWithSetErr,
+			httpMethod:     http.MethodDelete,
+			body:           `{"link":"tbank.com"}`,
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "задаем интервал проверки",
+			userRepo:       repoWithLinks,
+			httpMethod:     http.MethodPut,
+			body:           `{"link":"tbank.com","interval":"30m"}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "возвращаем адаптивное расписание",
+			userRepo:       repoWithLinks,
+			httpMethod:     http.MethodDelete,
+			body:           `{"link":"tbank.com"}`,
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(test.httpMethod, "", bytes.NewBufferString(test.body))
+
+		r.Header.Set("Tg-Chat-Id", goodID)
+
+		linkHandler := scraphandlers.NewLinkHandler(test.userRepo, transactor, logger)
+		linkHandler.HandleIntervalChanges(w, r)
+
+		assert.Equal(t, test.expectedStatus, w.Code, test.name)
+
+		if test.expectedBody != nil {
+			errResponse := &dto.APIErrResponse{}
+
+			assert.NoError(t, json.NewDecoder(w.Body).Decode(errResponse))
+			assert.Equal(t, test.expectedBody, errResponse)
+		} else {
+			assert.Empty(t, w.Body.String())
+		}
+	}
+}
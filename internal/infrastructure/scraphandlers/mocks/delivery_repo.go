@@ -0,0 +1,191 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// DeliveryRepo is an autogenerated mock type for the DeliveryRepo type
+type DeliveryRepo struct {
+	mock.Mock
+}
+
+type DeliveryRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *DeliveryRepo) EXPECT() *DeliveryRepo_Expecter {
+	return &DeliveryRepo_Expecter{mock: &_m.Mock}
+}
+
+// SetDigestTime provides a mock function with given fields: ctx, user, sendTime, now
+func (_m *DeliveryRepo) SetDigestTime(ctx context.Context, user int64, sendTime string, now time.Time) error {
+	ret := _m.Called(ctx, user, sendTime, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDigestTime")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, time.Time) error); ok {
+		r0 = rf(ctx, user, sendTime, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeliveryRepo_SetDigestTime_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetDigestTime'
+type DeliveryRepo_SetDigestTime_Call struct {
+	*mock.Call
+}
+
+// SetDigestTime is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user int64
+//   - sendTime string
+//   - now time.Time
+func (_e *DeliveryRepo_Expecter) SetDigestTime(ctx interface{}, user interface{}, sendTime interface{}, now interface{}) *DeliveryRepo_SetDigestTime_Call {
+	return &DeliveryRepo_SetDigestTime_Call{Call: _e.mock.On("SetDigestTime", ctx, user, sendTime, now)}
+}
+
+func (_c *DeliveryRepo_SetDigestTime_Call) Run(run func(ctx context.Context, user int64, sendTime string, now time.Time)) *DeliveryRepo_SetDigestTime_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *DeliveryRepo_SetDigestTime_Call) Return(_a0 error) *DeliveryRepo_SetDigestTime_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DeliveryRepo_SetDigestTime_Call) RunAndReturn(run func(context.Context, int64, string, time.Time) error) *DeliveryRepo_SetDigestTime_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetInstantDelivery provides a mock function with given fields: ctx, user
+func (_m *DeliveryRepo) SetInstantDelivery(ctx context.Context, user int64) error {
+	ret := _m.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetInstantDelivery")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeliveryRepo_SetInstantDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetInstantDelivery'
+type DeliveryRepo_SetInstantDelivery_Call struct {
+	*mock.Call
+}
+
+// SetInstantDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user int64
+func (_e *DeliveryRepo_Expecter) SetInstantDelivery(ctx interface{}, user interface{}) *DeliveryRepo_SetInstantDelivery_Call {
+	return &DeliveryRepo_SetInstantDelivery_Call{Call: _e.mock.On("SetInstantDelivery", ctx, user)}
+}
+
+func (_c *DeliveryRepo_SetInstantDelivery_Call) Run(run func(ctx context.Context, user int64)) *DeliveryRepo_SetInstantDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *DeliveryRepo_SetInstantDelivery_Call) Return(_a0 error) *DeliveryRepo_SetInstantDelivery_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *DeliveryRepo_SetInstantDelivery_Call) RunAndReturn(run func(context.Context, int64) error) *DeliveryRepo_SetInstantDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UserExist provides a mock function with given fields: ctx, userID
+func (_m *DeliveryRepo) UserExist(ctx context.Context, userID int64) (bool, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserExist")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (bool, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) bool); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeliveryRepo_UserExist_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserExist'
+type DeliveryRepo_UserExist_Call struct {
+	*mock.Call
+}
+
+// UserExist is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+func (_e *DeliveryRepo_Expecter) UserExist(ctx interface{}, userID interface{}) *DeliveryRepo_UserExist_Call {
+	return &DeliveryRepo_UserExist_Call{Call: _e.mock.On("UserExist", ctx, userID)}
+}
+
+func (_c *DeliveryRepo_UserExist_Call) Run(run func(ctx context.Context, userID int64)) *DeliveryRepo_UserExist_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *DeliveryRepo_UserExist_Call) Return(_a0 bool, _a1 error) *DeliveryRepo_UserExist_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *DeliveryRepo_UserExist_Call) RunAndReturn(run func(context.Context, int64) (bool, error)) *DeliveryRepo_UserExist_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewDeliveryRepo creates a new instance of DeliveryRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDeliveryRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DeliveryRepo {
+	mock := &DeliveryRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
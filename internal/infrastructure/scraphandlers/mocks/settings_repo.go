@@ -0,0 +1,285 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SettingsRepo is an autogenerated mock type for the SettingsRepo type
+type SettingsRepo struct {
+	mock.Mock
+}
+
+type SettingsRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *SettingsRepo) EXPECT() *SettingsRepo_Expecter {
+	return &SettingsRepo_Expecter{mock: &_m.Mock}
+}
+
+// DisableQuietHours provides a mock function with given fields: ctx, user
+func (_m *SettingsRepo) DisableQuietHours(ctx context.Context, user int64) error {
+	ret := _m.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DisableQuietHours")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SettingsRepo_DisableQuietHours_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DisableQuietHours'
+type SettingsRepo_DisableQuietHours_Call struct {
+	*mock.Call
+}
+
+// DisableQuietHours is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user int64
+func (_e *SettingsRepo_Expecter) DisableQuietHours(ctx interface{}, user interface{}) *SettingsRepo_DisableQuietHours_Call {
+	return &SettingsRepo_DisableQuietHours_Call{Call: _e.mock.On("DisableQuietHours", ctx, user)}
+}
+
+func (_c *SettingsRepo_DisableQuietHours_Call) Run(run func(ctx context.Context, user int64)) *SettingsRepo_DisableQuietHours_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *SettingsRepo_DisableQuietHours_Call) Return(_a0 error) *SettingsRepo_DisableQuietHours_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SettingsRepo_DisableQuietHours_Call) RunAndReturn(run func(context.Context, int64) error) *SettingsRepo_DisableQuietHours_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetLanguage provides a mock function with given fields: ctx, user, lang
+func (_m *SettingsRepo) SetLanguage(ctx context.Context, user int64, lang string) error {
+	ret := _m.Called(ctx, user, lang)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetLanguage")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, user, lang)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SettingsRepo_SetLanguage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetLanguage'
+type SettingsRepo_SetLanguage_Call struct {
+	*mock.Call
+}
+
+// SetLanguage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user int64
+//   - lang string
+func (_e *SettingsRepo_Expecter) SetLanguage(ctx interface{}, user interface{}, lang interface{}) *SettingsRepo_SetLanguage_Call {
+	return &SettingsRepo_SetLanguage_Call{Call: _e.mock.On("SetLanguage", ctx, user, lang)}
+}
+
+func (_c *SettingsRepo_SetLanguage_Call) Run(run func(ctx context.Context, user int64, lang string)) *SettingsRepo_SetLanguage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *SettingsRepo_SetLanguage_Call) Return(_a0 error) *SettingsRepo_SetLanguage_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SettingsRepo_SetLanguage_Call) RunAndReturn(run func(context.Context, int64, string) error) *SettingsRepo_SetLanguage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetQuietHours provides a mock function with given fields: ctx, user, start, end
+func (_m *SettingsRepo) SetQuietHours(ctx context.Context, user int64, start string, end string) error {
+	ret := _m.Called(ctx, user, start, end)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetQuietHours")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, string) error); ok {
+		r0 = rf(ctx, user, start, end)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SettingsRepo_SetQuietHours_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetQuietHours'
+type SettingsRepo_SetQuietHours_Call struct {
+	*mock.Call
+}
+
+// SetQuietHours is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user int64
+//   - start string
+//   - end string
+func (_e *SettingsRepo_Expecter) SetQuietHours(ctx interface{}, user interface{}, start interface{}, end interface{}) *SettingsRepo_SetQuietHours_Call {
+	return &SettingsRepo_SetQuietHours_Call{Call: _e.mock.On("SetQuietHours", ctx, user, start, end)}
+}
+
+func (_c *SettingsRepo_SetQuietHours_Call) Run(run func(ctx context.Context, user int64, start string, end string)) *SettingsRepo_SetQuietHours_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *SettingsRepo_SetQuietHours_Call) Return(_a0 error) *SettingsRepo_SetQuietHours_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SettingsRepo_SetQuietHours_Call) RunAndReturn(run func(context.Context, int64, string, string) error) *SettingsRepo_SetQuietHours_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetTimezone provides a mock function with given fields: ctx, user, timezone
+func (_m *SettingsRepo) SetTimezone(ctx context.Context, user int64, timezone string) error {
+	ret := _m.Called(ctx, user, timezone)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetTimezone")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, user, timezone)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SettingsRepo_SetTimezone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTimezone'
+type SettingsRepo_SetTimezone_Call struct {
+	*mock.Call
+}
+
+// SetTimezone is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user int64
+//   - timezone string
+func (_e *SettingsRepo_Expecter) SetTimezone(ctx interface{}, user interface{}, timezone interface{}) *SettingsRepo_SetTimezone_Call {
+	return &SettingsRepo_SetTimezone_Call{Call: _e.mock.On("SetTimezone", ctx, user, timezone)}
+}
+
+func (_c *SettingsRepo_SetTimezone_Call) Run(run func(ctx context.Context, user int64, timezone string)) *SettingsRepo_SetTimezone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *SettingsRepo_SetTimezone_Call) Return(_a0 error) *SettingsRepo_SetTimezone_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *SettingsRepo_SetTimezone_Call) RunAndReturn(run func(context.Context, int64, string) error) *SettingsRepo_SetTimezone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UserExist provides a mock function with given fields: ctx, userID
+func (_m *SettingsRepo) UserExist(ctx context.Context, userID int64) (bool, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserExist")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (bool, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) bool); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SettingsRepo_UserExist_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UserExist'
+type SettingsRepo_UserExist_Call struct {
+	*mock.Call
+}
+
+// UserExist is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+func (_e *SettingsRepo_Expecter) UserExist(ctx interface{}, userID interface{}) *SettingsRepo_UserExist_Call {
+	return &SettingsRepo_UserExist_Call{Call: _e.mock.On("UserExist", ctx, userID)}
+}
+
+func (_c *SettingsRepo_UserExist_Call) Run(run func(ctx context.Context, userID int64)) *SettingsRepo_UserExist_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *SettingsRepo_UserExist_Call) Return(_a0 bool, _a1 error) *SettingsRepo_UserExist_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *SettingsRepo_UserExist_Call) RunAndReturn(run func(context.Context, int64) (bool, error)) *SettingsRepo_UserExist_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewSettingsRepo creates a new instance of SettingsRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSettingsRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *SettingsRepo {
+	mock := &SettingsRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
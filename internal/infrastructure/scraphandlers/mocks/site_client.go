@@ -3,11 +3,9 @@
 package mocks
 
 import (
-	scrapper "linkTraccer/internal/domain/scrapper"
+	context "context"
 
 	mock "github.com/stretchr/testify/mock"
-
-	time "time"
 )
 
 // SiteClient is an autogenerated mock type for the SiteClient type
@@ -23,17 +21,17 @@ func (_m *SiteClient) EXPECT() *SiteClient_Expecter {
 	return &SiteClient_Expecter{mock: &_m.Mock}
 }
 
-// CanTrack provides a mock function with given fields: link
-func (_m *SiteClient) CanTrack(link string) bool {
-	ret := _m.Called(link)
+// CanTrack provides a mock function with given fields: ctx, link
+func (_m *SiteClient) CanTrack(ctx context.Context, link string) bool {
+	ret := _m.Called(ctx, link)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CanTrack")
 	}
 
 	var r0 bool
-	if rf, ok := ret.Get(0).(func(string) bool); ok {
-		r0 = rf(link)
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, link)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
@@ -47,14 +45,15 @@ type SiteClient_CanTrack_Call struct {
 }
 
 // CanTrack is a helper method to define mock.On call
+//   - ctx context.Context
 //   - link string
-func (_e *SiteClient_Expecter) CanTrack(link interface{}) *SiteClient_CanTrack_Call {
-	return &SiteClient_CanTrack_Call{Call: _e.mock.On("CanTrack", link)}
+func (_e *SiteClient_Expecter) CanTrack(ctx interface{}, link interface{}) *SiteClient_CanTrack_Call {
+	return &SiteClient_CanTrack_Call{Call: _e.mock.On("CanTrack", ctx, link)}
 }
 
-func (_c *SiteClient_CanTrack_Call) Run(run func(link string)) *SiteClient_CanTrack_Call {
+func (_c *SiteClient_CanTrack_Call) Run(run func(ctx context.Context, link string)) *SiteClient_CanTrack_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -64,66 +63,7 @@ func (_c *SiteClient_CanTrack_Call) Return(_a0 bool) *SiteClient_CanTrack_Call {
 	return _c
 }
 
-func (_c *SiteClient_CanTrack_Call) RunAndReturn(run func(string) bool) *SiteClient_CanTrack_Call {
-	_c.Call.Return(run)
-	return _c
-}
-
-// LinkUpdates provides a mock function with given fields: link, updatesSince
-func (_m *SiteClient) LinkUpdates(link string, updatesSince time.Time) ([]*scrapper.LinkUpdate, error) {
-	ret := _m.Called(link, updatesSince)
-
-	if len(ret) == 0 {
-		panic("no return value specified for LinkUpdates")
-	}
-
-	var r0 []*scrapper.LinkUpdate
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string, time.Time) ([]*scrapper.LinkUpdate, error)); ok {
-		return rf(link, updatesSince)
-	}
-	if rf, ok := ret.Get(0).(func(string, time.Time) []*scrapper.LinkUpdate); ok {
-		r0 = rf(link, updatesSince)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*scrapper.LinkUpdate)
-		}
-	}
-
-	if rf, ok := ret.Get(1).(func(string, time.Time) error); ok {
-		r1 = rf(link, updatesSince)
-	} else {
-		r1 = ret.Error(1)
-	}
-
-	return r0, r1
-}
-
-// SiteClient_LinkUpdates_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LinkUpdates'
-type SiteClient_LinkUpdates_Call struct {
-	*mock.Call
-}
-
-// LinkUpdates is a helper method to define mock.On call
-//   - link string
-//   - updatesSince time.Time
-func (_e *SiteClient_Expecter) LinkUpdates(link interface{}, updatesSince interface{}) *SiteClient_LinkUpdates_Call {
-	return &SiteClient_LinkUpdates_Call{Call: _e.mock.On("LinkUpdates", link, updatesSince)}
-}
-
-func (_c *SiteClient_LinkUpdates_Call) Run(run func(link string, updatesSince time.Time)) *SiteClient_LinkUpdates_Call {
-	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(time.Time))
-	})
-	return _c
-}
-
-func (_c *SiteClient_LinkUpdates_Call) Return(_a0 []*scrapper.LinkUpdate, _a1 error) *SiteClient_LinkUpdates_Call {
-	_c.Call.Return(_a0, _a1)
-	return _c
-}
-
-func (_c *SiteClient_LinkUpdates_Call) RunAndReturn(run func(string, time.Time) ([]*scrapper.LinkUpdate, error)) *SiteClient_LinkUpdates_Call {
+func (_c *SiteClient_CanTrack_Call) RunAndReturn(run func(context.Context, string) bool) *SiteClient_CanTrack_Call {
 	_c.Call.Return(run)
 	return _c
 }
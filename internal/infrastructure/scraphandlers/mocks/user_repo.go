@@ -4,10 +4,12 @@ package mocks
 
 import (
 	context "context"
-	scrapservice "linkTraccer/internal/application/scrapper/scrapservice"
+	scrapper "linkTraccer/internal/domain/scrapper"
 
 	mock "github.com/stretchr/testify/mock"
 
+	scrapservice "linkTraccer/internal/application/scrapper/scrapservice"
+
 	time "time"
 )
 
@@ -24,29 +26,127 @@ func (_m *UserRepo) EXPECT() *UserRepo_Expecter {
 	return &UserRepo_Expecter{mock: &_m.Mock}
 }
 
-// AllUserLinks provides a mock function with given fields: userID
-func (_m *UserRepo) AllUserLinks(userID int64) ([]string, error) {
-	ret := _m.Called(userID)
+// AddLinkFilters provides a mock function with given fields: ctx, userID, link, filters
+func (_m *UserRepo) AddLinkFilters(ctx context.Context, userID int64, link string, filters []string) error {
+	ret := _m.Called(ctx, userID, link, filters)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddLinkFilters")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, []string) error); ok {
+		r0 = rf(ctx, userID, link, filters)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepo_AddLinkFilters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddLinkFilters'
+type UserRepo_AddLinkFilters_Call struct {
+	*mock.Call
+}
+
+// AddLinkFilters is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - link string
+//   - filters []string
+func (_e *UserRepo_Expecter) AddLinkFilters(ctx interface{}, userID interface{}, link interface{}, filters interface{}) *UserRepo_AddLinkFilters_Call {
+	return &UserRepo_AddLinkFilters_Call{Call: _e.mock.On("AddLinkFilters", ctx, userID, link, filters)}
+}
+
+func (_c *UserRepo_AddLinkFilters_Call) Run(run func(ctx context.Context, userID int64, link string, filters []string)) *UserRepo_AddLinkFilters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string), args[3].([]string))
+	})
+	return _c
+}
+
+func (_c *UserRepo_AddLinkFilters_Call) Return(_a0 error) *UserRepo_AddLinkFilters_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepo_AddLinkFilters_Call) RunAndReturn(run func(context.Context, int64, string, []string) error) *UserRepo_AddLinkFilters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AddLinkTags provides a mock function with given fields: ctx, userID, link, tags
+func (_m *UserRepo) AddLinkTags(ctx context.Context, userID int64, link string, tags []string) error {
+	ret := _m.Called(ctx, userID, link, tags)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddLinkTags")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, []string) error); ok {
+		r0 = rf(ctx, userID, link, tags)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepo_AddLinkTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddLinkTags'
+type UserRepo_AddLinkTags_Call struct {
+	*mock.Call
+}
+
+// AddLinkTags is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - link string
+//   - tags []string
+func (_e *UserRepo_Expecter) AddLinkTags(ctx interface{}, userID interface{}, link interface{}, tags interface{}) *UserRepo_AddLinkTags_Call {
+	return &UserRepo_AddLinkTags_Call{Call: _e.mock.On("AddLinkTags", ctx, userID, link, tags)}
+}
+
+func (_c *UserRepo_AddLinkTags_Call) Run(run func(ctx context.Context, userID int64, link string, tags []string)) *UserRepo_AddLinkTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string), args[3].([]string))
+	})
+	return _c
+}
+
+func (_c *UserRepo_AddLinkTags_Call) Return(_a0 error) *UserRepo_AddLinkTags_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepo_AddLinkTags_Call) RunAndReturn(run func(context.Context, int64, string, []string) error) *UserRepo_AddLinkTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AllUserLinks provides a mock function with given fields: ctx, userID
+func (_m *UserRepo) AllUserLinks(ctx context.Context, userID int64) ([]*scrapper.UserLink, error) {
+	ret := _m.Called(ctx, userID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AllUserLinks")
 	}
 
-	var r0 []string
+	var r0 []*scrapper.UserLink
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int64) ([]string, error)); ok {
-		return rf(userID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]*scrapper.UserLink, error)); ok {
+		return rf(ctx, userID)
 	}
-	if rf, ok := ret.Get(0).(func(int64) []string); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []*scrapper.UserLink); ok {
+		r0 = rf(ctx, userID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
+			r0 = ret.Get(0).([]*scrapper.UserLink)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int64) error); ok {
-		r1 = rf(userID)
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -60,39 +160,40 @@ type UserRepo_AllUserLinks_Call struct {
 }
 
 // AllUserLinks is a helper method to define mock.On call
+//   - ctx context.Context
 //   - userID int64
-func (_e *UserRepo_Expecter) AllUserLinks(userID interface{}) *UserRepo_AllUserLinks_Call {
-	return &UserRepo_AllUserLinks_Call{Call: _e.mock.On("AllUserLinks", userID)}
+func (_e *UserRepo_Expecter) AllUserLinks(ctx interface{}, userID interface{}) *UserRepo_AllUserLinks_Call {
+	return &UserRepo_AllUserLinks_Call{Call: _e.mock.On("AllUserLinks", ctx, userID)}
 }
 
-func (_c *UserRepo_AllUserLinks_Call) Run(run func(userID int64)) *UserRepo_AllUserLinks_Call {
+func (_c *UserRepo_AllUserLinks_Call) Run(run func(ctx context.Context, userID int64)) *UserRepo_AllUserLinks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int64))
+		run(args[0].(context.Context), args[1].(int64))
 	})
 	return _c
 }
 
-func (_c *UserRepo_AllUserLinks_Call) Return(_a0 []string, _a1 error) *UserRepo_AllUserLinks_Call {
+func (_c *UserRepo_AllUserLinks_Call) Return(_a0 []*scrapper.UserLink, _a1 error) *UserRepo_AllUserLinks_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *UserRepo_AllUserLinks_Call) RunAndReturn(run func(int64) ([]string, error)) *UserRepo_AllUserLinks_Call {
+func (_c *UserRepo_AllUserLinks_Call) RunAndReturn(run func(context.Context, int64) ([]*scrapper.UserLink, error)) *UserRepo_AllUserLinks_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ChangeLastCheckTime provides a mock function with given fields: link, checkTime
-func (_m *UserRepo) ChangeLastCheckTime(link string, checkTime time.Time) error {
-	ret := _m.Called(link, checkTime)
+// ChangeLastCheckTime provides a mock function with given fields: ctx, link, checkTime
+func (_m *UserRepo) ChangeLastCheckTime(ctx context.Context, link string, checkTime time.Time) error {
+	ret := _m.Called(ctx, link, checkTime)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ChangeLastCheckTime")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, time.Time) error); ok {
-		r0 = rf(link, checkTime)
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, link, checkTime)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -106,15 +207,16 @@ type UserRepo_ChangeLastCheckTime_Call struct {
 }
 
 // ChangeLastCheckTime is a helper method to define mock.On call
+//   - ctx context.Context
 //   - link string
 //   - checkTime time.Time
-func (_e *UserRepo_Expecter) ChangeLastCheckTime(link interface{}, checkTime interface{}) *UserRepo_ChangeLastCheckTime_Call {
-	return &UserRepo_ChangeLastCheckTime_Call{Call: _e.mock.On("ChangeLastCheckTime", link, checkTime)}
+func (_e *UserRepo_Expecter) ChangeLastCheckTime(ctx interface{}, link interface{}, checkTime interface{}) *UserRepo_ChangeLastCheckTime_Call {
+	return &UserRepo_ChangeLastCheckTime_Call{Call: _e.mock.On("ChangeLastCheckTime", ctx, link, checkTime)}
 }
 
-func (_c *UserRepo_ChangeLastCheckTime_Call) Run(run func(link string, checkTime time.Time)) *UserRepo_ChangeLastCheckTime_Call {
+func (_c *UserRepo_ChangeLastCheckTime_Call) Run(run func(ctx context.Context, link string, checkTime time.Time)) *UserRepo_ChangeLastCheckTime_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(time.Time))
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
 	})
 	return _c
 }
@@ -124,7 +226,53 @@ func (_c *UserRepo_ChangeLastCheckTime_Call) Return(_a0 error) *UserRepo_ChangeL
 	return _c
 }
 
-func (_c *UserRepo_ChangeLastCheckTime_Call) RunAndReturn(run func(string, time.Time) error) *UserRepo_ChangeLastCheckTime_Call {
+func (_c *UserRepo_ChangeLastCheckTime_Call) RunAndReturn(run func(context.Context, string, time.Time) error) *UserRepo_ChangeLastCheckTime_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteUntrackedLinks provides a mock function with given fields: ctx
+func (_m *UserRepo) DeleteUntrackedLinks(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUntrackedLinks")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepo_DeleteUntrackedLinks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteUntrackedLinks'
+type UserRepo_DeleteUntrackedLinks_Call struct {
+	*mock.Call
+}
+
+// DeleteUntrackedLinks is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *UserRepo_Expecter) DeleteUntrackedLinks(ctx interface{}) *UserRepo_DeleteUntrackedLinks_Call {
+	return &UserRepo_DeleteUntrackedLinks_Call{Call: _e.mock.On("DeleteUntrackedLinks", ctx)}
+}
+
+func (_c *UserRepo_DeleteUntrackedLinks_Call) Run(run func(ctx context.Context)) *UserRepo_DeleteUntrackedLinks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *UserRepo_DeleteUntrackedLinks_Call) Return(_a0 error) *UserRepo_DeleteUntrackedLinks_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepo_DeleteUntrackedLinks_Call) RunAndReturn(run func(context.Context) error) *UserRepo_DeleteUntrackedLinks_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -176,6 +324,122 @@ func (_c *UserRepo_DeleteUser_Call) RunAndReturn(run func(context.Context, int64
 	return _c
 }
 
+// ExtendLinkLease provides a mock function with given fields: ctx, linkInfo
+func (_m *UserRepo) ExtendLinkLease(ctx context.Context, linkInfo *scrapper.LinkInfo) (bool, error) {
+	ret := _m.Called(ctx, linkInfo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExtendLinkLease")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *scrapper.LinkInfo) (bool, error)); ok {
+		return rf(ctx, linkInfo)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *scrapper.LinkInfo) bool); ok {
+		r0 = rf(ctx, linkInfo)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *scrapper.LinkInfo) error); ok {
+		r1 = rf(ctx, linkInfo)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepo_ExtendLinkLease_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExtendLinkLease'
+type UserRepo_ExtendLinkLease_Call struct {
+	*mock.Call
+}
+
+// ExtendLinkLease is a helper method to define mock.On call
+//   - ctx context.Context
+//   - linkInfo *scrapper.LinkInfo
+func (_e *UserRepo_Expecter) ExtendLinkLease(ctx interface{}, linkInfo interface{}) *UserRepo_ExtendLinkLease_Call {
+	return &UserRepo_ExtendLinkLease_Call{Call: _e.mock.On("ExtendLinkLease", ctx, linkInfo)}
+}
+
+func (_c *UserRepo_ExtendLinkLease_Call) Run(run func(ctx context.Context, linkInfo *scrapper.LinkInfo)) *UserRepo_ExtendLinkLease_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*scrapper.LinkInfo))
+	})
+	return _c
+}
+
+func (_c *UserRepo_ExtendLinkLease_Call) Return(_a0 bool, _a1 error) *UserRepo_ExtendLinkLease_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepo_ExtendLinkLease_Call) RunAndReturn(run func(context.Context, *scrapper.LinkInfo) (bool, error)) *UserRepo_ExtendLinkLease_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LinkCursors provides a mock function with given fields: ctx, linkID
+func (_m *UserRepo) LinkCursors(ctx context.Context, linkID int64) (map[string]*scrapper.Cursor, error) {
+	ret := _m.Called(ctx, linkID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LinkCursors")
+	}
+
+	var r0 map[string]*scrapper.Cursor
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (map[string]*scrapper.Cursor, error)); ok {
+		return rf(ctx, linkID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) map[string]*scrapper.Cursor); ok {
+		r0 = rf(ctx, linkID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]*scrapper.Cursor)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, linkID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepo_LinkCursors_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LinkCursors'
+type UserRepo_LinkCursors_Call struct {
+	*mock.Call
+}
+
+// LinkCursors is a helper method to define mock.On call
+//   - ctx context.Context
+//   - linkID int64
+func (_e *UserRepo_Expecter) LinkCursors(ctx interface{}, linkID interface{}) *UserRepo_LinkCursors_Call {
+	return &UserRepo_LinkCursors_Call{Call: _e.mock.On("LinkCursors", ctx, linkID)}
+}
+
+func (_c *UserRepo_LinkCursors_Call) Run(run func(ctx context.Context, linkID int64)) *UserRepo_LinkCursors_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *UserRepo_LinkCursors_Call) Return(_a0 map[string]*scrapper.Cursor, _a1 error) *UserRepo_LinkCursors_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepo_LinkCursors_Call) RunAndReturn(run func(context.Context, int64) (map[string]*scrapper.Cursor, error)) *UserRepo_LinkCursors_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewLinksPaginator provides a mock function with no fields
 func (_m *UserRepo) NewLinksPaginator() scrapservice.LinkPaginator {
 	ret := _m.Called()
@@ -223,17 +487,17 @@ func (_c *UserRepo_NewLinksPaginator_Call) RunAndReturn(run func() scrapservice.
 	return _c
 }
 
-// RegUser provides a mock function with given fields: UserID
-func (_m *UserRepo) RegUser(UserID int64) error {
-	ret := _m.Called(UserID)
+// RegUser provides a mock function with given fields: ctx, UserID
+func (_m *UserRepo) RegUser(ctx context.Context, UserID int64) error {
+	ret := _m.Called(ctx, UserID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for RegUser")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64) error); ok {
-		r0 = rf(UserID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, UserID)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -247,14 +511,15 @@ type UserRepo_RegUser_Call struct {
 }
 
 // RegUser is a helper method to define mock.On call
+//   - ctx context.Context
 //   - UserID int64
-func (_e *UserRepo_Expecter) RegUser(UserID interface{}) *UserRepo_RegUser_Call {
-	return &UserRepo_RegUser_Call{Call: _e.mock.On("RegUser", UserID)}
+func (_e *UserRepo_Expecter) RegUser(ctx interface{}, UserID interface{}) *UserRepo_RegUser_Call {
+	return &UserRepo_RegUser_Call{Call: _e.mock.On("RegUser", ctx, UserID)}
 }
 
-func (_c *UserRepo_RegUser_Call) Run(run func(UserID int64)) *UserRepo_RegUser_Call {
+func (_c *UserRepo_RegUser_Call) Run(run func(ctx context.Context, UserID int64)) *UserRepo_RegUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int64))
+		run(args[0].(context.Context), args[1].(int64))
 	})
 	return _c
 }
@@ -264,7 +529,152 @@ func (_c *UserRepo_RegUser_Call) Return(_a0 error) *UserRepo_RegUser_Call {
 	return _c
 }
 
-func (_c *UserRepo_RegUser_Call) RunAndReturn(run func(int64) error) *UserRepo_RegUser_Call {
+func (_c *UserRepo_RegUser_Call) RunAndReturn(run func(context.Context, int64) error) *UserRepo_RegUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveLinkCursors provides a mock function with given fields: ctx, linkID, cursors
+func (_m *UserRepo) SaveLinkCursors(ctx context.Context, linkID int64, cursors map[string]*scrapper.Cursor) error {
+	ret := _m.Called(ctx, linkID, cursors)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveLinkCursors")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, map[string]*scrapper.Cursor) error); ok {
+		r0 = rf(ctx, linkID, cursors)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepo_SaveLinkCursors_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveLinkCursors'
+type UserRepo_SaveLinkCursors_Call struct {
+	*mock.Call
+}
+
+// SaveLinkCursors is a helper method to define mock.On call
+//   - ctx context.Context
+//   - linkID int64
+//   - cursors map[string]*scrapper.Cursor
+func (_e *UserRepo_Expecter) SaveLinkCursors(ctx interface{}, linkID interface{}, cursors interface{}) *UserRepo_SaveLinkCursors_Call {
+	return &UserRepo_SaveLinkCursors_Call{Call: _e.mock.On("SaveLinkCursors", ctx, linkID, cursors)}
+}
+
+func (_c *UserRepo_SaveLinkCursors_Call) Run(run func(ctx context.Context, linkID int64, cursors map[string]*scrapper.Cursor)) *UserRepo_SaveLinkCursors_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(map[string]*scrapper.Cursor))
+	})
+	return _c
+}
+
+func (_c *UserRepo_SaveLinkCursors_Call) Return(_a0 error) *UserRepo_SaveLinkCursors_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepo_SaveLinkCursors_Call) RunAndReturn(run func(context.Context, int64, map[string]*scrapper.Cursor) error) *UserRepo_SaveLinkCursors_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ScheduleNextCheck provides a mock function with given fields: ctx, linkID, schedule
+func (_m *UserRepo) ScheduleNextCheck(ctx context.Context, linkID int64, schedule *scrapper.LinkSchedule) error {
+	ret := _m.Called(ctx, linkID, schedule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScheduleNextCheck")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, *scrapper.LinkSchedule) error); ok {
+		r0 = rf(ctx, linkID, schedule)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepo_ScheduleNextCheck_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ScheduleNextCheck'
+type UserRepo_ScheduleNextCheck_Call struct {
+	*mock.Call
+}
+
+// ScheduleNextCheck is a helper method to define mock.On call
+//   - ctx context.Context
+//   - linkID int64
+//   - schedule *scrapper.LinkSchedule
+func (_e *UserRepo_Expecter) ScheduleNextCheck(ctx interface{}, linkID interface{}, schedule interface{}) *UserRepo_ScheduleNextCheck_Call {
+	return &UserRepo_ScheduleNextCheck_Call{Call: _e.mock.On("ScheduleNextCheck", ctx, linkID, schedule)}
+}
+
+func (_c *UserRepo_ScheduleNextCheck_Call) Run(run func(ctx context.Context, linkID int64, schedule *scrapper.LinkSchedule)) *UserRepo_ScheduleNextCheck_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(*scrapper.LinkSchedule))
+	})
+	return _c
+}
+
+func (_c *UserRepo_ScheduleNextCheck_Call) Return(_a0 error) *UserRepo_ScheduleNextCheck_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepo_ScheduleNextCheck_Call) RunAndReturn(run func(context.Context, int64, *scrapper.LinkSchedule) error) *UserRepo_ScheduleNextCheck_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetCheckInterval provides a mock function with given fields: ctx, userID, link, interval
+func (_m *UserRepo) SetCheckInterval(ctx context.Context, userID int64, link string, interval time.Duration) error {
+	ret := _m.Called(ctx, userID, link, interval)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetCheckInterval")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, time.Duration) error); ok {
+		r0 = rf(ctx, userID, link, interval)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UserRepo_SetCheckInterval_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetCheckInterval'
+type UserRepo_SetCheckInterval_Call struct {
+	*mock.Call
+}
+
+// SetCheckInterval is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID int64
+//   - link string
+//   - interval time.Duration
+func (_e *UserRepo_Expecter) SetCheckInterval(ctx interface{}, userID interface{}, link interface{}, interval interface{}) *UserRepo_SetCheckInterval_Call {
+	return &UserRepo_SetCheckInterval_Call{Call: _e.mock.On("SetCheckInterval", ctx, userID, link, interval)}
+}
+
+func (_c *UserRepo_SetCheckInterval_Call) Run(run func(ctx context.Context, userID int64, link string, interval time.Duration)) *UserRepo_SetCheckInterval_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64), args[2].(string), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *UserRepo_SetCheckInterval_Call) Return(_a0 error) *UserRepo_SetCheckInterval_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *UserRepo_SetCheckInterval_Call) RunAndReturn(run func(context.Context, int64, string, time.Duration) error) *UserRepo_SetCheckInterval_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -318,17 +728,17 @@ func (_c *UserRepo_TrackLink_Call) RunAndReturn(run func(context.Context, int64,
 	return _c
 }
 
-// UntrackLink provides a mock function with given fields: user, link
-func (_m *UserRepo) UntrackLink(user int64, link string) error {
-	ret := _m.Called(user, link)
+// UntrackLink provides a mock function with given fields: ctx, user, link
+func (_m *UserRepo) UntrackLink(ctx context.Context, user int64, link string) error {
+	ret := _m.Called(ctx, user, link)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UntrackLink")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64, string) error); ok {
-		r0 = rf(user, link)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, user, link)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -342,15 +752,16 @@ type UserRepo_UntrackLink_Call struct {
 }
 
 // UntrackLink is a helper method to define mock.On call
+//   - ctx context.Context
 //   - user int64
 //   - link string
-func (_e *UserRepo_Expecter) UntrackLink(user interface{}, link interface{}) *UserRepo_UntrackLink_Call {
-	return &UserRepo_UntrackLink_Call{Call: _e.mock.On("UntrackLink", user, link)}
+func (_e *UserRepo_Expecter) UntrackLink(ctx interface{}, user interface{}, link interface{}) *UserRepo_UntrackLink_Call {
+	return &UserRepo_UntrackLink_Call{Call: _e.mock.On("UntrackLink", ctx, user, link)}
 }
 
-func (_c *UserRepo_UntrackLink_Call) Run(run func(user int64, link string)) *UserRepo_UntrackLink_Call {
+func (_c *UserRepo_UntrackLink_Call) Run(run func(ctx context.Context, user int64, link string)) *UserRepo_UntrackLink_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int64), args[1].(string))
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
 	})
 	return _c
 }
@@ -360,14 +771,14 @@ func (_c *UserRepo_UntrackLink_Call) Return(_a0 error) *UserRepo_UntrackLink_Cal
 	return _c
 }
 
-func (_c *UserRepo_UntrackLink_Call) RunAndReturn(run func(int64, string) error) *UserRepo_UntrackLink_Call {
+func (_c *UserRepo_UntrackLink_Call) RunAndReturn(run func(context.Context, int64, string) error) *UserRepo_UntrackLink_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UserExist provides a mock function with given fields: UserID
-func (_m *UserRepo) UserExist(UserID int64) (bool, error) {
-	ret := _m.Called(UserID)
+// UserExist provides a mock function with given fields: ctx, UserID
+func (_m *UserRepo) UserExist(ctx context.Context, UserID int64) (bool, error) {
+	ret := _m.Called(ctx, UserID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UserExist")
@@ -375,17 +786,17 @@ func (_m *UserRepo) UserExist(UserID int64) (bool, error) {
 
 	var r0 bool
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int64) (bool, error)); ok {
-		return rf(UserID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (bool, error)); ok {
+		return rf(ctx, UserID)
 	}
-	if rf, ok := ret.Get(0).(func(int64) bool); ok {
-		r0 = rf(UserID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) bool); ok {
+		r0 = rf(ctx, UserID)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
-	if rf, ok := ret.Get(1).(func(int64) error); ok {
-		r1 = rf(UserID)
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, UserID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -399,14 +810,15 @@ type UserRepo_UserExist_Call struct {
 }
 
 // UserExist is a helper method to define mock.On call
+//   - ctx context.Context
 //   - UserID int64
-func (_e *UserRepo_Expecter) UserExist(UserID interface{}) *UserRepo_UserExist_Call {
-	return &UserRepo_UserExist_Call{Call: _e.mock.On("UserExist", UserID)}
+func (_e *UserRepo_Expecter) UserExist(ctx interface{}, UserID interface{}) *UserRepo_UserExist_Call {
+	return &UserRepo_UserExist_Call{Call: _e.mock.On("UserExist", ctx, UserID)}
 }
 
-func (_c *UserRepo_UserExist_Call) Run(run func(UserID int64)) *UserRepo_UserExist_Call {
+func (_c *UserRepo_UserExist_Call) Run(run func(ctx context.Context, UserID int64)) *UserRepo_UserExist_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int64))
+		run(args[0].(context.Context), args[1].(int64))
 	})
 	return _c
 }
@@ -416,14 +828,14 @@ func (_c *UserRepo_UserExist_Call) Return(_a0 bool, _a1 error) *UserRepo_UserExi
 	return _c
 }
 
-func (_c *UserRepo_UserExist_Call) RunAndReturn(run func(int64) (bool, error)) *UserRepo_UserExist_Call {
+func (_c *UserRepo_UserExist_Call) RunAndReturn(run func(context.Context, int64) (bool, error)) *UserRepo_UserExist_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UserTrackLink provides a mock function with given fields: userID, URL
-func (_m *UserRepo) UserTrackLink(userID int64, URL string) (bool, error) {
-	ret := _m.Called(userID, URL)
+// UserTrackLink provides a mock function with given fields: ctx, userID, URL
+func (_m *UserRepo) UserTrackLink(ctx context.Context, userID int64, URL string) (bool, error) {
+	ret := _m.Called(ctx, userID, URL)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UserTrackLink")
@@ -431,17 +843,17 @@ func (_m *UserRepo) UserTrackLink(userID int64, URL string) (bool, error) {
 
 	var r0 bool
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int64, string) (bool, error)); ok {
-		return rf(userID, URL)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) (bool, error)); ok {
+		return rf(ctx, userID, URL)
 	}
-	if rf, ok := ret.Get(0).(func(int64, string) bool); ok {
-		r0 = rf(userID, URL)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) bool); ok {
+		r0 = rf(ctx, userID, URL)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
-	if rf, ok := ret.Get(1).(func(int64, string) error); ok {
-		r1 = rf(userID, URL)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, userID, URL)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -455,15 +867,16 @@ type UserRepo_UserTrackLink_Call struct {
 }
 
 // UserTrackLink is a helper method to define mock.On call
+//   - ctx context.Context
 //   - userID int64
 //   - URL string
-func (_e *UserRepo_Expecter) UserTrackLink(userID interface{}, URL interface{}) *UserRepo_UserTrackLink_Call {
-	return &UserRepo_UserTrackLink_Call{Call: _e.mock.On("UserTrackLink", userID, URL)}
+func (_e *UserRepo_Expecter) UserTrackLink(ctx interface{}, userID interface{}, URL interface{}) *UserRepo_UserTrackLink_Call {
+	return &UserRepo_UserTrackLink_Call{Call: _e.mock.On("UserTrackLink", ctx, userID, URL)}
 }
 
-func (_c *UserRepo_UserTrackLink_Call) Run(run func(userID int64, URL string)) *UserRepo_UserTrackLink_Call {
+func (_c *UserRepo_UserTrackLink_Call) Run(run func(ctx context.Context, userID int64, URL string)) *UserRepo_UserTrackLink_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int64), args[1].(string))
+		run(args[0].(context.Context), args[1].(int64), args[2].(string))
 	})
 	return _c
 }
@@ -473,14 +886,73 @@ func (_c *UserRepo_UserTrackLink_Call) Return(_a0 bool, _a1 error) *UserRepo_Use
 	return _c
 }
 
-func (_c *UserRepo_UserTrackLink_Call) RunAndReturn(run func(int64, string) (bool, error)) *UserRepo_UserTrackLink_Call {
+func (_c *UserRepo_UserTrackLink_Call) RunAndReturn(run func(context.Context, int64, string) (bool, error)) *UserRepo_UserTrackLink_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UsersWhoTrackLink provides a mock function with given fields: linkID
-func (_m *UserRepo) UsersWhoTrackLink(linkID int64) ([]int64, error) {
-	ret := _m.Called(linkID)
+// UsersFilters provides a mock function with given fields: ctx, linkID
+func (_m *UserRepo) UsersFilters(ctx context.Context, linkID int64) (map[int64][]string, error) {
+	ret := _m.Called(ctx, linkID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UsersFilters")
+	}
+
+	var r0 map[int64][]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) (map[int64][]string, error)); ok {
+		return rf(ctx, linkID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64) map[int64][]string); ok {
+		r0 = rf(ctx, linkID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int64][]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, linkID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UserRepo_UsersFilters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UsersFilters'
+type UserRepo_UsersFilters_Call struct {
+	*mock.Call
+}
+
+// UsersFilters is a helper method to define mock.On call
+//   - ctx context.Context
+//   - linkID int64
+func (_e *UserRepo_Expecter) UsersFilters(ctx interface{}, linkID interface{}) *UserRepo_UsersFilters_Call {
+	return &UserRepo_UsersFilters_Call{Call: _e.mock.On("UsersFilters", ctx, linkID)}
+}
+
+func (_c *UserRepo_UsersFilters_Call) Run(run func(ctx context.Context, linkID int64)) *UserRepo_UsersFilters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int64))
+	})
+	return _c
+}
+
+func (_c *UserRepo_UsersFilters_Call) Return(_a0 map[int64][]string, _a1 error) *UserRepo_UsersFilters_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *UserRepo_UsersFilters_Call) RunAndReturn(run func(context.Context, int64) (map[int64][]string, error)) *UserRepo_UsersFilters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UsersWhoTrackLink provides a mock function with given fields: ctx, linkID
+func (_m *UserRepo) UsersWhoTrackLink(ctx context.Context, linkID int64) ([]int64, error) {
+	ret := _m.Called(ctx, linkID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UsersWhoTrackLink")
@@ -488,19 +960,19 @@ func (_m *UserRepo) UsersWhoTrackLink(linkID int64) ([]int64, error) {
 
 	var r0 []int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(int64) ([]int64, error)); ok {
-		return rf(linkID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) ([]int64, error)); ok {
+		return rf(ctx, linkID)
 	}
-	if rf, ok := ret.Get(0).(func(int64) []int64); ok {
-		r0 = rf(linkID)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []int64); ok {
+		r0 = rf(ctx, linkID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]int64)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(int64) error); ok {
-		r1 = rf(linkID)
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, linkID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -514,14 +986,15 @@ type UserRepo_UsersWhoTrackLink_Call struct {
 }
 
 // UsersWhoTrackLink is a helper method to define mock.On call
+//   - ctx context.Context
 //   - linkID int64
-func (_e *UserRepo_Expecter) UsersWhoTrackLink(linkID interface{}) *UserRepo_UsersWhoTrackLink_Call {
-	return &UserRepo_UsersWhoTrackLink_Call{Call: _e.mock.On("UsersWhoTrackLink", linkID)}
+func (_e *UserRepo_Expecter) UsersWhoTrackLink(ctx interface{}, linkID interface{}) *UserRepo_UsersWhoTrackLink_Call {
+	return &UserRepo_UsersWhoTrackLink_Call{Call: _e.mock.On("UsersWhoTrackLink", ctx, linkID)}
 }
 
-func (_c *UserRepo_UsersWhoTrackLink_Call) Run(run func(linkID int64)) *UserRepo_UsersWhoTrackLink_Call {
+func (_c *UserRepo_UsersWhoTrackLink_Call) Run(run func(ctx context.Context, linkID int64)) *UserRepo_UsersWhoTrackLink_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(int64))
+		run(args[0].(context.Context), args[1].(int64))
 	})
 	return _c
 }
@@ -531,7 +1004,7 @@ func (_c *UserRepo_UsersWhoTrackLink_Call) Return(_a0 []int64, _a1 error) *UserR
 	return _c
 }
 
-func (_c *UserRepo_UsersWhoTrackLink_Call) RunAndReturn(run func(int64) ([]int64, error)) *UserRepo_UsersWhoTrackLink_Call {
+func (_c *UserRepo_UsersWhoTrackLink_Call) RunAndReturn(run func(context.Context, int64) ([]int64, error)) *UserRepo_UsersWhoTrackLink_Call {
 	_c.Call.Return(run)
 	return _c
 }
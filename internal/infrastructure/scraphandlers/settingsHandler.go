@@ -0,0 +1,235 @@
+package scraphandlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"linkTraccer/internal/domain/dto"
+	"linkTraccer/internal/domain/i18n"
+	"linkTraccer/internal/domain/scrapper"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+type SettingsRepo interface {
+	UserExist(ctx context.Context, userID scrapper.User) (bool, error)
+	SetQuietHours(ctx context.Context, user scrapper.User, start, end string) error
+	DisableQuietHours(ctx context.Context, user scrapper.User) error
+	SetTimezone(ctx context.Context, user scrapper.User, timezone string) error
+	SetLanguage(ctx context.Context, user scrapper.User, lang i18n.Lang) error
+}
+
+type SettingsHandler struct {
+	repo SettingsRepo
+	log  *slog.Logger
+}
+
+func NewSettingsHandler(repo SettingsRepo, log *slog.Logger) *SettingsHandler {
+	return &SettingsHandler{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// HandleQuietHoursChanges включает тихие часы (PUT) или выключает их (DELETE).
+func (s *SettingsHandler) HandleQuietHoursChanges(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	if r.Method != http.MethodPut && r.Method != http.MethodDelete {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	settings := &scrapper.QuietHoursSettings{}
+
+	if r.Method == http.MethodPut {
+		if err := json.NewDecoder(r.Body).Decode(settings); err != nil {
+			s.apiErrToResponse(w, dto.APIErrBadJSON, http.StatusBadRequest)
+
+			return
+		}
+
+		if !validQuietHours(settings) {
+			s.apiErrToResponse(w, dto.APIErrBadQuietHours, http.StatusBadRequest)
+
+			return
+		}
+	}
+
+	userID, ok := s.registeredChat(w, r)
+	if !ok {
+		return
+	}
+
+	var err error
+
+	if r.Method == http.MethodPut {
+		err = s.repo.SetQuietHours(r.Context(), userID, settings.Start, settings.End)
+	} else {
+		err = s.repo.DisableQuietHours(r.Context(), userID)
+	}
+
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+
+		s.log.Error(fmt.Sprintf("ошибка в БД при изменении тихих часов пользователя %d", userID), "err", err.Error())
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *SettingsHandler) HandleTimezoneChanges(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	if r.Method != http.MethodPut {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	settings := &scrapper.TimezoneSettings{}
+
+	if err := json.NewDecoder(r.Body).Decode(settings); err != nil {
+		s.apiErrToResponse(w, dto.APIErrBadJSON, http.StatusBadRequest)
+
+		return
+	}
+
+	if !validTimezone(settings.Timezone) {
+		s.apiErrToResponse(w, dto.APIErrBadTimezone, http.StatusBadRequest)
+
+		return
+	}
+
+	userID, ok := s.registeredChat(w, r)
+	if !ok {
+		return
+	}
+
+	if err := s.repo.SetTimezone(r.Context(), userID, settings.Timezone); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+
+		s.log.Error(fmt.Sprintf("ошибка в БД при изменении часового пояса пользователя %d", userID),
+			"err", err.Error())
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// HandleLanguageChanges меняет язык, на котором пользователю приходят уведомления.
+func (s *SettingsHandler) HandleLanguageChanges(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+
+	if r.Method != http.MethodPut {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	settings := &scrapper.LanguageSettings{}
+
+	if err := json.NewDecoder(r.Body).Decode(settings); err != nil {
+		s.apiErrToResponse(w, dto.APIErrBadJSON, http.StatusBadRequest)
+
+		return
+	}
+
+	if !i18n.Supported(settings.Language) {
+		s.apiErrToResponse(w, dto.APIErrBadLanguage, http.StatusBadRequest)
+
+		return
+	}
+
+	userID, ok := s.registeredChat(w, r)
+	if !ok {
+		return
+	}
+
+	if err := s.repo.SetLanguage(r.Context(), userID, settings.Language); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+
+		s.log.Error(fmt.Sprintf("ошибка в БД при изменении языка пользователя %d", userID),
+			"err", err.Error())
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// registeredChat сам пишет ответ, если чат не зарегистрирован.
+func (s *SettingsHandler) registeredChat(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	userID, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+
+	if err != nil {
+		s.apiErrToResponse(w, dto.APIErrIDNotNum, http.StatusBadRequest)
+
+		return 0, false
+	}
+
+	if userID < 0 {
+		s.apiErrToResponse(w, dto.APIErrNegativeID, http.StatusBadRequest)
+
+		return 0, false
+	}
+
+	userExist, err := s.repo.UserExist(r.Context(), userID)
+
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+
+		s.log.Error("ошибка в БД при проверке пользователя", "err", err.Error())
+
+		return 0, false
+	}
+
+	if !userExist {
+		s.apiErrToResponse(w, dto.APIErrUserNotRegistered, http.StatusNotFound)
+
+		return 0, false
+	}
+
+	return userID, true
+}
+
+func validQuietHours(settings *scrapper.QuietHoursSettings) bool {
+	start, err := time.Parse(scrapper.DigestTimeLayout, settings.Start)
+	if err != nil {
+		return false
+	}
+
+	end, err := time.Parse(scrapper.DigestTimeLayout, settings.End)
+	if err != nil {
+		return false
+	}
+
+	return !start.Equal(end)
+}
+
+func validTimezone(timezone string) bool {
+	if timezone == "" || timezone == "Local" {
+		return false
+	}
+
+	_, err := time.LoadLocation(timezone)
+
+	return err == nil
+}
+
+func (s *SettingsHandler) apiErrToResponse(w http.ResponseWriter, errAPI *dto.APIErrResponse, statusCode int) {
+	w.Header().Set(contentType, jsonType)
+	w.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(w).Encode(errAPI); err != nil {
+		s.log.Error("ошибка при формировании JSON APIErrResponse", "err", err.Error())
+	}
+}
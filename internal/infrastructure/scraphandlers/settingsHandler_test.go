@@ -0,0 +1,309 @@
+package scraphandlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"linkTraccer/internal/domain/dto"
+	"linkTraccer/internal/infrastructure/scraphandlers"
+	"linkTraccer/internal/infrastructure/scraphandlers/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSettingsHandler_HandleQuietHoursChanges(t *testing.T) {
+	repoWithErr := mocks.NewSettingsRepo(t)
+	repoWithoutUsers := mocks.NewSettingsRepo(t)
+	repoWithUsers := mocks.NewSettingsRepo(t)
+	repoWithSetErr := mocks.NewSettingsRepo(t)
+
+	repoWithErr.On("UserExist", mock.Anything, mock.Anything).Return(false, errRepo)
+	repoWithoutUsers.On("UserExist", mock.Anything, mock.Anything).Return(false, nil)
+	repoWithUsers.On("UserExist", mock.Anything, mock.Anything).Return(true, nil)
+	repoWithUsers.On("SetQuietHours", mock.Anything, int64(1), "23:00", "08:00").Return(nil)
+	repoWithUsers.On("DisableQuietHours", mock.Anything, int64(1)).Return(nil)
+	repoWithSetErr.On("UserExist", mock.Anything, mock.Anything).Return(true, nil)
+	repoWithSetErr.On("SetQuietHours", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errRepo)
+
+	type TestCase struct {
+		name           string
+		userID         string
+		body           string
+		repo           scraphandlers.SettingsRepo
+		httpMethod     string
+		expectedStatus int
+		expectedBody   *dto.APIErrResponse
+	}
+
+	tests := []TestCase{
+		{
+			name:           "обрабатываем метод, который не поддерживается",
+			userID:         "1",
+			httpMethod:     http.MethodPost,
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:           "пришел некорректный JSON",
+			userID:         "1",
+			body:           "{start:",
+			httpMethod:     http.MethodPut,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   dto.APIErrBadJSON,
+		},
+		{
+			name:           "некорректное время начала тихих часов",
+			userID:         "1",
+			body:           `{"start":"24:00","end":"08:00"}`,
+			httpMethod:     http.MethodPut,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   dto.APIErrBadQuietHours,
+		},
+		{
+			name:           "начало и конец тихих часов совпадают",
+			userID:         "1",
+			body:           `{"start":"08:00","end":"08:00"}`,
+			httpMethod:     http.MethodPut,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   dto.APIErrBadQuietHours,
+		},
+		{
+			name:           "передаем отрицательное id",
+			userID:         "-5",
+			httpMethod:     http.MethodDelete,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   dto.APIErrNegativeID,
+		},
+		{
+			name:           "ошибка в БД при проверке пользователя",
+			userID:         "1",
+			repo:           repoWithErr,
+			httpMethod:     http.MethodDelete,
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "пользователь не зарегистрирован",
+			userID:         "1",
+			body:           `{"start":"23:00","end":"08:00"}`,
+			repo:           repoWithoutUsers,
+			httpMethod:     http.MethodPut,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   dto.APIErrUserNotRegistered,
+		},
+		{
+			name:           "ошибка в БД при сохранении тихих часов",
+			userID:         "1",
+			body:           `{"start":"23:00","end":"08:00"}`,
+			repo:           repoWithSetErr,
+			httpMethod:     http.MethodPut,
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "включаем тихие часы",
+			userID:         "1",
+			body:           `{"start":"23:00","end":"08:00"}`,
+			repo:           repoWithUsers,
+			httpMethod:     http.MethodPut,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "выключаем тихие часы",
+			userID:         "1",
+			repo:           repoWithUsers,
+			httpMethod:     http.MethodDelete,
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(test.httpMethod, "", bytes.NewBufferString(test.body))
+
+		r = mux.SetURLVars(r, map[string]string{"id": test.userID})
+
+		settingsHandler := scraphandlers.NewSettingsHandler(test.repo, logger)
+
+		settingsHandler.HandleQuietHoursChanges(w, r)
+
+		assert.Equal(t, test.expectedStatus, w.Code, test.name)
+
+		if test.expectedBody != nil {
+			unmarshalBody := &dto.APIErrResponse{}
+
+			err := json.Unmarshal(w.Body.Bytes(), unmarshalBody)
+
+			assert.NoError(t, err, "ошибка при анмаршалинге тела ответа")
+			assert.Equal(t, test.expectedBody, unmarshalBody)
+		} else {
+			assert.Empty(t, w.Body.String())
+		}
+	}
+}
+
+func TestSettingsHandler_HandleTimezoneChanges(t *testing.T) {
+	repoWithUsers := mocks.NewSettingsRepo(t)
+	repoWithSetErr := mocks.NewSettingsRepo(t)
+
+	repoWithUsers.On("UserExist", mock.Anything, mock.Anything).Return(true, nil)
+	repoWithUsers.On("SetTimezone", mock.Anything, int64(1), "Asia/Yekaterinburg").Return(nil)
+	repoWithSetErr.On("UserExist", mock.Anything, mock.Anything).Return(true, nil)
+	repoWithSetErr.On("SetTimezone", mock.Anything, mock.Anything, mock.Anything).Return(errRepo)
+
+	type TestCase struct {
+		name           string
+		body           string
+		repo           scraphandlers.SettingsRepo
+		httpMethod     string
+		expectedStatus int
+		expectedBody   *dto.APIErrResponse
+	}
+
+	tests := []TestCase{
+		{
+			name:           "обрабатываем метод, который не поддерживается",
+			httpMethod:     http.MethodDelete,
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:           "пришел некорректный JSON",
+			body:           "{timezone:",
+			httpMethod:     http.MethodPut,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   dto.APIErrBadJSON,
+		},
+		{
+			name:           "неизвестный часовой пояс",
+			body:           `{"timezone":"Mars/Olympus"}`,
+			httpMethod:     http.MethodPut,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   dto.APIErrBadTimezone,
+		},
+		{
+			name:           "пустой часовой пояс",
+			body:           `{"timezone":""}`,
+			httpMethod:     http.MethodPut,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   dto.APIErrBadTimezone,
+		},
+		{
+			name:           "ошибка в БД при сохранении часового пояса",
+			body:           `{"timezone":"Asia/Yekaterinburg"}`,
+			repo:           repoWithSetErr,
+			httpMethod:     http.MethodPut,
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "часовой пояс сохранен",
+			body:           `{"timezone":"Asia/Yekaterinburg"}`,
+			repo:           repoWithUsers,
+			httpMethod:     http.MethodPut,
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(test.httpMethod, "", bytes.NewBufferString(test.body))
+
+		r = mux.SetURLVars(r, map[string]string{"id": "1"})
+
+		settingsHandler := scraphandlers.NewSettingsHandler(test.repo, logger)
+
+		settingsHandler.HandleTimezoneChanges(w, r)
+
+		assert.Equal(t, test.expectedStatus, w.Code, test.name)
+
+		if test.expectedBody != nil {
+			unmarshalBody := &dto.APIErrResponse{}
+
+			err := json.Unmarshal(w.Body.Bytes(), unmarshalBody)
+
+			assert.NoError(t, err, "ошибка при анмаршалинге тела ответа")
+			assert.Equal(t, test.expectedBody, unmarshalBody)
+		} else {
+			assert.Empty(t, w.Body.String())
+		}
+	}
+}
+
+func TestSettingsHandler_HandleLanguageChanges(t *testing.T) {
+	repoWithUsers := mocks.NewSettingsRepo(t)
+	repoWithSetErr := mocks.NewSettingsRepo(t)
+
+	repoWithUsers.On("UserExist", mock.Anything, mock.Anything).Return(true, nil)
+	repoWithUsers.On("SetLanguage", mock.Anything, int64(1), "en").Return(nil)
+	repoWithSetErr.On("UserExist", mock.Anything, mock.Anything).Return(true, nil)
+	repoWithSetErr.On("SetLanguage", mock.Anything, mock.Anything, mock.Anything).Return(errRepo)
+
+	type TestCase struct {
+		name           string
+		body           string
+		repo           scraphandlers.SettingsRepo
+		httpMethod     string
+		expectedStatus int
+		expectedBody   *dto.APIErrResponse
+	}
+
+	tests := []TestCase{
+		{
+			name:           "обрабатываем метод, который не поддерживается",
+			httpMethod:     http.MethodDelete,
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:           "пришел некорректный JSON",
+			body:           "{language:",
+			httpMethod:     http.MethodPut,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   dto.APIErrBadJSON,
+		},
+		{
+			name:           "неподдерживаемый язык",
+			body:           `{"language":"de"}`,
+			httpMethod:     http.MethodPut,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   dto.APIErrBadLanguage,
+		},
+		{
+			name:           "ошибка в БД при сохранении языка",
+			body:           `{"language":"en"}`,
+			repo:           repoWithSetErr,
+			httpMethod:     http.MethodPut,
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "язык сохранен",
+			body:           `{"language":"en"}`,
+			repo:           repoWithUsers,
+			httpMethod:     http.MethodPut,
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		r, _ := http.NewRequest(test.httpMethod, "", bytes.NewBufferString(test.body))
+
+		r = mux.SetURLVars(r, map[string]string{"id": "1"})
+
+		settingsHandler := scraphandlers.NewSettingsHandler(test.repo, logger)
+
+		settingsHandler.HandleLanguageChanges(w, r)
+
+		assert.Equal(t, test.expectedStatus, w.Code, test.name)
+
+		if test.expectedBody != nil {
+			unmarshalBody := &dto.APIErrResponse{}
+
+			err := json.Unmarshal(w.Body.Bytes(), unmarshalBody)
+
+			assert.NoError(t, err, "ошибка при анмаршалинге тела ответа")
+			assert.Equal(t, test.expectedBody, unmarshalBody)
+		} else {
+			assert.Empty(t, w.Body.String())
+		}
+	}
+}
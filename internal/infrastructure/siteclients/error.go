@@ -1,8 +1,10 @@
 package siteclients
 
 import (
+	"errors"
 	"fmt"
 	"linkTraccer/internal/domain/scrapper"
+	"time"
 )
 
 type Link = scrapper.Link
@@ -49,3 +51,58 @@ func NewErrNetwork(client, link string, err error) *ErrNetwork {
 func (e *ErrNetwork) Error() string {
 	return e.err.Error()
 }
+
+func (e *ErrNetwork) Unwrap() error {
+	return e.err
+}
+
+// ErrRateLimited - API ограничил частоту запросов к хосту до RetryAt.
+type ErrRateLimited struct {
+	host    string
+	retryAt time.Time
+}
+
+func NewErrRateLimited(host string, retryAt time.Time) *ErrRateLimited {
+	return &ErrRateLimited{
+		host:    host,
+		retryAt: retryAt,
+	}
+}
+
+func (e *ErrRateLimited) Error() string {
+	return fmt.Sprintf("хост %s ограничил частоту запросов до %s", e.host, e.retryAt.Format(time.RFC3339))
+}
+
+func (e *ErrRateLimited) RetryAt() time.Time {
+	return e.retryAt
+}
+
+// ErrBreakerOpen - размыкатель хоста открыт до RetryAt.
+type ErrBreakerOpen struct {
+	host    string
+	retryAt time.Time
+}
+
+func NewErrBreakerOpen(host string, retryAt time.Time) *ErrBreakerOpen {
+	return &ErrBreakerOpen{
+		host:    host,
+		retryAt: retryAt,
+	}
+}
+
+func (e *ErrBreakerOpen) Error() string {
+	return fmt.Sprintf("хост %s недоступен, запросы к нему приостановлены до %s", e.host, e.retryAt.Format(time.RFC3339))
+}
+
+func (e *ErrBreakerOpen) RetryAt() time.Time {
+	return e.retryAt
+}
+
+// Paused сообщает, что запрос к хосту не отправлен, потому что хост приостановлен.
+func Paused(err error) bool {
+	var rateLimited *ErrRateLimited
+
+	var breakerOpen *ErrBreakerOpen
+
+	return errors.As(err, &rateLimited) || errors.As(err, &breakerOpen)
+}
@@ -1,6 +1,7 @@
 package github
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"linkTraccer/internal/domain/scrapper"
@@ -18,9 +19,8 @@ const (
 	repoCreaterInd = 1
 	repoNameInd    = 2
 	emptyArg       = ""
-	issue          = "Issue"
-	pullRequest    = "Pull Request"
 	maxTitleLen    = 200
+	maxExcerptLen  = 200
 )
 
 type HTTPClient interface {
@@ -47,7 +47,22 @@ func NewClient(host, token string, client HTTPClient) *GitClient {
 	}
 }
 
-func (git *GitClient) CanTrack(link scrapper.Link) bool {
+func (git *GitClient) Host() string {
+	return git.host
+}
+
+// Supports проверяет ссылку без запросов к API.
+func (git *GitClient) Supports(link scrapper.Link) bool {
+	parsedLink, err := url.Parse(link)
+	if err != nil {
+		return false
+	}
+
+	return git.StaticLinkCheck(parsedLink, strings.Split(parsedLink.Path, "/"))
+}
+
+// CanTrack, пока хост приостановлен, проверяет ссылку только по виду.
+func (git *GitClient) CanTrack(ctx context.Context, link scrapper.Link) bool {
 	parsedLink, err := url.Parse(link)
 
 	if err != nil {
@@ -63,7 +78,7 @@ func (git *GitClient) CanTrack(link scrapper.Link) bool {
 	q := git.makeQueryParams(pathArgs, time.Now().Truncate(time.Second))
 	reqURL := git.makeRequestURL(q)
 
-	req, err := http.NewRequest(http.MethodGet, reqURL.String(), http.NoBody)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), http.NoBody)
 
 	if err != nil {
 		return false
@@ -74,7 +89,7 @@ func (git *GitClient) CanTrack(link scrapper.Link) bool {
 	resp, err := git.client.Do(req)
 
 	if err != nil {
-		return false
+		return siteclients.Paused(err)
 	}
 
 	defer resp.Body.Close()
@@ -96,9 +111,9 @@ func (git *GitClient) StaticLinkCheck(parsedLink *url.URL, pathArgs []string) bo
 	return true
 }
 
-func (git *GitClient) LinkUpdates(link scrapper.Link, updatesSince time.Time) (scrapper.LinkUpdates, error) {
+// LinkUpdates отсеивает по идентификатору элементы из секунды курсора: поиск GitHub точен до секунды.
+func (git *GitClient) LinkUpdates(ctx context.Context, link scrapper.Link, cursors *scrapper.LinkCursors) (scrapper.LinkUpdates, error) {
 	parsedLink, err := url.Parse(link)
-	updatesSince = updatesSince.Add(-time.Hour * 3)
 
 	if err != nil {
 		return nil, fmt.Errorf("в клиете %s при парсинге ссылки произошла ошибка: %w", clientName, err)
@@ -110,9 +125,14 @@ func (git *GitClient) LinkUpdates(link scrapper.Link, updatesSince time.Time) (s
 		return nil, siteclients.NewErrClientCantTrackLink(link, clientName)
 	}
 
+	updatesSince := cursors.Cursor(scrapper.IssueUpdate).ItemTime
+	if prSince := cursors.Cursor(scrapper.PRUpdate).ItemTime; prSince.Before(updatesSince) {
+		updatesSince = prSince
+	}
+
 	q := git.makeQueryParams(pathArgs, updatesSince)
 	reqURL := git.makeRequestURL(q)
-	req, err := http.NewRequest(http.MethodGet, reqURL.String(), http.NoBody)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), http.NoBody)
 
 	if err != nil {
 		return nil, fmt.Errorf("в клиете %s при формировании запроса произошла ошибка: %w", clientName, err)
@@ -138,28 +158,34 @@ func (git *GitClient) LinkUpdates(link scrapper.Link, updatesSince time.Time) (s
 		return nil, fmt.Errorf("в клиете %s при парсиге ответа произошла ошибка: %w", clientName, err)
 	}
 
-	return git.gitUpdatesToLinkUpdates(gitUpdates), nil
+	return git.gitUpdatesToLinkUpdates(gitUpdates, cursors), nil
 }
 
-func (git *GitClient) gitUpdatesToLinkUpdates(gitUpdates *scrapper.GitUpdates) scrapper.LinkUpdates {
+func (git *GitClient) gitUpdatesToLinkUpdates(gitUpdates *scrapper.GitUpdates, cursors *scrapper.LinkCursors) scrapper.LinkUpdates {
 	var updateType string
 
 	linkUpdates := make([]*scrapper.LinkUpdate, 0, gitUpdates.Count)
 
 	for _, update := range gitUpdates.Updates {
 		if update.PullRequest.URL == "" {
-			updateType = issue
+			updateType = scrapper.IssueUpdate
 		} else {
-			updateType = pullRequest
+			updateType = scrapper.PRUpdate
 		}
 
-		createdTimeToMsk := update.CreatedTime.Add(time.Hour * 3).Format("15:04:05 02-01-2006")
+		if !cursors.Cursor(updateType).Behind(update.CreatedTime, update.ID) {
+			continue
+		}
 
 		linkUpdates = append(linkUpdates, &scrapper.LinkUpdate{
-			Header:     updateType,
-			UserName:   update.GitUser.Login,
-			CreateTime: createdTimeToMsk,
-			Preview:    update.Title[:min(len(update.Title), maxTitleLen)],
+			Site:     scrapper.GitHubSite,
+			Type:     updateType,
+			ItemID:   update.ID,
+			ItemURL:  update.HTMLURL,
+			Author:   update.GitUser.Login,
+			ItemTime: update.CreatedTime.UTC(),
+			Title:    siteclients.Truncate(update.Title, maxTitleLen),
+			Excerpt:  siteclients.Truncate(update.Body, maxExcerptLen),
 		})
 	}
 
@@ -177,7 +203,7 @@ func (git *GitClient) makeQueryParams(pathArgs []string, updatesSince time.Time)
 }
 
 func makeQueryString(repoAuthor, repo string, sinceTime time.Time) string {
-	return "repo:" + repoAuthor + "/" + repo + " " + "created:>" + sinceTime.Format("2006-01-02T15:04:05Z")
+	return "repo:" + repoAuthor + "/" + repo + " " + "created:>=" + sinceTime.UTC().Format("2006-01-02T15:04:05Z")
 }
 
 func (git *GitClient) makeRequestURL(q url.Values) *url.URL {
@@ -2,9 +2,11 @@ package github_test
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"io"
 	"linkTraccer/internal/domain/scrapper"
+	"linkTraccer/internal/infrastructure/siteclients"
 	"linkTraccer/internal/infrastructure/siteclients/github"
 	"linkTraccer/internal/infrastructure/siteclients/mocks"
 	"net/http"
@@ -23,7 +25,9 @@ const (
 )
 
 var (
-	jsonData   = []byte(`{"items" : [{ "created_at" : "2025-02-25T11:39:14Z"}]}`)
+	jsonData = []byte(`{"items" : [{ "id" : 2, "created_at" : "2025-02-25T11:39:14Z", "title" : "new feature",
+		"html_url" : "https://github.com/orlov4919/test/issues/2", "user" : { "login" : "orlov4919" }}]}`)
+	itemTime   = time.Date(2025, 2, 25, 11, 39, 14, 0, time.UTC)
 	randomData = []byte("abcdsdfsdf")
 	errTest    = errors.New("произошел таймаут")
 )
@@ -138,7 +142,7 @@ func TestGitClient_CanTrack(t *testing.T) {
 	for _, test := range tests {
 		gitClient := github.NewClient(testHost, testToken, test.client)
 
-		assert.Equal(t, test.correct, gitClient.CanTrack(test.link))
+		assert.Equal(t, test.correct, gitClient.CanTrack(context.Background(), test.link))
 	}
 }
 
@@ -147,6 +151,7 @@ func TestGitClient_LinkUpdates(t *testing.T) {
 	clientWithErr := mocks.NewHTTPClient(t)
 	clientWithWrongJSON := mocks.NewHTTPClient(t)
 	clientWithOK := mocks.NewHTTPClient(t)
+	clientWithSeenItem := mocks.NewHTTPClient(t)
 
 	clientWith404.On("Do", mock.Anything).
 		Return(&http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(nil)}, nil)
@@ -156,15 +161,20 @@ func TestGitClient_LinkUpdates(t *testing.T) {
 		Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBuffer(randomData))}, nil)
 	clientWithOK.On("Do", mock.Anything).
 		Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBuffer(jsonData))}, nil)
+	clientWithSeenItem.On("Do", mock.Anything).
+		Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBuffer(jsonData))}, nil)
 
 	type testCase struct {
 		name    string
 		link    Link
 		client  github.HTTPClient
+		cursors *scrapper.LinkCursors
 		correct bool
 		updates scrapper.LinkUpdates
 	}
 
+	cursors := &scrapper.LinkCursors{Since: itemTime.Add(-time.Hour)}
+
 	tests := []testCase{
 		{
 			name:    "Произошла ошибка при парсинге ссылки",
@@ -201,17 +211,37 @@ func TestGitClient_LinkUpdates(t *testing.T) {
 			name:    "Получение обновлений выполнено успешно",
 			link:    "https://github.com/orlov4919/test",
 			client:  clientWithOK,
+			cursors: cursors,
 			correct: true,
 			updates: scrapper.LinkUpdates{&scrapper.LinkUpdate{
-				CreateTime: "14:39:14 25-02-2025",
-				Header:     "Issue",
+				Site:     scrapper.GitHubSite,
+				Type:     scrapper.IssueUpdate,
+				ItemID:   2,
+				ItemURL:  "https://github.com/orlov4919/test/issues/2",
+				Author:   "orlov4919",
+				ItemTime: itemTime,
+				Title:    "new feature",
+			}},
+		},
+		{
+			name:   "Issue уже обработан, курсор стоит на нем",
+			link:   "https://github.com/orlov4919/test",
+			client: clientWithSeenItem,
+			cursors: &scrapper.LinkCursors{ByType: map[scrapper.UpdateType]*scrapper.Cursor{
+				scrapper.IssueUpdate: {ItemID: 2, ItemTime: itemTime},
 			}},
+			correct: true,
+			updates: scrapper.LinkUpdates{},
 		},
 	}
 
 	for _, test := range tests {
+		if test.cursors == nil {
+			test.cursors = cursors
+		}
+
 		gitClient := github.NewClient(testHost, testToken, test.client)
-		updates, err := gitClient.LinkUpdates(test.link, time.Now())
+		updates, err := gitClient.LinkUpdates(context.Background(), test.link, test.cursors)
 
 		if test.correct {
 			assert.NoError(t, err)
@@ -221,3 +251,40 @@ func TestGitClient_LinkUpdates(t *testing.T) {
 		}
 	}
 }
+
+func TestGitClient_Supports(t *testing.T) {
+	gitClient := github.NewClient(testHost, testToken, mocks.NewHTTPClient(t))
+
+	assert.True(t, gitClient.Supports("https://github.com/orlov4919/test"), "ссылка проверяется без запросов к API")
+	assert.False(t, gitClient.Supports("https://gitehube.com/orlov4919/test"))
+	assert.False(t, gitClient.Supports("\nhttps://github.com/orlov4919/test"))
+}
+
+func TestGitClient_CanTrackPaused(t *testing.T) {
+	httpClient := mocks.NewHTTPClient(t)
+
+	httpClient.On("Do", mock.Anything).Return(nil, siteclients.NewErrBreakerOpen(testHost, time.Now().Add(time.Minute))).Once()
+
+	gitClient := github.NewClient(testHost, testToken, httpClient)
+
+	assert.True(t, gitClient.CanTrack(context.Background(), "https://github.com/orlov4919/test"),
+		"пока размыкатель хоста открыт, ссылка проверяется только по виду")
+}
+
+func TestGitClient_LinkUpdatesMultibyteText(t *testing.T) {
+	httpClient := mocks.NewHTTPClient(t)
+	title, body := strings.Repeat("ж", 250), strings.Repeat("🚀", 250)
+
+	httpClient.On("Do", mock.Anything).Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(
+		`{"items" : [{ "id" : 2, "created_at" : "2025-02-25T11:39:14Z", "title" : "` + title + `", "body" : "` + body +
+			`", "html_url" : "https://github.com/orlov4919/test/issues/2", "user" : { "login" : "orlov4919" }}]}`))}, nil)
+
+	gitClient := github.NewClient(testHost, testToken, httpClient)
+	updates, err := gitClient.LinkUpdates(context.Background(), "https://github.com/orlov4919/test",
+		&scrapper.LinkCursors{Since: itemTime.Add(-time.Hour)})
+
+	if assert.NoError(t, err) && assert.Len(t, updates, 1) {
+		assert.Equal(t, strings.Repeat("ж", 200), updates[0].Title, "заголовок обрезается по символам, а не по байтам")
+		assert.Equal(t, strings.Repeat("🚀", 200), updates[0].Excerpt)
+	}
+}
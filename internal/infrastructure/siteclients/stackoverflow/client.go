@@ -1,16 +1,20 @@
 package stackoverflow
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"html"
+	"io"
 	"linkTraccer/internal/domain/scrapper"
 	"linkTraccer/internal/infrastructure/siteclients"
 	"net/http"
 	"net/url"
 	"path"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/microcosm-cc/bluemonday"
@@ -40,16 +44,32 @@ const (
 	stackoverflowHost = "stackoverflow.com"
 )
 
+// Прямые ссылки на ответ и комментарий, сайт сам перенаправляет их на нужное место страницы вопроса.
+const (
+	answerURL  = "https://stackoverflow.com/a/%d"
+	commentURL = "https://stackoverflow.com/posts/comments/%d"
+)
+
 type HTTPClient interface {
 	Do(req *http.Request) (*http.Response, error)
 }
 
+// quotaWrapper - поля, которыми StackExchange просит притормозить.
+type quotaWrapper struct {
+	Backoff        int  `json:"backoff"`
+	QuotaRemaining *int `json:"quota_remaining"`
+}
+
+// StackClient не отправляет запросы, пока действует backoff или исчерпана квота.
 type StackClient struct {
 	scheme     string
 	basePath   string
 	host       string
 	client     HTTPClient
 	strCleaner func(s string) string
+
+	mu          sync.Mutex
+	pausedUntil time.Time
 }
 
 // при инициализации вводить api.stackexchange.com
@@ -64,7 +84,22 @@ func NewClient(host string, client HTTPClient, strCleaner func(string) string) *
 	}
 }
 
-func (stack *StackClient) CanTrack(link scrapper.Link) bool {
+func (stack *StackClient) Host() string {
+	return stack.host
+}
+
+// Supports проверяет ссылку без запросов к API.
+func (stack *StackClient) Supports(link scrapper.Link) bool {
+	parsedLink, err := url.Parse(link)
+	if err != nil {
+		return false
+	}
+
+	return stack.StaticLinkCheck(parsedLink, strings.Split(parsedLink.Path, "/"))
+}
+
+// CanTrack, пока хост приостановлен, проверяет ссылку только по виду.
+func (stack *StackClient) CanTrack(ctx context.Context, link scrapper.Link) bool {
 	parsedLink, err := url.Parse(link)
 
 	if err != nil {
@@ -77,11 +112,15 @@ func (stack *StackClient) CanTrack(link scrapper.Link) bool {
 		return false
 	}
 
+	if _, paused := stack.paused(); paused {
+		return true
+	}
+
 	q := url.Values{}
 	q.Add("site", "stackoverflow")
 
 	reqURL := stack.requestURL(pathArgs[indQuestionID], answers, q)
-	req, err := http.NewRequest(http.MethodGet, reqURL.String(), http.NoBody)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), http.NoBody)
 
 	if err != nil {
 		return false
@@ -90,7 +129,7 @@ func (stack *StackClient) CanTrack(link scrapper.Link) bool {
 	resp, err := stack.client.Do(req)
 
 	if err != nil {
-		return false
+		return siteclients.Paused(err)
 	}
 
 	defer resp.Body.Close()
@@ -118,11 +157,11 @@ func (stack *StackClient) StaticLinkCheck(parsedLink *url.URL, pathArgs []string
 	return true
 }
 
-func (stack *StackClient) LinkUpdates(link scrapper.Link, since time.Time) (scrapper.LinkUpdates, error) {
+// LinkUpdates отсеивает по идентификатору элементы из секунды курсора: fromdate включает границу.
+func (stack *StackClient) LinkUpdates(ctx context.Context, link scrapper.Link,
+	cursors *scrapper.LinkCursors) (scrapper.LinkUpdates, error) {
 	var questionTitle string
 
-	since = since.Add(-time.Hour * 4)
-
 	parsedLink, err := url.Parse(link)
 
 	if err != nil {
@@ -135,20 +174,28 @@ func (stack *StackClient) LinkUpdates(link scrapper.Link, since time.Time) (scra
 		return nil, siteclients.NewErrClientCantTrackLink(link, clientName)
 	}
 
-	newAnswers, err := stack.NewAnswers(pathArgs[indQuestionID], since)
+	newAnswers, err := stack.NewAnswers(ctx, pathArgs[indQuestionID], cursors.Cursor(scrapper.AnswerUpdate).ItemTime)
 
 	if err != nil {
 		return nil, fmt.Errorf("ошибка при получении новых ответов: %w", err)
 	}
 
-	newComments, err := stack.NewComments(pathArgs[indQuestionID], since)
+	newComments, err := stack.NewComments(ctx, pathArgs[indQuestionID], cursors.Cursor(scrapper.CommentUpdate).ItemTime)
 
 	if err != nil {
 		return nil, fmt.Errorf("ошибка при получении новых комментариев: %w", err)
 	}
 
+	newAnswers.Items = slices.DeleteFunc(newAnswers.Items, func(answer scrapper.StackAnswer) bool {
+		return !cursors.Cursor(scrapper.AnswerUpdate).Behind(time.Unix(answer.UpdateTime, 0), answer.ID)
+	})
+
+	newComments.Items = slices.DeleteFunc(newComments.Items, func(comment scrapper.StackComment) bool {
+		return !cursors.Cursor(scrapper.CommentUpdate).Behind(time.Unix(comment.UpdateTime, 0), comment.ID)
+	})
+
 	if len(newAnswers.Items) == 0 && len(newComments.Items) != 0 {
-		questionTitle, err = stack.getQuestionTitle(pathArgs)
+		questionTitle, err = stack.getQuestionTitle(ctx, pathArgs)
 	}
 
 	if len(newAnswers.Items) != 0 {
@@ -167,19 +214,27 @@ func (stack *StackClient) mergeUpdate(answers *scrapper.StackAnswers, comments *
 
 	for _, update := range answers.Items {
 		linkUpdates = append(linkUpdates, &scrapper.LinkUpdate{
-			Header:     title,
-			UserName:   update.Owner.UserName,
-			CreateTime: time.Unix(update.UpdateTime, 0).Format("15:04:05 02-01-2006"),
-			Preview:    stack.strCleaner(update.Body),
+			Site:     scrapper.StackOverflowSite,
+			Type:     scrapper.AnswerUpdate,
+			ItemID:   update.ID,
+			ItemURL:  fmt.Sprintf(answerURL, update.ID),
+			Author:   update.Owner.UserName,
+			ItemTime: time.Unix(update.UpdateTime, 0).UTC(),
+			Title:    title,
+			Excerpt:  stack.strCleaner(update.Body),
 		})
 	}
 
 	for _, update := range comments.Items {
 		linkUpdates = append(linkUpdates, &scrapper.LinkUpdate{
-			Header:     title,
-			UserName:   update.Owner.UserName,
-			CreateTime: time.Unix(update.UpdateTime, 0).Format("15:04:05 02-01-2006"),
-			Preview:    stack.strCleaner(update.Body),
+			Site:     scrapper.StackOverflowSite,
+			Type:     scrapper.CommentUpdate,
+			ItemID:   update.ID,
+			ItemURL:  fmt.Sprintf(commentURL, update.ID),
+			Author:   update.Owner.UserName,
+			ItemTime: time.Unix(update.UpdateTime, 0).UTC(),
+			Title:    title,
+			Excerpt:  stack.strCleaner(update.Body),
 		})
 	}
 
@@ -187,6 +242,10 @@ func (stack *StackClient) mergeUpdate(answers *scrapper.StackAnswers, comments *
 }
 
 func (stack *StackClient) NewUpdate(req *http.Request, update any) error {
+	if pausedUntil, paused := stack.paused(); paused {
+		return siteclients.NewErrRateLimited(stack.host, pausedUntil)
+	}
+
 	resp, err := stack.client.Do(req)
 
 	if err != nil {
@@ -199,14 +258,54 @@ func (stack *StackClient) NewUpdate(req *http.Request, update any) error {
 		return siteclients.NewErrBadRequestStatus("не смогли получить состояние ссылки", resp.StatusCode)
 	}
 
-	if err := json.NewDecoder(resp.Body).Decode(update); err != nil {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return siteclients.NewErrNetwork(clientName, req.URL.String(), err)
+	}
+
+	quota := &quotaWrapper{}
+
+	if err = json.Unmarshal(body, update); err == nil {
+		err = json.Unmarshal(body, quota)
+	}
+
+	if err != nil {
 		return fmt.Errorf("в клиете %s при парсиге ответа произошла ошибка: %w", clientName, err)
 	}
 
+	stack.respectQuota(quota, time.Now())
+
 	return nil
 }
 
-func (stack *StackClient) NewAnswers(questionID string, since time.Time) (*scrapper.StackAnswers, error) {
+// respectQuota приостанавливает запросы на время backoff, а если квота исчерпана - до ее сброса.
+func (stack *StackClient) respectQuota(quota *quotaWrapper, now time.Time) {
+	var until time.Time
+
+	if quota.Backoff > 0 {
+		until = now.Add(time.Duration(quota.Backoff) * time.Second)
+	}
+
+	if quota.QuotaRemaining != nil && *quota.QuotaRemaining <= 0 {
+		until = now.UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+	}
+
+	stack.mu.Lock()
+	defer stack.mu.Unlock()
+
+	if until.After(stack.pausedUntil) {
+		stack.pausedUntil = until
+	}
+}
+
+func (stack *StackClient) paused() (time.Time, bool) {
+	stack.mu.Lock()
+	defer stack.mu.Unlock()
+
+	return stack.pausedUntil, time.Now().Before(stack.pausedUntil)
+}
+
+func (stack *StackClient) NewAnswers(ctx context.Context, questionID string, since time.Time) (*scrapper.StackAnswers, error) {
 	q := url.Values{}
 
 	q.Add(site, stackoverflow)
@@ -215,7 +314,7 @@ func (stack *StackClient) NewAnswers(questionID string, since time.Time) (*scrap
 
 	reqURL := stack.requestURL(questionID, answers, q)
 
-	req, err := http.NewRequest(http.MethodGet, reqURL.String(), http.NoBody)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), http.NoBody)
 
 	if err != nil {
 		return nil, fmt.Errorf("в клиете %s при формировании запроса произошла ошибка: %w", clientName, err)
@@ -230,7 +329,7 @@ func (stack *StackClient) NewAnswers(questionID string, since time.Time) (*scrap
 	return newAnswers, nil
 }
 
-func (stack *StackClient) NewComments(questionID string, since time.Time) (*scrapper.StackComments, error) {
+func (stack *StackClient) NewComments(ctx context.Context, questionID string, since time.Time) (*scrapper.StackComments, error) {
 	q := url.Values{}
 
 	q.Add(site, stackoverflow)
@@ -238,7 +337,7 @@ func (stack *StackClient) NewComments(questionID string, since time.Time) (*scra
 	q.Add(filter, commentsFilter)
 
 	reqURL := stack.requestURL(questionID, comments, q)
-	req, err := http.NewRequest(http.MethodGet, reqURL.String(), http.NoBody)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), http.NoBody)
 
 	if err != nil {
 		return nil, fmt.Errorf("в клиете %s при формировании запроса произошла ошибка: %w", clientName, err)
@@ -253,7 +352,7 @@ func (stack *StackClient) NewComments(questionID string, since time.Time) (*scra
 	return newComments, nil
 }
 
-func (stack *StackClient) getQuestionTitle(pathArgs []string) (string, error) {
+func (stack *StackClient) getQuestionTitle(ctx context.Context, pathArgs []string) (string, error) {
 	q := url.Values{}
 
 	q.Add(site, stackoverflow)
@@ -266,7 +365,7 @@ func (stack *StackClient) getQuestionTitle(pathArgs []string) (string, error) {
 		RawQuery: q.Encode(),
 	}
 
-	req, err := http.NewRequest(http.MethodGet, reqURL.String(), http.NoBody)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), http.NoBody)
 
 	if err != nil {
 		return "", fmt.Errorf("в клиете %s при формировании запроса произошла ошибка: %w", clientName, err)
@@ -294,12 +393,6 @@ func HTMLStrCleaner(maxPreviewLen int) func(s string) string {
 	p := bluemonday.StripTagsPolicy()
 
 	return func(s string) string {
-		s = html.UnescapeString(p.Sanitize(s))
-
-		if len(s) > maxPreviewLen {
-			s = s[:maxPreviewLen]
-		}
-
-		return s
+		return siteclients.Truncate(html.UnescapeString(p.Sanitize(s)), maxPreviewLen)
 	}
 }
@@ -2,9 +2,11 @@ package stackoverflow_test
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"io"
 	"linkTraccer/internal/domain/scrapper"
+	"linkTraccer/internal/infrastructure/siteclients"
 	"linkTraccer/internal/infrastructure/siteclients/mocks"
 	"linkTraccer/internal/infrastructure/siteclients/stackoverflow"
 	"net/http"
@@ -157,7 +159,7 @@ func TestStackClient_CanTrack(t *testing.T) {
 
 	for _, test := range tests {
 		client := stackoverflow.NewClient(host, test.client, stackoverflow.HTMLStrCleaner(200))
-		actualRes := client.CanTrack(test.link)
+		actualRes := client.CanTrack(context.Background(), test.link)
 
 		assert.Equal(t, test.result, actualRes)
 	}
@@ -282,7 +284,7 @@ func TestStackClient_NewAnswers(t *testing.T) {
 
 	for _, test := range tests {
 		client := stackoverflow.NewClient(host, test.client, stackoverflow.HTMLStrCleaner(200))
-		updates, err := client.NewAnswers(test.questionID, time.Now())
+		updates, err := client.NewAnswers(context.Background(), test.questionID, time.Now())
 
 		if test.correct {
 			assert.Equal(t, test.expectedRes, updates)
@@ -293,6 +295,48 @@ func TestStackClient_NewAnswers(t *testing.T) {
 	}
 }
 
+func TestStackClient_Quota(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		retryAt time.Time
+	}{
+		{
+			name:    "API попросил не отправлять запросы backoff секунд",
+			body:    `{"items": [{"title": "hello"}], "backoff": 10, "quota_remaining": 100}`,
+			retryAt: time.Now().Add(10 * time.Second),
+		},
+		{
+			name:    "Дневная квота исчерпана",
+			body:    `{"items": [{"title": "hello"}], "quota_remaining": 0}`,
+			retryAt: time.Now().UTC().Truncate(24 * time.Hour).Add(24 * time.Hour),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			httpClient := mocks.NewHTTPClient(t)
+
+			httpClient.On("Do", mock.Anything).
+				Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(test.body))}, nil).Once()
+
+			client := stackoverflow.NewClient(host, httpClient, stackoverflow.HTMLStrCleaner(200))
+
+			updates, err := client.NewAnswers(context.Background(), "1", time.Now())
+			assert.NoError(t, err)
+			assert.Equal(t, &scrapper.StackAnswers{Items: []scrapper.StackAnswer{{Title: "hello"}}}, updates)
+
+			_, err = client.NewAnswers(context.Background(), "1", time.Now())
+
+			var rateErr *siteclients.ErrRateLimited
+
+			if assert.ErrorAs(t, err, &rateErr, "пока действует ограничение, запросы не отправляются") {
+				assert.WithinDuration(t, test.retryAt, rateErr.RetryAt(), 5*time.Second)
+			}
+		})
+	}
+}
+
 func TestStackClient_NewComments(t *testing.T) {
 	clientWithOkStatus := mocks.NewHTTPClient(t)
 	clientWithErrStatus := mocks.NewHTTPClient(t)
@@ -330,7 +374,7 @@ func TestStackClient_NewComments(t *testing.T) {
 
 	for _, test := range tests {
 		client := stackoverflow.NewClient(host, test.client, stackoverflow.HTMLStrCleaner(200))
-		updates, err := client.NewComments(test.questionID, time.Now())
+		updates, err := client.NewComments(context.Background(), test.questionID, time.Now())
 
 		if test.correct {
 			assert.Equal(t, test.expectedRes, updates)
@@ -406,3 +450,34 @@ func TestStackClient_NewComments(t *testing.T) {
 //		}
 //	}
 // }
+
+func TestStackClient_Supports(t *testing.T) {
+	client := stackoverflow.NewClient(host, mocks.NewHTTPClient(t), stackoverflow.HTMLStrCleaner(200))
+
+	assert.True(t, client.Supports("https://stackoverflow.com/questions/76814302"), "ссылка проверяется без запросов к API")
+	assert.False(t, client.Supports("http://stackoverflow.com/questions/76814302"))
+	assert.False(t, client.Supports("https://stackoverflow.com/users/76814302"))
+}
+
+func TestStackClient_Paused(t *testing.T) {
+	httpClient := mocks.NewHTTPClient(t)
+	link := "https://stackoverflow.com/questions/76814302"
+
+	httpClient.On("Do", mock.Anything).Return(&http.Response{StatusCode: http.StatusOK,
+		Body: io.NopCloser(strings.NewReader(`{"items": [], "backoff": 60}`))}, nil).Once()
+
+	client := stackoverflow.NewClient(host, httpClient, stackoverflow.HTMLStrCleaner(200))
+
+	_, err := client.NewAnswers(context.Background(), "76814302", time.Now())
+	assert.NoError(t, err)
+
+	assert.True(t, client.CanTrack(context.Background(), link), "пока действует backoff, ссылка проверяется только по виду")
+
+	_, err = client.LinkUpdates(context.Background(), link, &scrapper.LinkCursors{Since: time.Now()})
+
+	var retryLater interface{ RetryAt() time.Time }
+
+	if assert.ErrorAs(t, err, &retryLater, "проверка ссылки откладывается до конца backoff") {
+		assert.WithinDuration(t, time.Now().Add(time.Minute), retryLater.RetryAt(), 5*time.Second)
+	}
+}
@@ -0,0 +1,46 @@
+package siteclients_test
+
+import (
+	"linkTraccer/internal/infrastructure/siteclients"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name     string
+		s        string
+		maxRunes int
+		expected string
+	}{
+		{
+			name:     "строка короче лимита не меняется",
+			s:        "issue",
+			maxRunes: 10,
+			expected: "issue",
+		},
+		{
+			name:     "кириллица обрезается по символам, а не по байтам",
+			s:        "Привет, мир",
+			maxRunes: 6,
+			expected: "Привет",
+		},
+		{
+			name:     "эмодзи не разрывается",
+			s:        "ok 🚀🚀",
+			maxRunes: 4,
+			expected: "ok 🚀",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			truncated := siteclients.Truncate(test.s, test.maxRunes)
+
+			assert.Equal(t, test.expected, truncated)
+			assert.True(t, utf8.ValidString(truncated))
+		})
+	}
+}
@@ -0,0 +1,259 @@
+package siteclients
+
+import (
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Заголовки ограничения частоты запросов, X-RateLimit-* отдает GitHub.
+
+const (
+	retryAfterHeader     = "Retry-After"
+	rateRemainingHeader  = "X-RateLimit-Remaining"
+	rateResetHeader      = "X-RateLimit-Reset"
+	defaultRateLimitWait = time.Minute
+)
+
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type TransportConfig struct {
+	MaxRetries      int // повторов после сетевой ошибки или ответа 5xx
+	RetryDelay      time.Duration
+	MaxRetryDelay   time.Duration
+	BreakerFailures int // отказов хоста подряд до открытия размыкателя
+	BreakerCooldown time.Duration
+}
+
+// Transport повторяет запросы к API сайтов и приостанавливает запросы к недоступному хосту.
+type Transport struct {
+	client HTTPClient
+	config *TransportConfig
+
+	mu    sync.Mutex
+	hosts map[string]*hostState
+}
+
+// hostState - размыкатель и ограничение частоты запросов одного хоста.
+type hostState struct {
+	failures    int
+	open        bool
+	probing     bool
+	nextProbeAt time.Time
+	pausedUntil time.Time
+}
+
+func NewTransport(client HTTPClient, config *TransportConfig) *Transport {
+	return &Transport{
+		client: client,
+		config: config,
+		hosts:  make(map[string]*hostState),
+	}
+}
+
+func (t *Transport) Do(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+	retryable := req.Body == nil || req.Body == http.NoBody
+
+	for attempt := 0; ; attempt++ {
+		if err := t.allow(host); err != nil {
+			return nil, err
+		}
+
+		resp, err := t.client.Do(req)
+		if err != nil && req.Context().Err() != nil {
+			t.cancelProbe(host)
+
+			return nil, err
+		}
+
+		failed := err != nil || resp.StatusCode >= http.StatusInternalServerError
+
+		t.report(host, failed)
+
+		if err == nil {
+			if retryAt, limited := rateLimit(resp, time.Now()); limited {
+				t.pause(host, retryAt)
+
+				if resp.StatusCode != http.StatusOK {
+					closeBody(resp)
+
+					return nil, NewErrRateLimited(host, retryAt)
+				}
+			}
+		}
+
+		if !failed || !retryable || attempt >= t.config.MaxRetries {
+			return resp, err
+		}
+
+		delay := t.retryDelay(attempt)
+
+		if err == nil {
+			closeBody(resp)
+
+			if wait, ok := retryAfter(resp, time.Now()); ok {
+				if wait > t.config.MaxRetryDelay {
+					retryAt := time.Now().Add(wait)
+					t.pause(host, retryAt)
+
+					return nil, NewErrRateLimited(host, retryAt)
+				}
+
+				delay = max(delay, wait)
+			}
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+}
+
+// allow пропускает один пробный запрос в BreakerCooldown, пока размыкатель хоста открыт.
+func (t *Transport) allow(host string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state := t.state(host)
+	now := time.Now()
+
+	if now.Before(state.pausedUntil) {
+		return NewErrRateLimited(host, state.pausedUntil)
+	}
+
+	if !state.open {
+		return nil
+	}
+
+	if state.probing {
+		return NewErrBreakerOpen(host, now.Add(t.config.BreakerCooldown))
+	}
+
+	if now.Before(state.nextProbeAt) {
+		return NewErrBreakerOpen(host, state.nextProbeAt)
+	}
+
+	state.probing = true
+
+	return nil
+}
+
+// report считает отказом хоста сетевую ошибку и ответ 5xx.
+func (t *Transport) report(host string, failed bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state := t.state(host)
+	state.probing = false
+
+	if !failed {
+		state.failures, state.open = 0, false
+
+		return
+	}
+
+	state.failures++
+
+	if state.open || state.failures >= max(1, t.config.BreakerFailures) {
+		state.open, state.nextProbeAt = true, time.Now().Add(t.config.BreakerCooldown)
+	}
+}
+
+// cancelProbe освобождает место пробного запроса, если запрос отменили.
+func (t *Transport) cancelProbe(host string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.state(host).probing = false
+}
+
+func (t *Transport) pause(host string, until time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if state := t.state(host); until.After(state.pausedUntil) {
+		state.pausedUntil = until
+	}
+}
+
+func (t *Transport) state(host string) *hostState {
+	state, ok := t.hosts[host]
+	if !ok {
+		state = &hostState{}
+		t.hosts[host] = state
+	}
+
+	return state
+}
+
+// retryDelay случайно берет от половины до целой экспоненциальной паузы, чтобы реплики не повторяли запросы разом.
+func (t *Transport) retryDelay(attempt int) time.Duration {
+	delay := t.config.RetryDelay
+
+	for i := 0; i < attempt && delay < t.config.MaxRetryDelay; i++ {
+		delay *= 2
+	}
+
+	delay = min(delay, t.config.MaxRetryDelay)
+
+	if delay/2 <= 0 {
+		return delay
+	}
+
+	return delay/2 + rand.N(delay/2)
+}
+
+// rateLimit учитывает и успешный ответ с исчерпанным лимитом X-RateLimit.
+func rateLimit(resp *http.Response, now time.Time) (time.Time, bool) {
+	if resp.Header.Get(rateRemainingHeader) == "0" {
+		if reset, err := strconv.ParseInt(resp.Header.Get(rateResetHeader), 10, 64); err == nil {
+			return time.Unix(reset, 0), true
+		}
+	}
+
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusForbidden {
+		return time.Time{}, false
+	}
+
+	if wait, ok := retryAfter(resp, now); ok {
+		return now.Add(wait), true
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return now.Add(defaultRateLimitWait), true
+	}
+
+	return time.Time{}, false
+}
+
+// retryAfter разбирает Retry-After в секундах или в виде даты.
+func retryAfter(resp *http.Response, now time.Time) (time.Duration, bool) {
+	value := resp.Header.Get(retryAfterHeader)
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(0, time.Duration(seconds)*time.Second), true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(0, date.Sub(now)), true
+	}
+
+	return 0, false
+}
+
+// closeBody дочитывает тело ответа, чтобы соединение вернулось в пул.
+func closeBody(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+}
@@ -0,0 +1,161 @@
+package siteclients_test
+
+import (
+	"context"
+	"errors"
+	"linkTraccer/internal/infrastructure/siteclients"
+	"linkTraccer/internal/infrastructure/siteclients/mocks"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	errNet = errors.New("соединение сброшено")
+	config = &siteclients.TransportConfig{
+		MaxRetries:      2,
+		RetryDelay:      time.Millisecond,
+		MaxRetryDelay:   10 * time.Millisecond,
+		BreakerFailures: 3,
+		BreakerCooldown: time.Hour,
+	}
+)
+
+func response(status int, header http.Header) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &http.Response{StatusCode: status, Header: header, Body: http.NoBody}
+}
+
+func request(t *testing.T, host string) *http.Request {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://"+host+"/repos", http.NoBody)
+	assert.NoError(t, err)
+
+	return req
+}
+
+func TestTransport_RetriesServerErrors(t *testing.T) {
+	client := mocks.NewHTTPClient(t)
+
+	client.On("Do", mock.Anything).Return(response(http.StatusBadGateway, nil), nil).Once()
+	client.On("Do", mock.Anything).Return(nil, errNet).Once()
+	client.On("Do", mock.Anything).Return(response(http.StatusOK, nil), nil).Once()
+
+	resp, err := siteclients.NewTransport(client, config).Do(request(t, "api.github.com"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "после 5xx и сетевой ошибки запрос повторяется")
+}
+
+func TestTransport_DoesNotRetryClientErrors(t *testing.T) {
+	client := mocks.NewHTTPClient(t)
+
+	client.On("Do", mock.Anything).Return(response(http.StatusNotFound, nil), nil).Once()
+
+	resp, err := siteclients.NewTransport(client, config).Do(request(t, "api.github.com"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestTransport_BreakerOpensPerHost(t *testing.T) {
+	client := mocks.NewHTTPClient(t)
+	transport := siteclients.NewTransport(client, config)
+
+	client.On("Do", mock.MatchedBy(func(req *http.Request) bool { return req.URL.Host == "api.github.com" })).
+		Return(nil, errNet).Times(3)
+	client.On("Do", mock.MatchedBy(func(req *http.Request) bool { return req.URL.Host == "api.stackexchange.com" })).
+		Return(response(http.StatusOK, nil), nil).Once()
+
+	_, err := transport.Do(request(t, "api.github.com"))
+	assert.ErrorIs(t, err, errNet)
+
+	_, err = transport.Do(request(t, "api.github.com"))
+
+	var breakerErr *siteclients.ErrBreakerOpen
+
+	if assert.ErrorAs(t, err, &breakerErr, "после BreakerFailures отказов хост отключается") {
+		assert.WithinDuration(t, time.Now().Add(time.Hour), breakerErr.RetryAt(), time.Minute)
+	}
+
+	_, err = transport.Do(request(t, "api.stackexchange.com"))
+	assert.NoError(t, err, "размыкатель одного хоста не отключает другие")
+}
+
+func TestTransport_RateLimit(t *testing.T) {
+	reset := time.Now().Add(30 * time.Minute).Truncate(time.Second)
+
+	tests := []struct {
+		name    string
+		resp    *http.Response
+		retryAt time.Time
+	}{
+		{
+			name: "GitHub исчерпал лимит запросов",
+			resp: response(http.StatusForbidden, http.Header{
+				"X-Ratelimit-Remaining": {"0"},
+				"X-Ratelimit-Reset":     {strconv.FormatInt(reset.Unix(), 10)},
+			}),
+			retryAt: reset,
+		},
+		{
+			name:    "429 с Retry-After",
+			resp:    response(http.StatusTooManyRequests, http.Header{"Retry-After": {"120"}}),
+			retryAt: time.Now().Add(2 * time.Minute),
+		},
+		{
+			name:    "503 с Retry-After дольше допустимой паузы между повторами",
+			resp:    response(http.StatusServiceUnavailable, http.Header{"Retry-After": {"60"}}),
+			retryAt: time.Now().Add(time.Minute),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := mocks.NewHTTPClient(t)
+			transport := siteclients.NewTransport(client, config)
+
+			client.On("Do", mock.Anything).Return(test.resp, nil).Once()
+
+			_, err := transport.Do(request(t, "api.github.com"))
+
+			var rateErr *siteclients.ErrRateLimited
+
+			if assert.ErrorAs(t, err, &rateErr) {
+				assert.WithinDuration(t, test.retryAt, rateErr.RetryAt(), 5*time.Second)
+			}
+
+			_, err = transport.Do(request(t, "api.github.com"))
+			assert.ErrorAs(t, err, &rateErr, "до снятия ограничения запросы к хосту не отправляются")
+		})
+	}
+}
+
+func TestTransport_ExhaustedRateLimitOnSuccess(t *testing.T) {
+	client := mocks.NewHTTPClient(t)
+	transport := siteclients.NewTransport(client, config)
+	reset := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	client.On("Do", mock.Anything).Return(response(http.StatusOK, http.Header{
+		"X-Ratelimit-Remaining": {"0"},
+		"X-Ratelimit-Reset":     {strconv.FormatInt(reset.Unix(), 10)},
+	}), nil).Once()
+
+	resp, err := transport.Do(request(t, "api.github.com"))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "ответ, исчерпавший лимит, отдается клиенту")
+
+	_, err = transport.Do(request(t, "api.github.com"))
+
+	var rateErr *siteclients.ErrRateLimited
+
+	if assert.ErrorAs(t, err, &rateErr) {
+		assert.Equal(t, reset, rateErr.RetryAt())
+	}
+}
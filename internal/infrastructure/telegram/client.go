@@ -2,7 +2,9 @@ package telegram
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"linkTraccer/internal/domain/tgbot"
@@ -10,6 +12,7 @@ import (
 	"net/url"
 	"path"
 	"strconv"
+	"time"
 )
 
 type HTTPClient interface {
@@ -20,26 +23,35 @@ const (
 	setMyCommands = "setMyCommands"
 	getUpdates    = "getUpdates"
 	sendMessage   = "sendMessage"
-	jsonType      = "application/json"
+	setWebhook    = "setWebhook"
+	deleteWebhook = "deleteWebhook"
+
+	editMessageText     = "editMessageText"
+	answerCallbackQuery = "answerCallbackQuery"
+	jsonType            = "application/json"
 )
 
 type TgClient struct {
-	basePath string
-	scheme   string
-	host     string
-	client   HTTPClient
+	basePath   string
+	scheme     string
+	host       string
+	client     HTTPClient
+	limiter    *rateLimiter
+	maxRetries int
 }
 
-func NewClient(client HTTPClient, token, host string) *TgClient {
+func NewClient(client HTTPClient, token, host string, limits Limits) *TgClient {
 	return &TgClient{
-		scheme:   "https",
-		host:     host,
-		client:   client,
-		basePath: "bot" + token,
+		scheme:     "https",
+		host:       host,
+		client:     client,
+		basePath:   "bot" + token,
+		limiter:    newRateLimiter(limits),
+		maxRetries: limits.MaxRetries,
 	}
 }
 
-func (bot *TgClient) HandleUsersUpdates(offset, limit int) (tgbot.Updates, error) {
+func (bot *TgClient) HandleUsersUpdates(ctx context.Context, offset, limit int) (tgbot.Updates, error) {
 	if limit < 0 {
 		return nil, NewErrNegativeLimit(limit)
 	}
@@ -52,7 +64,7 @@ func (bot *TgClient) HandleUsersUpdates(offset, limit int) (tgbot.Updates, error
 	q.Add("limit", strconv.Itoa(limit))
 
 	requestURL = bot.makeRequestURL(getUpdates, q)
-	jsonData, err := RequestToAPI(bot.client, requestURL, http.MethodGet, nil)
+	jsonData, err := bot.call(ctx, http.MethodGet, requestURL, nil)
 
 	if err != nil {
 		return nil, fmt.Errorf("при запросе getUpdates к tg API произошла ошибка: %w", err)
@@ -67,30 +79,91 @@ func (bot *TgClient) HandleUsersUpdates(offset, limit int) (tgbot.Updates, error
 	return response.Updates, nil
 }
 
-func (bot *TgClient) SendMessage(userID int64, text string) error {
-	sendMessageURL := bot.makeRequestURL(sendMessage, nil)
+func (bot *TgClient) SendMessage(ctx context.Context, userID int64, text string) error {
+	return bot.sendMessage(ctx, &SendMessage{ID: userID, Text: text})
+}
+
+func (bot *TgClient) SendKeyboard(ctx context.Context, userID int64, text string, keyboard *tgbot.InlineKeyboardMarkup) error {
+	return bot.sendMessage(ctx, &SendMessage{ID: userID, Text: text, ReplyMarkup: keyboard})
+}
 
-	data := &SendMessage{
-		ID:   userID,
-		Text: text,
+// sendMessage один раз переотправляет сообщение, если группа стала супергруппой.
+func (bot *TgClient) sendMessage(ctx context.Context, data *SendMessage) error {
+	err := bot.trySendMessage(ctx, data)
+
+	var apiErr *ErrBotAPI
+
+	if errors.As(err, &apiErr) && apiErr.MigrateToChatID() != 0 {
+		migrated := *data
+		migrated.ID = apiErr.MigrateToChatID()
+
+		err = bot.trySendMessage(ctx, &migrated)
 	}
 
+	if err != nil {
+		return fmt.Errorf("при отправке сообщения на сервер телеграмм произошла ошибка: %w", err)
+	}
+
+	return nil
+}
+
+func (bot *TgClient) trySendMessage(ctx context.Context, data *SendMessage) error {
+	sendMessageURL := bot.makeRequestURL(sendMessage, nil)
+
 	jsonData, err := json.Marshal(data)
 
 	if err != nil {
 		return fmt.Errorf("при маршалинге сообщения, для отправки на сервер телеграмм возникла ошибка: %w", err)
 	}
 
-	_, err = RequestToAPI(bot.client, sendMessageURL, http.MethodPost, bytes.NewBuffer(jsonData))
+	wait := func(ctx context.Context) error {
+		return bot.limiter.Wait(ctx, data.ID)
+	}
+
+	_, err = bot.callLimited(ctx, wait, http.MethodPost, sendMessageURL, jsonData)
+
+	return err
+}
+
+// EditMessageText заменяет текст ранее отправленного сообщения, вместе с текстом убирается и его клавиатура.
+func (bot *TgClient) EditMessageText(ctx context.Context, userID int64, messageID int, text string) error {
+	editMessageURL := bot.makeRequestURL(editMessageText, nil)
+
+	jsonData, err := json.Marshal(&EditMessageText{ID: userID, MessageID: messageID, Text: text})
 
 	if err != nil {
-		return fmt.Errorf("при отправке сообщения на сервер телеграмм произошла ошибка: %w", err)
+		return fmt.Errorf("при маршалинге изменения сообщения возникла ошибка: %w", err)
+	}
+
+	_, err = bot.call(ctx, http.MethodPost, editMessageURL, jsonData)
+
+	if err != nil {
+		return fmt.Errorf("запрос на изменение сообщения закончился ошибкой: %w", err)
 	}
 
 	return nil
 }
 
-func (bot *TgClient) SetBotCommands(data *tgbot.SetCommands) error {
+// AnswerCallbackQuery подтверждает нажатие кнопки, иначе клиент telegram продолжает показывать загрузку.
+func (bot *TgClient) AnswerCallbackQuery(ctx context.Context, callbackID, text string) error {
+	answerURL := bot.makeRequestURL(answerCallbackQuery, nil)
+
+	jsonData, err := json.Marshal(&AnswerCallbackQuery{CallbackQueryID: callbackID, Text: text})
+
+	if err != nil {
+		return fmt.Errorf("при маршалинге ответа на нажатие кнопки возникла ошибка: %w", err)
+	}
+
+	_, err = bot.call(ctx, http.MethodPost, answerURL, jsonData)
+
+	if err != nil {
+		return fmt.Errorf("запрос на ответ на нажатие кнопки закончился ошибкой: %w", err)
+	}
+
+	return nil
+}
+
+func (bot *TgClient) SetBotCommands(ctx context.Context, data *tgbot.SetCommands) error {
 	setCommandsURL := bot.makeRequestURL(setMyCommands, nil)
 	jsonData, err := json.Marshal(data)
 
@@ -98,7 +171,7 @@ func (bot *TgClient) SetBotCommands(data *tgbot.SetCommands) error {
 		return fmt.Errorf("при маршалинге команд поддерживаемых ботом возникла ошибка: %w", err)
 	}
 
-	_, err = RequestToAPI(bot.client, setCommandsURL, http.MethodPost, bytes.NewBuffer(jsonData))
+	_, err = bot.call(ctx, http.MethodPost, setCommandsURL, jsonData)
 
 	if err != nil {
 		return fmt.Errorf("запрос на создание меню с командами закончился ошибкой: %w", err)
@@ -107,8 +180,76 @@ func (bot *TgClient) SetBotCommands(data *tgbot.SetCommands) error {
 	return nil
 }
 
-func RequestToAPI(client HTTPClient, url *url.URL, httpMethod string, data io.Reader) ([]byte, error) {
-	req, err := http.NewRequest(httpMethod, url.String(), data)
+// SetWebhook просит telegram передавать secretToken в X-Telegram-Bot-Api-Secret-Token.
+func (bot *TgClient) SetWebhook(ctx context.Context, webhookURL, secretToken string) error {
+	setWebhookURL := bot.makeRequestURL(setWebhook, nil)
+	jsonData, err := json.Marshal(&SetWebhook{URL: webhookURL, SecretToken: secretToken})
+
+	if err != nil {
+		return fmt.Errorf("при маршалинге параметров вебхука возникла ошибка: %w", err)
+	}
+
+	_, err = bot.call(ctx, http.MethodPost, setWebhookURL, jsonData)
+
+	if err != nil {
+		return fmt.Errorf("запрос на установку вебхука закончился ошибкой: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteWebhook отключает вебхук, без этого telegram не отдает обновления через getUpdates.
+func (bot *TgClient) DeleteWebhook(ctx context.Context) error {
+	deleteWebhookURL := bot.makeRequestURL(deleteWebhook, nil)
+
+	_, err := bot.call(ctx, http.MethodPost, deleteWebhookURL, nil)
+
+	if err != nil {
+		return fmt.Errorf("запрос на удаление вебхука закончился ошибкой: %w", err)
+	}
+
+	return nil
+}
+
+// call повторяет запрос после 429 не больше maxRetries раз.
+func (bot *TgClient) call(ctx context.Context, httpMethod string, requestURL *url.URL, jsonData []byte) ([]byte, error) {
+	noWait := func(context.Context) error { return nil }
+
+	return bot.callLimited(ctx, noWait, httpMethod, requestURL, jsonData)
+}
+
+// callLimited ждет wait перед каждой попыткой, чтобы повторы после 429 не обходили лимиты отправки.
+func (bot *TgClient) callLimited(ctx context.Context, wait func(ctx context.Context) error, httpMethod string,
+	requestURL *url.URL, jsonData []byte) ([]byte, error) {
+	for attempt := 0; ; attempt++ {
+		if err := wait(ctx); err != nil {
+			return nil, fmt.Errorf("ошибка при ожидании лимита отправки сообщений: %w", err)
+		}
+
+		var body io.Reader
+
+		if jsonData != nil {
+			body = bytes.NewReader(jsonData)
+		}
+
+		data, err := RequestToAPI(ctx, bot.client, requestURL, httpMethod, body)
+
+		var apiErr *ErrBotAPI
+
+		if err == nil || !errors.As(err, &apiErr) || apiErr.RetryAfter() == 0 || attempt >= bot.maxRetries {
+			return data, err
+		}
+
+		select {
+		case <-time.After(apiErr.RetryAfter()):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func RequestToAPI(ctx context.Context, client HTTPClient, url *url.URL, httpMethod string, data io.Reader) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, httpMethod, url.String(), data)
 
 	if err != nil {
 		return nil, fmt.Errorf("при создании запроса к botApi возникла ошибка: %w", err)
@@ -127,7 +268,13 @@ func RequestToAPI(client HTTPClient, url *url.URL, httpMethod string, data io.Re
 	defer r.Body.Close()
 
 	if r.StatusCode != http.StatusOK {
-		return nil, NewErrBotAPI(r.StatusCode)
+		answer := &DefaultServerAnswer{}
+
+		if body, err := io.ReadAll(r.Body); err == nil {
+			_ = json.Unmarshal(body, answer)
+		}
+
+		return nil, newErrBotAPIFromAnswer(r.StatusCode, answer)
 	}
 
 	return io.ReadAll(r.Body)
@@ -2,6 +2,7 @@ package telegram_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
@@ -9,8 +10,11 @@ import (
 	"linkTraccer/internal/infrastructure/telegram"
 	"linkTraccer/internal/infrastructure/telegram/mocks"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -84,7 +88,7 @@ func TestRequestToAPI(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		data, err := telegram.RequestToAPI(test.client, test.url, test.httpMethod, test.data)
+		data, err := telegram.RequestToAPI(context.Background(), test.client, test.url, test.httpMethod, test.data)
 
 		if test.correct {
 			bytes, _ := io.ReadAll(test.data)
@@ -149,8 +153,8 @@ func TestTgClient_HandleUsersUpdates(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		tgClient := telegram.NewClient(test.client, token, host)
-		updates, err := tgClient.HandleUsersUpdates(test.offset, test.limit)
+		tgClient := telegram.NewClient(test.client, token, host, telegram.DefaultLimits)
+		updates, err := tgClient.HandleUsersUpdates(context.Background(), test.offset, test.limit)
 
 		if test.corect {
 			assert.NoError(t, err)
@@ -194,8 +198,8 @@ func TestTgClient_SendMessage(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		tgClient := telegram.NewClient(test.client, token, host)
-		err := tgClient.SendMessage(test.id, test.text)
+		tgClient := telegram.NewClient(test.client, token, host, telegram.DefaultLimits)
+		err := tgClient.SendMessage(context.Background(), test.id, test.text)
 
 		if test.correct {
 			assert.NoError(t, err)
@@ -235,8 +239,8 @@ func TestTgClient_SetBotCommands(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		tgClient := telegram.NewClient(test.client, token, host)
-		err := tgClient.SetBotCommands(test.data)
+		tgClient := telegram.NewClient(test.client, token, host, telegram.DefaultLimits)
+		err := tgClient.SetBotCommands(context.Background(), test.data)
 
 		if test.correct {
 			assert.NoError(t, err)
@@ -246,6 +250,140 @@ func TestTgClient_SetBotCommands(t *testing.T) {
 	}
 }
 
+// newFakeTelegram поднимает локальный сервер, который отвечает как Bot API и запоминает тело последнего запроса.
+func newFakeTelegram(t *testing.T, status int, lastBody *[]byte) *httptest.Server {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/bot"+token+"/") {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		*lastBody, _ = io.ReadAll(r.Body)
+
+		w.WriteHeader(status)
+		_, _ = w.Write(defTgAnswer)
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestTgClient_SetWebhook(t *testing.T) {
+	var lastBody []byte
+
+	goodServer := newFakeTelegram(t, http.StatusOK, &lastBody)
+	badServer := newFakeTelegram(t, http.StatusUnauthorized, &lastBody)
+
+	type testCase struct {
+		name    string
+		server  *httptest.Server
+		correct bool
+	}
+
+	tests := []testCase{
+		{
+			name:    "имитируем ошибку от API",
+			server:  badServer,
+			correct: false,
+		},
+		{
+			name:    "вебхук установлен",
+			server:  goodServer,
+			correct: true,
+		},
+	}
+
+	for _, test := range tests {
+		tgClient := telegram.NewClient(test.server.Client(), token, test.server.Listener.Addr().String(),
+			telegram.DefaultLimits)
+		err := tgClient.SetWebhook(context.Background(), "https://bot.example.com/webhook", "secret")
+
+		if test.correct {
+			webhook := &telegram.SetWebhook{}
+
+			assert.NoError(t, err)
+			assert.NoError(t, json.Unmarshal(lastBody, webhook))
+			assert.Equal(t, &telegram.SetWebhook{URL: "https://bot.example.com/webhook", SecretToken: "secret"}, webhook)
+		} else {
+			assert.Error(t, err)
+		}
+	}
+}
+
+func TestTgClient_DeleteWebhook(t *testing.T) {
+	var lastBody []byte
+
+	goodServer := newFakeTelegram(t, http.StatusOK, &lastBody)
+	badServer := newFakeTelegram(t, http.StatusUnauthorized, &lastBody)
+
+	type testCase struct {
+		name    string
+		server  *httptest.Server
+		correct bool
+	}
+
+	tests := []testCase{
+		{
+			name:    "имитируем ошибку от API",
+			server:  badServer,
+			correct: false,
+		},
+		{
+			name:    "вебхук удален",
+			server:  goodServer,
+			correct: true,
+		},
+	}
+
+	for _, test := range tests {
+		tgClient := telegram.NewClient(test.server.Client(), token, test.server.Listener.Addr().String(),
+			telegram.DefaultLimits)
+		err := tgClient.DeleteWebhook(context.Background())
+
+		if test.correct {
+			assert.NoError(t, err)
+		} else {
+			assert.Error(t, err)
+		}
+	}
+}
+
+func TestTgClient_Keyboards(t *testing.T) {
+	var lastBody []byte
+
+	server := newFakeTelegram(t, http.StatusOK, &lastBody)
+	tgClient := telegram.NewClient(server.Client(), token, server.Listener.Addr().String(), telegram.DefaultLimits)
+
+	keyboard := &tgbot.InlineKeyboardMarkup{
+		InlineKeyboard: [][]tgbot.InlineKeyboardButton{{{Text: "tbank.ru", CallbackData: "untrack:1"}}},
+	}
+
+	assert.NoError(t, tgClient.SendKeyboard(context.Background(), 1, "hello", keyboard))
+	assert.JSONEq(t, `{"chat_id":1,"text":"hello","reply_markup":{"inline_keyboard":[[{"text":"tbank.ru","callback_data":"untrack:1"}]]}}`,
+		string(lastBody))
+
+	assert.NoError(t, tgClient.EditMessageText(context.Background(), 1, 10, "hello"))
+	assert.JSONEq(t, `{"chat_id":1,"message_id":10,"text":"hello"}`, string(lastBody))
+
+	assert.NoError(t, tgClient.AnswerCallbackQuery(context.Background(), "42", ""))
+	assert.JSONEq(t, `{"callback_query_id":"42"}`, string(lastBody))
+}
+
+func TestUpdate_CallbackQuery(t *testing.T) {
+	data := []byte(`{"update_id":1,"callback_query":{"id":"42","from":{"id":5},"message":{"message_id":10,"text":"hi"},"data":"skip"}}`)
+	update := tgbot.Update{}
+
+	assert.NoError(t, json.Unmarshal(data, &update))
+	assert.Equal(t, &tgbot.CallbackQuery{
+		ID:      "42",
+		From:    tgbot.User{ID: 5},
+		Message: &tgbot.Message{MessageID: 10, Text: "hi"},
+		Data:    "skip",
+	}, update.CallbackQuery)
+}
+
 // ИНТЕГРАЦИОННЫЕ ТЕСТЫ
 //
 //	func TestRequestToBotAPI(t *testing.T) {
@@ -533,3 +671,167 @@ func TestTgClient_SetBotCommands(t *testing.T) {
 //			w.WriteHeader(http.StatusBadRequest)
 //		}
 //	}
+
+// newScriptedTelegram поднимает сервер Bot API, который отвечает ответами из answers по порядку
+// и запоминает chat_id каждого запроса. Последний ответ повторяется.
+func newScriptedTelegram(t *testing.T, answers []string, chatIDs *[]int64) *httptest.Server {
+	var calls int
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		msg := &telegram.SendMessage{}
+		_ = json.NewDecoder(r.Body).Decode(msg)
+		*chatIDs = append(*chatIDs, msg.ID)
+
+		answer := &telegram.DefaultServerAnswer{}
+		_ = json.Unmarshal([]byte(answers[min(calls, len(answers)-1)]), answer)
+		calls++
+
+		status := http.StatusOK
+		if !answer.Ok {
+			status = answer.ErrorCode
+		}
+
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(answer)
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestTgClient_SendMessageErrors(t *testing.T) {
+	const (
+		okAnswer       = `{"ok":true}`
+		tooManyAnswer  = `{"ok":false,"error_code":429,"description":"Too Many Requests","parameters":{"retry_after":1}}`
+		blockedAnswer  = `{"ok":false,"error_code":403,"description":"Forbidden: bot was blocked by the user"}`
+		notFoundAnswer = `{"ok":false,"error_code":400,"description":"Bad Request: chat not found"}`
+		migrateAnswer  = `{"ok":false,"error_code":400,"description":"Bad Request: upgraded","parameters":{"migrate_to_chat_id":-100}}`
+		internalAnswer = `{"ok":false,"error_code":502,"description":"Bad Gateway"}`
+	)
+
+	type testCase struct {
+		name            string
+		answers         []string
+		limits          telegram.Limits
+		expectedChatIDs []int64
+		check           func(t *testing.T, err error)
+	}
+
+	tests := []testCase{
+		{
+			name:            "telegram просит подождать, после паузы сообщение отправлено",
+			answers:         []string{tooManyAnswer, okAnswer},
+			limits:          telegram.DefaultLimits,
+			expectedChatIDs: []int64{1, 1},
+			check: func(t *testing.T, err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name:            "повторы закончились, возвращаем временную ошибку",
+			answers:         []string{tooManyAnswer},
+			limits:          telegram.Limits{GlobalRPS: 30, ChatRPS: 1, MaxRetries: 0},
+			expectedChatIDs: []int64{1},
+			check: func(t *testing.T, err error) {
+				var apiErr *telegram.ErrBotAPI
+
+				assert.ErrorIs(t, err, tgbot.TooManyRequests)
+				assert.ErrorAs(t, err, &apiErr)
+				assert.True(t, apiErr.Temporary())
+				assert.Equal(t, time.Second, apiErr.RetryAfter())
+			},
+		},
+		{
+			name:            "пользователь заблокировал бота",
+			answers:         []string{blockedAnswer},
+			limits:          telegram.DefaultLimits,
+			expectedChatIDs: []int64{1},
+			check: func(t *testing.T, err error) {
+				var apiErr *telegram.ErrBotAPI
+
+				assert.ErrorIs(t, err, tgbot.BotBlocked)
+				assert.ErrorAs(t, err, &apiErr)
+				assert.False(t, apiErr.Temporary())
+				assert.Equal(t, "Forbidden: bot was blocked by the user", apiErr.Description())
+			},
+		},
+		{
+			name:            "чат не найден",
+			answers:         []string{notFoundAnswer},
+			limits:          telegram.DefaultLimits,
+			expectedChatIDs: []int64{1},
+			check: func(t *testing.T, err error) {
+				assert.ErrorIs(t, err, tgbot.ChatNotFound)
+				assert.True(t, tgbot.ChatUnavailable(err))
+			},
+		},
+		{
+			name:            "группа стала супергруппой, сообщение уходит в новый чат",
+			answers:         []string{migrateAnswer, okAnswer},
+			limits:          telegram.DefaultLimits,
+			expectedChatIDs: []int64{1, -100},
+			check: func(t *testing.T, err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name:            "ошибка на стороне telegram считается временной",
+			answers:         []string{internalAnswer},
+			limits:          telegram.DefaultLimits,
+			expectedChatIDs: []int64{1},
+			check: func(t *testing.T, err error) {
+				var apiErr *telegram.ErrBotAPI
+
+				assert.ErrorAs(t, err, &apiErr)
+				assert.True(t, apiErr.Temporary())
+				assert.NotErrorIs(t, err, tgbot.BotBlocked)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		var chatIDs []int64
+
+		server := newScriptedTelegram(t, test.answers, &chatIDs)
+		tgClient := telegram.NewClient(server.Client(), token, server.Listener.Addr().String(), test.limits)
+
+		test.check(t, tgClient.SendMessage(context.Background(), 1, "hello"))
+		assert.Equal(t, test.expectedChatIDs, chatIDs, test.name)
+	}
+}
+
+func TestTgClient_SendMessageRateLimit(t *testing.T) {
+	var chatIDs []int64
+
+	server := newScriptedTelegram(t, []string{`{"ok":true}`}, &chatIDs)
+	tgClient := telegram.NewClient(server.Client(), token, server.Listener.Addr().String(),
+		telegram.Limits{GlobalRPS: 1000, ChatRPS: 20})
+
+	start := time.Now()
+
+	// первые сообщения уходят сразу, остальные ждут пополнения бакета чата
+	for range 5 {
+		assert.NoError(t, tgClient.SendMessage(context.Background(), 1, "hello"))
+	}
+
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+	assert.Len(t, chatIDs, 5)
+}
+
+func TestTgClient_SendMessageRetryWaitsRateLimit(t *testing.T) {
+	const tooManyAnswer = `{"ok":false,"error_code":429,"description":"Too Many Requests","parameters":{"retry_after":1}}`
+
+	var chatIDs []int64
+
+	server := newScriptedTelegram(t, []string{tooManyAnswer, `{"ok":true}`}, &chatIDs)
+	tgClient := telegram.NewClient(server.Client(), token, server.Listener.Addr().String(),
+		telegram.Limits{GlobalRPS: 1000, ChatRPS: 0.5, MaxRetries: 1})
+
+	// бакет чата пополнится только через 2 секунды, поэтому повтор после паузы в 1 секунду не укладывается в дедлайн
+	ctx, cancel := context.WithTimeout(context.Background(), 1500*time.Millisecond)
+	defer cancel()
+
+	assert.Error(t, tgClient.SendMessage(ctx, 1, "hello"))
+	assert.Equal(t, []int64{1}, chatIDs, "повтор после 429 ждет лимит чата")
+}
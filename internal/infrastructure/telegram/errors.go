@@ -1,6 +1,14 @@
 package telegram
 
-import "fmt"
+import (
+	"fmt"
+	"linkTraccer/internal/domain/tgbot"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const chatNotFound = "chat not found"
 
 func NewErrNegativeLimit(limit int) *ErrNegativeLimit {
 	return &ErrNegativeLimit{
@@ -16,14 +24,74 @@ func (err ErrNegativeLimit) Error() string {
 	return fmt.Sprintf("Лимит не может быть %d, значение лимита должно быть 1 - 100", err.limit)
 }
 
+// ErrBotAPI хранит описание и параметры ответа Bot API.
 type ErrBotAPI struct {
-	code int
+	code            int
+	description     string
+	retryAfter      time.Duration
+	migrateToChatID int64
 }
 
 func (err *ErrBotAPI) Error() string {
-	return fmt.Sprintf("Запрос к BOT API закончился ошибкой %d", err.code)
+	if err.description == "" {
+		return fmt.Sprintf("Запрос к BOT API закончился ошибкой %d", err.code)
+	}
+
+	return fmt.Sprintf("Запрос к BOT API закончился ошибкой %d: %s", err.code, err.description)
+}
+
+// Unwrap позволяет проверять ошибку через errors.Is с ошибками tgbot.
+func (err *ErrBotAPI) Unwrap() error {
+	switch err.code {
+	case http.StatusForbidden:
+		return tgbot.BotBlocked
+	case http.StatusTooManyRequests:
+		return tgbot.TooManyRequests
+	case http.StatusBadRequest:
+		if strings.Contains(strings.ToLower(err.description), chatNotFound) {
+			return tgbot.ChatNotFound
+		}
+
+		return nil
+	default:
+		return nil
+	}
+}
+
+func (err *ErrBotAPI) Code() int {
+	return err.code
+}
+
+func (err *ErrBotAPI) Description() string {
+	return err.description
+}
+
+func (err *ErrBotAPI) RetryAfter() time.Duration {
+	return err.retryAfter
+}
+
+func (err *ErrBotAPI) MigrateToChatID() int64 {
+	return err.migrateToChatID
+}
+
+// Temporary сообщает, что запрос можно повторить позже: telegram перегружен или просит снизить частоту запросов.
+func (err *ErrBotAPI) Temporary() bool {
+	return err.code == http.StatusTooManyRequests || err.code >= http.StatusInternalServerError
 }
 
 func NewErrBotAPI(code int) *ErrBotAPI {
 	return &ErrBotAPI{code: code}
 }
+
+// newErrBotAPIFromAnswer собирает ошибку из тела ответа Bot API, тело может быть пустым или не JSON.
+func newErrBotAPIFromAnswer(code int, answer *DefaultServerAnswer) *ErrBotAPI {
+	err := NewErrBotAPI(code)
+	err.description = answer.Description
+
+	if answer.Parameters != nil {
+		err.retryAfter = time.Duration(answer.Parameters.RetryAfter) * time.Second
+		err.migrateToChatID = answer.Parameters.MigrateToChatID
+	}
+
+	return err
+}
@@ -0,0 +1,88 @@
+package telegram
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// в чат можно отправить не больше одного сообщения подряд, следующее ждет свой токен.
+	chatBurst = 1
+	// после такого простоя бакет чата снова полный, его можно удалить и создать заново.
+	chatIdleTTL = time.Minute
+	// при таком числе бакетов из карты удаляются бакеты простаивающих чатов.
+	chatsSweepSize = 10000
+)
+
+// Limits - ограничения Bot API на отправку сообщений и число повторов при ответе 429.
+type Limits struct {
+	GlobalRPS  float64
+	ChatRPS    float64
+	MaxRetries int
+}
+
+// DefaultLimits - лимиты из документации telegram: около 30 сообщений в секунду всего и 1 в секунду в один чат.
+var DefaultLimits = Limits{
+	GlobalRPS:  30,
+	ChatRPS:    1,
+	MaxRetries: 3,
+}
+
+type chatLimiter struct {
+	limiter  *rate.Limiter
+	lastUsed time.Time
+}
+
+// rateLimiter - общий токен бакет бота и отдельный бакет для каждого чата.
+type rateLimiter struct {
+	global    *rate.Limiter
+	chatLimit rate.Limit
+
+	mu    sync.Mutex
+	chats map[int64]*chatLimiter
+}
+
+func newRateLimiter(limits Limits) *rateLimiter {
+	return &rateLimiter{
+		global:    rate.NewLimiter(rate.Limit(limits.GlobalRPS), max(1, int(limits.GlobalRPS))),
+		chatLimit: rate.Limit(limits.ChatRPS),
+		chats:     make(map[int64]*chatLimiter),
+	}
+}
+
+// Wait блокируется, пока отправка сообщения в чат не уложится в оба лимита.
+func (l *rateLimiter) Wait(ctx context.Context, chatID int64) error {
+	if err := l.chat(chatID).Wait(ctx); err != nil {
+		return err
+	}
+
+	return l.global.Wait(ctx)
+}
+
+func (l *rateLimiter) chat(chatID int64) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+
+	if len(l.chats) >= chatsSweepSize {
+		for id, chat := range l.chats {
+			if now.Sub(chat.lastUsed) > chatIdleTTL {
+				delete(l.chats, id)
+			}
+		}
+	}
+
+	chat, ok := l.chats[chatID]
+	if !ok {
+		chat = &chatLimiter{limiter: rate.NewLimiter(l.chatLimit, chatBurst)}
+		l.chats[chatID] = chat
+	}
+
+	chat.lastUsed = now
+
+	return chat.limiter
+}
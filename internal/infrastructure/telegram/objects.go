@@ -8,10 +8,36 @@ type GetUpdateAnswer struct {
 }
 
 type DefaultServerAnswer struct {
-	Ok bool `json:"ok"`
+	Ok          bool                `json:"ok"`
+	ErrorCode   int                 `json:"error_code,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Parameters  *ResponseParameters `json:"parameters,omitempty"`
+}
+
+type ResponseParameters struct {
+	MigrateToChatID int64 `json:"migrate_to_chat_id,omitempty"`
+	RetryAfter      int   `json:"retry_after,omitempty"`
 }
 
 type SendMessage struct {
-	ID   int64  `json:"chat_id"`
-	Text string `json:"text"`
+	ID          int64                       `json:"chat_id"`
+	Text        string                      `json:"text"`
+	ReplyMarkup *tgbot.InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+}
+
+type EditMessageText struct {
+	ID          int64                       `json:"chat_id"`
+	MessageID   int                         `json:"message_id"`
+	Text        string                      `json:"text"`
+	ReplyMarkup *tgbot.InlineKeyboardMarkup `json:"reply_markup,omitempty"`
+}
+
+type AnswerCallbackQuery struct {
+	CallbackQueryID string `json:"callback_query_id"`
+	Text            string `json:"text,omitempty"`
+}
+
+type SetWebhook struct {
+	URL         string `json:"url"`
+	SecretToken string `json:"secret_token,omitempty"`
 }
@@ -0,0 +1,10 @@
+DROP TABLE userLinkFilters, userLinkTags, filters, tags;
+
+CREATE TABLE tags (
+                      tag_id BIGSERIAL,
+                      tag_name VARCHAR(50),
+
+                      PRIMARY KEY (tag_id)
+);
+
+ALTER TABLE userLinks ADD COLUMN tag_id BIGINT DEFAULT NULL REFERENCES tags(tag_id);
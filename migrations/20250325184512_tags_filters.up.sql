@@ -0,0 +1,45 @@
+ALTER TABLE userLinks DROP COLUMN tag_id;
+
+DROP TABLE tags;
+
+CREATE TABLE tags (
+                      tag_id BIGSERIAL,
+                      tag_name TEXT NOT NULL UNIQUE,
+
+                      PRIMARY KEY (tag_id)
+);
+
+CREATE TABLE filters (
+                      filter_id BIGSERIAL,
+                      filter_value TEXT NOT NULL UNIQUE,
+
+                      PRIMARY KEY (filter_id)
+);
+
+CREATE TABLE userLinkTags
+(
+                          user_id BIGINT NOT NULL,
+                          link_id BIGINT NOT NULL,
+                          tag_id  BIGINT NOT NULL,
+
+                          PRIMARY KEY (user_id, link_id, tag_id),
+                          FOREIGN KEY (user_id, link_id)
+                              REFERENCES userLinks(user_id, link_id) ON DELETE CASCADE,
+
+                          FOREIGN KEY (tag_id)
+                              REFERENCES tags(tag_id) ON DELETE CASCADE
+);
+
+CREATE TABLE userLinkFilters
+(
+                          user_id   BIGINT NOT NULL,
+                          link_id   BIGINT NOT NULL,
+                          filter_id BIGINT NOT NULL,
+
+                          PRIMARY KEY (user_id, link_id, filter_id),
+                          FOREIGN KEY (user_id, link_id)
+                              REFERENCES userLinks(user_id, link_id) ON DELETE CASCADE,
+
+                          FOREIGN KEY (filter_id)
+                              REFERENCES filters(filter_id) ON DELETE CASCADE
+);
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS pending_updates;
+
+ALTER TABLE update_time
+    DROP COLUMN last_sent_date,
+    DROP COLUMN user_id,
+    ALTER COLUMN send_time DROP NOT NULL,
+    ALTER COLUMN send_time TYPE TIMESTAMP USING (CURRENT_DATE + send_time);
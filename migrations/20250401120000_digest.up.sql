@@ -0,0 +1,27 @@
+ALTER TABLE update_time
+    ADD COLUMN user_id BIGINT NOT NULL UNIQUE,
+    ADD COLUMN last_sent_date DATE DEFAULT NULL,
+    ALTER COLUMN send_time TYPE TIME USING send_time::time,
+    ALTER COLUMN send_time SET NOT NULL,
+    ADD FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE;
+
+CREATE TABLE pending_updates
+(
+                          update_id   BIGSERIAL,
+                          user_id     BIGINT NOT NULL,
+                          link_id     BIGINT NOT NULL,
+                          update_type TEXT NOT NULL,
+                          header      TEXT NOT NULL,
+                          user_name   TEXT NOT NULL,
+                          create_time TEXT NOT NULL,
+                          preview     TEXT NOT NULL,
+
+                          PRIMARY KEY (update_id),
+                          FOREIGN KEY (user_id)
+                              REFERENCES users(user_id) ON DELETE CASCADE,
+
+                          FOREIGN KEY (link_id)
+                              REFERENCES links(link_id) ON DELETE CASCADE
+);
+
+CREATE INDEX pending_updates_user_idx ON pending_updates(user_id);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS held_updates;
+
+DROP TABLE IF EXISTS quiet_hours;
+
+ALTER TABLE users DROP COLUMN timezone;
@@ -0,0 +1,30 @@
+ALTER TABLE users
+    ADD COLUMN timezone TEXT NOT NULL DEFAULT 'Europe/Moscow';
+
+CREATE TABLE quiet_hours
+(
+                          user_id     BIGINT NOT NULL,
+                          quiet_start TIME NOT NULL,
+                          quiet_end   TIME NOT NULL,
+
+                          PRIMARY KEY (user_id),
+                          FOREIGN KEY (user_id)
+                              REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE TABLE held_updates
+(
+                          update_id   BIGSERIAL,
+                          user_id     BIGINT NOT NULL,
+                          link_id     BIGINT DEFAULT NULL,
+                          description TEXT NOT NULL,
+
+                          PRIMARY KEY (update_id),
+                          FOREIGN KEY (user_id)
+                              REFERENCES users(user_id) ON DELETE CASCADE,
+
+                          FOREIGN KEY (link_id)
+                              REFERENCES links(link_id) ON DELETE CASCADE
+);
+
+CREATE INDEX held_updates_user_idx ON held_updates(user_id);
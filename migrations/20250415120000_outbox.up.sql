@@ -0,0 +1,14 @@
+CREATE TABLE outbox
+(
+                          update_id   BIGSERIAL,
+                          link_id     BIGINT NOT NULL DEFAULT 0,
+                          link_url    TEXT NOT NULL DEFAULT '',
+                          description TEXT NOT NULL,
+                          chat_ids    BIGINT[] NOT NULL,
+                          created_at  TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                          sent_at     TIMESTAMP DEFAULT NULL,
+
+                          PRIMARY KEY (update_id)
+);
+
+CREATE INDEX outbox_unsent_idx ON outbox(update_id) WHERE sent_at IS NULL;
@@ -0,0 +1,5 @@
+ALTER TABLE held_updates
+    DROP COLUMN IF EXISTS event_id;
+
+ALTER TABLE outbox
+    DROP COLUMN IF EXISTS event_id;
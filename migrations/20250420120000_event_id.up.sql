@@ -0,0 +1,5 @@
+ALTER TABLE outbox
+    ADD COLUMN event_id TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE held_updates
+    ADD COLUMN event_id TEXT NOT NULL DEFAULT '';
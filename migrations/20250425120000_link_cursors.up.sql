@@ -0,0 +1,11 @@
+CREATE TABLE link_cursors
+(
+                          link_id     BIGINT NOT NULL,
+                          item_type   TEXT NOT NULL,
+                          item_id     BIGINT NOT NULL DEFAULT 0,
+                          item_time   TIMESTAMPTZ NOT NULL,
+
+                          PRIMARY KEY (link_id, item_type),
+                          FOREIGN KEY (link_id)
+                              REFERENCES links(link_id) ON DELETE CASCADE
+);
@@ -0,0 +1,13 @@
+ALTER TABLE pending_updates
+    RENAME COLUMN item_time TO create_time;
+
+ALTER TABLE pending_updates
+    ALTER COLUMN create_time TYPE TEXT
+        USING to_char(create_time AT TIME ZONE 'Europe/Moscow', 'HH24:MI:SS DD-MM-YYYY');
+
+ALTER TABLE outbox
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE current_setting('TimeZone'),
+    ALTER COLUMN sent_at TYPE TIMESTAMP USING sent_at AT TIME ZONE current_setting('TimeZone');
+
+ALTER TABLE links
+    ALTER COLUMN last_update_check TYPE TIMESTAMP USING last_update_check AT TIME ZONE 'Europe/Moscow';
@@ -0,0 +1,15 @@
+-- до этой миграции время хранилось без часового пояса по московскому времени
+ALTER TABLE links
+    ALTER COLUMN last_update_check TYPE TIMESTAMPTZ USING last_update_check AT TIME ZONE 'Europe/Moscow';
+
+-- время в outbox записывала сама база через CURRENT_TIMESTAMP, то есть в часовом поясе сессии
+ALTER TABLE outbox
+    ALTER COLUMN created_at TYPE TIMESTAMPTZ USING created_at AT TIME ZONE current_setting('TimeZone'),
+    ALTER COLUMN sent_at TYPE TIMESTAMPTZ USING sent_at AT TIME ZONE current_setting('TimeZone');
+
+ALTER TABLE pending_updates
+    ALTER COLUMN create_time TYPE TIMESTAMPTZ
+        USING to_timestamp(create_time, 'HH24:MI:SS DD-MM-YYYY')::timestamp AT TIME ZONE 'Europe/Moscow';
+
+ALTER TABLE pending_updates
+    RENAME COLUMN create_time TO item_time;
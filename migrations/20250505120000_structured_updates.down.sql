@@ -0,0 +1,14 @@
+ALTER TABLE pending_updates
+    DROP COLUMN IF EXISTS site,
+    DROP COLUMN IF EXISTS item_id,
+    DROP COLUMN IF EXISTS item_url;
+
+ALTER TABLE pending_updates RENAME COLUMN title TO header;
+ALTER TABLE pending_updates RENAME COLUMN author TO user_name;
+ALTER TABLE pending_updates RENAME COLUMN excerpt TO preview;
+
+ALTER TABLE held_updates
+    DROP COLUMN IF EXISTS content;
+
+ALTER TABLE outbox
+    DROP COLUMN IF EXISTS content;
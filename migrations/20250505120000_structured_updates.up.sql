@@ -0,0 +1,14 @@
+ALTER TABLE outbox
+    ADD COLUMN content JSONB DEFAULT NULL;
+
+ALTER TABLE held_updates
+    ADD COLUMN content JSONB DEFAULT NULL;
+
+ALTER TABLE pending_updates RENAME COLUMN header TO title;
+ALTER TABLE pending_updates RENAME COLUMN user_name TO author;
+ALTER TABLE pending_updates RENAME COLUMN preview TO excerpt;
+
+ALTER TABLE pending_updates
+    ADD COLUMN site     TEXT NOT NULL DEFAULT '',
+    ADD COLUMN item_id  BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN item_url TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,9 @@
+ALTER TABLE userLinks
+    DROP COLUMN IF EXISTS check_interval;
+
+DROP INDEX IF EXISTS links_next_check_idx;
+
+ALTER TABLE links
+    DROP COLUMN IF EXISTS next_check_at,
+    DROP COLUMN IF EXISTS check_interval,
+    DROP COLUMN IF EXISTS check_failures;
\ No newline at end of file
@@ -0,0 +1,11 @@
+ALTER TABLE links
+    ADD COLUMN next_check_at  TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ADD COLUMN check_interval INTERVAL NOT NULL DEFAULT INTERVAL '5 minutes',
+    ADD COLUMN check_failures INT NOT NULL DEFAULT 0;
+
+UPDATE links SET next_check_at = last_update_check + INTERVAL '5 minutes' WHERE last_update_check IS NOT NULL;
+
+CREATE INDEX links_next_check_idx ON links(next_check_at, link_id);
+
+ALTER TABLE userLinks
+    ADD COLUMN check_interval INTERVAL DEFAULT NULL;
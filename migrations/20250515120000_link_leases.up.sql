@@ -0,0 +1,2 @@
+ALTER TABLE links
+    ADD COLUMN leased_until TIMESTAMPTZ DEFAULT NULL;
@@ -0,0 +1,9 @@
+DROP INDEX outbox_unsent_idx;
+
+CREATE INDEX outbox_unsent_idx ON outbox(update_id) WHERE sent_at IS NULL;
+
+ALTER TABLE outbox
+    DROP COLUMN IF EXISTS attempts,
+    DROP COLUMN IF EXISTS last_error,
+    DROP COLUMN IF EXISTS leased_until,
+    DROP COLUMN IF EXISTS failed_at;
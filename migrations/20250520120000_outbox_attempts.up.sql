@@ -0,0 +1,9 @@
+ALTER TABLE outbox
+    ADD COLUMN attempts     INT NOT NULL DEFAULT 0,
+    ADD COLUMN last_error   TEXT DEFAULT NULL,
+    ADD COLUMN leased_until TIMESTAMPTZ DEFAULT NULL,
+    ADD COLUMN failed_at    TIMESTAMPTZ DEFAULT NULL;
+
+DROP INDEX outbox_unsent_idx;
+
+CREATE INDEX outbox_unsent_idx ON outbox(update_id) WHERE sent_at IS NULL AND failed_at IS NULL;